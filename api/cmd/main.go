@@ -1,12 +1,22 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"time"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/invitations"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/webhooks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/joho/godotenv"
@@ -18,11 +28,28 @@ import (
 type appConfig struct {
 	DSN          string
 	GoogleAPIKey string
+	SMTP         mailer.SMTPConfig
+	// MailOutboxDir is where mail is written when no SMTP server is configured.
+	MailOutboxDir string
+	// Dev allows starting without a token signing key; a temporary one is generated instead.
+	Dev               bool
+	JWTKeys           jwtpkg.KeyConfig
+	TokenTTLs         jwtpkg.TTLConfig
+	Auth              auth.Config
+	Invitations       invitations.Config
+	PasswordPolicy    types.PasswordPolicy
+	LoginThrottle     types.LoginThrottlePolicy
+	IdempotencyKeyTTL time.Duration
+	// OutboxEventRetention is how long dispatched events are kept for the event stream and the
+	// webhook delivery log.
+	OutboxEventRetention time.Duration
+	Webhooks             webhooks.Config
 }
 
 // loadConfig reads configuration from environment variables and populates an appConfig struct.
 func loadConfig() (*appConfig, error) {
-	if os.Getenv("DEV") == "true" {
+	dev := os.Getenv("DEV") == "true"
+	if dev {
 		// In development, load .env file. In production, env vars are expected to be set directly.
 		if err := godotenv.Load(); err != nil {
 			// This is a warning, not a fatal error, as env vars could be set by the system.
@@ -40,10 +67,44 @@ func loadConfig() (*appConfig, error) {
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		dbHost, dbPort, dbUser, dbPassword, dbName, dbSslMode)
 
+	throttle := types.ActiveLoginThrottlePolicy
 	cfg := &appConfig{
 		DSN:          dsn,
 		GoogleAPIKey: os.Getenv("GOOGLE_MAPS_API_KEY"), // No fallback, empty string is a valid state we check for later.
+		SMTP: mailer.SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"), // Empty means mail is written to MailOutboxDir instead.
+			Port:     utils.GetEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     utils.GetEnv("MAIL_FROM", "noreply@localhost"),
+		},
+		MailOutboxDir: utils.GetEnv("MAIL_OUTBOX_DIR", "outbox"),
+		Dev:           dev,
+		JWTKeys:       jwtpkg.KeyConfigFromEnv(),
+		TokenTTLs:     jwtpkg.TTLConfigFromEnv(),
+		Auth:          auth.ConfigFromEnv(),
+		Invitations:   invitations.ConfigFromEnv(),
+		PasswordPolicy: types.PasswordPolicy{
+			MinLength:     utils.GetEnvInt("PASSWORD_MIN_LENGTH", types.ActivePasswordPolicy.MinLength),
+			RequireUpper:  utils.GetEnvBool("PASSWORD_REQUIRE_UPPER", types.ActivePasswordPolicy.RequireUpper),
+			RequireLower:  utils.GetEnvBool("PASSWORD_REQUIRE_LOWER", types.ActivePasswordPolicy.RequireLower),
+			RequireDigit:  utils.GetEnvBool("PASSWORD_REQUIRE_DIGIT", types.ActivePasswordPolicy.RequireDigit),
+			RequireSymbol: utils.GetEnvBool("PASSWORD_REQUIRE_SYMBOL", types.ActivePasswordPolicy.RequireSymbol),
+		},
+		LoginThrottle: types.LoginThrottlePolicy{
+			MaxAccountFailures: utils.GetEnvInt("LOGIN_MAX_ACCOUNT_FAILURES", throttle.MaxAccountFailures),
+			MaxIPFailures:      utils.GetEnvInt("LOGIN_MAX_IP_FAILURES", throttle.MaxIPFailures),
+			FailureWindow:      utils.GetEnvDuration("LOGIN_FAILURE_WINDOW", throttle.FailureWindow),
+			BaseLockout:        utils.GetEnvDuration("LOGIN_LOCKOUT_BASE", throttle.BaseLockout),
+			MaxLockout:         utils.GetEnvDuration("LOGIN_LOCKOUT_MAX", throttle.MaxLockout),
+			LockoutMemory:      utils.GetEnvDuration("LOGIN_LOCKOUT_MEMORY", throttle.LockoutMemory),
+		},
+		IdempotencyKeyTTL:    utils.GetEnvDuration("IDEMPOTENCY_KEY_TTL", types.IdempotencyKeyTTL),
+		OutboxEventRetention: utils.GetEnvDuration("OUTBOX_EVENT_RETENTION", types.OutboxEventRetention),
+		Webhooks:             webhooks.ConfigFromEnv(),
 	}
+	// Only development may send webhooks to http or internal endpoints.
+	cfg.Webhooks.AllowInsecure = dev
 
 	return cfg, nil
 }
@@ -74,6 +135,28 @@ func main() {
 		logger.Fatalf("FATAL: failed to load configuration: %v", err)
 	}
 
+	// --- Settings ---
+	// Read after the .env file is loaded, so they are set here rather than when the packages load.
+	jwtpkg.SetTTLs(cfg.TokenTTLs)
+	auth.Configure(cfg.Auth)
+	invitations.Configure(cfg.Invitations)
+	types.ActivePasswordPolicy = cfg.PasswordPolicy
+	types.ActiveLoginThrottlePolicy = cfg.LoginThrottle
+	types.IdempotencyKeyTTL = cfg.IdempotencyKeyTTL
+	types.OutboxEventRetention = cfg.OutboxEventRetention
+
+	// --- Token Signing Keys ---
+	keys, err := jwtpkg.LoadKeySet(cfg.JWTKeys)
+	if errors.Is(err, jwtpkg.ErrNoSigningKey) && cfg.Dev {
+		keys, err = jwtpkg.GenerateKeySet()
+		logger.Println("warning: JWT_SIGNING_KEY_FILE not set, tokens are signed with a temporary key and stop working on restart")
+	}
+	if err != nil {
+		logger.Fatalf("FATAL: unable to load token signing keys: %v", err)
+	}
+	jwtpkg.SetKeySet(keys)
+	logger.Printf("signing tokens with %s key %s", keys.SigningKey().Method.Alg(), keys.SigningKey().ID)
+
 	// --- Database Connection ---
 	db, err := xorm.NewEngine("pgx", cfg.DSN)
 	if err != nil {
@@ -97,9 +180,34 @@ func main() {
 		logger.Println("warning: GOOGLE_MAPS_API_KEY not set, geocoding will be unavailable")
 	}
 
+	// --- Mailer ---
+	var mail mailer.Mailer
+	if cfg.SMTP.Host != "" {
+		mail = mailer.NewSMTPMailer(cfg.SMTP)
+	} else {
+		mail, err = mailer.NewFileMailer(cfg.MailOutboxDir, cfg.SMTP.From)
+		if err != nil {
+			logger.Fatalf("FATAL: unable to create mail outbox: %v", err)
+		}
+		logger.Printf("warning: SMTP_HOST not set, mail will be written to %s instead of sent", cfg.MailOutboxDir)
+	}
+
 	// --- Repository Initialization ---
 	globalRepo := repos.NewGlobalRepo(db, googleClient)
 
+	// --- Webhooks ---
+	// The dispatcher sends the events in the outbox to the companies' webhook endpoints until the
+	// server stops.
+	dispatchCtx, stopDispatcher := context.WithCancel(context.Background())
+	defer stopDispatcher()
+	go webhooks.NewDispatcher(globalRepo.Webhooks(), cfg.Webhooks, logger).Run(dispatchCtx)
+
+	// --- Event Stream ---
+	// The broker hears about new events from every API instance through Postgres notifications and
+	// wakes up the streams of their company.
+	broker := events.NewBroker()
+	go broker.Listen(dispatchCtx, cfg.DSN, logger)
+
 	// Create a new server instance
-	api.Run(globalRepo, logger)
+	api.Run(globalRepo, mail, broker, logger)
 }
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN fingerprint VARCHAR(64);
+
+-- Backfill using the same format as types.ProductFingerprint.
+UPDATE products p
+SET fingerprint = encode(sha256(convert_to(
+    p.commodity_id::text || ':' || COALESCE((
+        SELECT string_agg(
+            pav.commodity_attribute_id::text || '=' || char_length(COALESCE(pav.value, ''))::text || ':' || COALESCE(pav.value, '') || ';',
+            '' ORDER BY pav.commodity_attribute_id, COALESCE(pav.value, '') COLLATE "C"
+        )
+        FROM product_attribute_values pav
+        WHERE pav.product_id = p.id
+    ), ''),
+    'UTF8')), 'hex');
+
+-- Duplicates created before this migration keep working, but only the oldest visible product
+-- of each group keeps its fingerprint so the unique index below can be built.
+UPDATE products p
+SET fingerprint = NULL
+WHERE p.visible = TRUE
+  AND EXISTS (
+    SELECT 1 FROM products o
+    WHERE o.company_id = p.company_id
+      AND o.fingerprint = p.fingerprint
+      AND o.visible = TRUE
+      AND o.id < p.id
+  );
+
+CREATE UNIQUE INDEX uq_products_company_fingerprint ON products (company_id, fingerprint) WHERE visible = TRUE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS uq_products_company_fingerprint;
+ALTER TABLE products DROP COLUMN fingerprint;
+-- +goose StatementEnd
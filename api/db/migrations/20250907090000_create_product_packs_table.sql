@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE product_packs (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL,
+    product_id BIGINT NOT NULL,
+    pack_style VARCHAR(100) NOT NULL,
+    count_per_case INT NOT NULL CHECK (count_per_case > 0),
+    unit_weight DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (unit_weight >= 0),
+    net_weight DOUBLE PRECISION NOT NULL CHECK (net_weight > 0),
+    gross_weight DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (gross_weight >= 0),
+    weight_unit VARCHAR(2) NOT NULL CHECK (weight_unit IN ('lb', 'kg')),
+    case_length DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (case_length >= 0),
+    case_width DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (case_width >= 0),
+    case_height DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (case_height >= 0),
+    dimension_unit VARCHAR(2) NOT NULL DEFAULT '' CHECK (dimension_unit IN ('', 'in', 'cm')),
+    ti INT NOT NULL DEFAULT 0 CHECK (ti >= 0),
+    hi INT NOT NULL DEFAULT 0 CHECK (hi >= 0),
+    cases_per_pallet INT NOT NULL DEFAULT 0 CHECK (cases_per_pallet >= 0),
+    visible BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_product_packs_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    CONSTRAINT fk_product_packs_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_product_packs_product_id ON product_packs (product_id);
+CREATE INDEX idx_product_packs_company_id ON product_packs (company_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS product_packs;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE commodity_types (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    visible BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_commodity_types_name UNIQUE (name)
+);
+
+-- Keep the IDs of the former Go enum so existing rows and API clients keep working.
+-- 'unknown' only exists for legacy rows and is hidden so it cannot be assigned to new records.
+INSERT INTO commodity_types (id, name, visible) VALUES (0, 'unknown', FALSE), (1, 'produce', TRUE);
+SELECT setval(pg_get_serial_sequence('commodity_types', 'id'), 1);
+
+UPDATE commodities SET commodity_type = 0
+WHERE commodity_type NOT IN (SELECT id FROM commodity_types);
+UPDATE commodity_attributes SET commodity_type_name = 0
+WHERE commodity_type_name NOT IN (SELECT id FROM commodity_types);
+
+ALTER TABLE commodities
+    ADD CONSTRAINT fk_commodities_commodity_type FOREIGN KEY (commodity_type) REFERENCES commodity_types(id);
+ALTER TABLE commodity_attributes
+    ADD CONSTRAINT fk_commodity_attributes_commodity_type FOREIGN KEY (commodity_type_name) REFERENCES commodity_types(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE commodity_attributes DROP CONSTRAINT IF EXISTS fk_commodity_attributes_commodity_type;
+ALTER TABLE commodities DROP CONSTRAINT IF EXISTS fk_commodities_commodity_type;
+DROP TABLE IF EXISTS commodity_types;
+-- +goose StatementEnd
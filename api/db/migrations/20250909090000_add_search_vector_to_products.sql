@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE products ADD COLUMN search_vector TSVECTOR;
+
+-- Backfill using the same expression as the products repo.
+UPDATE products AS p
+SET search_vector =
+    setweight(to_tsvector('english', COALESCE(p.name, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(c.name, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE((
+        SELECT string_agg(pav.value, ' ')
+        FROM product_attribute_values pav
+        WHERE pav.product_id = p.id
+    ), '')), 'C')
+FROM commodities AS c
+WHERE c.id = p.commodity_id;
+
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_products_search_vector;
+ALTER TABLE products DROP COLUMN search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Company roles bundle permissions and are granted to users on top of their built-in roles.
+CREATE TABLE company_roles (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_company_roles_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_company_roles_company_id_name ON company_roles (company_id, name);
+
+CREATE TABLE user_company_roles (
+    user_id BIGINT NOT NULL,
+    company_role_id BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, company_role_id),
+    CONSTRAINT fk_user_company_roles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_company_roles_role FOREIGN KEY (company_role_id) REFERENCES company_roles(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_company_roles_company_role_id ON user_company_roles (company_role_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_company_roles;
+DROP TABLE IF EXISTS company_roles;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Failed logins are counted per email (key is the lowercased email, whether or not an account
+-- uses it) and per client IP address.
+CREATE TABLE login_throttles (
+    scope VARCHAR(16) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    lockouts INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP NULL,
+    last_lockout_at TIMESTAMP NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE TABLE login_lockout_events (
+    id BIGSERIAL PRIMARY KEY,
+    event VARCHAR(16) NOT NULL,
+    scope VARCHAR(16) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    user_id BIGINT NULL,
+    ip_address VARCHAR(64) NULL,
+    locked_until TIMESTAMP NULL,
+    actor_id BIGINT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_login_lockout_events_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_login_lockout_events_actor FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_login_lockout_events_key ON login_lockout_events (key);
+CREATE INDEX idx_login_lockout_events_user_id ON login_lockout_events (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS login_lockout_events;
+DROP TABLE IF EXISTS login_throttles;
+-- +goose StatementEnd
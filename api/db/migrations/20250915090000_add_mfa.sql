@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+-- mfa_secret is the TOTP secret; it is set on enrollment and MFA is on once the first code confirms
+-- it. mfa_last_step is the last TOTP time step used, so a code cannot be used twice.
+ALTER TABLE users ADD COLUMN mfa_secret VARCHAR(64) NULL;
+ALTER TABLE users ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN mfa_last_step BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE companies ADD COLUMN require_mfa BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Recovery codes are stored as SHA-256 hashes and can be used once.
+CREATE TABLE mfa_recovery_codes (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_mfa_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS mfa_recovery_codes;
+ALTER TABLE companies DROP COLUMN IF EXISTS require_mfa;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_last_step;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS mfa_secret;
+-- +goose StatementEnd
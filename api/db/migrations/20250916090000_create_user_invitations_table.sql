@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Invitations let admins add users without choosing their password. The invite token is stored as
+-- a SHA-256 hash; resending an invitation replaces it, so only the latest link works.
+CREATE TABLE user_invitations (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    address_id BIGINT NOT NULL,
+    role_ids BIGINT[] NOT NULL DEFAULT '{}',
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    user_id BIGINT NULL,
+    invited_by BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_user_invitations_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_invitations_address FOREIGN KEY (address_id) REFERENCES addresses(id),
+    CONSTRAINT fk_user_invitations_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_user_invitations_token_hash ON user_invitations (token_hash);
+CREATE INDEX idx_user_invitations_company_id ON user_invitations (company_id);
+CREATE INDEX idx_user_invitations_email ON user_invitations (email);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_invitations;
+-- +goose StatementEnd
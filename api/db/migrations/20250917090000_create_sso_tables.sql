@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A company's OpenID Connect identity provider. The client secret is needed to redeem codes, so it is
+-- stored as given; it is never returned by the API.
+CREATE TABLE company_sso_configs (
+    company_id BIGINT PRIMARY KEY,
+    issuer TEXT NOT NULL,
+    client_id TEXT NOT NULL,
+    client_secret TEXT NOT NULL,
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    jit_provisioning BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_company_sso_configs_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+);
+
+-- Sign-ins that were sent to the identity provider and have not come back yet. The state is stored
+-- as a SHA-256 hash and can be used once.
+CREATE TABLE sso_login_states (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NOT NULL,
+    state_hash VARCHAR(64) NOT NULL,
+    nonce TEXT NOT NULL,
+    code_verifier TEXT NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_sso_login_states_company FOREIGN KEY (company_id) REFERENCES companies(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_sso_login_states_state_hash ON sso_login_states (state_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS sso_login_states;
+DROP TABLE IF EXISTS company_sso_configs;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The audit log records every change made through the repos, in the same transaction as the change.
+-- It has no foreign keys, so that entries outlive the records and users they are about.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    company_id BIGINT NULL,
+    actor_id BIGINT NULL,
+    impersonated_user_id BIGINT NULL,
+    api_key_id BIGINT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_company_id ON audit_log (company_id, id DESC);
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id);
+CREATE INDEX idx_audit_log_actor_id ON audit_log (actor_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The event stream reads a company's events after the last one a client has seen.
+CREATE INDEX idx_outbox_events_company_id ON outbox_events (company_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_events_company_id;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Idempotency keys remember the response to a POST request sent with an Idempotency-Key header, so
+-- that a retry gets the same response instead of repeating the request. Keys are per owner, a user
+-- ("user:<id>") or an API key ("api_key:<id>"). A status code of 0 means the first request is still
+-- being handled.
+CREATE TABLE idempotency_keys (
+    owner VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    content_type VARCHAR(255) NOT NULL DEFAULT '',
+    response_body BYTEA NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (owner, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Records that can be updated carry a version, which every update increments. Updates only apply
+-- to the version they were based on, so concurrent changes cannot overwrite each other, and the
+-- version is the record's ETag.
+ALTER TABLE addresses ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE commodities ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE commodity_attributes ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE commodity_types ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE companies ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE company_attribute_settings ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE company_roles ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE locations ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE product_packs ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE products ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE users ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE webhook_endpoints ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE products DROP COLUMN IF EXISTS version;
+ALTER TABLE product_packs DROP COLUMN IF EXISTS version;
+ALTER TABLE locations DROP COLUMN IF EXISTS version;
+ALTER TABLE company_roles DROP COLUMN IF EXISTS version;
+ALTER TABLE company_attribute_settings DROP COLUMN IF EXISTS version;
+ALTER TABLE companies DROP COLUMN IF EXISTS version;
+ALTER TABLE commodity_types DROP COLUMN IF EXISTS version;
+ALTER TABLE commodity_attributes DROP COLUMN IF EXISTS version;
+ALTER TABLE commodities DROP COLUMN IF EXISTS version;
+ALTER TABLE addresses DROP COLUMN IF EXISTS version;
+-- +goose StatementEnd
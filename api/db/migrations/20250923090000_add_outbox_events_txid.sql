@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Event IDs are handed out when an event is written, not when its transaction commits, so the event
+-- stream cannot resume after an ID: an event with a lower ID may still commit later. Each event
+-- records the transaction that wrote it instead, and the stream only reads events whose transaction
+-- is older than every transaction still running, ordered by transaction and then ID.
+ALTER TABLE outbox_events ADD COLUMN txid BIGINT NOT NULL DEFAULT (pg_current_xact_id()::text::bigint);
+
+CREATE INDEX idx_outbox_events_company_txid ON outbox_events (company_id, txid, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_events_company_txid;
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS txid;
+-- +goose StatementEnd
@@ -19,4 +19,9 @@ package docs
 // @in header
 // @name X-App-Token
 // @description API token for authentication.
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-Api-Key
+// @description Company API key for integrations, limited to the key's scopes.
 type _ struct{}
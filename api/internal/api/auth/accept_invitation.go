@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Accept Invitation
+// @Description  Creates the invited user with the name and password they chose, using the token from an invitation email. The user joins the invitation's company with its roles and can log in right away. The token can be used once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body      AcceptInvitationPayload  true  "Invite Token, Name and Password"
+// @Success      201     {object}  types.User               "User created"
+// @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input, password policy not met, or invalid, expired, revoked or used token"
+// @Failure      409     {object}  middleware.ErrorResponse "A user with this email already exists"
+// @Failure      500     {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /auth/accept-invitation [post]
+// AcceptInvitation completes a user invitation.
+func AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	var payload AcceptInvitationPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	// The email is checked against as well once the invitation is known.
+	if err := types.ActivePasswordPolicy.Check(payload.Password, ""); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	user := &types.User{
+		FirstName: payload.FirstName,
+		LastName:  payload.LastName,
+		Password:  payload.Password,
+	}
+	if _, err := repo.Invitations().Accept(r.Context(), jwtpgk.HashOpaqueToken(payload.Token), user); err != nil {
+		var policyErr *types.PasswordPolicyError
+		switch {
+		case errors.Is(err, repos.ErrInvitationInvalid) || errors.As(err, &policyErr):
+			middleware.WriteError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, repos.ErrInvitationEmailTaken):
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+		default:
+			middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		}
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, user)
+}
@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Accept Invitation Handler", func() {
+	var (
+		rr      *httptest.ResponseRecorder
+		payload auth.AcceptInvitationPayload
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		payload = auth.AcceptInvitationPayload{
+			Token:     "invite-token",
+			FirstName: "Jane",
+			LastName:  "Doe",
+			Password:  "newpassword123",
+		}
+	})
+
+	performRequest := func() {
+		body, _ := json.Marshal(payload)
+		req := newAuthenticatedRequest(http.MethodPost, "/auth/accept-invitation", body, nil)
+		router.ServeHTTP(rr, req)
+	}
+
+	It("should create the user with the chosen name and password", func() {
+		mockInvitations.EXPECT().Accept(gomock.Any(), jwtpkg.HashOpaqueToken("invite-token"), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, user *types.User) (*types.Invitation, error) {
+				Expect(user.FirstName).To(Equal("Jane"))
+				Expect(user.LastName).To(Equal("Doe"))
+				Expect(user.Password).To(Equal("newpassword123"))
+				user.ID = 12
+				user.Email = "jane@example.com"
+				user.CompanyID = 3
+				return &types.Invitation{ID: 4, Status: types.InvitationStatusAccepted}, nil
+			})
+
+		performRequest()
+
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		var user map[string]interface{}
+		Expect(json.Unmarshal(rr.Body.Bytes(), &user)).To(Succeed())
+		Expect(user["email"]).To(Equal("jane@example.com"))
+		Expect(user).NotTo(HaveKey("password"))
+	})
+
+	It("should return 400 for an invalid, expired, revoked or used token", func() {
+		mockInvitations.EXPECT().Accept(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repos.ErrInvitationInvalid)
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 400 when the password breaks the password policy", func() {
+		mockInvitations.EXPECT().Accept(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, &types.PasswordPolicyError{Problems: []string{"must not be the email"}})
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 400 for a password that is too short without using the token", func() {
+		payload.Password = "short"
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 400 without a name", func() {
+		payload.FirstName = ""
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 409 when the email has been taken since the invitation was sent", func() {
+		mockInvitations.EXPECT().Accept(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repos.ErrInvitationEmailTaken)
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should return 500 when the database fails", func() {
+		mockInvitations.EXPECT().Accept(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database connection lost"))
+		performRequest()
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
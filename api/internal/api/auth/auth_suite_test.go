@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
 	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	. "github.com/onsi/ginkgo/v2"
@@ -23,34 +24,76 @@ func TestAuth(t *testing.T) {
 }
 
 var (
-	mockCtrl       *gomock.Controller
-	mockGlobalRepo *mock_repos.MockGlobalRepo
-	mockUsersRepo  *mock_repos.MockUsersRepo
-	router         *mux.Router
+	mockCtrl         *gomock.Controller
+	mockGlobalRepo   *mock_repos.MockGlobalRepo
+	mockUsersRepo    *mock_repos.MockUsersRepo
+	mockSessionsRepo *mock_repos.MockSessionsRepo
+	mockResetsRepo   *mock_repos.MockPasswordResetTokensRepo
+	mockThrottles    *mock_repos.MockLoginThrottlesRepo
+	mockCompanies    *mock_repos.MockCompaniesRepo
+	mockMFARepo      *mock_repos.MockMFARepo
+	mockInvitations  *mock_repos.MockInvitationsRepo
+	mockRolesRepo    *mock_repos.MockCompanyRolesRepo
+	mockSSORepo      *mock_repos.MockSSORepo
+	outbox           *mailer.OutboxMailer
+	router           *mux.Router
 )
 
 var _ = BeforeEach(func() {
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
 	mockUsersRepo = mock_repos.NewMockUsersRepo(mockCtrl)
+	mockSessionsRepo = mock_repos.NewMockSessionsRepo(mockCtrl)
+	mockResetsRepo = mock_repos.NewMockPasswordResetTokensRepo(mockCtrl)
+	mockThrottles = mock_repos.NewMockLoginThrottlesRepo(mockCtrl)
+	mockCompanies = mock_repos.NewMockCompaniesRepo(mockCtrl)
+	mockMFARepo = mock_repos.NewMockMFARepo(mockCtrl)
+	mockInvitations = mock_repos.NewMockInvitationsRepo(mockCtrl)
+	mockRolesRepo = mock_repos.NewMockCompanyRolesRepo(mockCtrl)
+	mockSSORepo = mock_repos.NewMockSSORepo(mockCtrl)
+	outbox = mailer.NewOutboxMailer()
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Sessions().Return(mockSessionsRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().PasswordResetTokens().Return(mockResetsRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().LoginThrottles().Return(mockThrottles).AnyTimes()
+	mockGlobalRepo.EXPECT().Companies().Return(mockCompanies).AnyTimes()
+	mockGlobalRepo.EXPECT().MFA().Return(mockMFARepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Invitations().Return(mockInvitations).AnyTimes()
+	mockGlobalRepo.EXPECT().CompanyRoles().Return(mockRolesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().SSO().Return(mockSSORepo).AnyTimes()
 
 	// Set up the router for auth handlers
 	router = mux.NewRouter()
 	router.HandleFunc("/login", auth.Login).Methods("POST")
+	router.HandleFunc("/login/mfa", auth.LoginMFA).Methods("POST")
+	router.HandleFunc("/login/mfa/enroll", auth.LoginEnrollMFA).Methods("POST")
+	router.HandleFunc("/auth/refresh", auth.Refresh).Methods("POST")
+	router.HandleFunc("/auth/logout", auth.Logout).Methods("POST")
+	router.HandleFunc("/auth/forgot-password", auth.ForgotPassword).Methods("POST")
+	router.HandleFunc("/auth/reset-password", auth.ResetPassword).Methods("POST")
+	router.HandleFunc("/auth/accept-invitation", auth.AcceptInvitation).Methods("POST")
+	router.HandleFunc("/auth/sso/start", auth.StartSSO).Methods("POST")
+	router.HandleFunc("/auth/sso/callback", auth.SSOCallback).Methods("POST")
+	router.HandleFunc("/auth/impersonate/{userId:[0-9]+}", auth.Impersonate).Methods("POST")
+	router.HandleFunc("/auth/mfa/enroll", auth.EnrollMFA).Methods("POST")
+	router.HandleFunc("/auth/mfa/confirm", auth.ConfirmMFA).Methods("POST")
+	router.HandleFunc("/auth/mfa/recovery-codes", auth.RegenerateMFARecoveryCodes).Methods("POST")
+	router.HandleFunc("/auth/mfa/disable", auth.DisableMFA).Methods("POST")
+	router.HandleFunc("/.well-known/jwks.json", auth.JWKS).Methods("GET")
 })
 
 var _ = AfterEach(func() {
 	mockCtrl.Finish()
 })
 
-// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo, the test outbox
 // and an optional authenticated user in the context.
 func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
 	req := httptest.NewRequest(method, url, bytes.NewReader(body))
 	ctxWithRepo := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	ctxWithRepo = context.WithValue(ctxWithRepo, middleware.MailerKey, mailer.Mailer(outbox))
 	if user != nil {
 		ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, user)
 		return req.WithContext(ctxWithAuth)
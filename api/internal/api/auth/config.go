@@ -0,0 +1,30 @@
+package auth
+
+import "github.com/happilymarrieddad/order-management-v3/api/utils"
+
+// Config holds the authentication settings that differ between deployments.
+type Config struct {
+	// ResetPasswordURL is the page of the web app that sets a new password.
+	ResetPasswordURL string
+	// SSORedirectURL is the page of the web app identity providers send users back to.
+	SSORedirectURL string
+	// MFAIssuer is the name authenticator apps show next to the user's email.
+	MFAIssuer string
+}
+
+// ConfigFromEnv reads the authentication settings from RESET_PASSWORD_URL, SSO_REDIRECT_URL and
+// MFA_ISSUER, falling back to the defaults.
+func ConfigFromEnv() Config {
+	return Config{
+		ResetPasswordURL: utils.GetEnv("RESET_PASSWORD_URL", resetPasswordURL),
+		SSORedirectURL:   utils.GetEnv("SSO_REDIRECT_URL", ssoRedirectURL),
+		MFAIssuer:        utils.GetEnv("MFA_ISSUER", mfaIssuer),
+	}
+}
+
+// Configure sets the authentication settings. It is meant to be called once at startup.
+func Configure(cfg Config) {
+	resetPasswordURL = cfg.ResetPasswordURL
+	ssoRedirectURL = cfg.SSORedirectURL
+	mfaIssuer = cfg.MFAIssuer
+}
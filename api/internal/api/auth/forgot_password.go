@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// passwordResetTokenTTL is how long a password reset link is valid.
+const passwordResetTokenTTL = time.Hour
+
+// resetPasswordURL is the page of the web app that sets a new password. The reset token is added
+// to it as the token query parameter.
+var resetPasswordURL = "http://localhost:3000/reset-password"
+
+// @Summary      Forgot Password
+// @Description  Emails the user a link to set a new password. The link is valid for one hour and can be used once. The response is the same whether or not the email belongs to a user. Too many requests for an email or from an IP address lock them out for a while.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body      ForgotPasswordPayload    true  "Account Email"
+// @Success      202     "Reset email sent if the account exists"
+// @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input"
+// @Failure      429     {object}  middleware.ErrorResponse "Too Many Requests - Locked out, see the Retry-After header"
+// @Failure      500     {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /auth/forgot-password [post]
+// ForgotPassword starts a password reset.
+func ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var payload ForgotPasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	ip := clientIP(r)
+	lockedUntil, err := repo.LoginThrottles().PasswordResetLockedUntil(r.Context(), payload.Email, ip)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if lockedUntil != nil {
+		writeTooManyRequests(w, *lockedUntil, "too many password reset requests, try again later")
+		return
+	}
+
+	user, found, err := repo.Users().GetByEmail(r.Context(), payload.Email)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	// Unknown accounts are counted too so that the lockout does not tell who has one.
+	known := found && user.Visible
+	var userID int64
+	if known {
+		userID = user.ID
+	}
+	events, err := repo.LoginThrottles().RecordPasswordReset(r.Context(), payload.Email, ip, userID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	logLockouts(events)
+
+	// The token is stored and mailed in the background. Doing it before responding would make the
+	// response slower, or fail, for known accounts only and tell who has one.
+	if known {
+		go sendPasswordReset(context.WithoutCancel(r.Context()), repo, middleware.GetMailer(r.Context()), user)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// sendPasswordReset stores a new reset token for the user and emails them the link. Errors are only
+// logged since the client has already been answered.
+func sendPasswordReset(ctx context.Context, repo repos.GlobalRepo, m mailer.Mailer, user *types.User) {
+	token, tokenHash, err := jwtpgk.GenerateOpaqueToken()
+	if err != nil {
+		log.Printf("unable to generate password reset token for user %d: %s", user.ID, err.Error())
+		return
+	}
+	if err := repo.PasswordResetTokens().Create(ctx, user.ID, tokenHash, time.Now().Add(passwordResetTokenTTL)); err != nil {
+		log.Printf("unable to store password reset token for user %d: %s", user.ID, err.Error())
+		return
+	}
+
+	msg := &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWe received a request to reset your password. Use the link below to choose a new one. It expires in one hour.\n\n%s?token=%s\n\nIf you did not ask to reset your password, you can ignore this email.\n",
+			user.FirstName, resetPasswordURL, url.QueryEscape(token),
+		),
+	}
+	if err := m.Send(ctx, msg); err != nil {
+		log.Printf("unable to send password reset email to user %d: %s", user.ID, err.Error())
+	}
+}
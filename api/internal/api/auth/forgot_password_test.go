@@ -0,0 +1,128 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Forgot Password Handler", func() {
+	var (
+		rr   *httptest.ResponseRecorder
+		user *types.User
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		user = &types.User{ID: 1, FirstName: "Test", Email: "test@example.com", Visible: true}
+	})
+
+	expectNotLocked := func(email string, userID int64) {
+		mockThrottles.EXPECT().PasswordResetLockedUntil(gomock.Any(), email, gomock.Any()).Return(nil, nil)
+		mockThrottles.EXPECT().RecordPasswordReset(gomock.Any(), email, gomock.Any(), userID).Return(nil, nil)
+	}
+
+	performRequest := func(email string) {
+		body, _ := json.Marshal(map[string]string{"email": email})
+		req := newAuthenticatedRequest(http.MethodPost, "/auth/forgot-password", body, nil)
+		router.ServeHTTP(rr, req)
+	}
+
+	It("should store a hashed reset token and email the token to the user", func() {
+		var storedHash string
+		expectNotLocked("test@example.com", 1)
+		mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+		mockResetsRepo.EXPECT().Create(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, tokenHash string, expiresAt time.Time) error {
+			Expect(expiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+			storedHash = tokenHash
+			return nil
+		})
+
+		performRequest("test@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Eventually(outbox.Messages).Should(HaveLen(1))
+		messages := outbox.Messages()
+		Expect(messages[0].To).To(Equal("test@example.com"))
+
+		// The emailed link carries the token itself; only its hash is stored.
+		idx := strings.Index(messages[0].Body, "?token=")
+		Expect(idx).To(BeNumerically(">", 0))
+		token, err := url.QueryUnescape(strings.Fields(messages[0].Body[idx+len("?token="):])[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(token).NotTo(Equal(storedHash))
+		Expect(jwtpkg.HashOpaqueToken(token)).To(Equal(storedHash))
+	})
+
+	It("should respond the same for an unknown email without sending mail", func() {
+		expectNotLocked("nobody@example.com", 0)
+		mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "nobody@example.com").Return(nil, false, nil)
+
+		performRequest("nobody@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Expect(outbox.Messages()).To(BeEmpty())
+	})
+
+	It("should not send mail to a deleted user", func() {
+		user.Visible = false
+		expectNotLocked("test@example.com", 0)
+		mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+
+		performRequest("test@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Expect(outbox.Messages()).To(BeEmpty())
+	})
+
+	It("should return 400 for an invalid email", func() {
+		performRequest("not-an-email")
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should respond before the token is stored and not mail it when storing fails", func() {
+		stored := make(chan struct{})
+		expectNotLocked("test@example.com", 1)
+		mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+		mockResetsRepo.EXPECT().Create(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, int64, string, time.Time) error {
+			defer close(stored)
+			return errors.New("db error")
+		})
+
+		performRequest("test@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusAccepted))
+		Eventually(stored).Should(BeClosed())
+		Consistently(outbox.Messages, 50*time.Millisecond).Should(BeEmpty())
+	})
+
+	It("should return 429 with Retry-After while the email or IP address is locked out", func() {
+		lockedUntil := time.Now().Add(5 * time.Minute)
+		mockThrottles.EXPECT().PasswordResetLockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(&lockedUntil, nil)
+
+		performRequest("test@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(rr.Header().Get("Retry-After")).To(Or(Equal("300"), Equal("299")))
+		Expect(outbox.Messages()).To(BeEmpty())
+	})
+
+	It("should return 500 when the lockout cannot be checked", func() {
+		mockThrottles.EXPECT().PasswordResetLockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, errors.New("db error"))
+
+		performRequest("test@example.com")
+
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Impersonate a user
+// @Description  Issues a short-lived access token that acts as another user, to see and reproduce what they see. Requires the users:impersonate platform permission.
+// @Description  The token names both the admin and the user and belongs to the admin's session: it cannot be refreshed, and logging out with it only ends the impersonation. Every write made with it is logged with the admin.
+// @Description  Other platform admins cannot be impersonated, and an impersonation token cannot start another impersonation.
+// @Tags         auth
+// @Produce      json
+// @Param        userId  path      int                       true  "User ID"
+// @Success      200     {object}  ImpersonationResponse     "Impersonation token"
+// @Failure      400     {object}  middleware.ErrorResponse  "Invalid User ID"
+// @Failure      401     {object}  middleware.ErrorResponse  "Unauthorized"
+// @Failure      403     {object}  middleware.ErrorResponse  "Forbidden"
+// @Failure      404     {object}  middleware.ErrorResponse  "User not found"
+// @Failure      500     {object}  middleware.ErrorResponse  "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/impersonate/{userId} [post]
+// Impersonate starts acting as another user.
+func Impersonate(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	// API keys have no session to impersonate from.
+	claims, found := middleware.GetAuthClaimsFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusForbidden, "forbidden")
+		return
+	}
+	if authUser.Impersonator != nil {
+		middleware.WriteError(w, http.StatusForbidden, "already impersonating a user")
+		return
+	}
+	if !authUser.HasPermission(types.PermissionUsersImpersonate) {
+		middleware.WriteError(w, http.StatusForbidden, "forbidden")
+		return
+	}
+
+	userID, err := strconv.ParseInt(mux.Vars(r)["userId"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	if userID == authUser.ID {
+		middleware.WriteError(w, http.StatusBadRequest, "cannot impersonate yourself")
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	user, found, err := repo.Users().Get(r.Context(), 0, userID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to retrieve user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	user.Permissions, err = repo.CompanyRoles().GetUserPermissions(r.Context(), user.ID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to retrieve user permissions")
+		return
+	}
+	for _, perm := range types.PlatformPermissions {
+		if user.HasPermission(perm) {
+			middleware.WriteError(w, http.StatusForbidden, "cannot impersonate a platform admin")
+			return
+		}
+	}
+
+	token, err := jwtpgk.GenerateImpersonationToken(user, authUser, claims.SessionID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+	log.Printf("impersonation: user %d started acting as user %d of company %d", authUser.ID, user.ID, user.CompanyID)
+
+	middleware.WriteJSON(w, http.StatusOK, ImpersonationResponse{
+		Token:     token,
+		ExpiresIn: int64(jwtpgk.ImpersonationTTL.Seconds()),
+		User:      user,
+	})
+}
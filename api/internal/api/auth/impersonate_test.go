@@ -0,0 +1,105 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Impersonate Handler", func() {
+	var (
+		rr     *httptest.ResponseRecorder
+		admin  *types.User
+		target *types.User
+		claims *jwtpkg.CustomClaims
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		admin = &types.User{ID: 1, CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+		target = &types.User{ID: 5, CompanyID: 3, Email: "user@example.com", Roles: types.Roles{types.RoleUser}}
+		claims = &jwtpkg.CustomClaims{UserID: admin.ID, SessionID: 7}
+	})
+
+	performRequest := func(url string, user *types.User, claims *jwtpkg.CustomClaims) {
+		req := newAuthenticatedRequest(http.MethodPost, url, nil, user)
+		if claims != nil {
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthClaimsKey, claims))
+		}
+		router.ServeHTTP(rr, req)
+	}
+
+	It("should issue a token naming both the admin and the user", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(target, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+
+		performRequest("/auth/impersonate/5", admin, claims)
+
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		var resp auth.ImpersonationResponse
+		Expect(json.Unmarshal(rr.Body.Bytes(), &resp)).To(Succeed())
+		Expect(resp.ExpiresIn).To(Equal(int64(jwtpkg.ImpersonationTTL.Seconds())))
+		Expect(resp.User.ID).To(Equal(int64(5)))
+
+		tokenClaims, err := jwtpkg.ValidateToken(resp.Token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tokenClaims.UserID).To(Equal(int64(5)))
+		Expect(tokenClaims.CompanyID).To(Equal(int64(3)))
+		Expect(tokenClaims.ImpersonatorID).To(Equal(int64(1)))
+		Expect(tokenClaims.SessionID).To(Equal(int64(7)))
+	})
+
+	It("should require the users:impersonate permission", func() {
+		admin.Roles = types.Roles{types.RoleAdmin}
+		performRequest("/auth/impersonate/5", admin, claims)
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should not start an impersonation from an impersonation token", func() {
+		impersonated := &types.User{ID: 6, CompanyID: 3, Roles: types.Roles{types.RoleSuperAdmin}, Impersonator: admin}
+		performRequest("/auth/impersonate/5", impersonated, claims)
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should not impersonate without a session", func() {
+		performRequest("/auth/impersonate/5", admin, nil)
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should not impersonate yourself", func() {
+		performRequest("/auth/impersonate/1", admin, claims)
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should not impersonate another platform admin", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(target, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+		target.Roles = types.Roles{types.RoleSuperAdmin}
+
+		performRequest("/auth/impersonate/5", admin, claims)
+
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 404 for an unknown user", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(nil, false, nil)
+		performRequest("/auth/impersonate/5", admin, claims)
+		Expect(rr.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 when the user cannot be loaded", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(nil, false, errors.New("db error"))
+		performRequest("/auth/impersonate/5", admin, claims)
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
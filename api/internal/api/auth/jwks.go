@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+)
+
+// @Summary      JSON Web Key Set
+// @Description  Lists the public keys access tokens are signed with, so that other services can verify them. Tokens name their key in the kid header. During a key rotation the set holds both the new and the previous key.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  jwtpkg.JWKS  "Public keys"
+// @Router       /.well-known/jwks.json [get]
+// JWKS publishes the token verification keys.
+func JWKS(w http.ResponseWriter, r *http.Request) {
+	// Verifiers may cache the keys briefly; a new key is published before it signs any token.
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	middleware.WriteJSON(w, http.StatusOK, jwtpgk.Keys().JWKS())
+}
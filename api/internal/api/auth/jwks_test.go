@@ -0,0 +1,28 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JWKS Handler", func() {
+	It("should publish the key tokens are signed with", func() {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+		router.ServeHTTP(rr, req)
+
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(rr.Header().Get("Cache-Control")).To(ContainSubstring("max-age"))
+
+		var set jwtpkg.JWKS
+		Expect(json.Unmarshal(rr.Body.Bytes(), &set)).To(Succeed())
+		Expect(set.Keys).NotTo(BeEmpty())
+		Expect(set.Keys[0].Kid).To(Equal(jwtpkg.Keys().SigningKey().ID))
+		Expect(set.Keys[0].Use).To(Equal("sig"))
+	})
+})
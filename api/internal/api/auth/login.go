@@ -2,23 +2,31 @@ package auth
 
 import (
 	"encoding/json"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
-	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"golang.org/x/crypto/bcrypt"
 )
 
 // @Summary      User Login
-// @Description  Authenticates a user and returns a JWT token.
+// @Description  Authenticates a user and starts a session. Returns a short-lived access token and a single-use refresh token.
+// @Description  Too many failed logins for an email or from a client lock it for a while; the lockout doubles every time.
+// @Description  Users with multi-factor authentication, or whose company requires it, get an MFAChallengeResponse instead of tokens and continue at /login/mfa.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        credentials body      LoginPayload             true  "Login Credentials"
-// @Success      200         {object}  LoginResponse            "Successfully authenticated"
+// @Success      200         {object}  LoginResponse            "Successfully authenticated, or an MFAChallengeResponse"
 // @Failure      400         {object}  middleware.ErrorResponse "Bad Request - Invalid input"
 // @Failure      401         {object}  middleware.ErrorResponse "Unauthorized - Invalid credentials"
+// @Failure      429         {object}  middleware.ErrorResponse "Too Many Requests - Too many failed logins for the email or client"
 // @Failure      500         {object}  middleware.ErrorResponse "Internal Server Error"
 // @Router       /auth/login [post]
 // Login handles user authentication.
@@ -35,6 +43,19 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	repo := middleware.GetRepo(r.Context())
+	ip := clientIP(r)
+
+	// Locked emails and clients are turned away before any password is checked. Unknown emails are
+	// counted and locked like known ones, so a lockout does not tell whether an account exists.
+	lockedUntil, err := repo.LoginThrottles().LockedUntil(r.Context(), payload.Email, ip)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if lockedUntil != nil {
+		writeLockedOut(w, *lockedUntil)
+		return
+	}
 
 	user, found, err := repo.Users().GetByEmail(r.Context(), payload.Email)
 	if err != nil {
@@ -42,17 +63,35 @@ func Login(w http.ResponseWriter, r *http.Request) {
 		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
 		return
 	}
-	if !found {
-		middleware.WriteError(w, http.StatusUnauthorized, "invalid email or password")
+	if !found || !user.Visible {
+		// Compare against a dummy hash so that unknown emails take as long as wrong passwords.
+		bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(payload.Password))
+		loginFailed(w, r, repo, payload.Email, ip, 0, "invalid email or password")
 		return
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(payload.Password)); err != nil {
-		middleware.WriteError(w, http.StatusUnauthorized, "invalid email or password")
+		loginFailed(w, r, repo, payload.Email, ip, user.ID, "invalid email or password")
+		return
+	}
+
+	// The failed logins are only forgotten once the second factor is entered too, so that knowing
+	// the password does not allow guessing codes without limit.
+	enrollmentRequired, err := mfaEnrollmentRequired(r, repo, user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
 		return
 	}
+	if user.MFAEnabled || enrollmentRequired {
+		writeMFAChallenge(w, user, enrollmentRequired)
+		return
+	}
+
+	if err := repo.LoginThrottles().Reset(r.Context(), payload.Email); err != nil {
+		log.Printf("failed to reset failed logins of user %d: %v", user.ID, err)
+	}
 
-	token, err := jwtpgk.GenerateToken(user)
+	res, err := startSession(r, repo, user)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
 		return
@@ -60,5 +99,46 @@ func Login(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(LoginResponse{Token: token})
+	json.NewEncoder(w).Encode(res)
+}
+
+// loginFailed counts a failed login for the email and client and answers with the message. The
+// message is the same whether or not the email belongs to an account.
+func loginFailed(w http.ResponseWriter, r *http.Request, repo repos.GlobalRepo, email, ip string, userID int64, message string) {
+	events, err := repo.LoginThrottles().RecordFailure(r.Context(), email, ip, userID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	logLockouts(events)
+	middleware.WriteError(w, http.StatusUnauthorized, message)
+}
+
+// writeLockedOut tells the client to wait until the lockout ends.
+func writeLockedOut(w http.ResponseWriter, lockedUntil time.Time) {
+	writeTooManyRequests(w, lockedUntil, "too many failed login attempts, try again later")
+}
+
+// writeTooManyRequests answers 429 with the message and a Retry-After header for when the lockout
+// ends.
+func writeTooManyRequests(w http.ResponseWriter, lockedUntil time.Time, message string) {
+	retryAfter := int64(math.Ceil(time.Until(lockedUntil).Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	middleware.WriteError(w, http.StatusTooManyRequests, message)
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// dummyPasswordHash returns a bcrypt hash with the default cost that belongs to no account.
+func dummyPasswordHash() []byte {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("order-management-dummy-password"), bcrypt.DefaultCost)
+	})
+	return dummyHash
 }
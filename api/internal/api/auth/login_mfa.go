@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/totp"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Complete Login with MFA
+// @Description  Exchanges the MFA token from /login and a code of the authenticator app, or a recovery code, for an access token and refresh token.
+// @Description  Users completing an enrollment required by their company send the first code of the new secret and get their recovery codes with the tokens.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        credentials body      MFALoginPayload          true  "MFA Token and Code"
+// @Success      200         {object}  LoginResponse            "Successfully authenticated"
+// @Failure      400         {object}  middleware.ErrorResponse "Bad Request - Invalid input or not enrolled"
+// @Failure      401         {object}  middleware.ErrorResponse "Unauthorized - Invalid MFA token or code"
+// @Failure      429         {object}  middleware.ErrorResponse "Too Many Requests - Too many failed logins for the email or client"
+// @Failure      500         {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /login/mfa [post]
+// LoginMFA completes a login with the second factor.
+func LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var payload MFALoginPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+	user, found := mfaChallengeUser(w, r, repo, payload.MFAToken)
+	if !found {
+		return
+	}
+
+	ip := clientIP(r)
+	lockedUntil, err := repo.LoginThrottles().LockedUntil(r.Context(), user.Email, ip)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if lockedUntil != nil {
+		writeLockedOut(w, *lockedUntil)
+		return
+	}
+
+	var recoveryCodes []string
+	if user.MFAEnabled {
+		ok, err := verifySecondFactor(r, repo, user, payload.Code)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "failed to verify code")
+			return
+		}
+		if !ok {
+			loginFailed(w, r, repo, user.Email, ip, user.ID, "invalid code")
+			return
+		}
+	} else {
+		if user.MFASecret == "" {
+			middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication has not been enrolled")
+			return
+		}
+		step, ok := totp.Validate(user.MFASecret, payload.Code, time.Now(), 0)
+		if !ok {
+			loginFailed(w, r, repo, user.Email, ip, user.ID, "invalid code")
+			return
+		}
+		recoveryCodes, err = enableMFA(r, repo, user, step)
+		if err != nil {
+			if errors.Is(err, repos.ErrMFANotEnrolled) {
+				middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication has not been enrolled")
+				return
+			}
+			middleware.WriteError(w, http.StatusInternalServerError, "failed to enable multi-factor authentication")
+			return
+		}
+	}
+
+	if err := repo.LoginThrottles().Reset(r.Context(), user.Email); err != nil {
+		log.Printf("failed to reset failed logins of user %d: %v", user.ID, err)
+	}
+
+	res, err := startSession(r, repo, user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+	res.RecoveryCodes = recoveryCodes
+
+	middleware.WriteJSON(w, http.StatusOK, res)
+}
+
+// @Summary      Enroll in MFA during Login
+// @Description  Creates a TOTP secret for a user whose company requires MFA and who has not enrolled yet. The first code of the secret completes the login at /login/mfa.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token body      MFAEnrollLoginPayload    true  "MFA Token"
+// @Success      200   {object}  MFAEnrollmentResponse    "New secret"
+// @Failure      400   {object}  middleware.ErrorResponse "Bad Request - Invalid input"
+// @Failure      401   {object}  middleware.ErrorResponse "Unauthorized - Invalid MFA token"
+// @Failure      409   {object}  middleware.ErrorResponse "Conflict - MFA is already enabled"
+// @Failure      500   {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /login/mfa/enroll [post]
+// LoginEnrollMFA starts the MFA enrollment of a user who is logging in.
+func LoginEnrollMFA(w http.ResponseWriter, r *http.Request) {
+	var payload MFAEnrollLoginPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+	user, found := mfaChallengeUser(w, r, repo, payload.MFAToken)
+	if !found {
+		return
+	}
+
+	startMFAEnrollment(w, r, repo, user)
+}
+
+// mfaChallengeUser returns the user an MFA challenge token was issued to and answers the request
+// when the token is invalid or the user is gone.
+func mfaChallengeUser(w http.ResponseWriter, r *http.Request, repo repos.GlobalRepo, token string) (*types.User, bool) {
+	claims, err := jwtpgk.ValidateMFAChallengeToken(token)
+	if err != nil {
+		middleware.WriteError(w, http.StatusUnauthorized, "invalid or expired MFA token")
+		return nil, false
+	}
+
+	user, found, err := repo.Users().Get(r.Context(), claims.CompanyID, claims.UserID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "invalid or expired MFA token")
+		return nil, false
+	}
+	return user, true
+}
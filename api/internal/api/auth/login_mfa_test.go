@@ -0,0 +1,228 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/totp"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("MFA Login", func() {
+	var (
+		rr     *httptest.ResponseRecorder
+		user   *types.User
+		secret string
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+
+		var err error
+		secret, err = totp.GenerateSecret()
+		Expect(err).NotTo(HaveOccurred())
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+		Expect(err).NotTo(HaveOccurred())
+		user = &types.User{
+			ID:         1,
+			Email:      "test@example.com",
+			Password:   string(hashedPassword),
+			CompanyID:  3,
+			Visible:    true,
+			MFAEnabled: true,
+			MFASecret:  secret,
+		}
+	})
+
+	currentCode := func() string {
+		code, err := totp.Code(secret, totp.Step(time.Now()))
+		Expect(err).NotTo(HaveOccurred())
+		return code
+	}
+
+	challengeToken := func() string {
+		token, err := jwtpkg.GenerateMFAChallengeToken(user)
+		Expect(err).NotTo(HaveOccurred())
+		return token
+	}
+
+	performLogin := func() {
+		body, _ := json.Marshal(map[string]string{"email": "test@example.com", "password": "password123"})
+		router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, "/login", body, nil))
+	}
+
+	performMFALogin := func(token, code string) {
+		body, _ := json.Marshal(auth.MFALoginPayload{MFAToken: token, Code: code})
+		router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, "/login/mfa", body, nil))
+	}
+
+	Describe("POST /login", func() {
+		It("should return an MFA challenge instead of tokens when MFA is enabled", func() {
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+
+			performLogin()
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.MFAChallengeResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.MFAToken).NotTo(BeEmpty())
+			Expect(response.EnrollmentRequired).To(BeFalse())
+			Expect(rr.Body.String()).NotTo(ContainSubstring(`"token"`))
+
+			_, err := jwtpkg.ValidateToken(response.MFAToken)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should require enrollment when the company requires MFA", func() {
+			user.MFAEnabled = false
+			user.MFASecret = ""
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3, RequireMFA: true}, true, nil)
+
+			performLogin()
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.MFAChallengeResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.MFAToken).NotTo(BeEmpty())
+			Expect(response.EnrollmentRequired).To(BeTrue())
+		})
+	})
+
+	Describe("POST /login/mfa", func() {
+		It("should return tokens for a valid authenticator code", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			mockThrottles.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+			mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			performMFALogin(challengeToken(), currentCode())
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.LoginResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Token).NotTo(BeEmpty())
+			Expect(response.RecoveryCodes).To(BeEmpty())
+		})
+
+		It("should return tokens for an unused recovery code", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), totp.HashRecoveryCode("k3x9q-7mwpd")).Return(true, nil)
+			mockThrottles.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+			mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			performMFALogin(challengeToken(), "K3X9Q-7MWPD")
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should count a wrong code as a failed login", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "test@example.com", gomock.Any(), int64(1)).Return(nil, nil)
+
+			performMFALogin(challengeToken(), "wrong-code")
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject a code that was already used", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseStep(gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			mockMFARepo.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), gomock.Any()).Return(false, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "test@example.com", gomock.Any(), int64(1)).Return(nil, nil)
+
+			performMFALogin(challengeToken(), currentCode())
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 429 while the email is locked", func() {
+			lockedUntil := time.Now().Add(time.Minute)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(&lockedUntil, nil)
+
+			performMFALogin(challengeToken(), currentCode())
+
+			Expect(rr.Code).To(Equal(http.StatusTooManyRequests))
+		})
+
+		It("should not accept an access token as the MFA token", func() {
+			accessToken, err := jwtpkg.GenerateToken(user, 7)
+			Expect(err).NotTo(HaveOccurred())
+
+			performMFALogin(accessToken, currentCode())
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should complete an enrollment and return recovery codes", func() {
+			user.MFAEnabled = false
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().Enable(gomock.Any(), int64(1), gomock.Any(), gomock.Len(totp.RecoveryCodeCount)).Return(nil)
+			mockThrottles.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+			mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+			performMFALogin(challengeToken(), currentCode())
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.LoginResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Token).NotTo(BeEmpty())
+			Expect(response.RecoveryCodes).To(HaveLen(totp.RecoveryCodeCount))
+		})
+
+		It("should return 400 for a user who has not enrolled", func() {
+			user.MFAEnabled = false
+			user.MFASecret = ""
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+
+			performMFALogin(challengeToken(), "123456")
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("POST /login/mfa/enroll", func() {
+		It("should create a secret for a user who has not enrolled", func() {
+			user.MFAEnabled = false
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockMFARepo.EXPECT().SetSecret(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+
+			body, _ := json.Marshal(auth.MFAEnrollLoginPayload{MFAToken: challengeToken()})
+			router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, "/login/mfa/enroll", body, nil))
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.MFAEnrollmentResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.Secret).NotTo(BeEmpty())
+			Expect(response.OTPAuthURI).To(HavePrefix("otpauth://totp/"))
+		})
+
+		It("should return 409 when MFA is already enabled", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+
+			body, _ := json.Marshal(auth.MFAEnrollLoginPayload{MFAToken: challengeToken()})
+			router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, "/login/mfa/enroll", body, nil))
+
+			Expect(rr.Code).To(Equal(http.StatusConflict))
+		})
+	})
+})
@@ -1,12 +1,15 @@
 package auth_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -30,26 +33,66 @@ var _ = Describe("Login Handler", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			user := &types.User{
-				ID:       1,
-				Email:    "test@example.com",
-				Password: string(hashedPassword),
+				ID:        1,
+				Email:     "test@example.com",
+				Password:  string(hashedPassword),
+				CompanyID: 3,
+				Visible:   true,
 			}
 
 			creds := map[string]string{"email": "test@example.com", "password": password}
 			body, _ := json.Marshal(creds)
 
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3}, true, nil)
+			mockThrottles.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+			mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session *types.Session, refreshTokenHash string) error {
+				Expect(session.UserID).To(Equal(int64(1)))
+				Expect(session.CompanyID).To(Equal(int64(3)))
+				Expect(session.ExpiresAt).To(BeTemporally(">", time.Now()))
+				Expect(refreshTokenHash).NotTo(BeEmpty())
+				session.ID = 7
+				return nil
+			})
 
 			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
 			auth.Login(rr, req)
 
 			Expect(rr.Code).To(Equal(http.StatusOK))
 
-			var response map[string]string
+			var response auth.LoginResponse
 			err = json.Unmarshal(rr.Body.Bytes(), &response)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(response).To(HaveKey("token"))
-			Expect(response["token"]).NotTo(BeEmpty())
+			Expect(response.Token).NotTo(BeEmpty())
+			Expect(response.RefreshToken).NotTo(BeEmpty())
+			Expect(response.ExpiresIn).To(Equal(int64(jwtpkg.AccessTokenTTL.Seconds())))
+
+			claims, err := jwtpkg.ValidateToken(response.Token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims.SessionID).To(Equal(int64(7)))
+			Expect(claims.ID).NotTo(BeEmpty())
+		})
+	})
+
+	Context("when the user has been deleted", func() {
+		It("should return 401 Unauthorized", func() {
+			password := "password123"
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			Expect(err).NotTo(HaveOccurred())
+
+			user := &types.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), Visible: false}
+			creds := map[string]string{"email": "test@example.com", "password": password}
+			body, _ := json.Marshal(creds)
+
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "test@example.com", gomock.Any(), int64(0)).Return(nil, nil)
+
+			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
+			auth.Login(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
 		})
 	})
 
@@ -58,7 +101,9 @@ var _ = Describe("Login Handler", func() {
 			creds := map[string]string{"email": "notfound@example.com", "password": "password123"}
 			body, _ := json.Marshal(creds)
 
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "notfound@example.com", gomock.Any()).Return(nil, nil)
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "notfound@example.com").Return(nil, false, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "notfound@example.com", gomock.Any(), int64(0)).Return(nil, nil)
 
 			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
 			auth.Login(rr, req)
@@ -73,11 +118,13 @@ var _ = Describe("Login Handler", func() {
 			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 			Expect(err).NotTo(HaveOccurred())
 
-			user := &types.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword)}
+			user := &types.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), Visible: true}
 			creds := map[string]string{"email": "test@example.com", "password": "wrongpassword"}
 			body, _ := json.Marshal(creds)
 
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "test@example.com", gomock.Any(), int64(1)).Return(nil, nil)
 
 			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
 			auth.Login(rr, req)
@@ -92,6 +139,7 @@ var _ = Describe("Login Handler", func() {
 			body, _ := json.Marshal(creds)
 
 			dbErr := errors.New("database connection lost")
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(nil, false, dbErr)
 
 			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
@@ -101,6 +149,76 @@ var _ = Describe("Login Handler", func() {
 		})
 	})
 
+	Context("when the session cannot be created", func() {
+		It("should return 500 Internal Server Error", func() {
+			password := "password123"
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+			Expect(err).NotTo(HaveOccurred())
+
+			user := &types.User{ID: 1, Email: "test@example.com", Password: string(hashedPassword), Visible: true}
+			creds := map[string]string{"email": "test@example.com", "password": password}
+			body, _ := json.Marshal(creds)
+
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "test@example.com").Return(user, true, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(0)).Return(&types.Company{}, true, nil)
+			mockThrottles.EXPECT().Reset(gomock.Any(), "test@example.com").Return(nil)
+			mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("database connection lost"))
+
+			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
+			auth.Login(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Context("when the email or client is locked out", func() {
+		It("should return 429 Too Many Requests with Retry-After without checking the password", func() {
+			creds := map[string]string{"email": "test@example.com", "password": "password123"}
+			body, _ := json.Marshal(creds)
+
+			lockedUntil := time.Now().Add(90 * time.Second)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(&lockedUntil, nil)
+
+			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
+			auth.Login(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusTooManyRequests))
+			Expect(rr.Header().Get("Retry-After")).To(Or(Equal("90"), Equal("89")))
+		})
+
+		It("should return 500 Internal Server Error when the lockout cannot be checked", func() {
+			creds := map[string]string{"email": "test@example.com", "password": "password123"}
+			body, _ := json.Marshal(creds)
+
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, errors.New("database connection lost"))
+
+			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
+			auth.Login(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Context("when a failed login locks the email", func() {
+		It("should still return 401 Unauthorized", func() {
+			creds := map[string]string{"email": "notfound@example.com", "password": "password123"}
+			body, _ := json.Marshal(creds)
+
+			lockedUntil := time.Now().Add(time.Minute)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "notfound@example.com", gomock.Any()).Return(nil, nil)
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "notfound@example.com").Return(nil, false, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "notfound@example.com", gomock.Any(), int64(0)).Return([]*types.LoginLockoutEvent{{
+				Event: types.LoginLockoutEventLocked, Scope: types.LoginThrottleScopeAccount, Key: "notfound@example.com", LockedUntil: &lockedUntil,
+			}}, nil)
+
+			req := newAuthenticatedRequest(http.MethodPost, "/login", body, nil)
+			auth.Login(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
 	Context("when the request body is invalid", func() {
 		It("should return 400 Bad Request for malformed JSON", func() {
 			body := []byte(`{"email": "test@example.com",`) // Malformed JSON
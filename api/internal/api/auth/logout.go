@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// @Summary      User Logout
+// @Description  Ends the session of the access token. The access token and the session's refresh token stop working immediately.
+// @Description  With an impersonation token only the impersonation ends; the admin's own session stays active.
+// @Tags         auth
+// @Success      204  "Logged out"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/logout [post]
+// Logout revokes the current session, or only the access token while impersonating.
+func Logout(w http.ResponseWriter, r *http.Request) {
+	claims, found := middleware.GetAuthClaimsFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	// Impersonation tokens share the admin's session, which should outlive the impersonation.
+	if claims.ImpersonatorID == 0 {
+		if err := repo.Sessions().Revoke(r.Context(), claims.SessionID); err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "failed to log out")
+			return
+		}
+	}
+	if err := repo.Sessions().RevokeAccessToken(r.Context(), claims.ID, claims.ExpiresAt.Time); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
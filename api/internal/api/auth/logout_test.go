@@ -0,0 +1,75 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Logout Handler", func() {
+	var (
+		rr     *httptest.ResponseRecorder
+		claims *jwtpkg.CustomClaims
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		claims = &jwtpkg.CustomClaims{
+			UserID:    1,
+			SessionID: 7,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        "token-id",
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+	})
+
+	performRequest := func(claims *jwtpkg.CustomClaims) {
+		req := newAuthenticatedRequest(http.MethodPost, "/auth/logout", nil, &types.User{ID: 1})
+		if claims != nil {
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthClaimsKey, claims))
+		}
+		router.ServeHTTP(rr, req)
+	}
+
+	It("should revoke the session and the access token", func() {
+		mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(7)).Return(nil)
+		mockSessionsRepo.EXPECT().RevokeAccessToken(gomock.Any(), "token-id", claims.ExpiresAt.Time).Return(nil)
+
+		performRequest(claims)
+
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should only revoke the access token while impersonating", func() {
+		claims.ImpersonatorID = 9
+		mockSessionsRepo.EXPECT().RevokeAccessToken(gomock.Any(), "token-id", claims.ExpiresAt.Time).Return(nil)
+
+		performRequest(claims)
+
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 401 without an access token", func() {
+		performRequest(nil)
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should return 500 when the session cannot be revoked", func() {
+		mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(7)).Return(errors.New("database connection lost"))
+
+		performRequest(claims)
+
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,342 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/totp"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// mfaIssuer is the name authenticator apps show next to the user's email.
+var mfaIssuer = "Order Management"
+
+// @Summary      Enroll in MFA
+// @Description  Creates a TOTP secret for the signed-in user's authenticator app. MFA is turned on once the first code is sent to /auth/mfa/confirm; enrolling again before that replaces the secret.
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  MFAEnrollmentResponse    "New secret"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      409  {object}  middleware.ErrorResponse "Conflict - MFA is already enabled"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/mfa/enroll [post]
+// EnrollMFA starts the MFA enrollment of the signed-in user.
+func EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	user, found := signedInUser(w, r)
+	if !found {
+		return
+	}
+
+	startMFAEnrollment(w, r, middleware.GetRepo(r.Context()), user)
+}
+
+// @Summary      Confirm MFA enrollment
+// @Description  Turns MFA on with the first code of the authenticator app and returns the user's recovery codes. The recovery codes are shown only once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        code body      MFACodePayload           true  "Authenticator Code"
+// @Success      200  {object}  MFARecoveryCodesResponse "MFA enabled"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid code or not enrolled"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      409  {object}  middleware.ErrorResponse "Conflict - MFA is already enabled"
+// @Failure      429  {object}  middleware.ErrorResponse "Too Many Requests - Too many wrong codes"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/mfa/confirm [post]
+// ConfirmMFA completes the MFA enrollment of the signed-in user.
+func ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	user, found := signedInUser(w, r)
+	if !found {
+		return
+	}
+
+	var payload MFACodePayload
+	if !decodeMFACodePayload(w, r, &payload) {
+		return
+	}
+
+	if user.MFAEnabled {
+		middleware.WriteError(w, http.StatusConflict, "multi-factor authentication is already enabled")
+		return
+	}
+	if user.MFASecret == "" {
+		middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication has not been enrolled")
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	var step int64
+	if !requireMFACode(w, r, repo, user, func() (bool, error) {
+		var ok bool
+		step, ok = totp.Validate(user.MFASecret, payload.Code, time.Now(), 0)
+		return ok, nil
+	}) {
+		return
+	}
+
+	codes, err := enableMFA(r, repo, user, step)
+	if err != nil {
+		if errors.Is(err, repos.ErrMFANotEnrolled) {
+			middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication has not been enrolled")
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to enable multi-factor authentication")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// @Summary      Regenerate MFA recovery codes
+// @Description  Replaces the signed-in user's recovery codes with a new set, confirmed with a code of the authenticator app. The earlier codes stop working and the new ones are shown only once.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        code body      MFACodePayload           true  "Authenticator Code"
+// @Success      200  {object}  MFARecoveryCodesResponse "New recovery codes"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid code or MFA not enabled"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      429  {object}  middleware.ErrorResponse "Too Many Requests - Too many wrong codes"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/mfa/recovery-codes [post]
+// RegenerateMFARecoveryCodes gives the signed-in user new recovery codes.
+func RegenerateMFARecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	user, found := signedInUser(w, r)
+	if !found {
+		return
+	}
+
+	var payload MFACodePayload
+	if !decodeMFACodePayload(w, r, &payload) {
+		return
+	}
+
+	if !user.MFAEnabled {
+		middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication is not enabled")
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+	if !requireMFACode(w, r, repo, user, func() (bool, error) {
+		return verifyTOTP(r, repo, user, payload.Code)
+	}) {
+		return
+	}
+
+	codes, hashes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate recovery codes")
+		return
+	}
+	if err := repo.MFA().ReplaceRecoveryCodes(r.Context(), user.ID, hashes); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate recovery codes")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, MFARecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// @Summary      Disable MFA
+// @Description  Turns MFA off for the signed-in user, confirmed with a code of the authenticator app or a recovery code. If the user's company requires MFA, the user has to enroll again at the next login.
+// @Tags         auth
+// @Accept       json
+// @Param        code body      MFACodePayload           true  "Authenticator or Recovery Code"
+// @Success      204  "MFA disabled"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid code or MFA not enabled"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      429  {object}  middleware.ErrorResponse "Too Many Requests - Too many wrong codes"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /auth/mfa/disable [post]
+// DisableMFA turns MFA off for the signed-in user.
+func DisableMFA(w http.ResponseWriter, r *http.Request) {
+	user, found := signedInUser(w, r)
+	if !found {
+		return
+	}
+
+	var payload MFACodePayload
+	if !decodeMFACodePayload(w, r, &payload) {
+		return
+	}
+
+	if !user.MFAEnabled {
+		middleware.WriteError(w, http.StatusBadRequest, "multi-factor authentication is not enabled")
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+	if !requireMFACode(w, r, repo, user, func() (bool, error) {
+		return verifySecondFactor(r, repo, user, payload.Code)
+	}) {
+		return
+	}
+
+	if err := repo.MFA().Disable(r.Context(), user.ID); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to disable multi-factor authentication")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// signedInUser returns the user of the request's access token. Requests made with an API key have
+// no person behind them and cannot manage MFA.
+func signedInUser(w http.ResponseWriter, r *http.Request) (*types.User, bool) {
+	user, found := middleware.GetAuthUserFromContext(r.Context())
+	_, hasClaims := middleware.GetAuthClaimsFromContext(r.Context())
+	if !found || !hasClaims {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+	return user, true
+}
+
+// decodeMFACodePayload reads a code from the request body and answers the request when it is
+// invalid.
+func decodeMFACodePayload(w http.ResponseWriter, r *http.Request, payload *MFACodePayload) bool {
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return false
+	}
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// requireMFACode checks a code of a signed-in user with verify and answers the request when it is
+// wrong. Wrong codes count as failed logins, so they cannot be guessed without limit.
+func requireMFACode(w http.ResponseWriter, r *http.Request, repo repos.GlobalRepo, user *types.User, verify func() (bool, error)) bool {
+	ip := clientIP(r)
+	lockedUntil, err := repo.LoginThrottles().LockedUntil(r.Context(), user.Email, ip)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return false
+	}
+	if lockedUntil != nil {
+		writeLockedOut(w, *lockedUntil)
+		return false
+	}
+
+	ok, err := verify()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to verify code")
+		return false
+	}
+	if !ok {
+		events, err := repo.LoginThrottles().RecordFailure(r.Context(), user.Email, ip, user.ID)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+			return false
+		}
+		logLockouts(events)
+		middleware.WriteError(w, http.StatusBadRequest, "invalid code")
+		return false
+	}
+	return true
+}
+
+// mfaEnrollmentRequired reports whether the user's company requires MFA and the user has not
+// turned it on yet.
+func mfaEnrollmentRequired(r *http.Request, repo repos.GlobalRepo, user *types.User) (bool, error) {
+	if user.MFAEnabled {
+		return false, nil
+	}
+	company, found, err := repo.Companies().Get(r.Context(), user.CompanyID)
+	if err != nil {
+		return false, err
+	}
+	return found && company.RequireMFA, nil
+}
+
+// writeMFAChallenge answers a login with a challenge token instead of tokens.
+func writeMFAChallenge(w http.ResponseWriter, user *types.User, enrollmentRequired bool) {
+	token, err := jwtpgk.GenerateMFAChallengeToken(user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, MFAChallengeResponse{
+		MFAToken:           token,
+		EnrollmentRequired: enrollmentRequired,
+		ExpiresIn:          int64(jwtpgk.MFAChallengeTTL.Seconds()),
+	})
+}
+
+// startMFAEnrollment gives the user a new TOTP secret, replacing one they have not confirmed yet.
+func startMFAEnrollment(w http.ResponseWriter, r *http.Request, repo repos.GlobalRepo, user *types.User) {
+	if user.MFAEnabled {
+		middleware.WriteError(w, http.StatusConflict, "multi-factor authentication is already enabled")
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to enroll in multi-factor authentication")
+		return
+	}
+	if err := repo.MFA().SetSecret(r.Context(), user.ID, secret); err != nil {
+		if errors.Is(err, repos.ErrMFAAlreadyEnabled) {
+			middleware.WriteError(w, http.StatusConflict, "multi-factor authentication is already enabled")
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to enroll in multi-factor authentication")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, MFAEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(mfaIssuer, user.Email, secret),
+	})
+}
+
+// enableMFA turns MFA on for a user whose first code belonged to step and returns their new
+// recovery codes.
+func enableMFA(r *http.Request, repo repos.GlobalRepo, user *types.User, step int64) ([]string, error) {
+	codes, hashes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.MFA().Enable(r.Context(), user.ID, step, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyTOTP checks a code of the user's authenticator app and uses it up.
+func verifyTOTP(r *http.Request, repo repos.GlobalRepo, user *types.User, code string) (bool, error) {
+	step, ok := totp.Validate(user.MFASecret, code, time.Now(), user.MFALastStep)
+	if !ok {
+		return false, nil
+	}
+	return repo.MFA().UseStep(r.Context(), user.ID, step)
+}
+
+// verifySecondFactor checks a code of the user's authenticator app or one of their recovery codes
+// and uses it up.
+func verifySecondFactor(r *http.Request, repo repos.GlobalRepo, user *types.User, code string) (bool, error) {
+	if ok, err := verifyTOTP(r, repo, user, code); err != nil || ok {
+		return ok, err
+	}
+	return repo.MFA().UseRecoveryCode(r.Context(), user.ID, totp.HashRecoveryCode(code))
+}
+
+// logLockouts logs the lockouts caused by a failed login.
+func logLockouts(events []*types.LoginLockoutEvent) {
+	for _, e := range events {
+		log.Printf("login lockout: %s %q locked until %s after failed logins from %s", e.Scope, e.Key, e.LockedUntil.Format(time.RFC3339), e.IPAddress)
+	}
+}
@@ -0,0 +1,145 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/totp"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("MFA Handlers", func() {
+	var (
+		rr     *httptest.ResponseRecorder
+		user   *types.User
+		secret string
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+
+		var err error
+		secret, err = totp.GenerateSecret()
+		Expect(err).NotTo(HaveOccurred())
+		user = &types.User{ID: 1, Email: "test@example.com", CompanyID: 3}
+	})
+
+	currentCode := func() string {
+		code, err := totp.Code(secret, totp.Step(time.Now()))
+		Expect(err).NotTo(HaveOccurred())
+		return code
+	}
+
+	performRequest := func(url string, payload interface{}) {
+		var body []byte
+		if payload != nil {
+			body, _ = json.Marshal(payload)
+		}
+		req := newAuthenticatedRequest(http.MethodPost, url, body, user)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.AuthClaimsKey, &jwtpkg.CustomClaims{UserID: 1, SessionID: 7}))
+		router.ServeHTTP(rr, req)
+	}
+
+	Describe("POST /auth/mfa/enroll", func() {
+		It("should create a secret", func() {
+			mockMFARepo.EXPECT().SetSecret(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+
+			performRequest("/auth/mfa/enroll", nil)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.MFAEnrollmentResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.OTPAuthURI).To(ContainSubstring("secret=" + response.Secret))
+		})
+
+		It("should return 409 when MFA is already enabled", func() {
+			mockMFARepo.EXPECT().SetSecret(gomock.Any(), int64(1), gomock.Any()).Return(repos.ErrMFAAlreadyEnabled)
+			performRequest("/auth/mfa/enroll", nil)
+			Expect(rr.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 401 for requests made with an API key", func() {
+			router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, "/auth/mfa/enroll", nil, user))
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Describe("POST /auth/mfa/confirm", func() {
+		BeforeEach(func() {
+			user.MFASecret = secret
+		})
+
+		It("should enable MFA and return recovery codes", func() {
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().Enable(gomock.Any(), int64(1), gomock.Any(), gomock.Len(totp.RecoveryCodeCount)).Return(nil)
+
+			performRequest("/auth/mfa/confirm", auth.MFACodePayload{Code: currentCode()})
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.MFARecoveryCodesResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.RecoveryCodes).To(HaveLen(totp.RecoveryCodeCount))
+		})
+
+		It("should count a wrong code as a failed login", func() {
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockThrottles.EXPECT().RecordFailure(gomock.Any(), "test@example.com", gomock.Any(), int64(1)).Return(nil, nil)
+
+			performRequest("/auth/mfa/confirm", auth.MFACodePayload{Code: "000000x"})
+
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 400 without an enrollment", func() {
+			user.MFASecret = ""
+			performRequest("/auth/mfa/confirm", auth.MFACodePayload{Code: currentCode()})
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("with MFA enabled", func() {
+		BeforeEach(func() {
+			user.MFAEnabled = true
+			user.MFASecret = secret
+		})
+
+		It("should regenerate the recovery codes", func() {
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseStep(gomock.Any(), int64(1), gomock.Any()).Return(true, nil)
+			mockMFARepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), int64(1), gomock.Len(totp.RecoveryCodeCount)).Return(nil)
+
+			performRequest("/auth/mfa/recovery-codes", auth.MFACodePayload{Code: currentCode()})
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should disable MFA with a recovery code", func() {
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(nil, nil)
+			mockMFARepo.EXPECT().UseRecoveryCode(gomock.Any(), int64(1), totp.HashRecoveryCode("k3x9q-7mwpd")).Return(true, nil)
+			mockMFARepo.EXPECT().Disable(gomock.Any(), int64(1)).Return(nil)
+
+			performRequest("/auth/mfa/disable", auth.MFACodePayload{Code: "k3x9q-7mwpd"})
+
+			Expect(rr.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 429 while the email is locked", func() {
+			lockedUntil := time.Now().Add(time.Minute)
+			mockThrottles.EXPECT().LockedUntil(gomock.Any(), "test@example.com", gomock.Any()).Return(&lockedUntil, nil)
+
+			performRequest("/auth/mfa/disable", auth.MFACodePayload{Code: currentCode()})
+
+			Expect(rr.Code).To(Equal(http.StatusTooManyRequests))
+		})
+	})
+})
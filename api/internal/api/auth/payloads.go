@@ -1,12 +1,117 @@
 package auth
 
+import "github.com/happilymarrieddad/order-management-v3/api/types"
+
 // LoginPayload defines the structure for a login request.
 type LoginPayload struct {
 	Email    string `json:"email" validate:"required,email" example:"test@example.com"`
 	Password string `json:"password" validate:"required" example:"password123"`
 }
 
-// LoginResponse defines the structure for a successful login response.
+// LoginResponse defines the structure for a successful login or refresh response.
 type LoginResponse struct {
 	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// RefreshToken is exchanged for a new token pair at /auth/refresh. It can be used only once.
+	RefreshToken string `json:"refresh_token" example:"q3Jp0S1d4V9n2bX8cT6yZk7wLm5hGf0aEr1uPo2iUs4"`
+	// ExpiresIn is the number of seconds until the access token expires.
+	ExpiresIn int64 `json:"expires_in" example:"900"`
+	// RecoveryCodes are returned once, when the login completed the user's MFA enrollment.
+	RecoveryCodes []string `json:"recovery_codes,omitempty" example:"k3x9q-7mwpd"`
+}
+
+// MFAChallengeResponse is returned by /login instead of tokens when the user has to enter a second
+// factor.
+type MFAChallengeResponse struct {
+	// MFAToken is exchanged for a token pair at /login/mfa together with a code.
+	MFAToken string `json:"mfa_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// EnrollmentRequired is set when the user's company requires MFA and the user has not enrolled
+	// yet. The user gets a secret from /login/mfa/enroll first.
+	EnrollmentRequired bool `json:"mfa_enrollment_required" example:"false"`
+	// ExpiresIn is the number of seconds until the MFA token expires.
+	ExpiresIn int64 `json:"expires_in" example:"300"`
+}
+
+// MFALoginPayload defines the structure for completing a login with a second factor.
+type MFALoginPayload struct {
+	MFAToken string `json:"mfa_token" validate:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// Code is the current code of the authenticator app, or one of the user's recovery codes.
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+// MFAEnrollLoginPayload defines the structure for enrolling in MFA during a login.
+type MFAEnrollLoginPayload struct {
+	MFAToken string `json:"mfa_token" validate:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// MFAEnrollmentResponse holds a new TOTP secret for the user's authenticator app.
+type MFAEnrollmentResponse struct {
+	Secret string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	// OTPAuthURI is the secret as a URI that authenticator apps import, usually shown as a QR code.
+	OTPAuthURI string `json:"otpauth_uri" example:"otpauth://totp/Order%20Management:test@example.com?algorithm=SHA1&digits=6&issuer=Order+Management&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// MFACodePayload defines the structure for requests confirmed with a code of the authenticator app.
+type MFACodePayload struct {
+	Code string `json:"code" validate:"required" example:"123456"`
+}
+
+// MFARecoveryCodesResponse holds a new set of recovery codes. They are shown only once.
+type MFARecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3x9q-7mwpd"`
+}
+
+// RefreshPayload defines the structure for a token refresh request.
+type RefreshPayload struct {
+	RefreshToken string `json:"refresh_token" validate:"required" example:"q3Jp0S1d4V9n2bX8cT6yZk7wLm5hGf0aEr1uPo2iUs4"`
+}
+
+// ForgotPasswordPayload defines the structure for a password reset request.
+type ForgotPasswordPayload struct {
+	Email string `json:"email" validate:"required,email" example:"test@example.com"`
+}
+
+// ResetPasswordPayload defines the structure for setting a new password with a reset token.
+type ResetPasswordPayload struct {
+	Token    string `json:"token" validate:"required" example:"q3Jp0S1d4V9n2bX8cT6yZk7wLm5hGf0aEr1uPo2iUs4"`
+	// Password must meet the password policy.
+	Password string `json:"password" validate:"required" example:"newpassword123"`
+}
+
+// AcceptInvitationPayload defines the structure for joining a company with an invite token.
+type AcceptInvitationPayload struct {
+	Token     string `json:"token" validate:"required" example:"q3Jp0S1d4V9n2bX8cT6yZk7wLm5hGf0aEr1uPo2iUs4"`
+	FirstName string `json:"first_name" validate:"required,min=2,max=50" example:"Jane"`
+	LastName  string `json:"last_name" validate:"required,min=2,max=50" example:"Doe"`
+	// Password must meet the password policy.
+	Password string `json:"password" validate:"required" example:"newpassword123"`
+}
+
+// ImpersonationResponse defines the structure for a successful impersonation response.
+type ImpersonationResponse struct {
+	// Token is an access token for the impersonated user. It cannot be refreshed.
+	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	// ExpiresIn is the number of seconds until the access token expires.
+	ExpiresIn int64       `json:"expires_in" example:"900"`
+	User      *types.User `json:"user"`
+}
+
+// SSOStartPayload defines the structure for starting a single sign-on with a company's identity
+// provider.
+type SSOStartPayload struct {
+	CompanyID int64 `json:"company_id" validate:"required" example:"1"`
+}
+
+// SSOStartResponse defines the structure for a started single sign-on.
+type SSOStartResponse struct {
+	// AuthorizationURL is the identity provider's sign-in page the user is sent to.
+	AuthorizationURL string `json:"authorization_url" example:"https://idp.example.com/authorize?client_id=..."`
+	// ExpiresIn is the number of seconds the user has to sign in and come back.
+	ExpiresIn int64 `json:"expires_in" example:"600"`
+}
+
+// SSOCallbackPayload defines the structure for finishing a single sign-on with the code and state
+// the identity provider sent the user back with.
+type SSOCallbackPayload struct {
+	State string `json:"state" validate:"required" example:"q3Jp0S1d4V9n2bX8cT6yZk7wLm5hGf0aEr1uPo2iUs4"`
+	Code  string `json:"code" validate:"required" example:"SplxlOBeZQQYbYS6WxSbIA"`
 }
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Refresh Tokens
+// @Description  Exchanges a refresh token for a new access token and refresh token. Each refresh token can be used only once; presenting a used one again ends its session.
+// @Description  Sessions of users whose company started requiring MFA after they logged in end here; the user logs in again and enrolls.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token body      RefreshPayload           true  "Refresh Token"
+// @Success      200   {object}  LoginResponse            "New token pair"
+// @Failure      400   {object}  middleware.ErrorResponse "Bad Request - Invalid input"
+// @Failure      401   {object}  middleware.ErrorResponse "Unauthorized - Invalid, expired or reused refresh token"
+// @Failure      500   {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /auth/refresh [post]
+// Refresh rotates a refresh token.
+func Refresh(w http.ResponseWriter, r *http.Request) {
+	var payload RefreshPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	refreshToken, refreshTokenHash, err := jwtpgk.GenerateOpaqueToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+
+	session, err := repo.Sessions().Rotate(r.Context(), jwtpgk.HashOpaqueToken(payload.RefreshToken), refreshTokenHash, time.Now().Add(jwtpgk.RefreshTokenTTL))
+	if err != nil {
+		if errors.Is(err, repos.ErrRefreshTokenInvalid) || errors.Is(err, repos.ErrRefreshTokenReused) {
+			middleware.WriteError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	user, found, err := repo.Users().Get(r.Context(), session.CompanyID, session.UserID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !found {
+		// The user was deleted or moved to another company since the session started.
+		if err := repo.Sessions().Revoke(r.Context(), session.ID); err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		middleware.WriteError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	}
+
+	enrollmentRequired, err := mfaEnrollmentRequired(r, repo, user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if enrollmentRequired {
+		if err := repo.Sessions().Revoke(r.Context(), session.ID); err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		middleware.WriteError(w, http.StatusUnauthorized, "multi-factor authentication required")
+		return
+	}
+
+	res, err := tokenResponse(user, session, refreshToken)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,133 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Refresh Handler", func() {
+	var (
+		rr      *httptest.ResponseRecorder
+		user    *types.User
+		session *types.Session
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		user = &types.User{ID: 1, Email: "test@example.com", CompanyID: 3, Visible: true}
+		session = &types.Session{ID: 7, UserID: 1, CompanyID: 3, ExpiresAt: time.Now().Add(time.Hour)}
+	})
+
+	performRequest := func(refreshToken string) {
+		body, _ := json.Marshal(map[string]string{"refresh_token": refreshToken})
+		req := newAuthenticatedRequest(http.MethodPost, "/auth/refresh", body, nil)
+		router.ServeHTTP(rr, req)
+	}
+
+	Context("when the refresh token is valid", func() {
+		It("should rotate the refresh token and return a new token pair", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), jwtpkg.HashOpaqueToken("old-token"), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, newHash string, expiresAt time.Time) (*types.Session, error) {
+					Expect(newHash).NotTo(Equal(jwtpkg.HashOpaqueToken("old-token")))
+					Expect(expiresAt).To(BeTemporally("~", time.Now().Add(jwtpkg.RefreshTokenTTL), time.Minute))
+					return session, nil
+				})
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3}, true, nil)
+
+			performRequest("old-token")
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var response auth.LoginResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &response)).To(Succeed())
+			Expect(response.RefreshToken).NotTo(BeEmpty())
+			Expect(response.RefreshToken).NotTo(Equal("old-token"))
+
+			claims, err := jwtpkg.ValidateToken(response.Token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims.UserID).To(Equal(int64(1)))
+			Expect(claims.SessionID).To(Equal(int64(7)))
+		})
+	})
+
+	Context("when the refresh token cannot be used", func() {
+		It("should return 401 for an unknown or expired token", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repos.ErrRefreshTokenInvalid)
+			performRequest("old-token")
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 401 for a token that was already used", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repos.ErrRefreshTokenReused)
+			performRequest("old-token")
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should revoke the session and return 401 when the user no longer exists", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(session, nil)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(nil, false, nil)
+			mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(7)).Return(nil)
+
+			performRequest("old-token")
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("when the user's company requires MFA", func() {
+		It("should revoke the session and return 401 when the user has not enrolled", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(session, nil)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3, RequireMFA: true}, true, nil)
+			mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(7)).Return(nil)
+
+			performRequest("old-token")
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+			Expect(rr.Body.String()).To(ContainSubstring("multi-factor authentication required"))
+		})
+
+		It("should rotate the token of a user who enrolled", func() {
+			user.MFAEnabled = true
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(session, nil)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+
+			performRequest("old-token")
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when the request is invalid", func() {
+		It("should return 400 without a refresh token", func() {
+			performRequest("")
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 400 for malformed JSON", func() {
+			req := newAuthenticatedRequest(http.MethodPost, "/auth/refresh", []byte(`{"refresh_token":`), nil)
+			router.ServeHTTP(rr, req)
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the database returns an error", func() {
+		It("should return 500 Internal Server Error", func() {
+			mockSessionsRepo.EXPECT().Rotate(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database connection lost"))
+			performRequest("old-token")
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
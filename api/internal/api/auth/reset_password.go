@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Reset Password
+// @Description  Sets a new password using the token from a reset email. The token can be used once, and all of the user's sessions are ended.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body      ResetPasswordPayload     true  "Reset Token and New Password"
+// @Success      204     "Password reset"
+// @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input, password policy not met, or invalid, expired or used token"
+// @Failure      500     {object}  middleware.ErrorResponse "Internal Server Error"
+// @Router       /auth/reset-password [post]
+// ResetPassword completes a password reset.
+func ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var payload ResetPasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	// The email is checked against as well once the token's user is known.
+	if err := types.ActivePasswordPolicy.Check(payload.Password, ""); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	if _, err := repo.PasswordResetTokens().ResetPassword(r.Context(), jwtpgk.HashOpaqueToken(payload.Token), payload.Password); err != nil {
+		var policyErr *types.PasswordPolicyError
+		if errors.Is(err, repos.ErrPasswordResetTokenInvalid) || errors.As(err, &policyErr) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,72 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Reset Password Handler", func() {
+	var rr *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+	})
+
+	performRequest := func(token, password string) {
+		body, _ := json.Marshal(map[string]string{"token": token, "password": password})
+		req := newAuthenticatedRequest(http.MethodPost, "/auth/reset-password", body, nil)
+		router.ServeHTTP(rr, req)
+	}
+
+	It("should set the new password using the hashed token", func() {
+		mockResetsRepo.EXPECT().ResetPassword(gomock.Any(), jwtpkg.HashOpaqueToken("reset-token"), "newpassword123").Return(int64(1), nil)
+
+		performRequest("reset-token", "newpassword123")
+
+		Expect(rr.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 400 for an invalid, expired or used token", func() {
+		mockResetsRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), repos.ErrPasswordResetTokenInvalid)
+
+		performRequest("reset-token", "newpassword123")
+
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 400 when the password breaks the password policy", func() {
+		mockResetsRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), &types.PasswordPolicyError{Problems: []string{"must contain a digit"}})
+
+		performRequest("reset-token", "newpassword")
+
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		Expect(rr.Body.String()).To(ContainSubstring("password must contain a digit"))
+	})
+
+	It("should return 400 for a short password", func() {
+		performRequest("reset-token", "short")
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 400 without a token", func() {
+		performRequest("", "newpassword123")
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 500 when the password cannot be reset", func() {
+		mockResetsRepo.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error"))
+
+		performRequest("reset-token", "newpassword123")
+
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// maxUserAgentLength is the longest user agent stored with a session.
+const maxUserAgentLength = 512
+
+// startSession creates a new session for the user and returns its first token pair.
+func startSession(r *http.Request, repo repos.GlobalRepo, user *types.User) (*LoginResponse, error) {
+	refreshToken, refreshTokenHash, err := jwtpgk.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent := r.UserAgent()
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	session := &types.Session{
+		UserID:    user.ID,
+		CompanyID: user.CompanyID,
+		UserAgent: userAgent,
+		IPAddress: clientIP(r),
+		ExpiresAt: time.Now().Add(jwtpgk.RefreshTokenTTL),
+	}
+	if err := repo.Sessions().Create(r.Context(), session, refreshTokenHash); err != nil {
+		return nil, err
+	}
+
+	return tokenResponse(user, session, refreshToken)
+}
+
+// tokenResponse issues an access token for the session and pairs it with the refresh token.
+func tokenResponse(user *types.User, session *types.Session, refreshToken string) (*LoginResponse, error) {
+	token, err := jwtpgk.GenerateToken(user, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(jwtpgk.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// clientIP returns the address of the client the request came from.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
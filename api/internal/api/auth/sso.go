@@ -0,0 +1,286 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpgk "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// ssoLoginTTL is how long a user has to sign in at the identity provider and come back.
+const ssoLoginTTL = 10 * time.Minute
+
+// ssoRedirectURL is the page of the web app identity providers send users back to. It passes the
+// code and state on to /auth/sso/callback, and must be registered with every identity provider.
+var ssoRedirectURL = "http://localhost:3000/sso/callback"
+
+// errSSOProfileIncomplete is returned when an identity lacks the name a new user needs.
+var errSSOProfileIncomplete = errors.New("identity provider did not provide a valid name for the new user")
+
+// @Summary      Start a single sign-on
+// @Description  Starts signing in with the OpenID Connect identity provider of a company. The web app sends the user to the returned authorization URL; the provider sends them back to SSO_REDIRECT_URL with a code and state for /auth/sso/callback.
+// @Description  The sign-in uses the authorization code flow with PKCE. The code verifier and nonce never leave the server.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        sso  body      SSOStartPayload           true  "Company"
+// @Success      200  {object}  SSOStartResponse          "Authorization URL"
+// @Failure      400  {object}  middleware.ErrorResponse  "Bad Request - Invalid input"
+// @Failure      404  {object}  middleware.ErrorResponse  "Not Found - Single sign-on is not configured for the company"
+// @Failure      500  {object}  middleware.ErrorResponse  "Internal Server Error"
+// @Failure      502  {object}  middleware.ErrorResponse  "Bad Gateway - The identity provider is unavailable"
+// @Router       /auth/sso/start [post]
+// StartSSO starts a single sign-on with a company's identity provider.
+func StartSSO(w http.ResponseWriter, r *http.Request) {
+	var payload SSOStartPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	config, found, err := repo.SSO().GetConfig(r.Context(), payload.CompanyID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "single sign-on is not configured for this company")
+		return
+	}
+
+	client, err := ssoClient(r.Context(), config)
+	if err != nil {
+		log.Printf("unable to reach identity provider of company %d: %s", config.CompanyID, err.Error())
+		middleware.WriteError(w, http.StatusBadGateway, "identity provider unavailable")
+		return
+	}
+
+	state, stateHash, err := jwtpgk.GenerateOpaqueToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	nonce, err := oidc.NewNonce()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if err := repo.SSO().CreateLoginState(r.Context(), &types.SSOLoginState{
+		CompanyID:    config.CompanyID,
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		ExpiresAt:    time.Now().Add(ssoLoginTTL),
+	}); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, SSOStartResponse{
+		AuthorizationURL: client.AuthCodeURL(state, nonce, oidc.CodeChallenge(verifier)),
+		ExpiresIn:        int64(ssoLoginTTL.Seconds()),
+	})
+}
+
+// @Summary      Finish a single sign-on
+// @Description  Redeems the code the identity provider sent the user back with and signs in the user with the provider's verified email. The email's domain must be one the company allows.
+// @Description  The email must belong to a user of the company. Companies with just-in-time provisioning get a new user with the User role instead. Platform admins cannot sign in with single sign-on.
+// @Description  Returns the same response as /auth/login, including an MFAChallengeResponse for users with multi-factor authentication.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        sso  body      SSOCallbackPayload        true  "Code and State"
+// @Success      200  {object}  LoginResponse             "Successfully authenticated, or an MFAChallengeResponse"
+// @Failure      400  {object}  middleware.ErrorResponse  "Bad Request - Invalid input or an invalid, expired or used state"
+// @Failure      401  {object}  middleware.ErrorResponse  "Unauthorized - The identity provider did not sign the user in"
+// @Failure      403  {object}  middleware.ErrorResponse  "Forbidden - The identity may not sign in to the company"
+// @Failure      500  {object}  middleware.ErrorResponse  "Internal Server Error"
+// @Failure      502  {object}  middleware.ErrorResponse  "Bad Gateway - The identity provider is unavailable"
+// @Router       /auth/sso/callback [post]
+// SSOCallback finishes a single sign-on.
+func SSOCallback(w http.ResponseWriter, r *http.Request) {
+	var payload SSOCallbackPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "validation failed: "+err.Error())
+		return
+	}
+
+	repo := middleware.GetRepo(r.Context())
+
+	state, err := repo.SSO().UseLoginState(r.Context(), jwtpgk.HashOpaqueToken(payload.State))
+	if err != nil {
+		if errors.Is(err, repos.ErrSSOLoginStateInvalid) {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid or expired single sign-on state")
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	// The configuration may have changed since the sign-in started; the current one applies.
+	config, found, err := repo.SSO().GetConfig(r.Context(), state.CompanyID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "single sign-on is not configured for this company")
+		return
+	}
+
+	client, err := ssoClient(r.Context(), config)
+	if err != nil {
+		log.Printf("unable to reach identity provider of company %d: %s", config.CompanyID, err.Error())
+		middleware.WriteError(w, http.StatusBadGateway, "identity provider unavailable")
+		return
+	}
+	identity, err := client.Exchange(r.Context(), payload.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		log.Printf("unable to finish single sign-on for company %d: %s", config.CompanyID, err.Error())
+		middleware.WriteError(w, http.StatusUnauthorized, "single sign-on failed")
+		return
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		middleware.WriteError(w, http.StatusForbidden, "identity provider did not verify the email")
+		return
+	}
+	if !config.AllowedDomains.Allows(identity.Email) {
+		middleware.WriteError(w, http.StatusForbidden, "email domain is not allowed to sign in to this company")
+		return
+	}
+
+	user, found, err := repo.Users().GetByEmail(r.Context(), identity.Email)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	switch {
+	case found && (!user.Visible || user.CompanyID != config.CompanyID):
+		middleware.WriteError(w, http.StatusForbidden, "email belongs to a user of another company")
+		return
+	case found && hasPlatformPermission(user):
+		// The identity provider is chosen by the company, so it must not vouch for the platform
+		// admins homed in the company; they sign in with their password.
+		middleware.WriteError(w, http.StatusForbidden, "platform admins cannot sign in with single sign-on")
+		return
+	case !found && !config.JITProvisioning:
+		middleware.WriteError(w, http.StatusForbidden, "no user with this email")
+		return
+	case !found:
+		if user, err = provisionSSOUser(r.Context(), repo, config, identity); err != nil {
+			if errors.Is(err, errSSOProfileIncomplete) {
+				middleware.WriteError(w, http.StatusForbidden, errSSOProfileIncomplete.Error())
+				return
+			}
+			middleware.WriteError(w, http.StatusInternalServerError, "failed to create user")
+			return
+		}
+		log.Printf("created user %d of company %d at their first single sign-on", user.ID, user.CompanyID)
+	}
+
+	// The identity provider replaces the password, not the second factor.
+	enrollmentRequired, err := mfaEnrollmentRequired(r, repo, user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if user.MFAEnabled || enrollmentRequired {
+		writeMFAChallenge(w, user, enrollmentRequired)
+		return
+	}
+
+	res, err := startSession(r, repo, user)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to generate token")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, res)
+}
+
+// hasPlatformPermission reports whether the user holds a permission that reaches beyond their
+// company. Company roles only grant company permissions, so the built-in roles decide.
+func hasPlatformPermission(user *types.User) bool {
+	for _, perm := range types.PlatformPermissions {
+		if user.HasPermission(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// ssoClient discovers a company's identity provider and returns a client for it.
+func ssoClient(ctx context.Context, config *types.SSOConfig) (*oidc.Client, error) {
+	provider, err := oidc.Discover(ctx, config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &oidc.Client{
+		Provider:     provider,
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		RedirectURL:  ssoRedirectURL,
+	}, nil
+}
+
+// provisionSSOUser creates a user with the User role for an identity signing in to a company for the
+// first time. The user gets the name the identity provider knows, starts at the company's address
+// and has a random password, which they can replace with a password reset.
+func provisionSSOUser(ctx context.Context, repo repos.GlobalRepo, config *types.SSOConfig, identity *oidc.Identity) (*types.User, error) {
+	company, found, err := repo.Companies().Get(ctx, config.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, errors.New("company not found")
+	}
+
+	password, _, err := jwtpgk.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &types.User{
+		FirstName: identity.GivenName,
+		LastName:  identity.FamilyName,
+		Email:     identity.Email,
+		Password:  password,
+		CompanyID: company.ID,
+		AddressID: company.AddressID,
+		Visible:   true,
+		Roles:     types.Roles{types.RoleUser},
+	}
+	if err := types.Validate(user); err != nil {
+		return nil, fmt.Errorf("%w: %s", errSSOProfileIncomplete, err.Error())
+	}
+	if err := repo.Users().Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
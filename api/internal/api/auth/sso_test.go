@@ -0,0 +1,254 @@
+package auth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc/oidctest"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Single Sign-On Handlers", func() {
+	var (
+		rr     *httptest.ResponseRecorder
+		idp    *oidctest.Server
+		config *types.SSOConfig
+		user   *types.User
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		idp = oidctest.NewServer("client-id", "client-secret")
+		DeferCleanup(idp.Close)
+		idp.SetUser(oidctest.User{Subject: "abc", Email: "jane@example.com", EmailVerified: true, GivenName: "Jane", FamilyName: "Doe"})
+
+		config = &types.SSOConfig{
+			CompanyID:      3,
+			Issuer:         idp.Issuer(),
+			ClientID:       "client-id",
+			ClientSecret:   "client-secret",
+			AllowedDomains: types.EmailDomains{"example.com"},
+		}
+		user = &types.User{ID: 5, CompanyID: 3, Email: "jane@example.com", Visible: true}
+	})
+
+	post := func(url string, payload interface{}) {
+		body, _ := json.Marshal(payload)
+		router.ServeHTTP(rr, newAuthenticatedRequest(http.MethodPost, url, body, nil))
+	}
+
+	// start begins a sign-in, lets the mock provider sign the user in and returns the code and the
+	// stored state.
+	start := func() (string, string, *types.SSOLoginState) {
+		var stored *types.SSOLoginState
+		mockSSORepo.EXPECT().GetConfig(gomock.Any(), int64(3)).Return(config, true, nil)
+		mockSSORepo.EXPECT().CreateLoginState(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, state *types.SSOLoginState) error {
+			stored = state
+			return nil
+		})
+
+		post("/auth/sso/start", auth.SSOStartPayload{CompanyID: 3})
+		Expect(rr.Code).To(Equal(http.StatusOK))
+
+		var res auth.SSOStartResponse
+		Expect(json.Unmarshal(rr.Body.Bytes(), &res)).To(Succeed())
+		code, state, err := idp.Authorize(res.AuthorizationURL)
+		Expect(err).NotTo(HaveOccurred())
+
+		rr = httptest.NewRecorder()
+		return code, state, stored
+	}
+
+	// callback finishes the sign-in with the code and state.
+	callback := func(code, state string, stored *types.SSOLoginState) {
+		mockSSORepo.EXPECT().UseLoginState(gomock.Any(), jwtpkg.HashOpaqueToken(state)).Return(stored, nil)
+		mockSSORepo.EXPECT().GetConfig(gomock.Any(), int64(3)).Return(config, true, nil)
+		post("/auth/sso/callback", auth.SSOCallbackPayload{State: state, Code: code})
+	}
+
+	expectSession := func() {
+		mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3, AddressID: 20}, true, nil).AnyTimes()
+		mockSessionsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, session *types.Session, _ string) error {
+			Expect(session.UserID).To(Equal(user.ID))
+			session.ID = 7
+			return nil
+		})
+	}
+
+	Describe("StartSSO", func() {
+		It("should keep the code verifier and nonce on the server", func() {
+			_, state, stored := start()
+
+			Expect(stored.CompanyID).To(Equal(int64(3)))
+			Expect(stored.StateHash).To(Equal(jwtpkg.HashOpaqueToken(state)))
+			Expect(stored.CodeVerifier).NotTo(BeEmpty())
+			Expect(stored.Nonce).NotTo(BeEmpty())
+			Expect(stored.ExpiresAt).To(BeTemporally(">", time.Now()))
+		})
+
+		It("should return 404 for a company without single sign-on", func() {
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), int64(3)).Return(nil, false, nil)
+			post("/auth/sso/start", auth.SSOStartPayload{CompanyID: 3})
+			Expect(rr.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 502 when the identity provider cannot be reached", func() {
+			config.Issuer = idp.Issuer() + "/missing"
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), int64(3)).Return(config, true, nil)
+			post("/auth/sso/start", auth.SSOStartPayload{CompanyID: 3})
+			Expect(rr.Code).To(Equal(http.StatusBadGateway))
+		})
+	})
+
+	Describe("SSOCallback", func() {
+		It("should sign in the existing user with the verified email", func() {
+			code, state, stored := start()
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(user, true, nil)
+			expectSession()
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var res auth.LoginResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &res)).To(Succeed())
+			claims, err := jwtpkg.ValidateToken(res.Token)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims.UserID).To(Equal(int64(5)))
+			Expect(res.RefreshToken).NotTo(BeEmpty())
+		})
+
+		It("should create the user on their first sign-in with just-in-time provisioning", func() {
+			config.JITProvisioning = true
+			code, state, stored := start()
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(nil, false, nil)
+			mockUsersRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, created *types.User) error {
+				Expect(created.FirstName).To(Equal("Jane"))
+				Expect(created.LastName).To(Equal("Doe"))
+				Expect(created.CompanyID).To(Equal(int64(3)))
+				Expect(created.AddressID).To(Equal(int64(20)))
+				Expect(created.Roles).To(Equal(types.Roles{types.RoleUser}))
+				Expect(created.Password).NotTo(BeEmpty())
+				created.ID = user.ID
+				return nil
+			})
+			expectSession()
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should not create users without just-in-time provisioning", func() {
+			code, state, stored := start()
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(nil, false, nil)
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not create users without a name", func() {
+			config.JITProvisioning = true
+			idp.SetUser(oidctest.User{Subject: "abc", Email: "jane@example.com", EmailVerified: true})
+			code, state, stored := start()
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(nil, false, nil)
+			mockCompanies.EXPECT().Get(gomock.Any(), int64(3)).Return(&types.Company{ID: 3, AddressID: 20}, true, nil)
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not sign in a user of another company", func() {
+			code, state, stored := start()
+			user.CompanyID = 4
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(user, true, nil)
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not sign in a platform admin of the company", func() {
+			code, state, stored := start()
+			user.Roles = types.Roles{types.RoleSuperAdmin}
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(user, true, nil)
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+			Expect(rr.Body.String()).To(ContainSubstring("platform admins"))
+		})
+
+		It("should reject emails of other domains", func() {
+			config.AllowedDomains = types.EmailDomains{"example.org"}
+			code, state, stored := start()
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject emails the identity provider did not verify", func() {
+			idp.SetUser(oidctest.User{Subject: "abc", Email: "jane@example.com", GivenName: "Jane", FamilyName: "Doe"})
+			code, state, stored := start()
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should ask users with MFA for their second factor", func() {
+			user.MFAEnabled = true
+			code, state, stored := start()
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(user, true, nil)
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			var res auth.MFAChallengeResponse
+			Expect(json.Unmarshal(rr.Body.Bytes(), &res)).To(Succeed())
+			Expect(res.MFAToken).NotTo(BeEmpty())
+		})
+
+		It("should return 401 when the ID token was replayed", func() {
+			code, state, stored := start()
+			idp.SetClaim("nonce", "replayed")
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 401 for a code of another sign-in", func() {
+			code, _, _ := start()
+			_, state, stored := start()
+
+			callback(code, state, stored)
+
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 400 for an unknown or used state", func() {
+			mockSSORepo.EXPECT().UseLoginState(gomock.Any(), jwtpkg.HashOpaqueToken("state")).Return(nil, repos.ErrSSOLoginStateInvalid)
+			post("/auth/sso/callback", auth.SSOCallbackPayload{State: "state", Code: "code"})
+			Expect(rr.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 when the state cannot be checked", func() {
+			mockSSORepo.EXPECT().UseLoginState(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+			post("/auth/sso/callback", auth.SSOCallbackPayload{State: "state", Code: "code"})
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("AuthMiddleware with an API key", func() {
+	const rawKey = "omk_test-key"
+
+	var (
+		ctrl            *gomock.Controller
+		mockGlobalRepo  *mock_repos.MockGlobalRepo
+		mockAPIKeysRepo *mock_repos.MockAPIKeysRepo
+		rr              *httptest.ResponseRecorder
+		apiKey          *types.APIKey
+		nextHandler     http.Handler
+		wasCalled       bool
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockGlobalRepo = mock_repos.NewMockGlobalRepo(ctrl)
+		mockAPIKeysRepo = mock_repos.NewMockAPIKeysRepo(ctrl)
+		mockGlobalRepo.EXPECT().APIKeys().Return(mockAPIKeysRepo).AnyTimes()
+		rr = httptest.NewRecorder()
+		apiKey = &types.APIKey{ID: 5, CompanyID: 3, Name: "ERP", Scopes: types.Scopes{"products:read"}}
+		wasCalled = false
+
+		nextHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wasCalled = true
+			authUser, found := middleware.GetAuthUserFromContext(r.Context())
+			Expect(found).To(BeTrue())
+			Expect(authUser.ID).To(BeZero())
+			Expect(authUser.CompanyID).To(Equal(int64(3)))
+			Expect(authUser.HasRole(types.RoleAdmin)).To(BeFalse())
+			key, found := middleware.GetAuthAPIKeyFromContext(r.Context())
+			Expect(found).To(BeTrue())
+			Expect(key).To(BeIdenticalTo(apiKey))
+			Expect(repos.AuditActorFromContext(r.Context())).To(Equal(repos.AuditActor{APIKeyID: 5}))
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	performRequest := func(method, path string) {
+		req := httptest.NewRequest(method, path, nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo))
+		req.Header.Set("X-Api-Key", rawKey)
+		middleware.AuthMiddleware(nextHandler).ServeHTTP(rr, req)
+	}
+
+	It("should authenticate as a service user of the key's company and record the use", func() {
+		mockAPIKeysRepo.EXPECT().GetByHash(gomock.Any(), jwtpkg.HashOpaqueToken(rawKey)).Return(apiKey, true, nil)
+		mockAPIKeysRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(5), gomock.Any()).Return(nil)
+
+		performRequest(http.MethodGet, "/api/v1/products/find")
+
+		Expect(wasCalled).To(BeTrue())
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should reject an unknown or revoked key", func() {
+		mockAPIKeysRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, false, nil)
+
+		performRequest(http.MethodGet, "/api/v1/products/find")
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject an expired key", func() {
+		expiredAt := time.Now().Add(-time.Hour)
+		apiKey.ExpiresAt = &expiredAt
+		mockAPIKeysRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(apiKey, true, nil)
+
+		performRequest(http.MethodGet, "/api/v1/products/find")
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should forbid a resource outside the key's scopes", func() {
+		mockAPIKeysRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(apiKey, true, nil)
+
+		performRequest(http.MethodGet, "/api/v1/users/find")
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should forbid writes with a read-only scope", func() {
+		mockAPIKeysRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(apiKey, true, nil)
+
+		performRequest(http.MethodPost, "/api/v1/products")
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+})
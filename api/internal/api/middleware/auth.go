@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"context"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
-	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
 	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
@@ -16,12 +20,33 @@ type contextKey string
 // AuthUserKey is the key for the authenticated user object in the context.
 const AuthUserKey contextKey = "ctx:authUser"
 
+// AuthClaimsKey is the key for the claims of the request's access token in the context.
+const AuthClaimsKey contextKey = "ctx:authClaims"
+
+// AuthAPIKeyKey is the key for the API key a request was authenticated with in the context.
+const AuthAPIKeyKey contextKey = "ctx:authAPIKey"
+
 // AuthMiddleware checks for a valid JWT in the X-App-Token header.
 // If the token is missing or invalid, it returns a 401 Unauthorized error.
-// If valid, it adds the user ID from the token's claims to the request context.
+// Tokens whose session has ended or whose jti has been revoked are rejected as well, and the
+// session's last use is recorded.
+// If valid, it adds the user and the token's claims to the request context, and records the
+// changes the request makes in the audit log against the user.
+// For impersonation tokens the user is the impersonated user, with the platform admin acting as them
+// in User.Impersonator, and every write is logged with the admin.
+// Requests without a token may authenticate with an API key in the X-Api-Key header instead.
+// Event stream requests (GET /api/v1/events), which browsers cannot add headers to, may pass the
+// token in the access_token parameter.
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		tokenString := r.Header.Get("X-App-Token")
+		if tokenString == "" && isStreamRequest(r) {
+			tokenString = r.URL.Query().Get("access_token")
+		}
+		if apiKey := r.Header.Get("X-Api-Key"); tokenString == "" && apiKey != "" {
+			authenticateAPIKey(w, r, next, apiKey)
+			return
+		}
 		if tokenString == "" {
 			WriteError(w, http.StatusUnauthorized, "missing token")
 			return
@@ -32,9 +57,28 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			WriteError(w, http.StatusUnauthorized, "invalid or expired token")
 			return
 		}
+		// Tokens issued before sessions existed cannot be revoked, so they are no longer accepted.
+		if claims.SessionID == 0 || claims.ID == "" {
+			WriteError(w, http.StatusUnauthorized, "invalid or expired token")
+			return
+		}
 
-		// OPTIMIZATION: Fetch the user object once and add it to the context.
 		repo := GetRepo(r.Context())
+		active, err := repo.Sessions().IsTokenActive(r.Context(), claims.SessionID, claims.ID)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, "failed to check token")
+			return
+		}
+		if !active {
+			WriteError(w, http.StatusUnauthorized, "token has been revoked")
+			return
+		}
+		if err := repo.Sessions().TouchLastUsed(r.Context(), claims.SessionID, time.Now()); err != nil {
+			// Usage tracking is not worth failing the request over.
+			log.Printf("unable to record use of session %d: %s", claims.SessionID, err.Error())
+		}
+
+		// OPTIMIZATION: Fetch the user object once and add it to the context.
 		user, found, err := repo.Users().Get(r.Context(), claims.CompanyID, claims.UserID)
 		if err != nil {
 			WriteError(w, http.StatusInternalServerError, "failed to retrieve user")
@@ -45,49 +89,75 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
+		user.Permissions, err = repo.CompanyRoles().GetUserPermissions(r.Context(), user.ID)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, "failed to retrieve user permissions")
+			return
+		}
+
+		if claims.ImpersonatorID != 0 {
+			user.Impersonator, err = getImpersonator(r.Context(), repo, claims.ImpersonatorID)
+			if err != nil {
+				WriteError(w, http.StatusInternalServerError, "failed to retrieve impersonator")
+				return
+			}
+			if user.Impersonator == nil {
+				WriteError(w, http.StatusUnauthorized, "impersonation is no longer allowed")
+				return
+			}
+		}
+
 		// Add the entire user object to the request's context.
 		ctx := context.WithValue(r.Context(), AuthUserKey, user)
+		ctx = context.WithValue(ctx, AuthClaimsKey, claims)
+		ctx = repos.WithAuditActor(ctx, auditActor(user))
+		if user.Impersonator != nil {
+			serveImpersonated(w, r.WithContext(ctx), next, user)
+			return
+		}
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// GetAuthUserFromContext retrieves the authenticated user object from the context.
-func GetAuthUserFromContext(ctx context.Context) (*types.User, bool) {
-	user, ok := ctx.Value(AuthUserKey).(*types.User)
-	return user, ok
-}
+// eventStreamPath is the only route that takes the access token from the URL. Tokens in URLs end up
+// in access logs, so no other route accepts them.
+const eventStreamPath = "/api/v1/events"
 
-// CheckAdminAndWriteError checks if the user in the context has the admin role.
-func CheckAdminAndWriteError(w http.ResponseWriter, r *http.Request) bool {
-	authUser, found := GetAuthUserFromContext(r.Context())
-	if !found {
-		WriteError(w, http.StatusUnauthorized, "unauthorized")
-		return true
+// isStreamRequest reports whether a request opens the event stream, as Server-Sent Events or a
+// WebSocket.
+func isStreamRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet || r.URL.Path != eventStreamPath {
+		return false
 	}
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream") || events.IsWebSocketRequest(r)
+}
 
-	if !authUser.HasRole(types.RoleAdmin) {
-		WriteError(w, http.StatusForbidden, "forbidden")
-		return true
+// auditActor returns who the changes a user makes are recorded against: the platform admin while
+// the user is impersonated.
+func auditActor(user *types.User) repos.AuditActor {
+	actor := repos.AuditActor{UserID: user.ActorID()}
+	if user.Impersonator != nil {
+		actor.ImpersonatedUserID = user.ID
 	}
+	return actor
+}
 
-	return false
+// GetAuthUserFromContext retrieves the authenticated user object from the context. While a platform
+// admin impersonates the user, the admin is the user's Impersonator.
+func GetAuthUserFromContext(ctx context.Context) (*types.User, bool) {
+	user, ok := ctx.Value(AuthUserKey).(*types.User)
+	return user, ok
 }
 
-// AuthUserAdminRequired is a middleware that checks if the authenticated user has the admin role.
-// If the user is not an admin, it writes a 403 Forbidden error.
-func AuthUserAdminRequired(next http.HandlerFunc) http.HandlerFunc {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if CheckAdminAndWriteError(w, r) {
-			return
-		}
-		next.ServeHTTP(w, r)
-	})
+// GetAuthAPIKeyFromContext retrieves the API key the request was authenticated with from the context.
+// It is not found for requests made with an access token.
+func GetAuthAPIKeyFromContext(ctx context.Context) (*types.APIKey, bool) {
+	key, ok := ctx.Value(AuthAPIKeyKey).(*types.APIKey)
+	return key, ok
 }
 
-// AuthUserAdminRequiredMuxMiddleware returns a mux.MiddlewareFunc that checks if the authenticated user has the admin role.
-// If the user is not an admin, it writes a 403 Forbidden error.
-func AuthUserAdminRequiredMuxMiddleware() mux.MiddlewareFunc {
-	return func(next http.Handler) http.Handler {
-		return AuthUserAdminRequired(next.ServeHTTP)
-	}
+// GetAuthClaimsFromContext retrieves the claims of the request's access token from the context.
+func GetAuthClaimsFromContext(ctx context.Context) (*jwtpkg.CustomClaims, bool) {
+	claims, ok := ctx.Value(AuthClaimsKey).(*jwtpkg.CustomClaims)
+	return claims, ok
 }
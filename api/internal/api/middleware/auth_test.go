@@ -0,0 +1,289 @@
+package middleware_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("AuthMiddleware", func() {
+	var (
+		ctrl             *gomock.Controller
+		mockGlobalRepo   *mock_repos.MockGlobalRepo
+		mockUsersRepo    *mock_repos.MockUsersRepo
+		mockSessionsRepo *mock_repos.MockSessionsRepo
+		mockRolesRepo    *mock_repos.MockCompanyRolesRepo
+		rr               *httptest.ResponseRecorder
+		user             *types.User
+		nextHandler      http.Handler
+		wasCalled        bool
+		actor            repos.AuditActor
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockGlobalRepo = mock_repos.NewMockGlobalRepo(ctrl)
+		mockUsersRepo = mock_repos.NewMockUsersRepo(ctrl)
+		mockSessionsRepo = mock_repos.NewMockSessionsRepo(ctrl)
+		mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
+		mockRolesRepo = mock_repos.NewMockCompanyRolesRepo(ctrl)
+		mockGlobalRepo.EXPECT().Sessions().Return(mockSessionsRepo).AnyTimes()
+		mockGlobalRepo.EXPECT().CompanyRoles().Return(mockRolesRepo).AnyTimes()
+		rr = httptest.NewRecorder()
+		user = &types.User{ID: 1, Email: "test@example.com", CompanyID: 3}
+		wasCalled = false
+
+		nextHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wasCalled = true
+			authUser, found := middleware.GetAuthUserFromContext(r.Context())
+			Expect(found).To(BeTrue())
+			Expect(authUser).To(BeIdenticalTo(user))
+			claims, found := middleware.GetAuthClaimsFromContext(r.Context())
+			Expect(found).To(BeTrue())
+			Expect(claims.SessionID).To(Equal(int64(7)))
+			actor = repos.AuditActorFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	performRequestWithMethod := func(method, token string) {
+		req := httptest.NewRequest(method, "/", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo))
+		if token != "" {
+			req.Header.Set("X-App-Token", token)
+		}
+		middleware.AuthMiddleware(nextHandler).ServeHTTP(rr, req)
+	}
+
+	performRequest := func(token string) {
+		performRequestWithMethod(http.MethodGet, token)
+	}
+
+	newToken := func(sessionID int64) string {
+		token, err := jwtpkg.GenerateToken(user, sessionID)
+		Expect(err).NotTo(HaveOccurred())
+		return token
+	}
+
+	It("should add the user and claims to the context for an active token", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Not(gomock.Eq(""))).Return(true, nil)
+		mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(1)).Return(types.Permissions{types.PermissionUsersManage}, nil)
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeTrue())
+		Expect(rr.Code).To(Equal(http.StatusOK))
+		Expect(user.HasPermission(types.PermissionUsersManage)).To(BeTrue())
+		Expect(actor).To(Equal(repos.AuditActor{UserID: 1}))
+	})
+
+	It("should return 500 if the user's permissions cannot be loaded", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(true, nil)
+		mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(1)).Return(nil, errors.New("db error"))
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should still serve the request when the session's last use cannot be recorded", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(true, nil)
+		mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(errors.New("db error"))
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(1)).Return(nil, nil)
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeTrue())
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should reject a token whose session or jti has been revoked", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(false, nil)
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject a token without a session", func() {
+		performRequest(newToken(0))
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should reject a missing or malformed token", func() {
+		performRequest("")
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+
+		rr = httptest.NewRecorder()
+		performRequest("not-a-token")
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		Expect(wasCalled).To(BeFalse())
+	})
+
+	Describe("access_token parameter", func() {
+		performQueryRequest := func(token string, header, value string) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events?access_token="+token, nil)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo))
+			if header != "" {
+				req.Header.Set(header, value)
+			}
+			middleware.AuthMiddleware(nextHandler).ServeHTTP(rr, req)
+		}
+
+		It("should accept the token for event stream requests", func() {
+			mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(true, nil)
+			mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(1)).Return(nil, nil)
+
+			performQueryRequest(newToken(7), "Accept", "text/event-stream")
+
+			Expect(wasCalled).To(BeTrue())
+			Expect(rr.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should ignore the token for other requests", func() {
+			performQueryRequest(newToken(7), "Accept", "application/json")
+
+			Expect(wasCalled).To(BeFalse())
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		DescribeTable("should ignore the token on other routes",
+			func(method, target string) {
+				req := httptest.NewRequest(method, target+"?access_token="+newToken(7), nil)
+				req = req.WithContext(context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo))
+				req.Header.Set("Accept", "text/event-stream")
+				middleware.AuthMiddleware(nextHandler).ServeHTTP(rr, req)
+
+				Expect(wasCalled).To(BeFalse())
+				Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+			},
+			Entry("GET of another route", http.MethodGet, "/api/v1/products/find"),
+			Entry("POST to the stream", http.MethodPost, "/api/v1/events"),
+			Entry("DELETE of another route", http.MethodDelete, "/api/v1/users/5"),
+		)
+	})
+
+	It("should reject a token for a user that no longer exists", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(true, nil)
+		mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+		mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(nil, false, nil)
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	Describe("impersonation tokens", func() {
+		var (
+			admin    *types.User
+			logs     *bytes.Buffer
+			original = log.Writer()
+		)
+
+		BeforeEach(func() {
+			admin = &types.User{ID: 9, CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+			logs = new(bytes.Buffer)
+			log.SetOutput(logs)
+
+			mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(true, nil)
+			mockSessionsRepo.EXPECT().TouchLastUsed(gomock.Any(), int64(7), gomock.Any()).Return(nil)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(3), int64(1)).Return(user, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(1)).Return(nil, nil)
+		})
+
+		AfterEach(func() {
+			log.SetOutput(original)
+		})
+
+		newImpersonationToken := func() string {
+			token, err := jwtpkg.GenerateImpersonationToken(user, admin, 7)
+			Expect(err).NotTo(HaveOccurred())
+			return token
+		}
+
+		It("should expose the impersonated user and the admin acting as them", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(9)).Return(admin, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(9)).Return(nil, nil)
+
+			performRequest(newImpersonationToken())
+
+			Expect(wasCalled).To(BeTrue())
+			Expect(rr.Code).To(Equal(http.StatusOK))
+			Expect(user.Impersonator).To(BeIdenticalTo(admin))
+			Expect(user.ActorID()).To(Equal(int64(9)))
+			Expect(actor).To(Equal(repos.AuditActor{UserID: 9, ImpersonatedUserID: 1}))
+			Expect(logs.String()).To(BeEmpty())
+		})
+
+		It("should log writes with the real actor", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(9)).Return(admin, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(9)).Return(nil, nil)
+
+			performRequestWithMethod(http.MethodPost, newImpersonationToken())
+
+			Expect(wasCalled).To(BeTrue())
+			Expect(logs.String()).To(ContainSubstring("user 9 acting as user 1 of company 3: POST / -> 200"))
+		})
+
+		It("should reject the token once the admin may no longer impersonate", func() {
+			admin.Roles = types.Roles{types.RoleAdmin}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(9)).Return(admin, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(9)).Return(nil, nil)
+
+			performRequest(newImpersonationToken())
+
+			Expect(wasCalled).To(BeFalse())
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject the token once the admin no longer exists", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(9)).Return(nil, false, nil)
+
+			performRequest(newImpersonationToken())
+
+			Expect(wasCalled).To(BeFalse())
+			Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 500 when the admin cannot be loaded", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(9)).Return(nil, false, errors.New("db error"))
+
+			performRequest(newImpersonationToken())
+
+			Expect(wasCalled).To(BeFalse())
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	It("should return 500 when revocation cannot be checked", func() {
+		mockSessionsRepo.EXPECT().IsTokenActive(gomock.Any(), int64(7), gomock.Any()).Return(false, errors.New("db error"))
+
+		performRequest(newToken(7))
+
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+)
+
+// BrokerKey is the key for the event Broker in the context.
+const BrokerKey repoContextKey = "ctx:broker"
+
+// BrokerMiddleware creates a new middleware that injects the event Broker into the request context.
+func BrokerMiddleware(b *events.Broker) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), BrokerKey, b)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetBroker retrieves the event Broker from the context.
+// It panics if the broker is not found, as this indicates a server configuration error.
+func GetBroker(ctx context.Context) *events.Broker {
+	return ctx.Value(BrokerKey).(*events.Broker)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag returns the entity tag of a record at the given version.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetETag sets the ETag header of a response that shows a record at the given version. Clients send
+// it back in If-Match to update the record only if nobody else has changed it since.
+func SetETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", ETag(version))
+}
+
+// IfMatch reports whether a request may change a record at the given version: it has no If-Match
+// header, or the header lists the record's entity tag or "*". Entity tags are compared strongly, so
+// weak tags never match.
+func IfMatch(r *http.Request, version int64) bool {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return true
+	}
+	etag := ETag(version)
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// WritePreconditionFailed writes the 412 response to an update of a record that has been changed
+// since the client read it. The body is the record as it is now, with its ETag, so that the client
+// can apply its change again.
+func WritePreconditionFailed(w http.ResponseWriter, version int64, current interface{}) {
+	SetETag(w, version)
+	WriteJSON(w, http.StatusPreconditionFailed, current)
+}
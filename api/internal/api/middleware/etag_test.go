@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ETags", func() {
+	var req *http.Request
+
+	BeforeEach(func() {
+		req = httptest.NewRequest(http.MethodPut, "/api/v1/locations/1", nil)
+	})
+
+	It("should quote the version", func() {
+		Expect(middleware.ETag(7)).To(Equal(`"7"`))
+	})
+
+	It("should allow an update without If-Match", func() {
+		Expect(middleware.IfMatch(req, 7)).To(BeTrue())
+	})
+
+	It("should allow an update whose If-Match lists the current version or *", func() {
+		req.Header.Set("If-Match", `"6", "7"`)
+		Expect(middleware.IfMatch(req, 7)).To(BeTrue())
+
+		req.Header.Set("If-Match", "*")
+		Expect(middleware.IfMatch(req, 7)).To(BeTrue())
+	})
+
+	It("should refuse an update based on another version", func() {
+		req.Header.Set("If-Match", `"6"`)
+		Expect(middleware.IfMatch(req, 7)).To(BeFalse())
+
+		req.Header.Set("If-Match", `W/"7"`)
+		Expect(middleware.IfMatch(req, 7)).To(BeFalse())
+	})
+
+	It("should write the current record with its ETag when the precondition fails", func() {
+		rr := httptest.NewRecorder()
+
+		middleware.WritePreconditionFailed(rr, 7, map[string]string{"name": "Dock"})
+
+		Expect(rr.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rr.Header().Get("ETag")).To(Equal(`"7"`))
+		Expect(rr.Body.String()).To(MatchJSON(`{"name":"Dock"}`))
+	})
+})
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+const (
+	// IdempotencyKeyHeader is the header clients send a key for a POST request in, to make retrying
+	// it safe.
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses that are replayed from an earlier request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength is the longest key accepted, the size of its column.
+	maxIdempotencyKeyLength = 255
+	// idempotencyStateKey is the context key of the idempotencyState of a request.
+	idempotencyStateKey contextKey = "ctx:idempotencyState"
+)
+
+// withheldResponseError answers retries of successful requests whose response is withheld.
+const withheldResponseError = "a request with this idempotency key already succeeded; its response contained a secret and is not returned again"
+
+// idempotencyState lets the handler of a request with an Idempotency-Key tell IdempotencyMiddleware
+// how to store its response.
+type idempotencyState struct {
+	withhold bool
+}
+
+// IdempotencyMiddleware makes POST requests with an Idempotency-Key header safe to retry. The first
+// request with a key is handled as usual and its response is stored; retries with the same key and
+// the same method, URL and body get the stored response without being handled again. Reusing a key
+// for a different request returns 422, and retrying while the first request is still being handled
+// returns 409. Keys belong to the authenticated user or API key and expire after
+// types.IdempotencyKeyTTL. Responses with a 5xx status are not stored, so the request can be retried.
+// Successful responses of routes wrapped with WithholdIdempotentResponse are not stored either.
+// It must run after AuthMiddleware.
+func IdempotencyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			WriteError(w, http.StatusBadRequest, fmt.Sprintf("idempotency key must be at most %d characters", maxIdempotencyKeyLength))
+			return
+		}
+		owner, ok := idempotencyOwner(r.Context())
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			WriteError(w, http.StatusBadRequest, "failed to read request body")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		requestHash := requestFingerprint(r, body)
+
+		repo := GetRepo(r.Context()).IdempotencyKeys()
+		stored, reserved, err := repo.Reserve(r.Context(), owner, key, requestHash)
+		if err != nil {
+			WriteError(w, http.StatusInternalServerError, "failed to check idempotency key")
+			return
+		}
+		if !reserved {
+			switch {
+			case stored.RequestHash != requestHash:
+				WriteError(w, http.StatusUnprocessableEntity, "idempotency key was already used for a different request")
+			case !stored.Completed():
+				WriteError(w, http.StatusConflict, "a request with this idempotency key is still being handled")
+			default:
+				if stored.ContentType != "" {
+					w.Header().Set("Content-Type", stored.ContentType)
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(stored.StatusCode)
+				w.Write(stored.ResponseBody)
+			}
+			return
+		}
+
+		// The key is released unless the response is stored, also when the handler panics.
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := repo.Release(context.WithoutCancel(r.Context()), owner, key); err != nil {
+				log.Printf("unable to release idempotency key of %s: %s", owner, err.Error())
+			}
+		}()
+
+		state := &idempotencyState{}
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), idempotencyStateKey, state)))
+		if rec.status >= http.StatusInternalServerError {
+			return
+		}
+		status, contentType, responseBody := rec.status, w.Header().Get("Content-Type"), rec.body.Bytes()
+		if state.withhold && status < http.StatusMultipleChoices {
+			// Retries learn that the request succeeded, so that they do not repeat it, but not
+			// the secret.
+			status, contentType = http.StatusConflict, "application/json"
+			responseBody, _ = json.Marshal(ErrorResponse{Error: withheldResponseError})
+		}
+		if err := repo.Complete(context.WithoutCancel(r.Context()), owner, key, status, contentType, responseBody); err != nil {
+			log.Printf("unable to store response for idempotency key of %s: %s", owner, err.Error())
+			return
+		}
+		completed = true
+	})
+}
+
+// WithholdIdempotentResponse wraps the handler of a route whose successful response holds a secret,
+// such as a new API key, so that IdempotencyMiddleware never stores it. A retry of a request that
+// succeeded gets a 409 instead of the response.
+func WithholdIdempotentResponse(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if state, ok := r.Context().Value(idempotencyStateKey).(*idempotencyState); ok {
+			state.withhold = true
+		}
+		next(w, r)
+	}
+}
+
+// idempotencyOwner returns who the keys of a request belong to: the API key it was authenticated
+// with, or the user. When an admin impersonates the user the keys are the admin's own, so their
+// responses are never replayed to the user or the other way around.
+func idempotencyOwner(ctx context.Context) (string, bool) {
+	if key, ok := GetAuthAPIKeyFromContext(ctx); ok {
+		return fmt.Sprintf("api_key:%d", key.ID), true
+	}
+	if user, ok := GetAuthUserFromContext(ctx); ok {
+		if user.Impersonator != nil {
+			return fmt.Sprintf("user:%d:as:%d", user.ID, user.Impersonator.ID), true
+		}
+		return fmt.Sprintf("user:%d", user.ID), true
+	}
+	return "", false
+}
+
+// requestFingerprint hashes what makes two requests the same: their method, URL and body.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", r.Method, r.URL.RequestURI())
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder writes a response through while keeping a copy of its status and body.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if !rec.wroteHeader {
+		rec.status = code
+		rec.wroteHeader = true
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
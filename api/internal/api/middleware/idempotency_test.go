@@ -0,0 +1,186 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("IdempotencyMiddleware", func() {
+	const body = `{"name":"Dock"}`
+
+	var (
+		ctrl           *gomock.Controller
+		mockGlobalRepo *mock_repos.MockGlobalRepo
+		mockKeysRepo   *mock_repos.MockIdempotencyKeysRepo
+		rr             *httptest.ResponseRecorder
+		user           *types.User
+		calls          int
+		status         int
+		requestHash    string
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockGlobalRepo = mock_repos.NewMockGlobalRepo(ctrl)
+		mockKeysRepo = mock_repos.NewMockIdempotencyKeysRepo(ctrl)
+		mockGlobalRepo.EXPECT().IdempotencyKeys().Return(mockKeysRepo).AnyTimes()
+		rr = httptest.NewRecorder()
+		user = &types.User{ID: 1, CompanyID: 3}
+		calls = 0
+		status = http.StatusCreated
+
+		sum := sha256.Sum256([]byte("POST /locations\n" + body))
+		requestHash = hex.EncodeToString(sum[:])
+	})
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		received, err := io.ReadAll(r.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(received)).To(Equal(body))
+		middleware.WriteJSON(w, status, map[string]int{"id": 9})
+	})
+
+	performRequest := func(method, key string) {
+		req := httptest.NewRequest(method, "/locations", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+		req = req.WithContext(ctx)
+		if key != "" {
+			req.Header.Set(middleware.IdempotencyKeyHeader, key)
+		}
+		middleware.IdempotencyMiddleware(next).ServeHTTP(rr, req)
+	}
+
+	It("should store the response to the first request with a key", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{}, true, nil)
+		mockKeysRepo.EXPECT().Complete(gomock.Any(), "user:1", "abc", http.StatusCreated, "application/json", []byte("{\"id\":9}\n")).Return(nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(calls).To(Equal(1))
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		Expect(rr.Header().Get(middleware.IdempotentReplayedHeader)).To(BeEmpty())
+	})
+
+	It("should replay the stored response to a retry", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{
+			RequestHash: requestHash, StatusCode: http.StatusCreated, ContentType: "application/json", ResponseBody: []byte(`{"id":9}`),
+		}, false, nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(calls).To(BeZero())
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		Expect(rr.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(rr.Header().Get(middleware.IdempotentReplayedHeader)).To(Equal("true"))
+		Expect(rr.Body.String()).To(Equal(`{"id":9}`))
+	})
+
+	It("should return 422 when the key was used for a different request", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{
+			RequestHash: "other", StatusCode: http.StatusCreated,
+		}, false, nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(calls).To(BeZero())
+		Expect(rr.Code).To(Equal(http.StatusUnprocessableEntity))
+	})
+
+	It("should return 409 while the first request is still being handled", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{RequestHash: requestHash}, false, nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(calls).To(BeZero())
+		Expect(rr.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should release the key when the request fails with a server error", func() {
+		status = http.StatusInternalServerError
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{}, true, nil)
+		mockKeysRepo.EXPECT().Release(gomock.Any(), "user:1", "abc").Return(nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should not store the successful response of a route that withholds it", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(&types.IdempotencyKey{}, true, nil)
+		mockKeysRepo.EXPECT().Complete(gomock.Any(), "user:1", "abc", http.StatusConflict, "application/json", gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ int, _ string, stored []byte) error {
+				Expect(string(stored)).NotTo(ContainSubstring(`"id"`))
+				Expect(string(stored)).To(ContainSubstring("already succeeded"))
+				return nil
+			})
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+		req.Header.Set(middleware.IdempotencyKeyHeader, "abc")
+		middleware.IdempotencyMiddleware(middleware.WithholdIdempotentResponse(next)).ServeHTTP(rr, req.WithContext(ctx))
+
+		Expect(calls).To(Equal(1))
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+		Expect(rr.Body.String()).To(ContainSubstring(`"id":9`))
+	})
+
+	It("should keep the keys of API keys apart from those of users", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "api_key:5", "abc", requestHash).Return(&types.IdempotencyKey{}, true, nil)
+		mockKeysRepo.EXPECT().Complete(gomock.Any(), "api_key:5", "abc", http.StatusCreated, gomock.Any(), gomock.Any()).Return(nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/locations", strings.NewReader(body))
+		ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+		ctx = context.WithValue(ctx, middleware.AuthAPIKeyKey, &types.APIKey{ID: 5})
+		req.Header.Set(middleware.IdempotencyKeyHeader, "abc")
+		middleware.IdempotencyMiddleware(next).ServeHTTP(rr, req.WithContext(ctx))
+
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("should keep the keys of an impersonating admin apart from those of the user", func() {
+		user.Impersonator = &types.User{ID: 7}
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1:as:7", "abc", requestHash).Return(&types.IdempotencyKey{}, true, nil)
+		mockKeysRepo.EXPECT().Complete(gomock.Any(), "user:1:as:7", "abc", http.StatusCreated, gomock.Any(), gomock.Any()).Return(nil)
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(rr.Code).To(Equal(http.StatusCreated))
+	})
+
+	It("should return 500 when the key cannot be checked", func() {
+		mockKeysRepo.EXPECT().Reserve(gomock.Any(), "user:1", "abc", requestHash).Return(nil, false, errors.New("db error"))
+
+		performRequest(http.MethodPost, "abc")
+
+		Expect(calls).To(BeZero())
+		Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should reject keys that are too long", func() {
+		performRequest(http.MethodPost, strings.Repeat("k", 256))
+		Expect(rr.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should pass through requests without a key and other methods", func() {
+		performRequest(http.MethodPost, "")
+		performRequest(http.MethodPut, "abc")
+		Expect(calls).To(Equal(2))
+	})
+})
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
+)
+
+// MailerKey is the key for the Mailer in the context.
+const MailerKey repoContextKey = "ctx:mailer"
+
+// MailerMiddleware creates a new middleware that injects the Mailer into the request context.
+func MailerMiddleware(m mailer.Mailer) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), MailerKey, m)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetMailer retrieves the Mailer from the context.
+// It panics if the mailer is not found, as this indicates a server configuration error.
+func GetMailer(ctx context.Context) mailer.Mailer {
+	return ctx.Value(MailerKey).(mailer.Mailer)
+}
@@ -0,0 +1,108 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RequirePermission", func() {
+	var (
+		rr        *httptest.ResponseRecorder
+		handler   http.Handler
+		wasCalled bool
+	)
+
+	BeforeEach(func() {
+		rr = httptest.NewRecorder()
+		wasCalled = false
+		handler = middleware.RequirePermission(types.PermissionUsersManage)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wasCalled = true
+			w.WriteHeader(http.StatusOK)
+		}))
+	})
+
+	performRequest := func(user *types.User) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if user != nil {
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthUserKey, user))
+		}
+		handler.ServeHTTP(rr, req)
+	}
+
+	It("should let admins through", func() {
+		performRequest(&types.User{Roles: types.Roles{types.RoleAdmin}})
+		Expect(wasCalled).To(BeTrue())
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should let users through whose company roles grant the permission", func() {
+		performRequest(&types.User{
+			Roles:       types.Roles{types.RoleUser},
+			Permissions: types.Permissions{types.PermissionUsersManage},
+		})
+		Expect(wasCalled).To(BeTrue())
+		Expect(rr.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should forbid users without the permission", func() {
+		performRequest(&types.User{Roles: types.Roles{types.RoleUser}})
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should reject unauthenticated requests", func() {
+		performRequest(nil)
+		Expect(wasCalled).To(BeFalse())
+		Expect(rr.Code).To(Equal(http.StatusUnauthorized))
+	})
+})
+
+var _ = Describe("RequestedCompanyID", func() {
+	var (
+		admin      *types.User
+		superAdmin *types.User
+	)
+
+	BeforeEach(func() {
+		admin = &types.User{CompanyID: 1, Roles: types.Roles{types.RoleAdmin}}
+		superAdmin = &types.User{CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+	})
+
+	requestedCompanyID := func(query string, user *types.User) (int64, error) {
+		return middleware.RequestedCompanyID(httptest.NewRequest(http.MethodGet, "/?"+query, nil), user)
+	}
+
+	It("should default to the user's own company", func() {
+		companyID, err := requestedCompanyID("", admin)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(companyID).To(Equal(int64(1)))
+	})
+
+	It("should let super admins ask for another company", func() {
+		companyID, err := requestedCompanyID("company_id=2", superAdmin)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(companyID).To(Equal(int64(2)))
+	})
+
+	It("should forbid company admins from asking for another company", func() {
+		_, err := requestedCompanyID("company_id=2", admin)
+		Expect(err).To(MatchError(middleware.ErrCompanyForbidden))
+	})
+
+	It("should reject an invalid company_id", func() {
+		_, err := requestedCompanyID("company_id=abc", admin)
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(middleware.ErrCompanyForbidden))
+	})
+
+	It("should scope lookups to the user's company unless they are a super admin", func() {
+		Expect(middleware.CompanyScope(admin)).To(Equal(int64(1)))
+		Expect(middleware.CompanyScope(superAdmin)).To(BeZero())
+	})
+})
@@ -2,11 +2,14 @@ package public_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 )
 
 var _ = Describe("Commodity Types Endpoint", func() {
@@ -26,6 +29,10 @@ var _ = Describe("Commodity Types Endpoint", func() {
 
 	Context("GET /commodity-types", func() {
 		It("should return a list of all commodity types", func() {
+			mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return([]*types.CommodityTypeRecord{
+				{ID: types.CommodityTypeProduce, Name: "produce"},
+			}, int64(1), nil)
+
 			req := createRequest("GET", "/commodity-types")
 			rr := executeRequest(req)
 
@@ -39,6 +46,15 @@ var _ = Describe("Commodity Types Endpoint", func() {
 			// Assert that the returned commodity types contain expected values
 			Expect(commodityTypes).To(ConsistOf("produce"))
 		})
+
+		It("should return 500 when the commodity types cannot be loaded", func() {
+			mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(nil, int64(0), errors.New("db error"))
+
+			req := createRequest("GET", "/commodity-types")
+			rr := executeRequest(req)
+
+			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
+		})
 	})
 
 	Context("Unsupported Methods for /commodity-types", func() {
@@ -33,7 +33,7 @@ func GetRoles(w http.ResponseWriter, r *http.Request) {
 
 // GetCommodityTypes godoc
 // @Summary      Get all commodity types
-// @Description  Retrieves a list of all available commodity types.
+// @Description  Retrieves the names of all available commodity types.
 // @Tags         public
 // @Accept       json
 // @Produce      json
@@ -41,10 +41,17 @@ func GetRoles(w http.ResponseWriter, r *http.Request) {
 // @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
 // @Router       /commodity-types [get]
 func GetCommodityTypes(w http.ResponseWriter, r *http.Request) {
-	commodityTypes := types.AllCommodityTypes()
+	gr := middleware.GetRepo(r.Context())
+
+	commodityTypes, _, err := gr.CommodityTypes().Find(r.Context(), nil)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodity types")
+		return
+	}
+
 	stringCommodityTypes := make([]string, len(commodityTypes))
 	for i, ct := range commodityTypes {
-		stringCommodityTypes[i] = ct.String()
+		stringCommodityTypes[i] = ct.Name
 	}
 
 	w.Header().Set("Content-Type", "application/json")
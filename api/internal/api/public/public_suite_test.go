@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/public"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
 )
 
 func TestPublic(t *testing.T) {
@@ -15,10 +18,24 @@ func TestPublic(t *testing.T) {
 }
 
 var (
-	router *mux.Router
+	mockCtrl               *gomock.Controller
+	mockGlobalRepo         *mock_repos.MockGlobalRepo
+	mockCommodityTypesRepo *mock_repos.MockCommodityTypesRepo
+	router                 *mux.Router
 )
 
-var _ = BeforeSuite(func() {
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockCommodityTypesRepo = mock_repos.NewMockCommodityTypesRepo(mockCtrl)
+
+	mockGlobalRepo.EXPECT().CommodityTypes().Return(mockCommodityTypesRepo).AnyTimes()
+
 	router = mux.NewRouter()
+	router.Use(middleware.RepoMiddleware(mockGlobalRepo))
 	public.AddPublicRoutes(router)
 })
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
@@ -37,7 +37,7 @@ var _ = Describe("Roles Endpoint", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Assert that the returned roles contain expected values
-			Expect(roles).To(ConsistOf("admin", "user"))
+			Expect(roles).To(ConsistOf("super_admin", "admin", "user"))
 		})
 	})
 
@@ -15,26 +15,47 @@ import (
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/auth"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
 	v1 "github.com/happilymarrieddad/order-management-v3/api/internal/api/v1"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 // Run starts the API server. It configures routes, middleware, and handles graceful shutdown.
 // This function will block until the server is shut down.
-func Run(repo repos.GlobalRepo, logger *log.Logger) error {
+func Run(repo repos.GlobalRepo, mail mailer.Mailer, broker *events.Broker, logger *log.Logger) error {
 	router := mux.NewRouter()
 
 	router.Use(middleware.RepoMiddleware(repo))
+	router.Use(middleware.MailerMiddleware(mail))
+	router.Use(middleware.BrokerMiddleware(broker))
 
 	// Add swagger route. Access it at http://localhost:8080/swagger/index.html
 	// The empty import of the docs package is necessary for swag to work.
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
+	router.HandleFunc("/.well-known/jwks.json", auth.JWKS).Methods("GET")
 	router.HandleFunc("/login", auth.Login).Methods("POST")
+	router.HandleFunc("/login/mfa", auth.LoginMFA).Methods("POST")
+	router.HandleFunc("/login/mfa/enroll", auth.LoginEnrollMFA).Methods("POST")
+	router.HandleFunc("/auth/refresh", auth.Refresh).Methods("POST")
+	router.HandleFunc("/auth/forgot-password", auth.ForgotPassword).Methods("POST")
+	router.HandleFunc("/auth/reset-password", auth.ResetPassword).Methods("POST")
+	router.HandleFunc("/auth/accept-invitation", auth.AcceptInvitation).Methods("POST")
+	router.HandleFunc("/auth/sso/start", auth.StartSSO).Methods("POST")
+	router.HandleFunc("/auth/sso/callback", auth.SSOCallback).Methods("POST")
+	router.Handle("/auth/logout", middleware.AuthMiddleware(http.HandlerFunc(auth.Logout))).Methods("POST")
+	router.Handle("/auth/impersonate/{userId:[0-9]+}", middleware.AuthMiddleware(http.HandlerFunc(auth.Impersonate))).Methods("POST")
+	router.Handle("/auth/mfa/enroll", middleware.AuthMiddleware(http.HandlerFunc(auth.EnrollMFA))).Methods("POST")
+	router.Handle("/auth/mfa/confirm", middleware.AuthMiddleware(http.HandlerFunc(auth.ConfirmMFA))).Methods("POST")
+	router.Handle("/auth/mfa/recovery-codes", middleware.AuthMiddleware(http.HandlerFunc(auth.RegenerateMFARecoveryCodes))).Methods("POST")
+	router.Handle("/auth/mfa/disable", middleware.AuthMiddleware(http.HandlerFunc(auth.DisableMFA))).Methods("POST")
 
 	// All routes under /api require authentication
 	api := router.PathPrefix("/api").Subrouter()
 	api.Use(middleware.AuthMiddleware)
+	// POST requests with an Idempotency-Key header are safe to retry.
+	api.Use(middleware.IdempotencyMiddleware)
 
 	// Create a subrouter for v1 of the API
 	v1Routes := api.PathPrefix("/v1").Subrouter()
@@ -43,10 +64,12 @@ func Run(repo repos.GlobalRepo, logger *log.Logger) error {
 
 	// --- Server Setup & Graceful Shutdown ---
 	serverAddr := ":8080"
-	// Define the CORS options, allowing the custom X-App-Token header.
+	// Define the CORS options, allowing the custom X-App-Token, X-Api-Key and Idempotency-Key
+	// headers, and Last-Event-ID for resuming event streams.
 	corsOpts := handlers.CORS(
 		handlers.AllowedOrigins([]string{"*"}), // Be more specific in production
-		handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization", "X-App-Token"}),
+		handlers.AllowedHeaders([]string{"X-Requested-With", "Content-Type", "Authorization", "X-App-Token", "X-Api-Key", "Idempotency-Key", "Last-Event-ID", "If-Match"}),
+		handlers.ExposedHeaders([]string{middleware.IdempotentReplayedHeader, "ETag"}),
 		handlers.AllowedMethods([]string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
 	)
 	srv := &http.Server{
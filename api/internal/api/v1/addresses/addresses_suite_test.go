@@ -26,6 +26,7 @@ var (
 	router            *mux.Router
 	adminUser         *types.User
 	normalUser        *types.User
+	superAdminUser    *types.User
 	company           *types.Company
 )
 
@@ -49,6 +50,7 @@ var _ = BeforeEach(func() {
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
 	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
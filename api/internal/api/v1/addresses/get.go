@@ -16,6 +16,7 @@ import (
 // @Produce      json
 // @Param        id   path      int  true  "Address ID"
 // @Success      200  {object}  types.Address
+// @Header       200  {string}  ETag "Version of the address, for If-Match"
 // @Failure      400  {object}  middleware.ErrorResponse "Invalid Address ID"
 // @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      404  {object}  middleware.ErrorResponse "Address not found"
@@ -49,6 +50,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, addr.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(addr)
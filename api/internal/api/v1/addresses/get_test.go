@@ -24,7 +24,7 @@ var _ = Describe("Get Address Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		address = &types.Address{ID: 1, Line1: "123 Main St"}
+		address = &types.Address{ID: 1, Line1: "123 Main St", Version: 1}
 	})
 
 	performRequest := func(addressID string, user *types.User) {
@@ -40,6 +40,7 @@ var _ = Describe("Get Address Endpoint", func() {
 			performRequest(strconv.FormatInt(address.ID, 10), adminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 			var result types.Address
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.ID).To(Equal(address.ID))
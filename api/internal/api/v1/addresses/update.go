@@ -2,31 +2,37 @@ package addresses
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
 
 // @Summary      Update an address
-// @Description  Updates an existing address with new details.
+// @Description  Updates an existing address with new details. Only addresses used by the user's company, its users or its locations can be updated, unless the user has the companies:manage permission.
 // @Tags         addresses
 // @Accept       json
 // @Produce      json
 // @Param        id      path      int                      true  "Address ID"
 // @Param        address body      UpdateAddressPayload     true  "Address Update Payload"
+// @Param        If-Match header   string                   false "ETag of the address the update is based on"
 // @Success      200     {object}  types.Address            "Successfully updated address"
+// @Header       200     {string}  ETag                     "Version of the updated address"
 // @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input or ID"
+// @Failure      403     {object}  middleware.ErrorResponse "Forbidden - The address is not used by the user's company"
 // @Failure      404     {object}  middleware.ErrorResponse "Not Found - Address not found"
+// @Failure      412     {object}  types.Address            "Precondition Failed - The address has been changed since; this is its current version"
 // @Security     BearerAuth
 // @Router       /addresses/{id} [put]
 // Update handles updating an existing address.
 func Update(w http.ResponseWriter, r *http.Request) {
 	// Get the authenticated user from the context (cached by AuthMiddleware).
-	_, found := middleware.GetAuthUserFromContext(r.Context())
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
 	if !found { // Should be caught by middleware, but good practice to check
 		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
 		return
@@ -63,6 +69,24 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Addresses are shared, so users may only change the ones their company uses unless they manage
+	// all companies.
+	if companyID := middleware.CompanyScope(authUser); companyID != 0 {
+		used, err := gr.Addresses().IsUsedByCompany(r.Context(), id, companyID)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to get address")
+			return
+		}
+		if !used {
+			middleware.WriteError(w, http.StatusForbidden, "user not authorized to update this address")
+			return
+		}
+	}
+	if !middleware.IfMatch(r, address.Version) {
+		middleware.WritePreconditionFailed(w, address.Version, address)
+		return
+	}
+
 	// Update fields
 	if payload.Line1 != nil {
 		address.Line1 = utils.Deref(payload.Line1)
@@ -84,10 +108,19 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := gr.Addresses().Update(r.Context(), address); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the address between reading and writing it.
+			current, found, err := gr.Addresses().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update address")
 		return
 	}
 
+	middleware.SetETag(w, address.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(address)
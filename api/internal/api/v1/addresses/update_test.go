@@ -16,6 +16,7 @@ import (
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/addresses"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -46,6 +47,7 @@ var _ = Describe("Update Address Endpoint", func() {
 	Context("Happy Path", func() {
 		It("should update an address successfully for an admin", func() {
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(true, nil)
 			mockAddressesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, addr *types.Address) error {
 				Expect(addr.Line1).To(Equal(*payload.Line1))
 				return nil
@@ -61,6 +63,7 @@ var _ = Describe("Update Address Endpoint", func() {
 
 		It("should update an address successfully for a normal user", func() {
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(true, nil)
 			mockAddressesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, addr *types.Address) error {
 				Expect(addr.Line1).To(Equal(*payload.Line1))
 				return nil
@@ -70,6 +73,15 @@ var _ = Describe("Update Address Endpoint", func() {
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
+
+		It("should update an address of another company for a super admin", func() {
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
 	})
 
 	Context("Authorization and Authentication", func() {
@@ -77,6 +89,15 @@ var _ = Describe("Update Address Endpoint", func() {
 			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, nil)
 			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
 		})
+
+		It("should fail if the address is not used by the user's company", func() {
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(false, nil)
+
+			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Invalid Input", func() {
@@ -112,12 +133,33 @@ var _ = Describe("Update Address Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
+		It("should return 500 if checking the address's company fails", func() {
+			dbErr := errors.New("db error")
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(false, dbErr)
+			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
 		It("should return 500 on update address db error", func() {
 			dbErr := errors.New("db error")
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(true, nil)
 			mockAddressesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(dbErr)
 			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, adminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
+
+		It("should return 412 with the current address if it was changed during the update", func() {
+			current := &types.Address{ID: targetAddress.ID, Line1: "Their Address", Version: 2}
+			first := mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(targetAddress, true, nil)
+			mockAddressesRepo.EXPECT().IsUsedByCompany(gomock.Any(), targetAddress.ID, company.ID).Return(true, nil)
+			mockAddressesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), targetAddress.ID).Return(current, true, nil).After(first)
+			performRequest(strconv.FormatInt(targetAddress.ID, 10), payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"2"`))
+			Expect(rec.Body.String()).To(ContainSubstring("Their Address"))
+		})
 	})
 })
@@ -0,0 +1,66 @@
+package apikeys_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/apikeys"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeys(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "API Keys Handler Suite")
+}
+
+var (
+	mockCtrl        *gomock.Controller
+	mockGlobalRepo  *mock_repos.MockGlobalRepo
+	mockAPIKeysRepo *mock_repos.MockAPIKeysRepo
+	router          *mux.Router
+	adminUser       *types.User
+	normalUser      *types.User
+	company         *types.Company
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockAPIKeysRepo = mock_repos.NewMockAPIKeysRepo(mockCtrl)
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().APIKeys().Return(mockAPIKeysRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	apikeys.AddRoutes(router)
+
+	// Set up common test data
+	company = &types.Company{ID: 1, Name: "Test Company"}
+	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
+	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo
+// and an optional authenticated user in the context.
+func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	if user != nil {
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+	}
+	return req.WithContext(ctx)
+}
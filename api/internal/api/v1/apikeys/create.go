@@ -0,0 +1,89 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// keyPrefix marks a string as an API key of this service, which helps secret scanners find leaked keys.
+const keyPrefix = "omk_"
+
+// displayPrefixLength is how many characters of a key are stored in the clear so that users can
+// tell their keys apart.
+const displayPrefixLength = 12
+
+// Create handles creating an API key for the user's company.
+//
+//	@Summary		Create an API key
+//	@Description	Creates an API key for the user's company. The key is only returned in this response; it is stored hashed. Requests made with the key in the X-Api-Key header act as a service user of the company and are limited to the key's scopes.
+//	@Tags			api-keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			key	body		CreateAPIKeyPayload	true	"API Key Payload"
+//	@Success		201	{object}	CreateAPIKeyResponse
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid request body or scope"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/api-keys [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateAPIKeyPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	for _, scope := range payload.Scopes {
+		if !types.IsValidScope(scope) {
+			middleware.WriteError(w, http.StatusBadRequest, fmt.Sprintf("invalid scope %q", scope))
+			return
+		}
+	}
+	if payload.ExpiresAt != nil && !payload.ExpiresAt.After(time.Now()) {
+		middleware.WriteError(w, http.StatusBadRequest, "expires_at must be in the future")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found { // Should be caught by middleware, but good practice to check
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	token, _, err := jwtpkg.GenerateOpaqueToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to generate api key")
+		return
+	}
+	key := keyPrefix + token
+
+	apiKey := &types.APIKey{
+		CompanyID: authUser.CompanyID,
+		Name:      payload.Name,
+		KeyPrefix: key[:displayPrefixLength],
+		KeyHash:   jwtpkg.HashOpaqueToken(key),
+		Scopes:    types.Scopes(payload.Scopes),
+		ExpiresAt: payload.ExpiresAt,
+		CreatedBy: authUser.ID,
+	}
+	if err := gr.APIKeys().Create(r.Context(), apiKey); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create api key")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, CreateAPIKeyResponse{APIKey: apiKey, Key: key})
+}
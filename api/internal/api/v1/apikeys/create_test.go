@@ -0,0 +1,96 @@
+package apikeys_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/apikeys"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Create API Key Handler", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload apikeys.CreateAPIKeyPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = apikeys.CreateAPIKeyPayload{
+			Name:   "ERP integration",
+			Scopes: []string{"products:read", "locations:write"},
+		}
+	})
+
+	performRequest := func(user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPost, "/api-keys", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should create a key for the user's company and return it once", func() {
+		var created *types.APIKey
+		mockAPIKeysRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, key *types.APIKey) error {
+			key.ID = 9
+			created = key
+			return nil
+		})
+
+		performRequest(adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusCreated))
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		key, ok := resp["key"].(string)
+		Expect(ok).To(BeTrue())
+		Expect(key).To(HavePrefix("omk_"))
+		Expect(resp["id"]).To(BeNumerically("==", 9))
+		Expect(resp).NotTo(HaveKey("key_hash"))
+
+		Expect(created.CompanyID).To(Equal(company.ID))
+		Expect(created.CreatedBy).To(Equal(adminUser.ID))
+		Expect(created.KeyHash).To(Equal(jwtpkg.HashOpaqueToken(key)))
+		Expect(strings.HasPrefix(key, created.KeyPrefix)).To(BeTrue())
+		Expect(created.Scopes).To(Equal(types.Scopes{"products:read", "locations:write"}))
+	})
+
+	It("should reject an unknown scope", func() {
+		payload.Scopes = []string{"orders:delete"}
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject a missing name or scopes", func() {
+		payload.Name = ""
+		payload.Scopes = nil
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject an expiry in the past", func() {
+		expiresAt := time.Now().Add(-time.Minute)
+		payload.ExpiresAt = &expiresAt
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should forbid non-admins", func() {
+		performRequest(normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 500 if the repo fails", func() {
+		mockAPIKeysRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,56 @@
+package apikeys
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Delete handles revoking one of the user's company's API keys.
+//
+//	@Summary		Revoke an API key
+//	@Description	Revokes an API key of the user's company. Requests made with it are rejected immediately.
+//	@Tags			api-keys
+//	@Param			id	path	int	true	"API Key ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid API Key ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"API key not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/api-keys/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid api key ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, found, err = gr.APIKeys().Get(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get api key")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	if err := gr.APIKeys().Revoke(r.Context(), authUser.CompanyID, id); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to revoke api key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package apikeys_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Delete API Key Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should revoke a key of the user's company", func() {
+		mockAPIKeysRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(&types.APIKey{ID: 4, CompanyID: company.ID}, true, nil)
+		mockAPIKeysRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/api-keys/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 404 if the key does not exist", func() {
+		mockAPIKeysRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/api-keys/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 if revoking fails", func() {
+		mockAPIKeysRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(&types.APIKey{ID: 4, CompanyID: company.ID}, true, nil)
+		mockAPIKeysRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(errors.New("db error"))
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/api-keys/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should forbid non-admins", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/api-keys/4", nil, normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,62 @@
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// Find handles listing the API keys of the user's company.
+//
+//	@Summary		Find API keys
+//	@Description	Lists the API keys of the user's company that have not been revoked, newest first. The keys themselves are never returned.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			limit	query		int	false	"Number of records to return"
+//	@Param			offset	query		int	false	"Number of records to skip"
+//	@Success		200		{object}	object{data=[]types.APIKey,total=int}	"A list of API keys"
+//	@Failure		400		{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/api-keys/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	keys, count, err := gr.APIKeys().Find(r.Context(), &repos.APIKeyFindOpts{
+		CompanyID: authUser.CompanyID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find api keys")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(keys, count))
+}
@@ -0,0 +1,47 @@
+package apikeys_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Find API Keys Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should list the keys of the user's company", func() {
+		keys := []*types.APIKey{{ID: 1, CompanyID: company.ID, Name: "ERP", KeyHash: "secret"}}
+		mockAPIKeysRepo.EXPECT().Find(gomock.Any(), &repos.APIKeyFindOpts{CompanyID: company.ID, Limit: 10}).Return(keys, int64(1), nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/find", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("secret"))
+		var result types.FindResult[*types.APIKey]
+		Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(1)))
+		Expect(result.Data).To(HaveLen(1))
+	})
+
+	It("should forbid non-admins", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/find", nil, normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 500 if the repo fails", func() {
+		mockAPIKeysRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/find", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
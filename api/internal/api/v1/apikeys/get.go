@@ -0,0 +1,52 @@
+package apikeys
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Get handles retrieving one of the user's company's API keys.
+//
+//	@Summary		Get an API key
+//	@Description	Gets an API key of the user's company by its ID. The key itself is never returned.
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			id	path		int	true	"API Key ID"
+//	@Success		200	{object}	types.APIKey
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid API Key ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"API key not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/api-keys/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid api key ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	key, found, err := gr.APIKeys().Get(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get api key")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "api key not found")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, key)
+}
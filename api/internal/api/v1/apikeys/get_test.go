@@ -0,0 +1,40 @@
+package apikeys_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Get API Key Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should get a key of the user's company", func() {
+		key := &types.APIKey{ID: 4, CompanyID: company.ID, Name: "ERP"}
+		mockAPIKeysRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(key, true, nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(ContainSubstring(`"name":"ERP"`))
+	})
+
+	It("should return 404 for a key of another company or a revoked key", func() {
+		mockAPIKeysRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should forbid non-admins", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/api-keys/4", nil, normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,23 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// CreateAPIKeyPayload defines the structure for creating an API key.
+type CreateAPIKeyPayload struct {
+	Name string `json:"name" validate:"required,max=100" example:"ERP integration"`
+	// Scopes are "<resource>:read" or "<resource>:write"; the resource may be "*" for all resources.
+	Scopes []string `json:"scopes" validate:"required,min=1" example:"products:read,locations:write"`
+	// ExpiresAt is optional. Keys without it never expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2026-01-01T00:00:00Z"`
+}
+
+// CreateAPIKeyResponse is the API key that was created together with the key itself. The key is
+// only ever returned here.
+type CreateAPIKeyResponse struct {
+	*types.APIKey
+	Key string `json:"key" example:"omk_2b9c..."`
+}
@@ -0,0 +1,22 @@
+package apikeys
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the API key routes on the given subrouter.
+// All routes require the api-keys:manage permission and only reach the user's own company's keys.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/api-keys").Subrouter()
+	s.Use(middleware.RequirePermission(types.PermissionAPIKeysManage))
+
+	// The response holds the only copy of the secret, so it is not stored for idempotent retries.
+	s.HandleFunc("", middleware.WithholdIdempotentResponse(Create)).Methods(http.MethodPost)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+}
@@ -0,0 +1,65 @@
+package auditlog_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/auditlog"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditLog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Audit Log Handler Suite")
+}
+
+var (
+	mockCtrl         *gomock.Controller
+	mockGlobalRepo   *mock_repos.MockGlobalRepo
+	mockAuditLogRepo *mock_repos.MockAuditLogRepo
+	router           *mux.Router
+	adminUser        *types.User
+	superAdminUser   *types.User
+	normalUser       *types.User
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockAuditLogRepo = mock_repos.NewMockAuditLogRepo(mockCtrl)
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().AuditLog().Return(mockAuditLogRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	auditlog.AddRoutes(router)
+
+	// Set up common test data
+	normalUser = &types.User{ID: 1, CompanyID: 1, Roles: types.Roles{types.RoleUser}}
+	adminUser = &types.User{ID: 2, CompanyID: 1, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo and an optional
+// authenticated user in the context.
+func newAuthenticatedRequest(method, url string, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, nil)
+	ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	if user != nil {
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+	}
+	return req.WithContext(ctx)
+}
@@ -0,0 +1,127 @@
+package auditlog
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// Find handles listing the audit log of a company.
+//
+//	@Summary		Find audit log entries
+//	@Description	Lists the changes made in the user's company, newest first, with who made them and how each column changed. Secrets show as "[redacted]". Users who manage all companies see every company's entries and the shared catalog's, unless they pass company_id.
+//	@Tags			audit-log
+//	@Produce		json
+//	@Param			entity_type	query		string	false	"Only changes to this kind of record"	Enums(address, api_key, commodity, commodity_attribute, commodity_type, company, company_attribute_setting, company_role, invitation, location, product, product_attribute_value, product_pack, sso_config, user, webhook_endpoint)
+//	@Param			entity_id	query		int		false	"Only changes to the record with this ID"
+//	@Param			actor_id	query		int		false	"Only changes made by this user"
+//	@Param			action		query		string	false	"Only changes of this kind"	Enums(create, update, delete)
+//	@Param			from		query		string	false	"Only changes made at or after this time (RFC 3339)"
+//	@Param			to			query		string	false	"Only changes made before this time (RFC 3339)"
+//	@Param			company_id	query		int		false	"Company ID"
+//	@Param			limit		query		int		false	"Number of records to return"
+//	@Param			offset		query		int		false	"Number of records to skip"
+//	@Success		200			{object}	object{data=[]types.AuditLogEntry,total=int}	"A list of audit log entries"
+//	@Failure		400			{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401			{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500			{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/audit-log [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+	query := r.URL.Query()
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	entityID, err := utils.GetQueryInt64(r, "entity_id")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid entity_id format")
+		return
+	}
+
+	actorID, err := utils.GetQueryInt64(r, "actor_id")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid actor_id format")
+		return
+	}
+
+	action := types.AuditAction(query.Get("action"))
+	switch action {
+	case "", types.AuditActionCreate, types.AuditActionUpdate, types.AuditActionDelete:
+	default:
+		middleware.WriteError(w, http.StatusBadRequest, "invalid action")
+		return
+	}
+
+	from, err := parseTime(query.Get("from"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid from format")
+		return
+	}
+
+	to, err := parseTime(query.Get("to"))
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid to format")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Users who manage all companies see the whole log unless they ask for one company.
+	var companyID int64
+	if query.Get("company_id") != "" || !authUser.HasPermission(types.PermissionCompaniesManage) {
+		companyID, err = middleware.RequestedCompanyID(r, authUser)
+		if err != nil {
+			middleware.WriteRequestedCompanyIDError(w, err)
+			return
+		}
+	}
+
+	entries, count, err := gr.AuditLog().Find(r.Context(), &repos.AuditLogFindOpts{
+		CompanyID:  companyID,
+		EntityType: types.AuditEntityType(query.Get("entity_type")),
+		EntityID:   entityID,
+		ActorID:    actorID,
+		Action:     action,
+		From:       from,
+		To:         to,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find audit log entries")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(entries, count))
+}
+
+// parseTime parses an optional RFC 3339 query parameter.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,94 @@
+package auditlog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Find Audit Log Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should list the entries of the user's company with the given filters", func() {
+		companyID, actorID := int64(1), int64(2)
+		from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+		mockAuditLogRepo.EXPECT().Find(gomock.Any(), &repos.AuditLogFindOpts{
+			CompanyID:  1,
+			EntityType: types.AuditEntityProduct,
+			EntityID:   7,
+			ActorID:    2,
+			Action:     types.AuditActionUpdate,
+			From:       from,
+			Limit:      10,
+		}).Return([]*types.AuditLogEntry{{
+			ID: 1, CompanyID: &companyID, ActorID: &actorID, EntityType: types.AuditEntityProduct, EntityID: 7,
+			Action:  types.AuditActionUpdate,
+			Changes: types.AuditChanges{"name": {Before: json.RawMessage(`"a"`), After: json.RawMessage(`"b"`)}},
+		}}, int64(1), nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet,
+			"/audit-log?entity_type=product&entity_id=7&actor_id=2&action=update&from=2025-09-01T00:00:00Z", adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var result struct {
+			Data  []types.AuditLogEntry `json:"data"`
+			Total int64                 `json:"total"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(1)))
+		Expect(result.Data[0].Changes).To(HaveKeyWithValue("name", types.AuditChange{Before: json.RawMessage(`"a"`), After: json.RawMessage(`"b"`)}))
+	})
+
+	It("should list every company's entries to users who manage all companies", func() {
+		mockAuditLogRepo.EXPECT().Find(gomock.Any(), &repos.AuditLogFindOpts{Limit: 10}).Return(nil, int64(0), nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log", superAdminUser))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should let users who manage all companies list one company's entries", func() {
+		mockAuditLogRepo.EXPECT().Find(gomock.Any(), &repos.AuditLogFindOpts{CompanyID: 5, Limit: 10}).Return(nil, int64(0), nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log?company_id=5", superAdminUser))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should forbid a company admin from listing another company's entries", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log?company_id=5", adminUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should forbid users without the audit-log:read permission", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log", normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	DescribeTable("should fail with invalid filters",
+		func(query string) {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log?"+query, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		},
+		Entry("action", "action=rename"),
+		Entry("from", "from=yesterday"),
+		Entry("to", "to=2025-09-01"),
+		Entry("entity_id", "entity_id=abc"),
+		Entry("actor_id", "actor_id=abc"),
+		Entry("limit", "limit=abc"),
+	)
+
+	It("should return 500 when the entries cannot be listed", func() {
+		mockAuditLogRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/audit-log", adminUser))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
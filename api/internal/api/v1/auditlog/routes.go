@@ -0,0 +1,19 @@
+package auditlog
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the audit log routes on the given subrouter.
+// All routes require the audit-log:read permission and only reach the user's own company's
+// entries, unless the user manages all companies.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/audit-log").Subrouter()
+	s.Use(middleware.RequirePermission(types.PermissionAuditLogRead))
+
+	s.HandleFunc("", Find).Methods(http.MethodGet)
+}
@@ -24,8 +24,9 @@ var (
 	mockCompaniesRepo    *mock_repos.MockCompaniesRepo
 	mockAddressesRepo    *mock_repos.MockAddressesRepo
 	mockCommoditiesRepo *mock_repos.MockCommoditiesRepo
+	mockCommodityTypesRepo *mock_repos.MockCommodityTypesRepo
 	router               *mux.Router
-	adminUser            *types.User
+	superAdminUser       *types.User
 	normalUser           *types.User
 	company              *types.Company
 )
@@ -37,12 +38,14 @@ var _ = BeforeEach(func() {
 	mockCompaniesRepo = mock_repos.NewMockCompaniesRepo(mockCtrl)
 	mockAddressesRepo = mock_repos.NewMockAddressesRepo(mockCtrl)
 	mockCommoditiesRepo = mock_repos.NewMockCommoditiesRepo(mockCtrl)
+	mockCommodityTypesRepo = mock_repos.NewMockCommodityTypesRepo(mockCtrl)
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Companies().Return(mockCompaniesRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Addresses().Return(mockAddressesRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Commodities().Return(mockCommoditiesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().CommodityTypes().Return(mockCommodityTypesRepo).AnyTimes()
 
 	// Set up the router
 	router = mux.NewRouter()
@@ -51,7 +54,7 @@ var _ = BeforeEach(func() {
 	// Set up common test data
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
-	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
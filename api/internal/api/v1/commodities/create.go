@@ -33,6 +33,17 @@ func Create(w http.ResponseWriter, r *http.Request) {
 
 	gr := middleware.GetRepo(r.Context())
 
+	// Validate commodity type exists
+	_, found, err := gr.CommodityTypes().Get(r.Context(), payload.CommodityType)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity type")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "commodity type not found")
+		return
+	}
+
 	commodity := &types.Commodity{
 		Name:          payload.Name,
 		CommodityType: payload.CommodityType,
@@ -40,13 +40,14 @@ var _ = Describe("Create Commodity Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should create a commodity successfully for an admin", func() {
+		It("should create a commodity successfully for a super admin", func() {
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
 			mockCommoditiesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, com *types.Commodity) error {
 				com.ID = 1
 				return nil
 			})
 
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusCreated))
 			var result types.Commodity
@@ -70,23 +71,37 @@ var _ = Describe("Create Commodity Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPost, "/commodities", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPost, "/commodities", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail if a required field is missing", func() {
 			payload.Name = ""
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the commodity type does not exist", func() {
+			payload.CommodityType = types.CommodityType(999)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), payload.CommodityType).Return(nil, false, nil)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
 
 	Context("Repository Errors", func() {
+		It("should return 500 on commodity type lookup db error", func() {
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(nil, false, errors.New("db error"))
+			performRequest(payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
 		It("should return 500 on commodity creation db error", func() {
 			dbErr := errors.New("db error")
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
 			mockCommoditiesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
@@ -37,7 +37,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should find commodities successfully for an admin", func() {
+		It("should find commodities successfully for a super admin", func() {
 			queryParams := url.Values{}
 			expectedOpts := &repos.FindCommoditiesOpts{
 				Limit:  10,
@@ -45,7 +45,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			}
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Commodity{com1, com2}, int64(2), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Commodity]
@@ -78,7 +78,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			}
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Commodity{com2}, int64(2), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Commodity]
@@ -99,7 +99,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			}
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Commodity{com1}, int64(1), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Commodity]
@@ -120,7 +120,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			}
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Commodity{com1}, int64(1), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Commodity]
@@ -141,7 +141,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			}
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Commodity{com1}, int64(1), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Commodity]
@@ -164,7 +164,7 @@ var _ = Describe("Find Commodities Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), dbErr)
 
-			performRequest(url.Values{}, adminUser)
+			performRequest(url.Values{}, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
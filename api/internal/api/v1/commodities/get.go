@@ -15,6 +15,7 @@ import (
 // @Produce      json
 // @Param        id  path      int                      true  "Commodity ID"
 // @Success      200 {object}  types.Commodity           "Successfully retrieved commodity"
+// @Header       200 {string}  ETag                      "Version of the commodity, for If-Match"
 // @Failure      400 {object}  middleware.ErrorResponse "Bad Request - Invalid ID"
 // @Failure      404 {object}  middleware.ErrorResponse "Not Found - Commodity not found"
 // @Failure      500 {object}  middleware.ErrorResponse "Internal Server Error"
@@ -46,6 +47,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, commodity.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(commodity)
@@ -24,7 +24,7 @@ var _ = Describe("Get Commodity Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		commodity = &types.Commodity{ID: 1, Name: "Test Commodity"}
+		commodity = &types.Commodity{ID: 1, Name: "Test Commodity", Version: 1}
 	})
 
 	performRequest := func(commodityID string, user *types.User) {
@@ -34,12 +34,13 @@ var _ = Describe("Get Commodity Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should get a commodity successfully for an admin", func() {
+		It("should get a commodity successfully for a super admin", func() {
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), commodity.ID).Return(commodity, true, nil)
 
-			performRequest(strconv.FormatInt(commodity.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(commodity.ID, 10), superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 			var result types.Commodity
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.ID).To(Equal(commodity.ID))
@@ -63,7 +64,7 @@ var _ = Describe("Get Commodity Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid commodity ID", func() {
-			performRequest("invalid-id", adminUser)
+			performRequest("invalid-id", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 	})
@@ -71,14 +72,14 @@ var _ = Describe("Get Commodity Endpoint", func() {
 	Context("Repository Errors", func() {
 		It("should return 404 if the commodity is not found", func() {
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), commodity.ID).Return(nil, false, nil)
-			performRequest(strconv.FormatInt(commodity.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(commodity.ID, 10), superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on a database error", func() {
 			dbErr := errors.New("db error")
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), commodity.ID).Return(nil, false, dbErr)
-			performRequest(strconv.FormatInt(commodity.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(commodity.ID, 10), superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
@@ -3,10 +3,11 @@ package commodities
 import (
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // AddRoutes configures the commodity-related routes on the given subrouter.
-// All routes require authentication. POST, PUT, and /find require admin privileges.
+// All routes require authentication. POST and PUT require the catalog:manage permission.
 func AddRoutes(r *mux.Router) {
 	s := r.PathPrefix("/commodities").Subrouter()
 
@@ -14,9 +15,9 @@ func AddRoutes(r *mux.Router) {
 	s.HandleFunc("/{id:[0-9]+}", Get).Methods("GET")
 	s.HandleFunc("/find", Find).Methods("GET")
 
-	// Routes for admin users only
-	adminRouter := s.NewRoute().Subrouter()
-	adminRouter.Use(middleware.AuthUserAdminRequiredMuxMiddleware())
-	adminRouter.HandleFunc("", Create).Methods("POST")
-	adminRouter.HandleFunc("/{id:[0-9]+}", Update).Methods("PUT")
+	// Routes for users who manage the shared catalog
+	catalogRouter := s.NewRoute().Subrouter()
+	catalogRouter.Use(middleware.RequirePermission(types.PermissionCatalogManage))
+	catalogRouter.HandleFunc("", Create).Methods("POST")
+	catalogRouter.HandleFunc("/{id:[0-9]+}", Update).Methods("PUT")
 }
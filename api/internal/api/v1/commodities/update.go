@@ -2,11 +2,13 @@ package commodities
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -18,9 +20,12 @@ import (
 // @Produce      json
 // @Param        id        path      int                      true  "Commodity ID"
 // @Param        commodity body      UpdateCommodityPayload   true  "Commodity Update Payload"
+// @Param        If-Match  header    string                   false "ETag of the commodity the update is based on"
 // @Success      200       {object}  types.Commodity          "Successfully updated commodity"
+// @Header       200       {string}  ETag                     "Version of the updated commodity"
 // @Failure      400       {object}  middleware.ErrorResponse "Bad Request - Invalid input or validation failed"
 // @Failure      404       {object}  middleware.ErrorResponse "Not Found - Commodity not found"
+// @Failure      412       {object}  types.Commodity          "Precondition Failed - The commodity has been changed since; this is its current version"
 // @Failure      500       {object}  middleware.ErrorResponse "Internal Server Error"
 // @Security     AppTokenAuth
 // @Router       /commodities/{id} [put]
@@ -60,19 +65,43 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		middleware.WriteError(w, http.StatusNotFound, "commodity not found")
 		return
 	}
+	if !middleware.IfMatch(r, commodity.Version) {
+		middleware.WritePreconditionFailed(w, commodity.Version, commodity)
+		return
+	}
 
 	if payload.Name != nil {
 				commodity.Name = utils.Deref(payload.Name)
 	}
 	if payload.CommodityType != nil {
-				commodity.CommodityType = utils.Deref(payload.CommodityType)
+		// Validate commodity type exists
+		_, found, err := gr.CommodityTypes().Get(r.Context(), *payload.CommodityType)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity type")
+			return
+		}
+		if !found {
+			middleware.WriteError(w, http.StatusBadRequest, "commodity type not found")
+			return
+		}
+
+		commodity.CommodityType = utils.Deref(payload.CommodityType)
 	}
 
 	if err := gr.Commodities().Update(r.Context(), commodity); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the commodity between reading and writing it.
+			current, found, err := gr.Commodities().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update commodity")
 		return
 	}
 
+	middleware.SetETag(w, commodity.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(commodity)
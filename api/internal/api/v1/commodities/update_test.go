@@ -16,6 +16,7 @@ import (
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commodities"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -44,20 +45,33 @@ var _ = Describe("Update Commodity Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should update a commodity successfully for an admin", func() {
+		It("should update a commodity successfully for a super admin", func() {
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
 			mockCommoditiesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, com *types.Commodity) error {
 				Expect(com.Name).To(Equal(utils.Deref(payload.Name)))
 				return nil
 			})
 
-			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.Commodity
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.Name).To(Equal(utils.Deref(payload.Name)))
 		})
+		It("should update the commodity type when it exists", func() {
+			payload.CommodityType = utils.Ref(types.CommodityTypeProduce)
+			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
+			mockCommoditiesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, com *types.Commodity) error {
+				Expect(com.CommodityType).To(Equal(types.CommodityTypeProduce))
+				return nil
+			})
+
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
 	})
 
 	Context("Authorization and Authentication", func() {
@@ -74,12 +88,12 @@ var _ = Describe("Update Commodity Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid commodity ID", func() {
-			performRequest("invalid-id", payload, adminUser)
+			performRequest("invalid-id", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPut, "/commodities/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPut, "/commodities/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -87,7 +101,7 @@ var _ = Describe("Update Commodity Endpoint", func() {
 		It("should fail if no fields are provided for update", func() {
 			payload.Name = nil
 			payload.CommodityType = nil
-			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
@@ -95,14 +109,30 @@ var _ = Describe("Update Commodity Endpoint", func() {
 	Context("Dependency and Repository Errors", func() {
 		It("should return 404 if the commodity to update is not found", func() {
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(nil, false, nil)
-			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on get commodity db error", func() {
 			dbErr := errors.New("db error")
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(nil, false, dbErr)
-			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 400 if the commodity type does not exist", func() {
+			payload.CommodityType = utils.Ref(types.CommodityType(999))
+			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityType(999)).Return(nil, false, nil)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 on commodity type lookup db error", func() {
+			payload.CommodityType = utils.Ref(types.CommodityTypeProduce)
+			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(nil, false, errors.New("db error"))
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
@@ -110,8 +140,19 @@ var _ = Describe("Update Commodity Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
 			mockCommoditiesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
+
+		It("should return 412 with the current commodity if it was changed during the update", func() {
+			current := &types.Commodity{ID: targetCommodity.ID, Name: "Their Change", Version: 2}
+			first := mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(targetCommodity, true, nil)
+			mockCommoditiesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockCommoditiesRepo.EXPECT().Get(gomock.Any(), targetCommodity.ID).Return(current, true, nil).After(first)
+			performRequest(strconv.FormatInt(targetCommodity.ID, 10), payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"2"`))
+			Expect(rec.Body.String()).To(ContainSubstring("Their Change"))
+		})
 	})
 })
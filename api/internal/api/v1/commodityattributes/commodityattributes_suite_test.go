@@ -25,8 +25,9 @@ var (
 	mockAddressesRepo         *mock_repos.MockAddressesRepo
 	mockLocationsRepo         *mock_repos.MockLocationsRepo
 	mockCommodityAttributesRepo *mock_repos.MockCommodityAttributesRepo
+	mockCommodityTypesRepo *mock_repos.MockCommodityTypesRepo
 	router                    *mux.Router
-	adminUser                 *types.User
+	superAdminUser            *types.User
 	normalUser                *types.User
 	company                   *types.Company
 )
@@ -39,6 +40,7 @@ var _ = BeforeEach(func() {
 	mockAddressesRepo = mock_repos.NewMockAddressesRepo(mockCtrl)
 	mockLocationsRepo = mock_repos.NewMockLocationsRepo(mockCtrl)
 	mockCommodityAttributesRepo = mock_repos.NewMockCommodityAttributesRepo(mockCtrl)
+	mockCommodityTypesRepo = mock_repos.NewMockCommodityTypesRepo(mockCtrl)
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
@@ -46,6 +48,7 @@ var _ = BeforeEach(func() {
 	mockGlobalRepo.EXPECT().Addresses().Return(mockAddressesRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Locations().Return(mockLocationsRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().CommodityAttributes().Return(mockCommodityAttributesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().CommodityTypes().Return(mockCommodityTypesRepo).AnyTimes()
 
 	// Set up the router
 	router = mux.NewRouter()
@@ -54,7 +57,7 @@ var _ = BeforeEach(func() {
 	// Set up common test data
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
-	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
@@ -36,6 +36,17 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate commodity type exists
+	_, found, err := gr.CommodityTypes().Get(r.Context(), payload.CommodityType)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity type")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "commodity type not found")
+		return
+	}
+
 	ca := &types.CommodityAttribute{
 		Name:          payload.Name,
 		CommodityType: payload.CommodityType,
@@ -40,13 +40,14 @@ var _ = Describe("Create Commodity Attribute Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should create a commodity attribute successfully for an admin", func() {
+		It("should create a commodity attribute successfully for a super admin", func() {
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
 			mockCommodityAttributesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ca *types.CommodityAttribute) error {
 				ca.ID = 3 // Simulate ID generation
 				return nil
 			})
 
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusCreated))
 			var result types.CommodityAttribute
@@ -71,29 +72,37 @@ var _ = Describe("Create Commodity Attribute Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPost, "/commodity-attributes", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPost, "/commodity-attributes", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail if a required field is missing", func() {
 			payload.Name = ""
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail if an invalid commodity type is provided", func() {
 			payload.CommodityType = 999 // Invalid type
-			performRequest(payload, adminUser)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), payload.CommodityType).Return(nil, false, nil)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
 
 	Context("Repository Errors", func() {
+		It("should return 500 on commodity type lookup db error", func() {
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(nil, false, errors.New("db error"))
+			performRequest(payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
 		It("should return 500 on commodity attribute creation db error", func() {
 			dbErr := errors.New("db error")
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
 			mockCommodityAttributesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
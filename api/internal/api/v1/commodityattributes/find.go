@@ -3,6 +3,7 @@ package commodityattributes
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
@@ -18,7 +19,7 @@ import (
 // @Param        limit query int false "Number of records to return"
 // @Param        offset query int false "Number of records to skip"
 // @Param        id query []int false "Filter by Commodity Attribute IDs"
-// @Param        commodity_types query []string false "Filter by Commodity Types (e.g., 'grain', 'fruit')"
+// @Param        commodity_types query []string false "Filter by Commodity Type IDs or names (e.g., '1', 'produce')"
 // @Success      200  {object}  object{data=[]types.CommodityAttribute,total=int} "A list of commodity attributes"
 // @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
 // @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
@@ -55,11 +56,33 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var commodityTypes []types.CommodityType
+	// Commodity types can be given by ID or by name. Names are resolved against the commodity_types table.
+	var (
+		commodityTypes []types.CommodityType
+		typeNames      []string
+	)
 	for _, ctStr := range r.URL.Query()["commodity_types"] {
-		ct, err := types.ParseCommodityType(ctStr)
-		if err == nil && ct != types.CommodityTypeUnknown {
-			commodityTypes = append(commodityTypes, ct)
+		if id, err := strconv.Atoi(ctStr); err == nil {
+			commodityTypes = append(commodityTypes, types.CommodityType(id))
+		} else {
+			typeNames = append(typeNames, ctStr)
+		}
+	}
+	if len(typeNames) > 0 {
+		records, _, err := gr.CommodityTypes().Find(r.Context(), &repos.CommodityTypeFindOpts{Names: typeNames})
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodity types")
+			return
+		}
+		if len(records) == 0 && len(commodityTypes) == 0 {
+			// None of the requested types exist, so nothing can match.
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(types.NewFindResult([]*types.CommodityAttribute{}, 0))
+			return
+		}
+		for _, record := range records {
+			commodityTypes = append(commodityTypes, record.ID)
 		}
 	}
 
@@ -37,7 +37,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should find commodity attributes successfully for an admin", func() {
+		It("should find commodity attributes successfully for a super admin", func() {
 			expectedAttributes := []*types.CommodityAttribute{attr1, attr2}
 			expectedOpts := &repos.CommodityAttributeFindOpts{
 				Limit:  10,
@@ -45,7 +45,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 			}
 			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return(expectedAttributes, int64(len(expectedAttributes)), nil)
 
-			performRequest(url.Values{}, adminUser)
+			performRequest(url.Values{}, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.CommodityAttribute]
@@ -83,7 +83,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 			params := url.Values{}
 			params.Set("limit", "1")
 			params.Set("offset", "1")
-			performRequest(params, adminUser)
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.CommodityAttribute]
@@ -107,7 +107,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 			for _, id := range ids {
 				params.Add("id", strconv.FormatInt(id, 10))
 			}
-			performRequest(params, adminUser)
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
@@ -122,21 +122,48 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 				Offset: 0,
 				CommodityTypes: commodityTypes,
 			}
+			mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(&repos.CommodityTypeFindOpts{Names: []string{"produce"}})).Return([]*types.CommodityTypeRecord{{ID: types.CommodityTypeProduce, Name: "produce"}}, int64(1), nil)
 			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return(expectedAttributes, int64(1), nil)
 
 			params := url.Values{}
-			for _, ct := range commodityTypes {
-				params.Add("commodity_types", ct.String())
+			params.Add("commodity_types", "produce")
+			performRequest(params, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should filter by commodity type IDs without a lookup", func() {
+			expectedOpts := &repos.CommodityAttributeFindOpts{
+				Limit:          10,
+				Offset:         0,
+				CommodityTypes: []types.CommodityType{types.CommodityTypeProduce},
 			}
-			performRequest(params, adminUser)
+			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.CommodityAttribute{}, int64(0), nil)
+
+			params := url.Values{}
+			params.Add("commodity_types", "1")
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
 
+		It("should return an empty result when no requested commodity type exists", func() {
+			mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return([]*types.CommodityTypeRecord{}, int64(0), nil)
+
+			params := url.Values{}
+			params.Add("commodity_types", "grain")
+			performRequest(params, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result types.FindResult[*types.CommodityAttribute]
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.Total).To(BeZero())
+		})
+
 		It("should handle invalid limit parameter gracefully", func() {
 			params := url.Values{}
 			params.Add("limit", "invalid")
-			performRequest(params, adminUser)
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -144,7 +171,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 		It("should handle invalid offset parameter gracefully", func() {
 			params := url.Values{}
 			params.Add("offset", "invalid")
-			performRequest(params, adminUser)
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -160,7 +187,7 @@ var _ = Describe("Find Commodity Attributes Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), dbErr)
 
-			performRequest(url.Values{}, adminUser)
+			performRequest(url.Values{}, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
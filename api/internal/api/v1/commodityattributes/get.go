@@ -17,6 +17,7 @@ import (
 // @Produce      json
 // @Param        id   path      int  true  "Commodity Attribute ID"
 // @Success      200  {object}  types.CommodityAttribute
+// @Header       200  {string}  ETag "Version of the commodity attribute, for If-Match"
 // @Failure      400  {object}  middleware.ErrorResponse "Invalid Commodity Attribute ID"
 // @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      404  {object}  middleware.ErrorResponse "Commodity Attribute not found"
@@ -50,6 +51,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, attr.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(attr)
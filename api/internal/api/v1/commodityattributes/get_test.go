@@ -24,7 +24,7 @@ var _ = Describe("Get Commodity Attribute Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		targetAttribute = &types.CommodityAttribute{ID: 1, Name: "Test Attribute", CommodityType: types.CommodityTypeProduce}
+		targetAttribute = &types.CommodityAttribute{ID: 1, Name: "Test Attribute", CommodityType: types.CommodityTypeProduce, Version: 1}
 	})
 
 	performRequest := func(attributeID string, user *types.User) {
@@ -34,12 +34,13 @@ var _ = Describe("Get Commodity Attribute Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should get a commodity attribute successfully for an admin", func() {
+		It("should get a commodity attribute successfully for a super admin", func() {
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
 
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 			var result types.CommodityAttribute
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.ID).To(Equal(targetAttribute.ID))
@@ -63,7 +64,7 @@ var _ = Describe("Get Commodity Attribute Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid ID", func() {
-			performRequest("invalid-id", adminUser)
+			performRequest("invalid-id", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 	})
@@ -71,14 +72,14 @@ var _ = Describe("Get Commodity Attribute Endpoint", func() {
 	Context("Repository Errors", func() {
 		It("should return 404 if the commodity attribute is not found", func() {
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(nil, false, nil)
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on a database error", func() {
 			dbErr := errors.New("db error")
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(nil, false, dbErr)
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
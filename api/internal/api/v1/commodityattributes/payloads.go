@@ -5,7 +5,7 @@ import "github.com/happilymarrieddad/order-management-v3/api/types"
 // CreateCommodityAttributePayload defines the request body for creating a new commodity attribute.
 type CreateCommodityAttributePayload struct {
 	Name          string              `json:"name" validate:"required,min=2,max=255"`
-	CommodityType types.CommodityType `json:"commodityType" validate:"required,oneof=1"`
+	CommodityType types.CommodityType `json:"commodityType" validate:"required"`
 }
 
 // UpdateCommodityAttributePayload defines the request body for updating an existing commodity attribute.
@@ -2,12 +2,14 @@ package commodityattributes
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -20,12 +22,15 @@ import (
 // @Produce      json
 // @Param        id        path      int  true  "Commodity Attribute ID"
 // @Param        attribute body      UpdateCommodityAttributePayload true  "Commodity Attribute Update Payload"
+// @Param        If-Match  header    string                          false "ETag of the commodity attribute the update is based on"
 // @Success      200       {object}  types.CommodityAttribute        "Successfully updated commodity attribute"
+// @Header       200       {string}  ETag                            "Version of the updated commodity attribute"
 // @Failure      400       {object}  middleware.ErrorResponse        "Bad Request - Invalid input"
 // @Failure      401       {object}  middleware.ErrorResponse        "Unauthorized - Missing or invalid token"
 // @Failure      403       {object}  middleware.ErrorResponse        "Forbidden - Insufficient permissions"
 // @Failure      404       {object}  middleware.ErrorResponse        "Not Found - Commodity attribute not found"
 // @Failure      409       {object}  middleware.ErrorResponse        "Conflict - Duplicate attribute name"
+// @Failure      412       {object}  types.CommodityAttribute        "Precondition Failed - The commodity attribute has been changed since; this is its current version"
 // @Failure      500       {object}  middleware.ErrorResponse        "Internal Server Error"
 // @Router       /commodity-attributes/{id} [put]
 func Update(w http.ResponseWriter, r *http.Request) {
@@ -58,15 +63,38 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		middleware.WriteError(w, http.StatusNotFound, "commodity attribute not found")
 		return
 	}
+	if !middleware.IfMatch(r, ca.Version) {
+		middleware.WritePreconditionFailed(w, ca.Version, ca)
+		return
+	}
 
 	if payload.Name != nil {
 				ca.Name = utils.Deref(payload.Name)
 	}
 	if payload.CommodityType != nil {
-				ca.CommodityType = utils.Deref(payload.CommodityType)
+		// Validate commodity type exists
+		_, found, err := gr.CommodityTypes().Get(r.Context(), *payload.CommodityType)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity type")
+			return
+		}
+		if !found {
+			middleware.WriteError(w, http.StatusBadRequest, "commodity type not found")
+			return
+		}
+
+		ca.CommodityType = utils.Deref(payload.CommodityType)
 	}
 
 	if err := gr.CommodityAttributes().Update(r.Context(), ca); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the commodity attribute between reading and writing it.
+			current, found, err := gr.CommodityAttributes().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			middleware.WriteError(w, http.StatusConflict, "Commodity attribute with this name already exists")
 			return
@@ -75,6 +103,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, ca.Version)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(ca)
 }
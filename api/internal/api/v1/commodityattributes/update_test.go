@@ -16,6 +16,7 @@ import (
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commodityattributes"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -44,14 +45,14 @@ var _ = Describe("Update Commodity Attribute Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should update a commodity attribute successfully for an admin", func() {
+		It("should update a commodity attribute successfully for a super admin", func() {
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
 			mockCommodityAttributesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ca *types.CommodityAttribute) error {
 				Expect(ca.Name).To(Equal(*payload.Name))
 				return nil
 			})
 
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.CommodityAttribute
@@ -67,7 +68,18 @@ var _ = Describe("Update Commodity Attribute Endpoint", func() {
 				return nil
 			})
 
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should update the commodity type when it exists", func() {
+			payload.CommodityType = utils.Ref(types.CommodityTypeProduce)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce"}, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
@@ -87,19 +99,19 @@ var _ = Describe("Update Commodity Attribute Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid ID", func() {
-			performRequest("invalid-id", payload, adminUser)
+			performRequest("invalid-id", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPut, "/commodity-attributes/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPut, "/commodity-attributes/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail if no fields are provided for update", func() {
 			payload.Name = nil
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
@@ -107,23 +119,42 @@ var _ = Describe("Update Commodity Attribute Endpoint", func() {
 	Context("Dependency and Repository Errors", func() {
 		It("should return 404 if the commodity attribute to update is not found", func() {
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(nil, false, nil)
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on get commodity attribute db error", func() {
 			dbErr := errors.New("db error")
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(nil, false, dbErr)
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
+		It("should return 400 if the commodity type does not exist", func() {
+			payload.CommodityType = utils.Ref(types.CommodityType(999))
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
+			mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityType(999)).Return(nil, false, nil)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
 		It("should return 500 on update commodity attribute db error", func() {
 			dbErr := errors.New("db error")
 			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
 			mockCommodityAttributesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, adminUser)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
+
+		It("should return 412 with the current commodity attribute if it was changed during the update", func() {
+			current := &types.CommodityAttribute{ID: targetAttribute.ID, Name: "Their Change", Version: 2}
+			first := mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(targetAttribute, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), targetAttribute.ID).Return(current, true, nil).After(first)
+			performRequest(strconv.FormatInt(targetAttribute.ID, 10), payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"2"`))
+			Expect(rec.Body.String()).To(ContainSubstring("Their Change"))
+		})
 	})
 })
@@ -0,0 +1,67 @@
+package commoditytypes_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commoditytypes"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCommodityTypes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Commodity Types Handler Suite")
+}
+
+var (
+	mockCtrl               *gomock.Controller
+	mockGlobalRepo         *mock_repos.MockGlobalRepo
+	mockCommodityTypesRepo *mock_repos.MockCommodityTypesRepo
+	router                 *mux.Router
+	superAdminUser         *types.User
+	normalUser             *types.User
+	company                *types.Company
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockCommodityTypesRepo = mock_repos.NewMockCommodityTypesRepo(mockCtrl)
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().CommodityTypes().Return(mockCommodityTypesRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	commoditytypes.AddRoutes(router)
+
+	// Set up common test data
+	company = &types.Company{ID: 1, Name: "Test Company"}
+	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
+	superAdminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo
+// and an optional authenticated user in the context.
+func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctxWithRepo := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	if user != nil {
+		ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, user)
+		return req.WithContext(ctxWithAuth)
+	}
+	return req.WithContext(ctxWithRepo)
+}
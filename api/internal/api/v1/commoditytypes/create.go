@@ -0,0 +1,54 @@
+package commoditytypes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Create a commodity type
+// @Description  Creates a new commodity type. Names are stored lowercased.
+// @Tags         commodity-types
+// @Accept       json
+// @Produce      json
+// @Param        commodityType body      CreateCommodityTypePayload true "Commodity Type Creation Payload"
+// @Success      201           {object}  types.CommodityTypeRecord
+// @Failure      400           {object}  middleware.ErrorResponse "Bad Request - Invalid input"
+// @Failure      401           {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403           {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      409           {object}  middleware.ErrorResponse "Conflict - Name already exists"
+// @Failure      500           {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /commodity-types [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	var payload CreateCommodityTypePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	commodityType := &types.CommodityTypeRecord{Name: payload.Name}
+	if err := gr.CommodityTypes().Create(r.Context(), commodityType); err != nil {
+		if errors.Is(err, repos.ErrCommodityTypeNameExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create commodity type")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(commodityType)
+}
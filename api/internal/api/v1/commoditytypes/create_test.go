@@ -0,0 +1,74 @@
+package commoditytypes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commoditytypes"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Create Commodity Type Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload commoditytypes.CreateCommodityTypePayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = commoditytypes.CreateCommodityTypePayload{Name: "dairy"}
+	})
+
+	performRequest := func(payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPost, "/commodity-types", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should create a commodity type for a super admin", func() {
+		mockCommodityTypesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ct *types.CommodityTypeRecord) error {
+			Expect(ct.Name).To(Equal("dairy"))
+			ct.ID = 2
+			return nil
+		})
+
+		performRequest(payload, superAdminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusCreated))
+		var result types.CommodityTypeRecord
+		Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+		Expect(result.ID).To(Equal(types.CommodityType(2)))
+	})
+
+	It("should fail if not an admin", func() {
+		performRequest(payload, normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should fail if the name is missing", func() {
+		payload.Name = ""
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 409 if the name already exists", func() {
+		mockCommodityTypesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repos.ErrCommodityTypeNameExists)
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should return 500 on create db error", func() {
+		mockCommodityTypesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,49 @@
+package commoditytypes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Delete a commodity type
+// @Description  Deletes a commodity type. Existing commodities and attributes keep it, but it can no longer be assigned.
+// @Tags         commodity-types
+// @Param        id   path      int  true  "Commodity Type ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Commodity type not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /commodity-types/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid commodity type ID")
+		return
+	}
+
+	_, found, err := gr.CommodityTypes().Get(r.Context(), types.CommodityType(id))
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get commodity type")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "commodity type not found")
+		return
+	}
+
+	if err := gr.CommodityTypes().Delete(r.Context(), types.CommodityType(id)); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete commodity type")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
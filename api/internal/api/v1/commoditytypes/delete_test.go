@@ -0,0 +1,57 @@
+package commoditytypes_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Delete Commodity Type Endpoint", func() {
+	var (
+		rec           *httptest.ResponseRecorder
+		commodityType *types.CommodityTypeRecord
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		commodityType = &types.CommodityTypeRecord{ID: 2, Name: "grain"}
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodDelete, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should delete a commodity type for a super admin", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+		mockCommodityTypesRepo.EXPECT().Delete(gomock.Any(), commodityType.ID).Return(nil)
+
+		performRequest("/commodity-types/2", superAdminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should fail if not an admin", func() {
+		performRequest("/commodity-types/2", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 404 if the commodity type is not found", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(nil, false, nil)
+		performRequest("/commodity-types/2", superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 on delete db error", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+		mockCommodityTypesRepo.EXPECT().Delete(gomock.Any(), commodityType.ID).Return(errors.New("db error"))
+		performRequest("/commodity-types/2", superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
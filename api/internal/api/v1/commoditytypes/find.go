@@ -0,0 +1,75 @@
+package commoditytypes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// @Summary      Find commodity types
+// @Description  Finds commodity types with optional filters and pagination.
+// @Tags         commodity-types
+// @Produce      json
+// @Param        id     query []int    false "Filter by Commodity Type IDs" collectionFormat(multi)
+// @Param        name   query []string false "Filter by names" collectionFormat(multi)
+// @Param        limit  query int      false "Number of records to return"
+// @Param        offset query int      false "Number of records to skip"
+// @Success      200  {object}  object{data=[]types.CommodityTypeRecord,total=int} "A list of commodity types"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /commodity-types/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	_, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	ids, err := utils.GetQueryInt64Slice(r, "id")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid id format")
+		return
+	}
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	opts := &repos.CommodityTypeFindOpts{
+		Names:  r.URL.Query()["name"],
+		Limit:  limit,
+		Offset: offset,
+	}
+	for _, id := range ids {
+		opts.IDs = append(opts.IDs, types.CommodityType(id))
+	}
+
+	commodityTypes, count, err := gr.CommodityTypes().Find(r.Context(), opts)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodity types")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(types.NewFindResult(commodityTypes, count))
+}
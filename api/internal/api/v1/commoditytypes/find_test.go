@@ -0,0 +1,71 @@
+package commoditytypes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Find Commodity Types Endpoint", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should find commodity types with default pagination", func() {
+		expectedOpts := &repos.CommodityTypeFindOpts{Limit: 10}
+		mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.CommodityTypeRecord{
+			{ID: types.CommodityTypeProduce, Name: "produce"},
+		}, int64(1), nil)
+
+		performRequest("/commodity-types/find", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var result types.FindResult[*types.CommodityTypeRecord]
+		Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(1)))
+	})
+
+	It("should filter by id and name", func() {
+		expectedOpts := &repos.CommodityTypeFindOpts{
+			IDs:   []types.CommodityType{types.CommodityTypeProduce},
+			Names: []string{"produce"},
+			Limit: 10,
+		}
+		mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.CommodityTypeRecord{}, int64(0), nil)
+
+		performRequest("/commodity-types/find?id=1&name=produce", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should fail with an invalid limit", func() {
+		performRequest("/commodity-types/find?limit=abc", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/commodity-types/find", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should return 500 on find db error", func() {
+		mockCommodityTypesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+		performRequest("/commodity-types/find", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
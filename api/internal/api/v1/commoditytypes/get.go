@@ -0,0 +1,55 @@
+package commoditytypes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Get a commodity type
+// @Description  Gets a commodity type by its ID.
+// @Tags         commodity-types
+// @Produce      json
+// @Param        id   path      int  true  "Commodity Type ID"
+// @Success      200  {object}  types.CommodityTypeRecord
+// @Header       200  {string}  ETag "Version of the commodity type, for If-Match"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      404  {object}  middleware.ErrorResponse "Commodity type not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /commodity-types/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	_, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid commodity type ID")
+		return
+	}
+
+	commodityType, found, err := gr.CommodityTypes().Get(r.Context(), types.CommodityType(id))
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get commodity type")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "commodity type not found")
+		return
+	}
+
+	middleware.SetETag(w, commodityType.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(commodityType)
+}
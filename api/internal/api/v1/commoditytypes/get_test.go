@@ -0,0 +1,56 @@
+package commoditytypes_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Get Commodity Type Endpoint", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should return a commodity type for any authenticated user", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(&types.CommodityTypeRecord{ID: types.CommodityTypeProduce, Name: "produce", Version: 1}, true, nil)
+
+		performRequest("/commodity-types/1", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
+		var result types.CommodityTypeRecord
+		Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+		Expect(result.Name).To(Equal("produce"))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/commodity-types/1", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should return 404 if the commodity type is not found", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityType(9)).Return(nil, false, nil)
+		performRequest("/commodity-types/9", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 on get db error", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), types.CommodityTypeProduce).Return(nil, false, errors.New("db error"))
+		performRequest("/commodity-types/1", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
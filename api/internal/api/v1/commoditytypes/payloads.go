@@ -0,0 +1,11 @@
+package commoditytypes
+
+// CreateCommodityTypePayload defines the structure for creating a new commodity type.
+type CreateCommodityTypePayload struct {
+	Name string `json:"name" validate:"required,max=100" example:"dairy"`
+}
+
+// UpdateCommodityTypePayload defines the structure for renaming a commodity type.
+type UpdateCommodityTypePayload struct {
+	Name string `json:"name" validate:"required,max=100" example:"dairy"`
+}
@@ -0,0 +1,26 @@
+package commoditytypes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the commodity type routes on the given subrouter.
+// All routes require authentication. Managing commodity types requires the catalog:manage permission.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/commodity-types").Subrouter()
+
+	// Routes for any authenticated user
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+
+	// Routes for users who manage the shared catalog
+	catalogRouter := s.NewRoute().Subrouter()
+	catalogRouter.Use(middleware.RequirePermission(types.PermissionCatalogManage))
+	catalogRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	catalogRouter.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	catalogRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+}
@@ -0,0 +1,90 @@
+package commoditytypes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Update a commodity type
+// @Description  Renames a commodity type. Its ID, and therefore every reference to it, is unchanged.
+// @Tags         commodity-types
+// @Accept       json
+// @Produce      json
+// @Param        id            path      int                        true "Commodity Type ID"
+// @Param        commodityType body      UpdateCommodityTypePayload true "Commodity Type Update Payload"
+// @Param        If-Match      header    string                     false "ETag of the commodity type the update is based on"
+// @Success      200           {object}  types.CommodityTypeRecord
+// @Header       200           {string}  ETag                     "Version of the updated commodity type"
+// @Failure      400           {object}  middleware.ErrorResponse "Bad Request - Invalid input"
+// @Failure      401           {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403           {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404           {object}  middleware.ErrorResponse "Commodity type not found"
+// @Failure      409           {object}  middleware.ErrorResponse "Conflict - Name already exists"
+// @Failure      412           {object}  types.CommodityTypeRecord "Precondition Failed - The commodity type has been changed since; this is its current version"
+// @Failure      500           {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /commodity-types/{id} [put]
+func Update(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid commodity type ID")
+		return
+	}
+
+	var payload UpdateCommodityTypePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	commodityType, found, err := gr.CommodityTypes().Get(r.Context(), types.CommodityType(id))
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get commodity type")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "commodity type not found")
+		return
+	}
+	if !middleware.IfMatch(r, commodityType.Version) {
+		middleware.WritePreconditionFailed(w, commodityType.Version, commodityType)
+		return
+	}
+
+	commodityType.Name = payload.Name
+	if err := gr.CommodityTypes().Update(r.Context(), commodityType); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the commodity type between reading and writing it.
+			current, found, err := gr.CommodityTypes().Get(r.Context(), types.CommodityType(id))
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		if errors.Is(err, repos.ErrCommodityTypeNameExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update commodity type")
+		return
+	}
+
+	middleware.SetETag(w, commodityType.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(commodityType)
+}
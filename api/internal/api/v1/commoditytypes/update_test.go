@@ -0,0 +1,97 @@
+package commoditytypes_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commoditytypes"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Update Commodity Type Endpoint", func() {
+	var (
+		rec           *httptest.ResponseRecorder
+		payload       commoditytypes.UpdateCommodityTypePayload
+		commodityType *types.CommodityTypeRecord
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = commoditytypes.UpdateCommodityTypePayload{Name: "grains"}
+		commodityType = &types.CommodityTypeRecord{ID: 2, Name: "grain", Version: 3}
+	})
+
+	performRequest := func(payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, "/commodity-types/2", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should rename a commodity type for a super admin", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+		mockCommodityTypesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ct *types.CommodityTypeRecord) error {
+			Expect(ct.Name).To(Equal("grains"))
+			return nil
+		})
+
+		performRequest(payload, superAdminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should return 412 with the current commodity type if If-Match is stale", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, "/commodity-types/2", body, superAdminUser)
+		req.Header.Set("If-Match", `"2"`)
+		router.ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+		var current types.CommodityTypeRecord
+		Expect(json.NewDecoder(rec.Body).Decode(&current)).To(Succeed())
+		Expect(current.Name).To(Equal("grain"))
+	})
+
+	It("should fail if not an admin", func() {
+		performRequest(payload, normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should fail if the name is missing", func() {
+		payload.Name = ""
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 404 if the commodity type is not found", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(nil, false, nil)
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 409 if the name already exists", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+		mockCommodityTypesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrCommodityTypeNameExists)
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should return 500 on update db error", func() {
+		mockCommodityTypesRepo.EXPECT().Get(gomock.Any(), commodityType.ID).Return(commodityType, true, nil)
+		mockCommodityTypesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		performRequest(payload, superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -23,8 +23,11 @@ var (
 	mockUsersRepo     *mock_repos.MockUsersRepo
 	mockCompaniesRepo *mock_repos.MockCompaniesRepo
 	mockAddressesRepo *mock_repos.MockAddressesRepo
+	mockProductsRepo  *mock_repos.MockProductsRepo
+	mockSSORepo       *mock_repos.MockSSORepo
 	router            *mux.Router
 	adminUser         *types.User
+	superAdminUser    *types.User
 	normalUser        *types.User
 	company           *types.Company
 )
@@ -35,20 +38,25 @@ var _ = BeforeEach(func() {
 	mockUsersRepo = mock_repos.NewMockUsersRepo(mockCtrl)
 	mockCompaniesRepo = mock_repos.NewMockCompaniesRepo(mockCtrl)
 	mockAddressesRepo = mock_repos.NewMockAddressesRepo(mockCtrl)
+	mockProductsRepo = mock_repos.NewMockProductsRepo(mockCtrl)
+	mockSSORepo = mock_repos.NewMockSSORepo(mockCtrl)
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Companies().Return(mockCompaniesRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Addresses().Return(mockAddressesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Products().Return(mockProductsRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().SSO().Return(mockSSORepo).AnyTimes()
 
 	// Set up the router
 	router = mux.NewRouter()
 	companies.AddRoutes(router)
 
 	// Set up common test data
-	company = &types.Company{ID: 1, Name: "Test Company"}
+	company = &types.Company{ID: 1, Name: "Test Company", Version: 1}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
-	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	adminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
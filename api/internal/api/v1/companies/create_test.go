@@ -42,14 +42,14 @@ var _ = Describe("Create Company Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should create a company successfully for an admin", func() {
+		It("should create a company successfully for a super admin", func() {
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(address, true, nil)
 			mockCompaniesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *types.Company) error {
 				c.ID = 3 // Simulate ID generation
 				return nil
 			})
 
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusCreated))
 			var result types.Company
@@ -70,18 +70,23 @@ var _ = Describe("Create Company Endpoint", func() {
 			performRequest(payload, normalUser)
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
+
+		It("should fail for a company admin", func() {
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Invalid Input", func() {
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPost, "/companies", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPost, "/companies", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should fail if a required field is missing", func() {
 			payload.Name = ""
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
@@ -89,14 +94,14 @@ var _ = Describe("Create Company Endpoint", func() {
 	Context("Dependency and Repository Errors", func() {
 		It("should fail if the address does not exist", func() {
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(nil, false, nil)
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
 		It("should return 500 on address validation db error", func() {
 			dbErr := errors.New("db error")
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(nil, false, dbErr)
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
@@ -104,7 +109,7 @@ var _ = Describe("Create Company Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(address, true, nil)
 			mockCompaniesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest(payload, adminUser)
+			performRequest(payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
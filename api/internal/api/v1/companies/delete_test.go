@@ -30,10 +30,10 @@ var _ = Describe("Delete Company Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should delete a company successfully for an admin", func() {
+		It("should delete a company successfully for a super admin", func() {
 			mockCompaniesRepo.EXPECT().Delete(gomock.Any(), company.ID).Return(nil)
 
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusNoContent))
 		})
@@ -49,11 +49,16 @@ var _ = Describe("Delete Company Endpoint", func() {
 			performRequest("1", normalUser)
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
+
+		It("should fail for a company admin", func() {
+			performRequest("1", adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid company ID", func() {
-			performRequest("invalid-id", adminUser)
+			performRequest("invalid-id", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 	})
@@ -61,14 +66,14 @@ var _ = Describe("Delete Company Endpoint", func() {
 	Context("Repository Errors", func() {
 		It("should return 404 if the company is not found", func() {
 			mockCompaniesRepo.EXPECT().Delete(gomock.Any(), company.ID).Return(types.NewNotFoundError("company"))
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on a database error", func() {
 			dbErr := errors.New("db error")
 			mockCompaniesRepo.EXPECT().Delete(gomock.Any(), company.ID).Return(dbErr)
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
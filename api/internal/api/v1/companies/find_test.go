@@ -36,7 +36,7 @@ var _ = Describe("Find Companies Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should find companies successfully for an admin", func() {
+		It("should find companies successfully for a super admin", func() {
 			queryParams := url.Values{}
 			expectedOpts := &repos.CompanyFindOpts{
 				Limit:  10,
@@ -44,7 +44,7 @@ var _ = Describe("Find Companies Endpoint", func() {
 			}
 			mockCompaniesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Company{comp1, comp2}, int64(2), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Company]
@@ -65,7 +65,7 @@ var _ = Describe("Find Companies Endpoint", func() {
 			}
 			mockCompaniesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Company{comp2}, int64(2), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Company]
@@ -86,7 +86,7 @@ var _ = Describe("Find Companies Endpoint", func() {
 			}
 			mockCompaniesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(expectedOpts)).Return([]*types.Company{comp1}, int64(1), nil)
 
-			performRequest(queryParams, adminUser)
+			performRequest(queryParams, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.FindResult[types.Company]
@@ -107,6 +107,11 @@ var _ = Describe("Find Companies Endpoint", func() {
 			performRequest(url.Values{}, normalUser)
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
+
+		It("should fail for a company admin", func() {
+			performRequest(url.Values{}, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Error Paths", func() {
@@ -114,7 +119,7 @@ var _ = Describe("Find Companies Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockCompaniesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), dbErr)
 
-			performRequest(url.Values{}, adminUser)
+			performRequest(url.Values{}, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
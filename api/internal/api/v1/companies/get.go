@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"strconv"
 
-	"github.com/happilymarrieddad/order-management-v3/api/types"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
@@ -18,6 +17,7 @@ import (
 // @Produce      json
 // @Param        id   path      int  true  "Company ID"
 // @Success      200  {object}  types.Company
+// @Header       200  {string}  ETag "Version of the company, for If-Match"
 // @Failure      400  {object}  middleware.ErrorResponse "Invalid Company ID"
 // @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
@@ -41,7 +41,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !authUser.HasRole(types.RoleAdmin) && authUser.CompanyID != id {
+	if !authUser.CanAccessCompany(id) {
 		middleware.WriteError(w, http.StatusForbidden, "user not authorized to view this company")
 		return
 	}
@@ -57,6 +57,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, company.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(company)
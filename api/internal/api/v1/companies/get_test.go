@@ -31,12 +31,13 @@ var _ = Describe("Get Company Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should get a company successfully for an admin", func() {
+		It("should get a company successfully for a super admin", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
 
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 			var result types.Company
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.ID).To(Equal(company.ID))
@@ -67,7 +68,7 @@ var _ = Describe("Get Company Endpoint", func() {
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid company ID", func() {
-			performRequest("invalid-id", adminUser)
+			performRequest("invalid-id", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 	})
@@ -75,14 +76,14 @@ var _ = Describe("Get Company Endpoint", func() {
 	Context("Repository Errors", func() {
 		It("should return 404 if the company is not found", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(nil, false, nil)
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on a database error", func() {
 			dbErr := errors.New("db error")
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(nil, false, dbErr)
-			performRequest("1", adminUser)
+			performRequest("1", superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
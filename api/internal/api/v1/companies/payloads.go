@@ -9,6 +9,35 @@ type CreateCompanyPayload struct {
 // UpdateCompanyPayload defines the structure for updating a company.
 // At least one field must be provided.
 type UpdateCompanyPayload struct {
-	Name      *string `json:"name,omitempty" validate:"required_without_all=AddressID"`
-	AddressID *int64  `json:"address_id,omitempty" validate:"required_without_all=Name"`
+	Name                *string `json:"name,omitempty" validate:"required_without_all=AddressID ProductNameTemplate RequireMFA"`
+	AddressID           *int64  `json:"address_id,omitempty" validate:"required_without_all=Name ProductNameTemplate RequireMFA"`
+	ProductNameTemplate *string `json:"product_name_template,omitempty" validate:"required_without_all=Name AddressID RequireMFA" example:"{Size} {Color}[ ({Grade})] - {commodity}"`
+	// RequireMFA makes the company's users log in with multi-factor authentication. Users who have
+	// not enrolled are asked to at their next login.
+	RequireMFA *bool `json:"require_mfa,omitempty" validate:"required_without_all=Name AddressID ProductNameTemplate" example:"true"`
+}
+
+// RederiveProductNamesPayload defines the optional body for re-deriving a company's product names.
+type RederiveProductNamesPayload struct {
+	BatchSize int `json:"batch_size,omitempty" validate:"omitempty,min=1,max=1000" example:"100"`
+}
+
+// RederiveProductNamesResponse reports how many products were renamed.
+type RederiveProductNamesResponse struct {
+	Renamed int64 `json:"renamed" example:"42"`
+}
+
+// SSOConfigPayload defines the structure for setting a company's OpenID Connect identity provider.
+type SSOConfigPayload struct {
+	// Issuer is the identity provider's issuer URL. Its endpoints are discovered from it.
+	Issuer   string `json:"issuer" validate:"required,url" example:"https://login.example.com"`
+	ClientID string `json:"client_id" validate:"required" example:"order-management"`
+	// ClientSecret is required for a company without an identity provider. Leaving it out keeps the
+	// current secret.
+	ClientSecret *string `json:"client_secret,omitempty" validate:"omitempty,min=1" example:"s3cr3t"`
+	// AllowedDomains are the email domains whose identities may sign in.
+	AllowedDomains []string `json:"allowed_domains" validate:"required,min=1,dive,fqdn" example:"example.com"`
+	// JITProvisioning creates a user with the User role the first time an identity without a user
+	// signs in.
+	JITProvisioning bool `json:"jit_provisioning" example:"false"`
 }
@@ -0,0 +1,81 @@
+package companies
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Re-derive a company's product names
+// @Description  Rebuilds the name of every product owned by the company from its current naming template and
+// @Description  attribute display order. Products are processed in batches, one transaction per batch.
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                          true   "Company ID"
+// @Param        body    body      RederiveProductNamesPayload  false  "Batch options"
+// @Success      200     {object}  RederiveProductNamesResponse "Number of products renamed"
+// @Failure      400     {object}  middleware.ErrorResponse     "Bad Request - Invalid input or ID"
+// @Failure      401     {object}  middleware.ErrorResponse     "Unauthorized"
+// @Failure      403     {object}  middleware.ErrorResponse     "Forbidden"
+// @Failure      404     {object}  middleware.ErrorResponse     "Not Found - Company not found"
+// @Failure      500     {object}  middleware.ErrorResponse     "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /companies/{id}/products/rederive-names [post]
+func RederiveProductNames(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid company ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if !authUser.CanAccessCompany(id) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to rederive product names for this company")
+		return
+	}
+
+	// The body is optional; an empty body uses the default batch size.
+	var payload RederiveProductNamesPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	_, found, err = gr.Companies().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "company not found")
+		return
+	}
+
+	renamed, err := gr.Products().RederiveNames(r.Context(), id, payload.BatchSize)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to re-derive product names")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(RederiveProductNamesResponse{Renamed: renamed})
+}
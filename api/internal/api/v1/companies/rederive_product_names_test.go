@@ -0,0 +1,125 @@
+package companies_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companies"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Rederive Product Names Endpoint", func() {
+	var (
+		rec           *httptest.ResponseRecorder
+		targetCompany *types.Company
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		targetCompany = &types.Company{ID: 1, Name: "Test Company", AddressID: 10}
+	})
+
+	performRequest := func(companyID string, body []byte, user *types.User) {
+		var err error
+		rec, err = testutils.PerformRequest(router, http.MethodPost, "/companies/"+companyID+"/products/rederive-names", url.Values{}, bytes.NewBuffer(body), user, mockGlobalRepo)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Context("Happy Path", func() {
+		It("should re-derive product names with the default batch size when no body is sent", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockProductsRepo.EXPECT().RederiveNames(gomock.Any(), targetCompany.ID, 0).Return(int64(42), nil)
+
+			performRequest("1", nil, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result companies.RederiveProductNamesResponse
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.Renamed).To(Equal(int64(42)))
+		})
+
+		It("should pass the requested batch size to the repository", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockProductsRepo.EXPECT().RederiveNames(gomock.Any(), targetCompany.ID, 25).Return(int64(3), nil)
+
+			body, _ := json.Marshal(companies.RederiveProductNamesPayload{BatchSize: 25})
+			performRequest("1", body, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest("1", nil, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if not an admin", func() {
+			performRequest("1", nil, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should let a company admin re-derive the names of their own company", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockProductsRepo.EXPECT().RederiveNames(gomock.Any(), targetCompany.ID, 0).Return(int64(1), nil)
+
+			performRequest("1", nil, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should forbid a company admin from re-deriving another company's names", func() {
+			performRequest("99", nil, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with an invalid company ID", func() {
+			performRequest("invalid-id", nil, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fail with a malformed JSON body", func() {
+			performRequest("1", []byte(`{`), superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with a batch size that is too large", func() {
+			body, _ := json.Marshal(companies.RederiveProductNamesPayload{BatchSize: 5000})
+			performRequest("1", body, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Dependency and Repository Errors", func() {
+		It("should return 404 if the company is not found", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(nil, false, nil)
+			performRequest("1", nil, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 on get company db error", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(nil, false, errors.New("db error"))
+			performRequest("1", nil, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 500 when re-deriving fails", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockProductsRepo.EXPECT().RederiveNames(gomock.Any(), targetCompany.ID, 0).Return(int64(0), errors.New("db error"))
+			performRequest("1", nil, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
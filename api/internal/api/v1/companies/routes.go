@@ -3,21 +3,31 @@ package companies
 import (
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // AddRoutes configures the company-related routes on the given subrouter.
-// All routes require authentication. POST, PUT, and /find require admin privileges.
+// All routes require authentication. Company admins may change their own company; creating, listing
+// and deleting companies requires the companies:manage permission.
 func AddRoutes(r *mux.Router) {
 	s := r.PathPrefix("/companies").Subrouter()
 
 	// Routes for any authenticated user
 	s.HandleFunc("/{id:[0-9]+}", Get).Methods("GET")
 
-	// Routes for admin users only
-	adminRouter := s.NewRoute().Subrouter()
-	adminRouter.Use(middleware.AuthUserAdminRequiredMuxMiddleware())
-	adminRouter.HandleFunc("/{id:[0-9]+}", Update).Methods("PUT")
-	adminRouter.HandleFunc("", Create).Methods("POST")
-	adminRouter.HandleFunc("/find", Find).Methods("GET")
-	adminRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods("DELETE")
+	// Routes for users who manage their company's settings. The handlers check the company.
+	settingsRouter := s.NewRoute().Subrouter()
+	settingsRouter.Use(middleware.RequirePermission(types.PermissionCompanySettingsManage))
+	settingsRouter.HandleFunc("/{id:[0-9]+}", Update).Methods("PUT")
+	settingsRouter.HandleFunc("/{id:[0-9]+}/products/rederive-names", RederiveProductNames).Methods("POST")
+	settingsRouter.HandleFunc("/{id:[0-9]+}/sso", GetSSO).Methods("GET")
+	settingsRouter.HandleFunc("/{id:[0-9]+}/sso", SaveSSO).Methods("PUT")
+	settingsRouter.HandleFunc("/{id:[0-9]+}/sso", DeleteSSO).Methods("DELETE")
+
+	// Routes for users who manage all companies
+	managerRouter := s.NewRoute().Subrouter()
+	managerRouter.Use(middleware.RequirePermission(types.PermissionCompaniesManage))
+	managerRouter.HandleFunc("", Create).Methods("POST")
+	managerRouter.HandleFunc("/find", Find).Methods("GET")
+	managerRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods("DELETE")
 }
\ No newline at end of file
@@ -0,0 +1,182 @@
+package companies
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// @Summary      Get a company's single sign-on
+// @Description  Gets the OpenID Connect identity provider the company's users can sign in with. The client secret is never returned.
+// @Tags         companies
+// @Produce      json
+// @Param        id   path      int  true  "Company ID"
+// @Success      200  {object}  types.SSOConfig
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid Company ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Single sign-on not configured"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /companies/{id}/sso [get]
+func GetSSO(w http.ResponseWriter, r *http.Request) {
+	id, ok := ssoCompanyID(w, r)
+	if !ok {
+		return
+	}
+
+	config, found, err := middleware.GetRepo(r.Context()).SSO().GetConfig(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get single sign-on")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "single sign-on is not configured")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, config)
+}
+
+// @Summary      Set a company's single sign-on
+// @Description  Sets the OpenID Connect identity provider the company's users can sign in with at /auth/sso/start, replacing the current one.
+// @Description  The issuer must publish a discovery document. SSO_REDIRECT_URL has to be registered as a redirect URL of the client.
+// @Tags         companies
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int               true  "Company ID"
+// @Param        sso  body      SSOConfigPayload  true  "Identity Provider"
+// @Success      200  {object}  types.SSOConfig
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid input or an issuer without a discovery document"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Company not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /companies/{id}/sso [put]
+func SaveSSO(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, ok := ssoCompanyID(w, r)
+	if !ok {
+		return
+	}
+
+	var payload SSOConfigPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	_, found, err := gr.Companies().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "company not found")
+		return
+	}
+
+	config, found, err := gr.SSO().GetConfig(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get single sign-on")
+		return
+	}
+	if !found {
+		if payload.ClientSecret == nil {
+			middleware.WriteError(w, http.StatusBadRequest, "client_secret is required")
+			return
+		}
+		config = &types.SSOConfig{CompanyID: id}
+	}
+
+	// Checking the issuer now saves users from finding a typo at their next sign-in.
+	if _, err := oidc.Discover(r.Context(), payload.Issuer); err != nil {
+		log.Printf("unable to discover identity provider for company %d: %s", id, err.Error())
+		middleware.WriteError(w, http.StatusBadRequest, "unable to discover the identity provider of the issuer")
+		return
+	}
+
+	config.Issuer = payload.Issuer
+	config.ClientID = payload.ClientID
+	if payload.ClientSecret != nil {
+		config.ClientSecret = utils.Deref(payload.ClientSecret)
+	}
+	config.AllowedDomains = make(types.EmailDomains, len(payload.AllowedDomains))
+	for i, domain := range payload.AllowedDomains {
+		config.AllowedDomains[i] = strings.ToLower(domain)
+	}
+	config.JITProvisioning = payload.JITProvisioning
+
+	if err := gr.SSO().SaveConfig(r.Context(), config); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to save single sign-on")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, config)
+}
+
+// @Summary      Remove a company's single sign-on
+// @Description  Removes the company's identity provider. Sign-ins in progress with it fail; users keep their passwords.
+// @Tags         companies
+// @Param        id   path      int  true  "Company ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid Company ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Single sign-on not configured"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /companies/{id}/sso [delete]
+func DeleteSSO(w http.ResponseWriter, r *http.Request) {
+	id, ok := ssoCompanyID(w, r)
+	if !ok {
+		return
+	}
+
+	deleted, err := middleware.GetRepo(r.Context()).SSO().DeleteConfig(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to remove single sign-on")
+		return
+	}
+	if !deleted {
+		middleware.WriteError(w, http.StatusNotFound, "single sign-on is not configured")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ssoCompanyID reads the company ID of a single sign-on request and checks that the user may manage
+// the company. It answers the request and returns false otherwise.
+func ssoCompanyID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid company ID")
+		return 0, false
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return 0, false
+	}
+	if !authUser.CanAccessCompany(id) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to manage this company")
+		return 0, false
+	}
+	return id, true
+}
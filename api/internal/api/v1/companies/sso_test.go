@@ -0,0 +1,173 @@
+package companies_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companies"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc/oidctest"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+var _ = Describe("Company Single Sign-On Endpoints", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		idp     *oidctest.Server
+		config  *types.SSOConfig
+		payload companies.SSOConfigPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		idp = oidctest.NewServer("client-id", "client-secret")
+		DeferCleanup(idp.Close)
+
+		config = &types.SSOConfig{
+			CompanyID:      company.ID,
+			Issuer:         "https://old.example.com",
+			ClientID:       "old-client",
+			ClientSecret:   "old-secret",
+			AllowedDomains: types.EmailDomains{"example.com"},
+		}
+		payload = companies.SSOConfigPayload{
+			Issuer:         idp.Issuer(),
+			ClientID:       "client-id",
+			ClientSecret:   utils.Ref("client-secret"),
+			AllowedDomains: []string{"Example.com", "example.org"},
+		}
+	})
+
+	performRequest := func(method string, body interface{}, user *types.User) {
+		var buf bytes.Buffer
+		if body != nil {
+			Expect(json.NewEncoder(&buf).Encode(body)).To(Succeed())
+		}
+		var err error
+		rec, err = testutils.PerformRequest(router, method, "/companies/1/sso", url.Values{}, &buf, user, mockGlobalRepo)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	Describe("GetSSO", func() {
+		It("should return the configuration without the client secret", func() {
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(config, true, nil)
+
+			performRequest(http.MethodGet, nil, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"clientId":"old-client"`))
+			Expect(rec.Body.String()).NotTo(ContainSubstring("old-secret"))
+		})
+
+		It("should return 404 without a configuration", func() {
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(nil, false, nil)
+			performRequest(http.MethodGet, nil, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require the company-settings:manage permission", func() {
+			performRequest(http.MethodGet, nil, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not show another company's configuration", func() {
+			other := &types.User{ID: 9, CompanyID: 2, Roles: types.Roles{types.RoleAdmin}}
+			performRequest(http.MethodGet, nil, other)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("SaveSSO", func() {
+		It("should save a discoverable identity provider", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(nil, false, nil)
+			mockSSORepo.EXPECT().SaveConfig(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, saved *types.SSOConfig) error {
+				Expect(saved.CompanyID).To(Equal(company.ID))
+				Expect(saved.Issuer).To(Equal(idp.Issuer()))
+				Expect(saved.ClientSecret).To(Equal("client-secret"))
+				Expect(saved.AllowedDomains).To(Equal(types.EmailDomains{"example.com", "example.org"}))
+				return nil
+			})
+
+			performRequest(http.MethodPut, payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).NotTo(ContainSubstring("client-secret"))
+		})
+
+		It("should keep the client secret when it is left out", func() {
+			payload.ClientSecret = nil
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(config, true, nil)
+			mockSSORepo.EXPECT().SaveConfig(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, saved *types.SSOConfig) error {
+				Expect(saved.ClientID).To(Equal("client-id"))
+				Expect(saved.ClientSecret).To(Equal("old-secret"))
+				return nil
+			})
+
+			performRequest(http.MethodPut, payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should require a client secret for a new configuration", func() {
+			payload.ClientSecret = nil
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(nil, false, nil)
+
+			performRequest(http.MethodPut, payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject an issuer without a discovery document", func() {
+			payload.Issuer = idp.Issuer() + "/missing"
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(config, true, nil)
+
+			performRequest(http.MethodPut, payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject invalid domains", func() {
+			payload.AllowedDomains = []string{"not a domain"}
+			performRequest(http.MethodPut, payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 when the configuration cannot be saved", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockSSORepo.EXPECT().GetConfig(gomock.Any(), company.ID).Return(config, true, nil)
+			mockSSORepo.EXPECT().SaveConfig(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+			performRequest(http.MethodPut, payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("DeleteSSO", func() {
+		It("should remove the configuration", func() {
+			mockSSORepo.EXPECT().DeleteConfig(gomock.Any(), company.ID).Return(true, nil)
+			performRequest(http.MethodDelete, nil, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 404 without a configuration", func() {
+			mockSSORepo.EXPECT().DeleteConfig(gomock.Any(), company.ID).Return(false, nil)
+			performRequest(http.MethodDelete, nil, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
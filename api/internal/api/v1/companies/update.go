@@ -2,25 +2,33 @@ package companies
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
 
 // @Summary      Update a company
-// @Description  Updates an existing company's details.
+// @Description  Updates an existing company's details. Changing the product name template does not rename
+// @Description  existing products; use POST /companies/{id}/products/rederive-names afterwards. Setting require_mfa
+// @Description  makes the company's users log in with multi-factor authentication.
 // @Tags         companies
 // @Accept       json
 // @Produce      json
 // @Param        id      path      int                      true  "Company ID"
 // @Param        company body      UpdateCompanyPayload     true  "Company Update Payload"
+// @Param        If-Match header   string                   false "ETag of the company the update is based on"
 // @Success      200     {object}  types.Company            "Successfully updated company"
+// @Header       200     {string}  ETag                     "Version of the updated company"
 // @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input or ID"
+// @Failure      403     {object}  middleware.ErrorResponse "Forbidden - Company is not the user's own"
 // @Failure      404     {object}  middleware.ErrorResponse "Not Found - Company not found"
+// @Failure      412     {object}  types.Company            "Precondition Failed - The company has been changed since; this is its current version"
 // @Security     AppTokenAuth
 // @Router       /companies/{id} [put]
 func Update(w http.ResponseWriter, r *http.Request) {
@@ -32,6 +40,16 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if !authUser.CanAccessCompany(id) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to update this company")
+		return
+	}
+
 	var payload UpdateCompanyPayload
 	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
@@ -52,9 +70,10 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		middleware.WriteError(w, http.StatusNotFound, "company not found")
 		return
 	}
-
-	// Only admins can update companies.
-	// This check is done in the middleware layer.
+	if !middleware.IfMatch(r, company.Version) {
+		middleware.WritePreconditionFailed(w, company.Version, company)
+		return
+	}
 
 	if payload.Name != nil {
 		company.Name = utils.Deref(payload.Name)
@@ -74,11 +93,33 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		company.AddressID = utils.Deref(payload.AddressID)
 	}
 
+	if payload.ProductNameTemplate != nil {
+		// An empty template resets the company to the default naming behavior.
+		if _, err := types.ParseProductNameTemplate(utils.Deref(payload.ProductNameTemplate)); err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid product name template: "+err.Error())
+			return
+		}
+		company.ProductNameTemplate = utils.Deref(payload.ProductNameTemplate)
+	}
+
+	if payload.RequireMFA != nil {
+		company.RequireMFA = utils.Deref(payload.RequireMFA)
+	}
+
 	if err := gr.Companies().Update(r.Context(), company); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the company between reading and writing it.
+			current, found, err := gr.Companies().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update company")
 		return
 	}
 
+	middleware.SetETag(w, company.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(company)
@@ -15,6 +15,7 @@ import (
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/testutils"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companies"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -46,7 +47,7 @@ var _ = Describe("Update Company Endpoint", func() {
 	}
 
 	Context("Happy Path", func() {
-		It("should update a company successfully for an admin", func() {
+		It("should update a company successfully for a super admin", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
 			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *types.Company) error {
@@ -55,7 +56,7 @@ var _ = Describe("Update Company Endpoint", func() {
 				return nil
 			})
 
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 			var result types.Company
@@ -72,7 +73,7 @@ var _ = Describe("Update Company Endpoint", func() {
 				return nil
 			})
 
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
@@ -87,9 +88,52 @@ var _ = Describe("Update Company Endpoint", func() {
 				return nil
 			})
 
+			performRequest("1", payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should update only the product name template if it is the only field provided", func() {
+			payload = companies.UpdateCompanyPayload{ProductNameTemplate: utils.Ref("{Size} {Color}[ ({Grade})] - {commodity}")}
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *types.Company) error {
+				Expect(c.Name).To(Equal(targetCompany.Name)) // Should remain unchanged
+				Expect(c.ProductNameTemplate).To(Equal(*payload.ProductNameTemplate))
+				return nil
+			})
+
+			performRequest("1", payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should reset the product name template when an empty template is provided", func() {
+			targetCompany.ProductNameTemplate = "{commodity} {Size}"
+			payload = companies.UpdateCompanyPayload{ProductNameTemplate: utils.Ref("")}
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *types.Company) error {
+				Expect(c.ProductNameTemplate).To(BeEmpty())
+				return nil
+			})
+
+			performRequest("1", payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should let a company admin require MFA for their company", func() {
+			payload = companies.UpdateCompanyPayload{RequireMFA: utils.Ref(true)}
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, c *types.Company) error {
+				Expect(c.RequireMFA).To(BeTrue())
+				Expect(c.Name).To(Equal(targetCompany.Name))
+				return nil
+			})
+
 			performRequest("1", payload, adminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"require_mfa":true`))
 		})
 	})
 
@@ -105,16 +149,31 @@ var _ = Describe("Update Company Endpoint", func() {
 			performRequest("1", payload, normalUser)
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
+
+		It("should let a company admin update their own company", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
+			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			performRequest("1", payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should forbid a company admin from updating another company", func() {
+			performRequest("99", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Invalid Input", func() {
 		It("should fail with an invalid company ID", func() {
-			performRequest("invalid-id", payload, adminUser)
+			performRequest("invalid-id", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should fail with a malformed JSON body", func() {
-			rec, err := testutils.PerformRequest(router, http.MethodPut, "/companies/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), adminUser, mockGlobalRepo)
+			rec, err := testutils.PerformRequest(router, http.MethodPut, "/companies/1", url.Values{}, bytes.NewBuffer([]byte(`{`)), superAdminUser, mockGlobalRepo)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -123,7 +182,14 @@ var _ = Describe("Update Company Endpoint", func() {
 			payload.Name = nil
 			payload.AddressID = nil
 			// No mock expectation for CompaniesRepo.Get here, as the custom validation in handler will return early.
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with an invalid product name template", func() {
+			payload = companies.UpdateCompanyPayload{ProductNameTemplate: utils.Ref("{Size {commodity}")}
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 	})
@@ -131,21 +197,21 @@ var _ = Describe("Update Company Endpoint", func() {
 	Context("Dependency and Repository Errors", func() {
 		It("should return 404 if the company to update is not found", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(nil, false, nil)
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
 		It("should return 500 on get company db error", func() {
 			dbErr := errors.New("db error")
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(nil, false, dbErr)
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
 		It("should return 400 if the new address does not exist", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(nil, false, nil)
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
@@ -153,7 +219,7 @@ var _ = Describe("Update Company Endpoint", func() {
 			dbErr := errors.New("db error")
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(nil, false, dbErr)
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
@@ -162,8 +228,20 @@ var _ = Describe("Update Company Endpoint", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
 			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(dbErr)
-			performRequest("1", payload, adminUser)
+			performRequest("1", payload, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
+
+		It("should return 412 with the current company if it was changed during the update", func() {
+			current := &types.Company{ID: targetCompany.ID, Name: "Their Company", AddressID: 10, Version: 2}
+			first := mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(targetCompany, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
+			mockCompaniesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), targetCompany.ID).Return(current, true, nil).After(first)
+			performRequest("1", payload, superAdminUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"2"`))
+			Expect(rec.Body.String()).To(ContainSubstring("Their Company"))
+		})
 	})
 })
\ No newline at end of file
@@ -0,0 +1,91 @@
+package companyattributesettings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Create a company attribute setting
+// @Description  Sets the display order of a commodity attribute for a company.
+// @Tags         company-attribute-settings
+// @Accept       json
+// @Produce      json
+// @Param        setting body      CreateCompanyAttributeSettingPayload true  "Company Attribute Setting Creation Payload"
+// @Success      201     {object}  types.CompanyAttributeSetting        "Successfully created setting"
+// @Failure      400     {object}  middleware.ErrorResponse             "Bad Request - Invalid input or validation failed"
+// @Failure      401     {object}  middleware.ErrorResponse             "Unauthorized"
+// @Failure      403     {object}  middleware.ErrorResponse             "Forbidden"
+// @Failure      409     {object}  middleware.ErrorResponse             "Conflict - Attribute or display order already used"
+// @Failure      500     {object}  middleware.ErrorResponse             "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateCompanyAttributeSettingPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	if !authUser.CanAccessCompany(payload.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to change the attribute settings of this company")
+		return
+	}
+
+	// Validate dependencies
+	_, found, err := gr.Companies().Get(r.Context(), payload.CompanyID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate company")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "company not found")
+		return
+	}
+
+	_, found, err = gr.CommodityAttributes().Get(r.Context(), payload.CommodityAttributeID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity attribute")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "commodity attribute not found")
+		return
+	}
+
+	setting := &types.CompanyAttributeSetting{
+		CompanyID:            payload.CompanyID,
+		CommodityAttributeID: payload.CommodityAttributeID,
+		DisplayOrder:         payload.DisplayOrder,
+	}
+
+	if err := gr.CompanyAttributeSettings().Create(r.Context(), setting); err != nil {
+		if errors.Is(err, repos.ErrCompanyAttributeSettingExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create company attribute setting")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(setting)
+}
@@ -0,0 +1,136 @@
+package companyattributesettings_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyattributesettings"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Create Company Attribute Setting Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload companyattributesettings.CreateCompanyAttributeSettingPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = companyattributesettings.CreateCompanyAttributeSettingPayload{
+			CompanyID:            company.ID,
+			CommodityAttributeID: 2,
+			DisplayOrder:         1,
+		}
+	})
+
+	performRequest := func(payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPost, "/company-attribute-settings", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should create a setting successfully for an admin", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(company, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), payload.CommodityAttributeID).Return(&types.CommodityAttribute{ID: payload.CommodityAttributeID}, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *types.CompanyAttributeSetting) error {
+				Expect(s.CompanyID).To(Equal(payload.CompanyID))
+				Expect(s.CommodityAttributeID).To(Equal(payload.CommodityAttributeID))
+				Expect(s.DisplayOrder).To(Equal(payload.DisplayOrder))
+				s.ID = 10
+				return nil
+			})
+
+			performRequest(payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+			var result types.CompanyAttributeSetting
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.ID).To(Equal(int64(10)))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest(payload, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if not an admin", func() {
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail if a company admin creates a setting for another company", func() {
+			payload.CompanyID = 99
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with a malformed JSON body", func() {
+			req := newAuthenticatedRequest(http.MethodPost, "/company-attribute-settings", []byte(`{`), adminUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the display order is missing", func() {
+			payload.DisplayOrder = 0
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the commodity attribute ID is missing", func() {
+			payload.CommodityAttributeID = 0
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Dependency and Repository Errors", func() {
+		It("should return 400 if the company does not exist", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(nil, false, nil)
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 on get company db error", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(nil, false, errors.New("db error"))
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 400 if the commodity attribute does not exist", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(company, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), payload.CommodityAttributeID).Return(nil, false, nil)
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 409 if the attribute or display order is already used", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(company, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), payload.CommodityAttributeID).Return(&types.CommodityAttribute{ID: payload.CommodityAttributeID}, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repos.ErrCompanyAttributeSettingExists)
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 500 on create db error", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(company, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), payload.CommodityAttributeID).Return(&types.CommodityAttribute{ID: payload.CommodityAttributeID}, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package companyattributesettings
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// @Summary      Delete a company attribute setting
+// @Description  Deletes a company attribute setting by its ID.
+// @Tags         company-attribute-settings
+// @Param        id   path      int  true  "Company Attribute Setting ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Setting not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid company attribute setting ID")
+		return
+	}
+
+	setting, found, err := gr.CompanyAttributeSettings().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company attribute setting")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "company attribute setting not found")
+		return
+	}
+
+	if !authUser.CanAccessCompany(setting.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to change the attribute settings of this company")
+		return
+	}
+
+	if err := gr.CompanyAttributeSettings().Delete(r.Context(), id); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete company attribute setting")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,76 @@
+package companyattributesettings_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Delete Company Attribute Setting Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		setting *types.CompanyAttributeSetting
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		setting = &types.CompanyAttributeSetting{ID: 5, CompanyID: company.ID, CommodityAttributeID: 2, DisplayOrder: 1}
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodDelete, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should delete a setting successfully for an admin", func() {
+		mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+		mockCompanyAttributeSettingsRepo.EXPECT().Delete(gomock.Any(), setting.ID).Return(nil)
+
+		performRequest("/company-attribute-settings/5", adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/company-attribute-settings/5", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should fail if not an admin", func() {
+		performRequest("/company-attribute-settings/5", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should fail if a company admin deletes a setting of another company", func() {
+		setting.CompanyID = 99
+		mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+		performRequest("/company-attribute-settings/5", adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should fail with an invalid ID", func() {
+		performRequest("/company-attribute-settings/invalid-id", adminUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 404 if the setting is not found", func() {
+		mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(nil, false, nil)
+		performRequest("/company-attribute-settings/5", adminUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 on delete db error", func() {
+		mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+		mockCompanyAttributeSettingsRepo.EXPECT().Delete(gomock.Any(), setting.ID).Return(errors.New("db error"))
+		performRequest("/company-attribute-settings/5", adminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
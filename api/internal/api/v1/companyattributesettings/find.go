@@ -0,0 +1,49 @@
+package companyattributesettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Find company attribute settings
+// @Description  Lists the attribute display order of the authenticated user's company, sorted by display order.
+// @Tags         company-attribute-settings
+// @Produce      json
+// @Param        company_id query int false "Company to list (super admins only, defaults to your company)"
+// @Success      200  {object}  object{data=[]types.CompanyAttributeSetting,total=int} "A list of settings"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	// Users see their own company; super admins may ask for another one with company_id.
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	opts := &repos.CompanyAttributeSettingFindOpts{CompanyIDs: []int64{companyID}}
+
+	settings, count, err := gr.CompanyAttributeSettings().Find(r.Context(), opts)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find company attribute settings")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(types.NewFindResult(settings, count))
+}
@@ -0,0 +1,74 @@
+package companyattributesettings_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Find Company Attribute Settings Endpoint", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	performRequest := func(user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, "/company-attribute-settings/find", nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should find the settings of the user's company", func() {
+			settings := []*types.CompanyAttributeSetting{
+				{ID: 1, CompanyID: company.ID, CommodityAttributeID: 3, DisplayOrder: 1},
+				{ID: 2, CompanyID: company.ID, CommodityAttributeID: 1, DisplayOrder: 2},
+			}
+			mockCompanyAttributeSettingsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.CompanyAttributeSettingFindOpts) ([]*types.CompanyAttributeSetting, int64, error) {
+				Expect(opts.CompanyIDs).To(Equal([]int64{normalUser.CompanyID}))
+				return settings, int64(len(settings)), nil
+			})
+
+			performRequest(normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result types.FindResult[*types.CompanyAttributeSetting]
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.Total).To(Equal(int64(2)))
+			Expect(result.Data).To(HaveLen(2))
+		})
+
+		It("should restrict admins to their own company", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.CompanyAttributeSettingFindOpts) ([]*types.CompanyAttributeSetting, int64, error) {
+				Expect(opts.CompanyIDs).To(Equal([]int64{adminUser.CompanyID}))
+				return nil, 0, nil
+			})
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Error Paths", func() {
+		It("should fail if not authenticated", func() {
+			performRequest(nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 500 on a database error", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+			performRequest(normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
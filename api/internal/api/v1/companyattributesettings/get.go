@@ -0,0 +1,61 @@
+package companyattributesettings
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// @Summary      Get a company attribute setting
+// @Description  Gets a company attribute setting by its ID.
+// @Tags         company-attribute-settings
+// @Produce      json
+// @Param        id   path      int  true  "Company Attribute Setting ID"
+// @Success      200  {object}  types.CompanyAttributeSetting
+// @Header       200  {string}  ETag "Version of the setting, for If-Match"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Setting not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid company attribute setting ID")
+		return
+	}
+
+	setting, found, err := gr.CompanyAttributeSettings().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company attribute setting")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "company attribute setting not found")
+		return
+	}
+
+	// Normal users can only view settings of their own company. Users who manage all companies can view any setting.
+	if !authUser.CanAccessCompany(setting.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to view this company attribute setting")
+		return
+	}
+
+	middleware.SetETag(w, setting.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(setting)
+}
@@ -0,0 +1,96 @@
+package companyattributesettings_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Get Company Attribute Setting Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		setting *types.CompanyAttributeSetting
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		setting = &types.CompanyAttributeSetting{ID: 5, CompanyID: company.ID, CommodityAttributeID: 2, DisplayOrder: 1, Version: 1}
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should get a setting of the user's own company", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+			performRequest("/company-attribute-settings/5", normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
+			var result types.CompanyAttributeSetting
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.ID).To(Equal(setting.ID))
+		})
+
+		It("should allow a super admin to get a setting of another company", func() {
+			setting.CompanyID = 99
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+			performRequest("/company-attribute-settings/5", superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Error Paths", func() {
+		It("should fail if not authenticated", func() {
+			performRequest("/company-attribute-settings/5", nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should return 403 if a non-admin gets a setting of another company", func() {
+			setting.CompanyID = 99
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+			performRequest("/company-attribute-settings/5", normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 403 if a company admin gets a setting of another company", func() {
+			setting.CompanyID = 99
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+			performRequest("/company-attribute-settings/5", adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail with an invalid ID", func() {
+			performRequest("/company-attribute-settings/invalid-id", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 404 if the setting is not found", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(nil, false, nil)
+			performRequest("/company-attribute-settings/5", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 on a database error", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(nil, false, errors.New("db error"))
+			performRequest("/company-attribute-settings/5", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,22 @@
+package companyattributesettings
+
+// CreateCompanyAttributeSettingPayload defines the structure for creating a new company attribute setting.
+type CreateCompanyAttributeSettingPayload struct {
+	CompanyID            int64 `json:"company_id" validate:"required" example:"1"`
+	CommodityAttributeID int64 `json:"commodity_attribute_id" validate:"required" example:"2"`
+	DisplayOrder         int   `json:"display_order" validate:"required,min=1" example:"1"`
+}
+
+// UpdateCompanyAttributeSettingPayload defines the structure for updating a company attribute setting.
+// At least one field must be provided.
+type UpdateCompanyAttributeSettingPayload struct {
+	CommodityAttributeID *int64 `json:"commodity_attribute_id,omitempty" validate:"required_without_all=DisplayOrder,omitempty,gt=0"`
+	DisplayOrder         *int   `json:"display_order,omitempty" validate:"required_without_all=CommodityAttributeID,omitempty,min=1"`
+}
+
+// ReorderCompanyAttributeSettingsPayload defines the structure for replacing a company's attribute order.
+// The first attribute ID gets display order 1, the second 2, and so on.
+type ReorderCompanyAttributeSettingsPayload struct {
+	CompanyID             int64   `json:"company_id" validate:"required" example:"1"`
+	CommodityAttributeIDs []int64 `json:"commodity_attribute_ids" validate:"required,unique,dive,gt=0" example:"3,1,2"`
+}
@@ -0,0 +1,84 @@
+package companyattributesettings
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Replace a company's attribute order
+// @Description  Rewrites every display order of the company in one transaction. The first attribute ID gets display
+// @Description  order 1, the second 2, and so on. Attributes missing from the list lose their setting. Existing
+// @Description  product names are not changed; use POST /companies/{id}/products/rederive-names afterwards.
+// @Tags         company-attribute-settings
+// @Accept       json
+// @Produce      json
+// @Param        order body      ReorderCompanyAttributeSettingsPayload true  "Ordered commodity attribute IDs"
+// @Success      200   {object}  object{data=[]types.CompanyAttributeSetting,total=int} "The new order"
+// @Failure      400   {object}  middleware.ErrorResponse "Bad Request - Invalid input or unknown attribute"
+// @Failure      401   {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403   {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      500   {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings/order [put]
+func Reorder(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	var payload ReorderCompanyAttributeSettingsPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	if !authUser.CanAccessCompany(payload.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to change the attribute settings of this company")
+		return
+	}
+
+	// Validate dependencies
+	_, found, err := gr.Companies().Get(r.Context(), payload.CompanyID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate company")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "company not found")
+		return
+	}
+
+	if len(payload.CommodityAttributeIDs) > 0 {
+		_, count, err := gr.CommodityAttributes().Find(r.Context(), &repos.CommodityAttributeFindOpts{IDs: payload.CommodityAttributeIDs})
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity attributes")
+			return
+		}
+		if count != int64(len(payload.CommodityAttributeIDs)) {
+			middleware.WriteError(w, http.StatusBadRequest, "one or more commodity attributes not found")
+			return
+		}
+	}
+
+	settings, err := gr.CompanyAttributeSettings().Reorder(r.Context(), payload.CompanyID, payload.CommodityAttributeIDs)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to reorder company attribute settings")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(types.NewFindResult(settings, int64(len(settings))))
+}
@@ -0,0 +1,162 @@
+package companyattributesettings_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyattributesettings"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Reorder Company Attribute Settings Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload companyattributesettings.ReorderCompanyAttributeSettingsPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = companyattributesettings.ReorderCompanyAttributeSettingsPayload{
+			CompanyID:             company.ID,
+			CommodityAttributeIDs: []int64{3, 1, 2},
+		}
+	})
+
+	performRequest := func(payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, "/company-attribute-settings/order", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	expectAttributesFound := func(count int64) {
+		mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.CommodityAttributeFindOpts) ([]*types.CommodityAttribute, int64, error) {
+			Expect(opts.IDs).To(Equal(payload.CommodityAttributeIDs))
+			return nil, count, nil
+		})
+	}
+
+	Context("Happy Path", func() {
+		It("should rewrite the company's attribute order", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			expectAttributesFound(3)
+			mockCompanyAttributeSettingsRepo.EXPECT().Reorder(gomock.Any(), company.ID, payload.CommodityAttributeIDs).Return([]*types.CompanyAttributeSetting{
+				{ID: 1, CompanyID: company.ID, CommodityAttributeID: 3, DisplayOrder: 1},
+				{ID: 2, CompanyID: company.ID, CommodityAttributeID: 1, DisplayOrder: 2},
+				{ID: 3, CompanyID: company.ID, CommodityAttributeID: 2, DisplayOrder: 3},
+			}, nil)
+
+			performRequest(payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result types.FindResult[*types.CompanyAttributeSetting]
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.Total).To(Equal(int64(3)))
+			Expect(result.Data[0].CommodityAttributeID).To(Equal(int64(3)))
+		})
+
+		It("should clear the order when an empty list is provided", func() {
+			payload.CommodityAttributeIDs = []int64{}
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Reorder(gomock.Any(), company.ID, []int64{}).Return([]*types.CompanyAttributeSetting{}, nil)
+
+			performRequest(payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest(payload, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if not an admin", func() {
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail if a company admin reorders another company's attributes", func() {
+			payload.CompanyID = 99
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should let a super admin reorder another company's attributes", func() {
+			otherCompany := &types.Company{ID: 99, Name: "Other Company"}
+			payload.CompanyID = otherCompany.ID
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), otherCompany.ID).Return(otherCompany, true, nil)
+			expectAttributesFound(3)
+			mockCompanyAttributeSettingsRepo.EXPECT().Reorder(gomock.Any(), otherCompany.ID, payload.CommodityAttributeIDs).Return([]*types.CompanyAttributeSetting{}, nil)
+
+			performRequest(payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with a malformed JSON body", func() {
+			req := newAuthenticatedRequest(http.MethodPut, "/company-attribute-settings/order", []byte(`{`), adminUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the attribute list is missing", func() {
+			payload.CommodityAttributeIDs = nil
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the attribute list contains duplicates", func() {
+			payload.CommodityAttributeIDs = []int64{1, 2, 1}
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the company ID is missing", func() {
+			payload.CompanyID = 0
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Dependency and Repository Errors", func() {
+		It("should return 400 if the company does not exist", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(nil, false, nil)
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 400 if a commodity attribute does not exist", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			expectAttributesFound(2)
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 on find attributes db error", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 500 on reorder db error", func() {
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			expectAttributesFound(3)
+			mockCompanyAttributeSettingsRepo.EXPECT().Reorder(gomock.Any(), company.ID, payload.CommodityAttributeIDs).Return(nil, errors.New("db error"))
+			performRequest(payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
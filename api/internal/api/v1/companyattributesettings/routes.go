@@ -0,0 +1,28 @@
+package companyattributesettings
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the company attribute setting routes on the given subrouter.
+// All routes require authentication. Changing settings requires the company-settings:manage permission;
+// the handlers check that the settings belong to the user's company.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/company-attribute-settings").Subrouter()
+
+	// Routes for any authenticated user
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+
+	// Routes for users who manage their company's settings
+	settingsRouter := s.NewRoute().Subrouter()
+	settingsRouter.Use(middleware.RequirePermission(types.PermissionCompanySettingsManage))
+	settingsRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	settingsRouter.HandleFunc("/order", Reorder).Methods(http.MethodPut)
+	settingsRouter.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	settingsRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+}
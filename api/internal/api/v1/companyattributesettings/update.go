@@ -0,0 +1,119 @@
+package companyattributesettings
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// @Summary      Update a company attribute setting
+// @Description  Updates the attribute or display order of a single setting. Use PUT /company-attribute-settings/order
+// @Description  to rewrite the whole order at once.
+// @Tags         company-attribute-settings
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                                  true  "Company Attribute Setting ID"
+// @Param        setting body      UpdateCompanyAttributeSettingPayload true  "Company Attribute Setting Update Payload"
+// @Param        If-Match header   string                               false "ETag of the setting the update is based on"
+// @Success      200     {object}  types.CompanyAttributeSetting        "Successfully updated setting"
+// @Header       200     {string}  ETag                                 "Version of the updated setting"
+// @Failure      400     {object}  middleware.ErrorResponse             "Bad Request - Invalid input or ID"
+// @Failure      401     {object}  middleware.ErrorResponse             "Unauthorized"
+// @Failure      403     {object}  middleware.ErrorResponse             "Forbidden"
+// @Failure      404     {object}  middleware.ErrorResponse             "Not Found - Setting not found"
+// @Failure      409     {object}  middleware.ErrorResponse             "Conflict - Attribute or display order already used"
+// @Failure      412     {object}  types.CompanyAttributeSetting        "Precondition Failed - The setting has been changed since; this is its current version"
+// @Failure      500     {object}  middleware.ErrorResponse             "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /company-attribute-settings/{id} [put]
+func Update(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid company attribute setting ID")
+		return
+	}
+
+	var payload UpdateCompanyAttributeSettingPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	setting, found, err := gr.CompanyAttributeSettings().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company attribute setting")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "company attribute setting not found")
+		return
+	}
+
+	if !authUser.CanAccessCompany(setting.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to change the attribute settings of this company")
+		return
+	}
+	if !middleware.IfMatch(r, setting.Version) {
+		middleware.WritePreconditionFailed(w, setting.Version, setting)
+		return
+	}
+
+	if payload.CommodityAttributeID != nil {
+		_, found, err := gr.CommodityAttributes().Get(r.Context(), utils.Deref(payload.CommodityAttributeID))
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to validate commodity attribute")
+			return
+		}
+		if !found {
+			middleware.WriteError(w, http.StatusBadRequest, "commodity attribute not found")
+			return
+		}
+		setting.CommodityAttributeID = utils.Deref(payload.CommodityAttributeID)
+	}
+
+	if payload.DisplayOrder != nil {
+		setting.DisplayOrder = utils.Deref(payload.DisplayOrder)
+	}
+
+	if err := gr.CompanyAttributeSettings().Update(r.Context(), setting); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the setting between reading and writing it.
+			current, found, err := gr.CompanyAttributeSettings().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		if errors.Is(err, repos.ErrCompanyAttributeSettingExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update company attribute setting")
+		return
+	}
+
+	middleware.SetETag(w, setting.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(setting)
+}
@@ -0,0 +1,168 @@
+package companyattributesettings_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyattributesettings"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+var _ = Describe("Update Company Attribute Setting Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		setting *types.CompanyAttributeSetting
+		payload companyattributesettings.UpdateCompanyAttributeSettingPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		setting = &types.CompanyAttributeSetting{ID: 5, CompanyID: company.ID, CommodityAttributeID: 2, DisplayOrder: 1, Version: 3}
+		payload = companyattributesettings.UpdateCompanyAttributeSettingPayload{DisplayOrder: utils.Ref(3)}
+	})
+
+	performRequest := func(path string, payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, path, body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should update the display order", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *types.CompanyAttributeSetting) error {
+				Expect(s.DisplayOrder).To(Equal(3))
+				Expect(s.CommodityAttributeID).To(Equal(int64(2)))
+				return nil
+			})
+
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should update the commodity attribute", func() {
+			payload = companyattributesettings.UpdateCompanyAttributeSettingPayload{CommodityAttributeID: utils.Ref(int64(7))}
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), int64(7)).Return(&types.CommodityAttribute{ID: 7}, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s *types.CompanyAttributeSetting) error {
+				Expect(s.CommodityAttributeID).To(Equal(int64(7)))
+				Expect(s.DisplayOrder).To(Equal(1))
+				return nil
+			})
+
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest("/company-attribute-settings/5", payload, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if not an admin", func() {
+			performRequest("/company-attribute-settings/5", payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail if a company admin updates a setting of another company", func() {
+			setting.CompanyID = 99
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with an invalid ID", func() {
+			performRequest("/company-attribute-settings/invalid-id", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should fail with a malformed JSON body", func() {
+			req := newAuthenticatedRequest(http.MethodPut, "/company-attribute-settings/5", []byte(`{`), adminUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if no fields are provided", func() {
+			performRequest("/company-attribute-settings/5", companyattributesettings.UpdateCompanyAttributeSettingPayload{}, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with a display order below 1", func() {
+			payload.DisplayOrder = utils.Ref(0)
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Dependency and Repository Errors", func() {
+		It("should return 404 if the setting is not found", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(nil, false, nil)
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 400 if the new commodity attribute does not exist", func() {
+			payload = companyattributesettings.UpdateCompanyAttributeSettingPayload{CommodityAttributeID: utils.Ref(int64(7))}
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCommodityAttributesRepo.EXPECT().Get(gomock.Any(), int64(7)).Return(nil, false, nil)
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 409 if the display order is already used", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrCompanyAttributeSettingExists)
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should return 500 on update db error", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 412 with the current setting if If-Match is stale", func() {
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/company-attribute-settings/5", body, adminUser)
+			req.Header.Set("If-Match", `"2"`)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+			var current types.CompanyAttributeSetting
+			Expect(json.NewDecoder(rec.Body).Decode(&current)).To(Succeed())
+			Expect(current.DisplayOrder).To(Equal(1))
+		})
+
+		It("should return 412 with the current setting if it was changed during the update", func() {
+			current := &types.CompanyAttributeSetting{ID: 5, CompanyID: company.ID, CommodityAttributeID: 2, DisplayOrder: 4, Version: 4}
+			first := mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(setting, true, nil)
+			mockCompanyAttributeSettingsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockCompanyAttributeSettingsRepo.EXPECT().Get(gomock.Any(), setting.ID).Return(current, true, nil).After(first)
+			performRequest("/company-attribute-settings/5", payload, adminUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package companyroles_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyroles"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompanyRoles(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Company Roles Handler Suite")
+}
+
+var (
+	mockCtrl       *gomock.Controller
+	mockGlobalRepo *mock_repos.MockGlobalRepo
+	mockRolesRepo  *mock_repos.MockCompanyRolesRepo
+	router         *mux.Router
+	adminUser      *types.User
+	normalUser     *types.User
+	company        *types.Company
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockRolesRepo = mock_repos.NewMockCompanyRolesRepo(mockCtrl)
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().CompanyRoles().Return(mockRolesRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	companyroles.AddRoutes(router)
+
+	// Set up common test data
+	company = &types.Company{ID: 1, Name: "Test Company"}
+	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
+	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo
+// and an optional authenticated user in the context.
+func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	if user != nil {
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+	}
+	return req.WithContext(ctx)
+}
@@ -0,0 +1,74 @@
+package companyroles
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// Create handles creating a role for the user's company.
+//
+//	@Summary		Create a company role
+//	@Description	Creates a role that bundles company permissions. Callers can only include permissions they have themselves.
+//	@Tags			company-roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			role	body		CreateCompanyRolePayload	true	"Company Role Payload"
+//	@Success		201		{object}	types.CompanyRole
+//	@Failure		400		{object}	middleware.ErrorResponse	"Invalid request body or permission"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		409		{object}	middleware.ErrorResponse	"Role name already exists"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/company-roles [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateCompanyRolePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if message, forbidden := checkGrantablePermissions(authUser, payload.Permissions); message != "" {
+		if forbidden {
+			middleware.WriteError(w, http.StatusForbidden, message)
+		} else {
+			middleware.WriteError(w, http.StatusBadRequest, message)
+		}
+		return
+	}
+
+	role := &types.CompanyRole{
+		CompanyID:   authUser.CompanyID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Permissions: types.Permissions{}.Merge(payload.Permissions),
+	}
+	if err := gr.CompanyRoles().Create(r.Context(), role); err != nil {
+		if errors.Is(err, repos.ErrCompanyRoleNameExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create role")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, role)
+}
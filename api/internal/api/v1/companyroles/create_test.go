@@ -0,0 +1,88 @@
+package companyroles_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyroles"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Create Company Role Handler", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload companyroles.CreateCompanyRolePayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = companyroles.CreateCompanyRolePayload{
+			Name:        "Warehouse manager",
+			Permissions: []types.Permission{types.PermissionLocationsWrite, types.PermissionLocationsDelete},
+		}
+	})
+
+	performRequest := func(user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/company-roles", body, user))
+	}
+
+	It("should create a role for the user's company", func() {
+		mockRolesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, role *types.CompanyRole) error {
+			Expect(role.CompanyID).To(Equal(company.ID))
+			Expect(role.Permissions).To(Equal(types.Permissions{types.PermissionLocationsWrite, types.PermissionLocationsDelete}))
+			role.ID = 4
+			return nil
+		})
+
+		performRequest(adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusCreated))
+		Expect(rec.Body.String()).To(ContainSubstring(`"id":4`))
+	})
+
+	It("should reject platform permissions", func() {
+		payload.Permissions = []types.Permission{types.PermissionCompaniesManage}
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject unknown permissions", func() {
+		payload.Permissions = []types.Permission{"orders:delete"}
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should not let a user grant permissions they do not have", func() {
+		roleManager := &types.User{
+			ID: 7, CompanyID: company.ID, Roles: types.Roles{types.RoleUser},
+			Permissions: types.Permissions{types.PermissionRolesManage},
+		}
+		performRequest(roleManager)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 409 if the name is taken", func() {
+		mockRolesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repos.ErrCompanyRoleNameExists)
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should return 500 if the repo fails", func() {
+		mockRolesRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should require the roles:manage permission", func() {
+		performRequest(normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,61 @@
+package companyroles
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Delete handles removing one of the user's company's roles.
+//
+//	@Summary		Delete a company role
+//	@Description	Deletes a role of the user's company. Users who had it lose its permissions immediately. Callers can only delete roles whose permissions they have themselves.
+//	@Tags			company-roles
+//	@Param			id	path	int	true	"Role ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Role ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Role not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/company-roles/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid role ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	role, found, err := gr.CompanyRoles().Get(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get role")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "role not found")
+		return
+	}
+
+	if message, forbidden := checkGrantablePermissions(authUser, role.Permissions); forbidden {
+		middleware.WriteError(w, http.StatusForbidden, message)
+		return
+	}
+
+	if err := gr.CompanyRoles().Delete(r.Context(), authUser.CompanyID, id); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete role")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
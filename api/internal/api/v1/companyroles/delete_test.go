@@ -0,0 +1,39 @@
+package companyroles_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Delete Company Role Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should delete a role of the user's company", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(&types.CompanyRole{ID: 4, CompanyID: company.ID}, true, nil)
+		mockRolesRepo.EXPECT().Delete(gomock.Any(), company.ID, int64(4)).Return(nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/company-roles/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 404 if the role does not exist", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/company-roles/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should require the roles:manage permission", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/company-roles/4", nil, normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+})
@@ -0,0 +1,43 @@
+package companyroles_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Find Company Roles Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should list the roles of the user's company", func() {
+		roles := []*types.CompanyRole{{ID: 1, CompanyID: company.ID, Name: "Sales"}}
+		mockRolesRepo.EXPECT().Find(gomock.Any(), &repos.CompanyRoleFindOpts{CompanyID: company.ID, Limit: 10}).Return(roles, int64(1), nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/company-roles/find", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var result types.FindResult[*types.CompanyRole]
+		Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(1)))
+	})
+
+	It("should list the permissions a role can grant", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/company-roles/permissions", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var perms []types.Permission
+		Expect(json.Unmarshal(rec.Body.Bytes(), &perms)).To(Succeed())
+		Expect(perms).To(ContainElement(types.PermissionUsersManage))
+		Expect(perms).NotTo(ContainElement(types.PermissionCompaniesManage))
+	})
+})
@@ -0,0 +1,54 @@
+package companyroles
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Get handles retrieving one of the user's company's roles.
+//
+//	@Summary		Get a company role
+//	@Description	Gets a role of the user's company by its ID.
+//	@Tags			company-roles
+//	@Produce		json
+//	@Param			id	path		int	true	"Role ID"
+//	@Success		200	{object}	types.CompanyRole
+//	@Header			200	{string}	ETag	"Version of the role, for If-Match"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Role ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Role not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/company-roles/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid role ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	role, found, err := gr.CompanyRoles().Get(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get role")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "role not found")
+		return
+	}
+
+	middleware.SetETag(w, role.Version)
+	middleware.WriteJSON(w, http.StatusOK, role)
+}
@@ -0,0 +1,35 @@
+package companyroles_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Get Company Role Handler", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	It("should get a role of the user's company", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(&types.CompanyRole{ID: 4, CompanyID: company.ID, Name: "Sales", Version: 1}, true, nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/company-roles/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
+		Expect(rec.Body.String()).To(ContainSubstring(`"name":"Sales"`))
+	})
+
+	It("should return 404 for a role of another company", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/company-roles/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,37 @@
+package companyroles
+
+import (
+	"fmt"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// CreateCompanyRolePayload defines the structure for creating a company role.
+type CreateCompanyRolePayload struct {
+	Name        string             `json:"name" validate:"required,max=100" example:"Warehouse manager"`
+	Description string             `json:"description" validate:"max=255" example:"Manages locations and products"`
+	Permissions []types.Permission `json:"permissions" validate:"required,min=1" example:"locations:write,locations:delete"`
+}
+
+// UpdateCompanyRolePayload defines the structure for updating a company role.
+// At least one field must be provided.
+type UpdateCompanyRolePayload struct {
+	Name        *string            `json:"name,omitempty" validate:"required_without_all=Description Permissions,omitempty,max=100"`
+	Description *string            `json:"description,omitempty" validate:"required_without_all=Name Permissions,omitempty,max=255"`
+	Permissions []types.Permission `json:"permissions,omitempty" validate:"required_without_all=Name Description,omitempty,min=1"`
+}
+
+// checkGrantablePermissions returns an error message if a role with the permissions could not be
+// granted by the user: only company permissions can be part of a role, and only those the user has
+// themselves. forbidden reports whether the problem is the user's own lack of a permission.
+func checkGrantablePermissions(authUser *types.User, perms []types.Permission) (message string, forbidden bool) {
+	for _, perm := range perms {
+		if !types.IsCompanyPermission(perm) {
+			return fmt.Sprintf("permission %q cannot be granted by a company role", perm), false
+		}
+		if !authUser.HasPermission(perm) {
+			return fmt.Sprintf("cannot grant permission %s you do not have", perm), true
+		}
+	}
+	return "", false
+}
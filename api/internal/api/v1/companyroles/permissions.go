@@ -0,0 +1,23 @@
+package companyroles
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// ListPermissions handles listing the permissions a company role can grant.
+//
+//	@Summary		List grantable permissions
+//	@Description	Lists the permissions that can be part of a company role.
+//	@Tags			company-roles
+//	@Produce		json
+//	@Success		200	{array}		string
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Security		AppTokenAuth
+//	@Router			/company-roles/permissions [get]
+func ListPermissions(w http.ResponseWriter, r *http.Request) {
+	middleware.WriteJSON(w, http.StatusOK, types.CompanyPermissions)
+}
@@ -0,0 +1,23 @@
+package companyroles
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the company role routes on the given subrouter.
+// All routes require the roles:manage permission and only reach the user's own company's roles.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/company-roles").Subrouter()
+	s.Use(middleware.RequirePermission(types.PermissionRolesManage))
+
+	s.HandleFunc("", Create).Methods(http.MethodPost)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+	s.HandleFunc("/permissions", ListPermissions).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	s.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+}
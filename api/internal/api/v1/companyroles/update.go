@@ -0,0 +1,116 @@
+package companyroles
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// Update handles changing one of the user's company's roles.
+//
+//	@Summary		Update a company role
+//	@Description	Changes a role's name, description or permissions. The change applies to every user with the role. Callers can only change roles whose current and new permissions they have themselves.
+//	@Tags			company-roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"Role ID"
+//	@Param			role	body		UpdateCompanyRolePayload	true	"Company Role Payload"
+//	@Param			If-Match	header	string	false	"ETag of the role the update is based on"
+//	@Success		200		{object}	types.CompanyRole
+//	@Header			200		{string}	ETag						"Version of the updated role"
+//	@Failure		400		{object}	middleware.ErrorResponse	"Invalid request body or permission"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	middleware.ErrorResponse	"Role not found"
+//	@Failure		409		{object}	middleware.ErrorResponse	"Role name already exists"
+//	@Failure		412		{object}	types.CompanyRole			"The role has been changed since; this is its current version"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/company-roles/{id} [put]
+func Update(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid role ID")
+		return
+	}
+
+	var payload UpdateCompanyRolePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	role, found, err := gr.CompanyRoles().Get(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get role")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "role not found")
+		return
+	}
+
+	// A role the user could not have created is out of their reach, as is the role they would turn
+	// it into.
+	if message, forbidden := checkGrantablePermissions(authUser, append(append([]types.Permission{}, role.Permissions...), payload.Permissions...)); message != "" {
+		if forbidden {
+			middleware.WriteError(w, http.StatusForbidden, message)
+		} else {
+			middleware.WriteError(w, http.StatusBadRequest, message)
+		}
+		return
+	}
+	if !middleware.IfMatch(r, role.Version) {
+		middleware.WritePreconditionFailed(w, role.Version, role)
+		return
+	}
+
+	if payload.Name != nil {
+		role.Name = *payload.Name
+	}
+	if payload.Description != nil {
+		role.Description = *payload.Description
+	}
+	if payload.Permissions != nil {
+		role.Permissions = types.Permissions{}.Merge(payload.Permissions)
+	}
+
+	if err := gr.CompanyRoles().Update(r.Context(), role); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the role between reading and writing it.
+			current, found, err := gr.CompanyRoles().Get(r.Context(), authUser.CompanyID, id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		if errors.Is(err, repos.ErrCompanyRoleNameExists) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update role")
+		return
+	}
+
+	middleware.SetETag(w, role.Version)
+	middleware.WriteJSON(w, http.StatusOK, role)
+}
@@ -0,0 +1,99 @@
+package companyroles_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Update Company Role Handler", func() {
+	var (
+		rec  *httptest.ResponseRecorder
+		role *types.CompanyRole
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		role = &types.CompanyRole{ID: 4, CompanyID: company.ID, Name: "Sales", Permissions: types.Permissions{types.PermissionOrdersRead}, Version: 3}
+	})
+
+	performRequest := func(body string, user *types.User) {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPut, "/company-roles/4", []byte(body), user))
+	}
+
+	It("should change the role's permissions", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(role, true, nil)
+		mockRolesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, updated *types.CompanyRole) error {
+			Expect(updated.Name).To(Equal("Sales"))
+			Expect(updated.Permissions).To(Equal(types.Permissions{types.PermissionOrdersRead, types.PermissionOrdersWrite}))
+			return nil
+		})
+
+		performRequest(`{"permissions":["orders:read","orders:write"]}`, adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should require at least one field", func() {
+		performRequest(`{}`, adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should not let a user change a role with permissions they do not have", func() {
+		role.Permissions = types.Permissions{types.PermissionUsersManage}
+		roleManager := &types.User{
+			ID: 7, CompanyID: company.ID, Roles: types.Roles{types.RoleUser},
+			Permissions: types.Permissions{types.PermissionRolesManage},
+		}
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(role, true, nil)
+
+		performRequest(`{"name":"Renamed"}`, roleManager)
+
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 409 if the new name is taken", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(role, true, nil)
+		mockRolesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrCompanyRoleNameExists)
+
+		performRequest(`{"name":"Buyers"}`, adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusConflict))
+	})
+
+	It("should return 412 with the current role if If-Match is stale", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(role, true, nil)
+		req := newAuthenticatedRequest(http.MethodPut, "/company-roles/4", []byte(`{"name":"Buyers"}`), adminUser)
+		req.Header.Set("If-Match", `"2"`)
+
+		router.ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+		Expect(rec.Body.String()).To(ContainSubstring(`"name":"Sales"`))
+	})
+
+	It("should return 412 with the current role if it was changed during the update", func() {
+		current := &types.CompanyRole{ID: 4, CompanyID: company.ID, Name: "Sellers", Version: 4}
+		first := mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(role, true, nil)
+		mockRolesRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(current, true, nil).After(first)
+
+		performRequest(`{"name":"Buyers"}`, adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+		Expect(rec.Body.String()).To(ContainSubstring(`"name":"Sellers"`))
+	})
+
+	It("should return 404 if the role does not exist", func() {
+		mockRolesRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		performRequest(`{"name":"Buyers"}`, adminUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+})
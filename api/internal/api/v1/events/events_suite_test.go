@@ -0,0 +1,67 @@
+package events_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/events"
+	eventspkg "github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEvents(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Events Handler Suite")
+}
+
+var (
+	mockCtrl       *gomock.Controller
+	mockGlobalRepo *mock_repos.MockGlobalRepo
+	mockEventsRepo *mock_repos.MockEventsRepo
+	broker         *eventspkg.Broker
+	router         *mux.Router
+	normalUser     *types.User
+	superAdminUser *types.User
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockEventsRepo = mock_repos.NewMockEventsRepo(mockCtrl)
+	broker = eventspkg.NewBroker()
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().Events().Return(mockEventsRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	events.AddRoutes(router)
+
+	// Set up common test data
+	normalUser = &types.User{ID: 1, CompanyID: 1, Roles: types.Roles{types.RoleUser}}
+	superAdminUser = &types.User{ID: 3, CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo, the broker and an
+// optional authenticated user in the context.
+func newAuthenticatedRequest(ctx context.Context, method, url string, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, nil)
+	ctx = context.WithValue(ctx, middleware.RepoKey, mockGlobalRepo)
+	ctx = context.WithValue(ctx, middleware.BrokerKey, broker)
+	if user != nil {
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+	}
+	return req.WithContext(ctx)
+}
@@ -0,0 +1,16 @@
+package events
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// AddRoutes configures the event stream routes on the given subrouter.
+// The stream only carries the events of the user's own company, unless the user manages all
+// companies and passes company_id.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/events").Subrouter()
+
+	s.HandleFunc("", Stream).Methods(http.MethodGet)
+}
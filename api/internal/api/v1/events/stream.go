@@ -0,0 +1,160 @@
+package events
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	eventspkg "github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+)
+
+// pageSize is how many events are read from the database at a time.
+const pageSize = 100
+
+// heartbeatInterval is how often an idle stream sends a heartbeat. Every heartbeat also checks for
+// events, in case a notification was lost while the listener reconnected or an event was held back
+// until an older transaction ended.
+var heartbeatInterval = 15 * time.Second
+
+// Stream handles streaming a company's events as they happen.
+//
+//	@Summary		Stream events
+//	@Description	Streams the changes made in the user's company as Server-Sent Events, with the event's ID, its type as the event name and the event as JSON data. Requests that ask to upgrade to a WebSocket get one JSON event per text message instead. The stream starts after the event in the Last-Event-ID header or the last_event_id parameter, so reconnecting clients get the events they missed; without one it starts with the next change. Events are sent once no earlier transaction can still add an event before them, so their IDs are not always in ascending order. Browsers, which cannot set headers on these requests, may pass the access token in the access_token parameter.
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		int		false	"Resume after the event with this ID"
+//	@Param			last_event_id	query		int		false	"Resume after the event with this ID, for clients that cannot set headers"
+//	@Param			company_id		query		int		false	"Company ID"
+//	@Success		200				{object}	types.OutboxEvent	"A stream of events"
+//	@Success		101				"Switched to a WebSocket"
+//	@Failure		400				{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401				{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403				{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500				{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/events [get]
+func Stream(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	gr := middleware.GetRepo(ctx)
+
+	authUser, found := middleware.GetAuthUserFromContext(ctx)
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	lastID, err := lastEventID(r)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid last event id format")
+		return
+	}
+
+	// Subscribing before reading the first page means no event committed in between is missed.
+	wakeups, unsubscribe := middleware.GetBroker(ctx).Subscribe(companyID)
+	defer unsubscribe()
+
+	var position repos.EventPosition
+	if lastID < 0 {
+		position, err = gr.Events().Head(ctx)
+	} else {
+		position, err = gr.Events().Position(ctx, companyID, lastID)
+	}
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "failed to retrieve events")
+		return
+	}
+
+	var stream eventspkg.Writer
+	if eventspkg.IsWebSocketRequest(r) {
+		ws, err := eventspkg.UpgradeWebSocket(w, r)
+		if errors.Is(err, eventspkg.ErrBadHandshake) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "failed to open websocket")
+			return
+		}
+		defer ws.Close()
+		stream = ws
+	} else {
+		sse, err := eventspkg.NewSSEWriter(w)
+		if err != nil {
+			return
+		}
+		stream = sse
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		if position, err = sendEvents(r, stream, companyID, position); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("event stream of company %d ended: %s", companyID, err.Error())
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stream.Done():
+			return
+		case <-wakeups:
+		case <-heartbeat.C:
+			if err := stream.WriteHeartbeat(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendEvents sends the company's events after a position, and returns the position after the last
+// one sent.
+func sendEvents(r *http.Request, stream eventspkg.Writer, companyID int64, position repos.EventPosition) (repos.EventPosition, error) {
+	gr := middleware.GetRepo(r.Context())
+	for {
+		events, err := gr.Events().FindAfter(r.Context(), companyID, position, pageSize)
+		if err != nil {
+			return position, err
+		}
+		for _, event := range events {
+			if err := stream.WriteEvent(event); err != nil {
+				return position, err
+			}
+			position = repos.PositionOf(event)
+		}
+		if len(events) < pageSize {
+			return position, nil
+		}
+	}
+}
+
+// lastEventID returns the ID of the last event the client has, from the Last-Event-ID header that
+// browsers send when they reconnect or the last_event_id parameter. It returns -1 when the client
+// has none.
+func lastEventID(r *http.Request) (int64, error) {
+	value := r.Header.Get("Last-Event-ID")
+	if value == "" {
+		value = r.URL.Query().Get("last_event_id")
+	}
+	if value == "" {
+		return -1, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0, errors.New("invalid last event id")
+	}
+	return id, nil
+}
@@ -0,0 +1,149 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Stream Events Handler", func() {
+	var (
+		rec    *httptest.ResponseRecorder
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	// disconnect ends the request once the stream has sent the events it was given.
+	disconnect := func(events ...*types.OutboxEvent) func(context.Context, int64, repos.EventPosition, int) ([]*types.OutboxEvent, error) {
+		return func(context.Context, int64, repos.EventPosition, int) ([]*types.OutboxEvent, error) {
+			cancel()
+			return events, nil
+		}
+	}
+
+	// after is the position of the event with ID 4.
+	after := repos.EventPosition{TxID: 700, ID: 4}
+
+	It("should send the events after the Last-Event-ID as Server-Sent Events", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(1), int64(4)).Return(after, nil)
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), after, 100).DoAndReturn(disconnect(
+			&types.OutboxEvent{ID: 5, CompanyID: 1, EventType: "product.created", EntityType: types.AuditEntityProduct, EntityID: 9, Data: types.EventData(`{"id":9}`)},
+		))
+
+		req := newAuthenticatedRequest(ctx, http.MethodGet, "/events", normalUser)
+		req.Header.Set("Last-Event-ID", "4")
+		router.ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+		Expect(rec.Body.String()).To(HavePrefix("retry: 5000\n\n"))
+		Expect(rec.Body.String()).To(ContainSubstring("id: 5\nevent: product.created\ndata: {\"id\":5,\"companyId\":1,\"type\":\"product.created\",\"entityType\":\"product\",\"entityId\":9,\"data\":{\"id\":9},"))
+	})
+
+	It("should start with the next change when the client has no events", func() {
+		head := repos.EventPosition{TxID: 900}
+		mockEventsRepo.EXPECT().Head(gomock.Any()).Return(head, nil)
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), head, 100).DoAndReturn(disconnect())
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events", normalUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(Equal("retry: 5000\n\n"))
+	})
+
+	It("should accept the last event ID as a parameter", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(1), int64(0)).Return(repos.EventPosition{}, nil)
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), repos.EventPosition{}, 100).DoAndReturn(disconnect())
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events?last_event_id=0", normalUser))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should send the events of a new change when the broker wakes the stream up", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(1), int64(4)).Return(after, nil)
+		first := mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), after, 100).DoAndReturn(
+			func(context.Context, int64, repos.EventPosition, int) ([]*types.OutboxEvent, error) {
+				go broker.Publish(1)
+				return nil, nil
+			})
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), after, 100).After(first).DoAndReturn(disconnect(
+			&types.OutboxEvent{ID: 5, CompanyID: 1, EventType: "user.updated"},
+		))
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events?last_event_id=4", normalUser))
+
+		Expect(rec.Body.String()).To(ContainSubstring("id: 5\nevent: user.updated\n"))
+	})
+
+	It("should resume after the last event sent, not after the highest ID", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(1), int64(4)).Return(after, nil)
+		first := mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), after, 100).DoAndReturn(
+			func(context.Context, int64, repos.EventPosition, int) ([]*types.OutboxEvent, error) {
+				go broker.Publish(1)
+				return []*types.OutboxEvent{
+					{ID: 9, TxID: 701, CompanyID: 1, EventType: "user.updated"},
+					{ID: 6, TxID: 702, CompanyID: 1, EventType: "user.updated"},
+				}, nil
+			})
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(1), repos.EventPosition{TxID: 702, ID: 6}, 100).After(first).DoAndReturn(disconnect())
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events?last_event_id=4", normalUser))
+
+		Expect(rec.Body.String()).To(ContainSubstring("id: 9\n"))
+		Expect(rec.Body.String()).To(ContainSubstring("id: 6\n"))
+	})
+
+	It("should let users who manage all companies stream another company's events", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(5), int64(0)).Return(repos.EventPosition{}, nil)
+		mockEventsRepo.EXPECT().FindAfter(gomock.Any(), int64(5), repos.EventPosition{}, 100).DoAndReturn(disconnect())
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events?company_id=5&last_event_id=0", superAdminUser))
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should forbid streaming another company's events", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events?company_id=5", normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should fail with an invalid last event ID", func() {
+		req := newAuthenticatedRequest(ctx, http.MethodGet, "/events", normalUser)
+		req.Header.Set("Last-Event-ID", "abc")
+		router.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should fail when the start of the stream cannot be found", func() {
+		mockEventsRepo.EXPECT().Head(gomock.Any()).Return(repos.EventPosition{}, errors.New("db error"))
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events", normalUser))
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("should reject an invalid websocket handshake", func() {
+		mockEventsRepo.EXPECT().Position(gomock.Any(), int64(1), int64(0)).Return(repos.EventPosition{}, nil)
+		req := newAuthenticatedRequest(ctx, http.MethodGet, "/events?last_event_id=0", normalUser)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		router.ServeHTTP(rec, req)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 401 without an authenticated user", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(ctx, http.MethodGet, "/events", nil))
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+})
@@ -0,0 +1,171 @@
+package invitations
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// Create handles inviting a user.
+//
+//	@Summary		Invite a user
+//	@Description	Emails a single-use link to join the company. The invitee chooses their own name and password when accepting it and gets the invitation's roles. The link expires after seven days. Requires the users:manage permission, and assigning roles also requires roles:manage.
+//	@Tags			invitations
+//	@Accept			json
+//	@Produce		json
+//	@Param			invitation	body		CreateInvitationPayload	true	"Invitation Payload"
+//	@Success		201			{object}	types.Invitation
+//	@Failure		400			{object}	middleware.ErrorResponse	"Invalid request body, unknown role, or email already taken"
+//	@Failure		401			{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		409			{object}	middleware.ErrorResponse	"An invitation for this email is already pending"
+//	@Failure		500			{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/invitations [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateInvitationPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found { // Should be caught by middleware, but good practice to check
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	companyID := payload.CompanyID
+	if companyID == 0 {
+		companyID = authUser.CompanyID
+	}
+	if !authUser.CanAccessCompany(companyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to invite users to this company")
+		return
+	}
+
+	_, found, err := gr.Users().GetByEmail(r.Context(), payload.Email)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to check for existing user")
+		return
+	}
+	if found {
+		middleware.WriteError(w, http.StatusBadRequest, "user with that email already exists")
+		return
+	}
+
+	company, found, err := gr.Companies().Get(r.Context(), companyID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate company")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusBadRequest, "company not found")
+		return
+	}
+
+	addressID := payload.AddressID
+	if addressID == 0 {
+		addressID = company.AddressID
+	} else {
+		_, found, err = gr.Addresses().Get(r.Context(), addressID)
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to validate address")
+			return
+		}
+		if !found {
+			middleware.WriteError(w, http.StatusBadRequest, "address not found")
+			return
+		}
+	}
+
+	if len(payload.RoleIDs) > 0 && !checkRoles(w, r, authUser, companyID, payload.RoleIDs) {
+		return
+	}
+
+	token, tokenHash, expiresAt, err := newInviteToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to generate invitation")
+		return
+	}
+
+	invitation := &types.Invitation{
+		CompanyID: companyID,
+		Email:     payload.Email,
+		AddressID: addressID,
+		RoleIDs:   types.IDs(payload.RoleIDs),
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		InvitedBy: authUser.ID,
+	}
+	if err := gr.Invitations().Create(r.Context(), invitation); err != nil {
+		if errors.Is(err, repos.ErrInvitationPending) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create invitation")
+		return
+	}
+
+	if err := sendInvitation(r.Context(), invitation, company, authUser, token); err != nil {
+		log.Printf("unable to send invitation %d: %s", invitation.ID, err.Error())
+		// An invitation nobody received would block inviting the email again until it expires.
+		if err := gr.Invitations().Revoke(r.Context(), companyID, invitation.ID); err != nil {
+			log.Printf("unable to revoke unsent invitation %d: %s", invitation.ID, err.Error())
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to send invitation email")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, invitation)
+}
+
+// checkRoles makes sure the invited roles belong to the company and that the user may hand them
+// out: assigning roles requires roles:manage and every permission of the roles. It writes the error
+// response and returns false otherwise.
+func checkRoles(w http.ResponseWriter, r *http.Request, authUser *types.User, companyID int64, roleIDs []int64) bool {
+	if !authUser.HasPermission(types.PermissionRolesManage) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to assign roles")
+		return false
+	}
+
+	roles, _, err := middleware.GetRepo(r.Context()).CompanyRoles().Find(r.Context(), &repos.CompanyRoleFindOpts{
+		IDs:       roleIDs,
+		CompanyID: companyID,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get roles")
+		return false
+	}
+
+	known := make(map[int64]bool, len(roles))
+	for _, role := range roles {
+		known[role.ID] = true
+		for _, perm := range role.Permissions {
+			if !authUser.HasPermission(perm) {
+				middleware.WriteError(w, http.StatusForbidden, fmt.Sprintf("cannot assign role %q with permission %s you do not have", role.Name, perm))
+				return false
+			}
+		}
+	}
+	for _, id := range roleIDs {
+		if !known[id] {
+			middleware.WriteError(w, http.StatusBadRequest, repos.ErrCompanyRoleNotFound.Error())
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package invitations_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/invitations"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Create Invitation Handler", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload invitations.CreateInvitationPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = invitations.CreateInvitationPayload{Email: "jane@example.com"}
+	})
+
+	performRequest := func(user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/invitations", body, user))
+	}
+
+	expectNewEmail := func() {
+		mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(nil, false, nil)
+		mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+	}
+
+	Context("Happy Path", func() {
+		It("should create the invitation and email a link with its token", func() {
+			expectNewEmail()
+			var created *types.Invitation
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, invitation *types.Invitation) error {
+				invitation.ID = 4
+				invitation.Status = types.InvitationStatusPending
+				created = invitation
+				return nil
+			})
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+			Expect(rec.Body.String()).NotTo(ContainSubstring(created.TokenHash))
+			Expect(created.CompanyID).To(Equal(company.ID))
+			Expect(created.AddressID).To(Equal(company.AddressID))
+			Expect(created.InvitedBy).To(Equal(adminUser.ID))
+			Expect(created.ExpiresAt).To(BeTemporally("~", time.Now().Add(7*24*time.Hour), time.Minute))
+
+			messages := outbox.Messages()
+			Expect(messages).To(HaveLen(1))
+			Expect(messages[0].To).To(Equal("jane@example.com"))
+			Expect(messages[0].Subject).To(ContainSubstring(company.Name))
+			Expect(messages[0].Body).To(ContainSubstring("Ada Admin"))
+
+			_, link, found := strings.Cut(messages[0].Body, "?token=")
+			Expect(found).To(BeTrue())
+			token, err := url.QueryUnescape(strings.Fields(link)[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jwtpkg.HashOpaqueToken(token)).To(Equal(created.TokenHash))
+		})
+
+		It("should invite with roles the user may hand out", func() {
+			payload.RoleIDs = []int64{5}
+			expectNewEmail()
+			mockRolesRepo.EXPECT().Find(gomock.Any(), &repos.CompanyRoleFindOpts{IDs: []int64{5}, CompanyID: company.ID}).
+				Return([]*types.CompanyRole{{ID: 5, CompanyID: company.ID, Name: "Buyer", Permissions: types.Permissions{types.PermissionProductsManage}}}, int64(1), nil)
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, invitation *types.Invitation) error {
+				Expect(invitation.RoleIDs).To(Equal(types.IDs{5}))
+				return nil
+			})
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("should use the given address", func() {
+			payload.AddressID = 30
+			expectNewEmail()
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), int64(30)).Return(&types.Address{ID: 30}, true, nil)
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, invitation *types.Invitation) error {
+				Expect(invitation.AddressID).To(Equal(int64(30)))
+				return nil
+			})
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+	})
+
+	Context("Authorization", func() {
+		It("should require the users:manage permission", func() {
+			performRequest(normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should forbid a company admin from inviting to another company", func() {
+			payload.CompanyID = 99
+			performRequest(adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should let a super admin invite to another company", func() {
+			payload.CompanyID = 99
+			other := &types.Company{ID: 99, Name: "Other", AddressID: 21}
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(nil, false, nil)
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), int64(99)).Return(other, true, nil)
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			performRequest(superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("should forbid assigning a role with permissions the user does not have", func() {
+			payload.RoleIDs = []int64{5}
+			expectNewEmail()
+			mockRolesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).
+				Return([]*types.CompanyRole{{ID: 5, CompanyID: company.ID, Name: "Operator", Permissions: types.Permissions{types.PermissionCompaniesManage}}}, int64(1), nil)
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with an invalid email", func() {
+			payload.Email = "not-an-email"
+			performRequest(adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail when a user with the email exists", func() {
+			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), "jane@example.com").Return(&types.User{ID: 8}, true, nil)
+			performRequest(adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with a role of another company", func() {
+			payload.RoleIDs = []int64{5}
+			expectNewEmail()
+			mockRolesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), nil)
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 409 when the email has a pending invitation", func() {
+			expectNewEmail()
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(repos.ErrInvitationPending)
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusConflict))
+			Expect(outbox.Messages()).To(BeEmpty())
+		})
+	})
+
+	Context("Dependency Errors", func() {
+		It("should revoke the invitation and return 500 when the email cannot be sent", func() {
+			sender = failingMailer{}
+			expectNewEmail()
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, invitation *types.Invitation) error {
+				invitation.ID = 4
+				return nil
+			})
+			mockInvitationsRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(nil)
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 500 when the invitation cannot be stored", func() {
+			expectNewEmail()
+			mockInvitationsRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+
+			performRequest(adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
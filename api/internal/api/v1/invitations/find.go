@@ -0,0 +1,79 @@
+package invitations
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// Find handles listing the invitations of a company.
+//
+//	@Summary		Find invitations
+//	@Description	Lists the invitations of the user's company, newest first. Users who manage all companies can list another company's invitations with company_id. Invite links are never returned.
+//	@Tags			invitations
+//	@Produce		json
+//	@Param			status		query		string	false	"Only invitations in this state"	Enums(pending, accepted, revoked, expired)
+//	@Param			company_id	query		int		false	"Company ID"
+//	@Param			limit		query		int		false	"Number of records to return"
+//	@Param			offset		query		int		false	"Number of records to skip"
+//	@Success		200			{object}	object{data=[]types.Invitation,total=int}	"A list of invitations"
+//	@Failure		400			{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401			{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500			{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/invitations/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	status := types.InvitationStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", types.InvitationStatusPending, types.InvitationStatusAccepted, types.InvitationStatusRevoked, types.InvitationStatusExpired:
+	default:
+		middleware.WriteError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	invitations, count, err := gr.Invitations().Find(r.Context(), &repos.InvitationFindOpts{
+		CompanyID: companyID,
+		Status:    status,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find invitations")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(invitations, count))
+}
@@ -0,0 +1,90 @@
+package invitations_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Find and Get Invitation Handlers", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		invitation *types.Invitation
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		invitation = &types.Invitation{
+			ID: 4, CompanyID: company.ID, Email: "jane@example.com", TokenHash: "secret-hash",
+			ExpiresAt: time.Now().Add(time.Hour), Status: types.InvitationStatusPending,
+		}
+	})
+
+	Describe("GET /invitations/find", func() {
+		It("should list the invitations of the user's company by status", func() {
+			mockInvitationsRepo.EXPECT().Find(gomock.Any(), &repos.InvitationFindOpts{
+				CompanyID: company.ID,
+				Status:    types.InvitationStatusPending,
+				Limit:     10,
+			}).Return([]*types.Invitation{invitation}, int64(1), nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/find?status=pending", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).NotTo(ContainSubstring("secret-hash"))
+			var result struct {
+				Data  []types.Invitation `json:"data"`
+				Total int64              `json:"total"`
+			}
+			Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Total).To(Equal(int64(1)))
+			Expect(result.Data[0].Status).To(Equal(types.InvitationStatusPending))
+		})
+
+		It("should fail with an unknown status", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/find?status=lost", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should forbid a company admin from listing another company's invitations", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/find?company_id=99", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 500 when the invitations cannot be listed", func() {
+			mockInvitationsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/find", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("GET /invitations/{id}", func() {
+		It("should return an invitation of the user's company", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/4", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"status":"pending"`))
+		})
+
+		It("should return 404 for an invitation of another company", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/4", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require the users:manage permission", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/invitations/4", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
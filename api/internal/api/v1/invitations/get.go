@@ -0,0 +1,52 @@
+package invitations
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Get handles retrieving one invitation.
+//
+//	@Summary		Get an invitation
+//	@Description	Retrieves an invitation of the user's company with its status. The invite link is never returned.
+//	@Tags			invitations
+//	@Produce		json
+//	@Param			id	path		int	true	"Invitation ID"
+//	@Success		200	{object}	types.Invitation
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Invitation ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Invitation not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/invitations/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid invitation ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	invitation, found, err := gr.Invitations().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get invitation")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "invitation not found")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, invitation)
+}
@@ -0,0 +1,94 @@
+package invitations_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/invitations"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvitations(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Invitations Handler Suite")
+}
+
+var (
+	mockCtrl            *gomock.Controller
+	mockGlobalRepo      *mock_repos.MockGlobalRepo
+	mockInvitationsRepo *mock_repos.MockInvitationsRepo
+	mockUsersRepo       *mock_repos.MockUsersRepo
+	mockCompaniesRepo   *mock_repos.MockCompaniesRepo
+	mockAddressesRepo   *mock_repos.MockAddressesRepo
+	mockRolesRepo       *mock_repos.MockCompanyRolesRepo
+	outbox              *mailer.OutboxMailer
+	sender              mailer.Mailer
+	router              *mux.Router
+	adminUser           *types.User
+	superAdminUser      *types.User
+	normalUser          *types.User
+	company             *types.Company
+)
+
+// failingMailer is a Mailer whose deliveries always fail.
+type failingMailer struct{}
+
+func (failingMailer) Send(context.Context, *mailer.Message) error {
+	return errors.New("smtp server unavailable")
+}
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockInvitationsRepo = mock_repos.NewMockInvitationsRepo(mockCtrl)
+	mockUsersRepo = mock_repos.NewMockUsersRepo(mockCtrl)
+	mockCompaniesRepo = mock_repos.NewMockCompaniesRepo(mockCtrl)
+	mockAddressesRepo = mock_repos.NewMockAddressesRepo(mockCtrl)
+	mockRolesRepo = mock_repos.NewMockCompanyRolesRepo(mockCtrl)
+	outbox = mailer.NewOutboxMailer()
+	sender = outbox
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().Invitations().Return(mockInvitationsRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Companies().Return(mockCompaniesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Addresses().Return(mockAddressesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().CompanyRoles().Return(mockRolesRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	invitations.AddRoutes(router)
+
+	// Set up common test data
+	company = &types.Company{ID: 1, Name: "Test Company", AddressID: 20}
+	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
+	adminUser = &types.User{ID: 2, CompanyID: company.ID, FirstName: "Ada", LastName: "Admin", Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo, the test mailer
+// and an optional authenticated user in the context.
+func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctx := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	ctx = context.WithValue(ctx, middleware.MailerKey, sender)
+	if user != nil {
+		ctx = context.WithValue(ctx, middleware.AuthUserKey, user)
+	}
+	return req.WithContext(ctx)
+}
@@ -0,0 +1,59 @@
+package invitations
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// invitationTTL is how long an invite link is valid. Resending an invitation starts it over.
+const invitationTTL = 7 * 24 * time.Hour
+
+// acceptInvitationURL is the page of the web app where invitees choose their name and password.
+// The invite token is added to it as the token query parameter.
+var acceptInvitationURL = "http://localhost:3000/accept-invitation"
+
+// Config holds the invitation settings that differ between deployments.
+type Config struct {
+	// AcceptInvitationURL is the page of the web app where invitees choose their name and password.
+	AcceptInvitationURL string
+}
+
+// ConfigFromEnv reads the invitation settings from ACCEPT_INVITATION_URL, falling back to the
+// defaults.
+func ConfigFromEnv() Config {
+	return Config{AcceptInvitationURL: utils.GetEnv("ACCEPT_INVITATION_URL", acceptInvitationURL)}
+}
+
+// Configure sets the invitation settings. It is meant to be called once at startup.
+func Configure(cfg Config) {
+	acceptInvitationURL = cfg.AcceptInvitationURL
+}
+
+// newInviteToken generates an invite token and returns it with its hash and expiry.
+func newInviteToken() (string, string, time.Time, error) {
+	token, tokenHash, err := jwtpkg.GenerateOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return token, tokenHash, time.Now().Add(invitationTTL), nil
+}
+
+// sendInvitation emails the invite link to the invitee.
+func sendInvitation(ctx context.Context, invitation *types.Invitation, company *types.Company, inviter *types.User, token string) error {
+	return middleware.GetMailer(ctx).Send(ctx, &mailer.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to %s", company.Name),
+		Body: fmt.Sprintf(
+			"Hi,\n\n%s %s invited you to join %s. Use the link below to choose your name and password. It expires on %s.\n\n%s?token=%s\n\nIf you were not expecting this invitation, you can ignore this email.\n",
+			inviter.FirstName, inviter.LastName, company.Name, invitation.ExpiresAt.Format("January 2, 2006"), acceptInvitationURL, url.QueryEscape(token),
+		),
+	})
+}
@@ -0,0 +1,14 @@
+package invitations
+
+// CreateInvitationPayload defines the structure for inviting a user.
+type CreateInvitationPayload struct {
+	Email string `json:"email" validate:"required,email" example:"jane@example.com"`
+	// RoleIDs are the company roles the user gets on accepting. Assigning roles requires the
+	// roles:manage permission and every permission of the roles.
+	RoleIDs []int64 `json:"role_ids,omitempty" example:"1,2"`
+	// CompanyID defaults to the user's own company. Only users who manage all companies can invite
+	// to another one.
+	CompanyID int64 `json:"company_id,omitempty"`
+	// AddressID defaults to the company's address.
+	AddressID int64 `json:"address_id,omitempty"`
+}
@@ -0,0 +1,86 @@
+package invitations
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// Resend handles emailing an invitation again.
+//
+//	@Summary		Resend an invitation
+//	@Description	Emails a new invite link and extends the invitation by seven days. The link sent earlier stops working. Expired invitations can be resent; accepted and revoked ones cannot.
+//	@Tags			invitations
+//	@Produce		json
+//	@Param			id	path		int	true	"Invitation ID"
+//	@Success		200	{object}	types.Invitation
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Invitation ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Invitation not found"
+//	@Failure		409	{object}	middleware.ErrorResponse	"Invitation was already accepted or revoked"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/invitations/{id}/resend [post]
+func Resend(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid invitation ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	invitation, found, err := gr.Invitations().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get invitation")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "invitation not found")
+		return
+	}
+
+	company, found, err := gr.Companies().Get(r.Context(), invitation.CompanyID)
+	if err != nil || !found {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get company")
+		return
+	}
+
+	token, tokenHash, expiresAt, err := newInviteToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to generate invitation")
+		return
+	}
+
+	if err := gr.Invitations().Renew(r.Context(), invitation.CompanyID, invitation.ID, tokenHash, expiresAt); err != nil {
+		if errors.Is(err, repos.ErrInvitationNotPending) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to renew invitation")
+		return
+	}
+	invitation.ExpiresAt = expiresAt
+	invitation.Status = types.InvitationStatusPending
+
+	if err := sendInvitation(r.Context(), invitation, company, authUser, token); err != nil {
+		log.Printf("unable to resend invitation %d: %s", invitation.ID, err.Error())
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to send invitation email")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, invitation)
+}
@@ -0,0 +1,122 @@
+package invitations_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Resend and Revoke Invitation Handlers", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		invitation *types.Invitation
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		invitation = &types.Invitation{
+			ID: 4, CompanyID: company.ID, Email: "jane@example.com", TokenHash: "old-hash",
+			ExpiresAt: time.Now().Add(-time.Hour), Status: types.InvitationStatusExpired,
+		}
+	})
+
+	Describe("POST /invitations/{id}/resend", func() {
+		It("should email a new link and extend the invitation", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			var newHash string
+			mockInvitationsRepo.EXPECT().Renew(gomock.Any(), company.ID, int64(4), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, _ int64, tokenHash string, expiresAt time.Time) error {
+					Expect(tokenHash).NotTo(Equal("old-hash"))
+					Expect(expiresAt).To(BeTemporally("~", time.Now().Add(7*24*time.Hour), time.Minute))
+					newHash = tokenHash
+					return nil
+				})
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/invitations/4/resend", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"status":"pending"`))
+
+			messages := outbox.Messages()
+			Expect(messages).To(HaveLen(1))
+			_, link, _ := strings.Cut(messages[0].Body, "?token=")
+			token, err := url.QueryUnescape(strings.Fields(link)[0])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jwtpkg.HashOpaqueToken(token)).To(Equal(newHash))
+		})
+
+		It("should return 409 for an invitation that was accepted or revoked", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockInvitationsRepo.EXPECT().Renew(gomock.Any(), company.ID, int64(4), gomock.Any(), gomock.Any()).Return(repos.ErrInvitationNotPending)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/invitations/4/resend", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusConflict))
+			Expect(outbox.Messages()).To(BeEmpty())
+		})
+
+		It("should return 404 for an invitation of another company", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/invitations/4/resend", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 when the email cannot be sent", func() {
+			sender = failingMailer{}
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockCompaniesRepo.EXPECT().Get(gomock.Any(), company.ID).Return(company, true, nil)
+			mockInvitationsRepo.EXPECT().Renew(gomock.Any(), company.ID, int64(4), gomock.Any(), gomock.Any()).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/invitations/4/resend", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("DELETE /invitations/{id}", func() {
+		It("should revoke the invitation", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockInvitationsRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/invitations/4", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 409 for an invitation that was already accepted", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockInvitationsRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(repos.ErrInvitationNotPending)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/invitations/4", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusConflict))
+		})
+
+		It("should require the users:manage permission", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/invitations/4", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 500 when the invitation cannot be revoked", func() {
+			mockInvitationsRepo.EXPECT().Get(gomock.Any(), company.ID, int64(4)).Return(invitation, true, nil)
+			mockInvitationsRepo.EXPECT().Revoke(gomock.Any(), company.ID, int64(4)).Return(errors.New("db error"))
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/invitations/4", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
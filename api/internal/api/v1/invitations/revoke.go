@@ -0,0 +1,63 @@
+package invitations
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+)
+
+// Revoke handles withdrawing an invitation.
+//
+//	@Summary		Revoke an invitation
+//	@Description	Withdraws an invitation that was not accepted yet. Its link stops working immediately, and the email can be invited again.
+//	@Tags			invitations
+//	@Param			id	path	int	true	"Invitation ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Invitation ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Invitation not found"
+//	@Failure		409	{object}	middleware.ErrorResponse	"Invitation was already accepted or revoked"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/invitations/{id} [delete]
+func Revoke(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid invitation ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	invitation, found, err := gr.Invitations().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get invitation")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "invitation not found")
+		return
+	}
+
+	if err := gr.Invitations().Revoke(r.Context(), invitation.CompanyID, invitation.ID); err != nil {
+		if errors.Is(err, repos.ErrInvitationNotPending) {
+			middleware.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to revoke invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,24 @@
+package invitations
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the user invitation routes on the given subrouter.
+// All routes require the users:manage permission and only reach the user's own company's
+// invitations, unless the user manages all companies. Invitations are accepted through the public
+// /auth/accept-invitation route.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/invitations").Subrouter()
+	s.Use(middleware.RequirePermission(types.PermissionUsersManage))
+
+	s.HandleFunc("", Create).Methods(http.MethodPost)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}/resend", Resend).Methods(http.MethodPost)
+	s.HandleFunc("/{id:[0-9]+}", Revoke).Methods(http.MethodDelete)
+}
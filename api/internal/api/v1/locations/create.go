@@ -41,7 +41,7 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !authUser.HasRole(types.RoleAdmin) && authUser.CompanyID != payload.CompanyID {
+	if !authUser.CanAccessCompany(payload.CompanyID) {
 		middleware.WriteError(w, http.StatusForbidden, "user not authorized to create locations for this company")
 		return
 	}
@@ -39,8 +39,8 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Before deleting, we must ensure the user has ownership of the location.
-	// Admins can delete any location.
-	if !authUser.HasRole(types.RoleAdmin) {
+	// Users who manage all companies can delete any location.
+	if !authUser.HasPermission(types.PermissionCompaniesManage) {
 		loc, found, err := gr.Locations().Get(r.Context(), authUser.CompanyID, id) // Corrected call
 		if err != nil {
 			middleware.WriteError(w, http.StatusInternalServerError, "unable to verify location ownership")
@@ -34,9 +34,17 @@ var _ = Describe("Delete Location Endpoint", func() {
 	}
 
 	Context("Router-level Tests", func() {
-		It("should delete a location successfully for an admin", func() {
-			// Admin path doesn't do a Get first, but the non-admin path does.
-			// Since the route is admin-only, we only test the admin path.
+		It("should delete a location successfully for a super admin", func() {
+			// Super admins can delete any location, so there is no ownership check first.
+			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(nil)
+
+			performRequest(locationID, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should let a company admin delete their company's location", func() {
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), company.ID, locationID).Return(&types.Location{ID: locationID, CompanyID: company.ID}, true, nil)
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(nil)
 
 			performRequest(locationID, adminUser)
@@ -44,6 +52,14 @@ var _ = Describe("Delete Location Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusNoContent))
 		})
 
+		It("should not let a company admin delete another company's location", func() {
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), company.ID, locationID).Return(nil, false, nil)
+
+			performRequest(locationID, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
 		It("should fail if not authenticated", func() {
 			performRequest(locationID, nil)
 			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
@@ -54,16 +70,35 @@ var _ = Describe("Delete Location Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
 
+		It("should let a user whose company role grants locations:delete delete their company's location", func() {
+			normalUser.Permissions = types.Permissions{types.PermissionLocationsDelete}
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), company.ID, locationID).Return(&types.Location{ID: locationID, CompanyID: company.ID}, true, nil)
+			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(nil)
+
+			performRequest(locationID, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should not let a company role reach another company's location", func() {
+			normalUser.Permissions = types.Permissions{types.PermissionLocationsDelete}
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), company.ID, locationID).Return(nil, false, nil)
+
+			performRequest(locationID, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
 		It("should fail with an invalid ID", func() {
-			req := newAuthenticatedRequest(http.MethodDelete, "/locations/invalid-id", nil, adminUser)
+			req := newAuthenticatedRequest(http.MethodDelete, "/locations/invalid-id", nil, superAdminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
-		It("should return 404 if the location is not found on the Delete for admin", func() {
+		It("should return 404 if the location is not found on the Delete for super admin", func() {
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(types.NewNotFoundError("location"))
 
-			performRequest(locationID, adminUser)
+			performRequest(locationID, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
@@ -71,7 +106,7 @@ var _ = Describe("Delete Location Endpoint", func() {
 		It("should return 500 on a database error during delete", func() {
 			dbErr := errors.New("db error")
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(dbErr)
-			performRequest(locationID, adminUser)
+			performRequest(locationID, superAdminUser)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
@@ -84,7 +119,7 @@ var _ = Describe("Delete Location Endpoint", func() {
 			w = httptest.NewRecorder()
 		})
 
-		It("should delete a location successfully for an admin (direct call)", func() {
+		It("should delete a location successfully for a super admin (direct call)", func() {
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(nil)
 
 			// Manually construct request and context
@@ -92,7 +127,7 @@ var _ = Describe("Delete Location Endpoint", func() {
 			r = httptest.NewRequest(http.MethodDelete, "/locations/"+strconv.FormatInt(locationID, 10), nil)
 			r = mux.SetURLVars(r, vars)
 			ctxWithRepo := context.WithValue(r.Context(), middleware.RepoKey, mockGlobalRepo)
-			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, adminUser)
+			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, superAdminUser)
 			r = r.WithContext(ctxWithAuth)
 
 			locations.Delete(w, r)
@@ -105,7 +140,7 @@ var _ = Describe("Delete Location Endpoint", func() {
 			r = httptest.NewRequest(http.MethodDelete, "/locations/invalid-id", nil)
 			r = mux.SetURLVars(r, vars)
 			ctxWithRepo := context.WithValue(r.Context(), middleware.RepoKey, mockGlobalRepo)
-			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, adminUser)
+			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, superAdminUser)
 			r = r.WithContext(ctxWithAuth)
 
 			locations.Delete(w, r)
@@ -175,13 +210,13 @@ var _ = Describe("Delete Location Endpoint", func() {
 
 		It("should return 404 if location not found on final delete (direct call)", func() {
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(types.NewNotFoundError("location"))
-			// No Get expectation for admin path
+			// No Get expectation for super admin path
 
 			vars := map[string]string{"id": strconv.FormatInt(locationID, 10)}
 			r = httptest.NewRequest(http.MethodDelete, "/locations/"+strconv.FormatInt(locationID, 10), nil)
 			r = mux.SetURLVars(r, vars)
 			ctxWithRepo := context.WithValue(r.Context(), middleware.RepoKey, mockGlobalRepo)
-			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, adminUser)
+			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, superAdminUser)
 			r = r.WithContext(ctxWithAuth)
 
 			locations.Delete(w, r)
@@ -192,13 +227,13 @@ var _ = Describe("Delete Location Endpoint", func() {
 		It("should return 500 on database error on final delete (direct call)", func() {
 			dbErr := errors.New("db error")
 			mockLocationsRepo.EXPECT().Delete(gomock.Any(), locationID).Return(dbErr)
-			// No Get expectation for admin path
+			// No Get expectation for super admin path
 
 			vars := map[string]string{"id": strconv.FormatInt(locationID, 10)}
 			r = httptest.NewRequest(http.MethodDelete, "/locations/"+strconv.FormatInt(locationID, 10), nil)
 			r = mux.SetURLVars(r, vars)
 			ctxWithRepo := context.WithValue(r.Context(), middleware.RepoKey, mockGlobalRepo)
-			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, adminUser)
+			ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, superAdminUser)
 			r = r.WithContext(ctxWithAuth)
 
 			locations.Delete(w, r)
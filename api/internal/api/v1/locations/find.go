@@ -18,6 +18,7 @@ import (
 // @Param        limit query int false "Number of records to return"
 // @Param        offset query int false "Number of records to skip"
 // @Param        name query string false "Location name filter"
+// @Param        company_id query int false "Company to list (super admins only, defaults to your company)"
 // @Success      200  {object}  object{data=[]types.Location,total=int} "A list of locations"
 // @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
 // @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
@@ -54,8 +55,13 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// For now we force everyone to only see locations in their own company.
-	opts.CompanyIDs = []int64{authUser.CompanyID}
+	// Users see their own company; super admins may ask for another one with company_id.
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+	opts.CompanyIDs = []int64{companyID}
 
 	locations, count, err := gr.Locations().Find(r.Context(), &opts)
 	if err != nil {
@@ -7,7 +7,6 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
-	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // @Summary      Get a location
@@ -17,6 +16,7 @@ import (
 // @Produce      json
 // @Param        id   path      int  true  "Location ID"
 // @Success      200  {object}  types.Location
+// @Header       200  {string}  ETag "Version of the location, for If-Match"
 // @Failure      400  {object}  middleware.ErrorResponse "Invalid Location ID"
 // @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
@@ -39,11 +39,8 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Admins can access any location, non-admins can only access locations for their company
-	var companyID int64
-	if !authUser.HasRole(types.RoleAdmin) {
-		companyID = authUser.CompanyID
-	}
+	// Users who manage all companies can access any location, others only locations of their company
+	companyID := middleware.CompanyScope(authUser)
 
 	loc, found, err := gr.Locations().Get(r.Context(), companyID, id)
 	if err != nil {
@@ -56,6 +53,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	middleware.SetETag(w, loc.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(loc)
@@ -22,7 +22,7 @@ var _ = Describe("Get Location Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		location = &types.Location{ID: 1, Name: "Test Location", CompanyID: company.ID, AddressID: 1}
+		location = &types.Location{ID: 1, Name: "Test Location", CompanyID: company.ID, AddressID: 1, Version: 2}
 	})
 
 	performRequest := func(locationID int64, user *types.User) {
@@ -37,6 +37,7 @@ var _ = Describe("Get Location Endpoint", func() {
 			performRequest(location.ID, normalUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"2"`))
 
 			var returnedLocation types.Location
 			err := json.NewDecoder(rec.Body).Decode(&returnedLocation)
@@ -44,10 +45,10 @@ var _ = Describe("Get Location Endpoint", func() {
 			Expect(returnedLocation.ID).To(Equal(location.ID))
 		})
 
-		It("should get a location successfully for an admin for any company", func() {
+		It("should get a location successfully for a super admin for any company", func() {
 			mockLocationsRepo.EXPECT().Get(gomock.Any(), int64(0), location.ID).Return(location, true, nil)
 
-			performRequest(location.ID, adminUser)
+			performRequest(location.ID, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
@@ -68,6 +69,15 @@ var _ = Describe("Get Location Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusNotFound))
 		})
 
+		It("should return 404 if a company admin tries to get a location for another company", func() {
+			otherLocation := &types.Location{ID: 2, Name: "Other Location", CompanyID: 99}
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, otherLocation.ID).Return(nil, false, nil)
+
+			performRequest(otherLocation.ID, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
 		It("should fail with an invalid ID", func() {
 			req := newAuthenticatedRequest(http.MethodGet, "/locations/invalid-id", nil, normalUser)
 			router.ServeHTTP(rec, req)
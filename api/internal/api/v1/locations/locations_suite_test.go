@@ -31,6 +31,7 @@ var (
 	mockLocationsRepo *mock_repos.MockLocationsRepo
 	router            *mux.Router
 	adminUser         *types.User
+	superAdminUser    *types.User
 	normalUser        *types.User
 	company           *types.Company
 )
@@ -57,6 +58,7 @@ var _ = BeforeEach(func() {
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
 	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
@@ -5,21 +5,27 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // AddRoutes configures the location-related routes on the given subrouter.
-// All routes require authentication. POST, PUT, and /find require admin privileges.
+// All routes require authentication. POST and PUT require the locations:write permission and
+// DELETE requires locations:delete.
 func AddRoutes(r *mux.Router) {
 	s := r.PathPrefix("/locations").Subrouter()
 
 	// Routes for any authenticated user
-	s.HandleFunc("", Create).Methods(http.MethodPost)
 	s.HandleFunc("/find", Find).Methods(http.MethodGet)
 	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
-	s.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
 
-	// Routes for admin users only
-	adminRouter := s.NewRoute().Subrouter()
-	adminRouter.Use(middleware.AuthUserAdminRequiredMuxMiddleware())
-	adminRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+	// Routes for users who may change locations
+	writeRouter := s.NewRoute().Subrouter()
+	writeRouter.Use(middleware.RequirePermission(types.PermissionLocationsWrite))
+	writeRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	writeRouter.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+
+	// Routes for users who may delete locations
+	deleteRouter := s.NewRoute().Subrouter()
+	deleteRouter.Use(middleware.RequirePermission(types.PermissionLocationsDelete))
+	deleteRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
 }
@@ -2,11 +2,13 @@ package locations
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
@@ -17,11 +19,14 @@ import (
 // @Produce      json
 // @Param        id       path      int                      true  "Location ID"
 // @Param        location body      UpdateLocationPayload    true  "Location Update Payload"
+// @Param        If-Match header    string                   false "ETag of the location the update is based on"
 // @Success      200      {object}  types.Location           "Successfully updated location"
+// @Header       200      {string}  ETag                     "Version of the updated location"
 // @Failure      400      {object}  middleware.ErrorResponse "Bad Request - Invalid input or ID"
 // @Failure      401      {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      403      {object}  middleware.ErrorResponse "Forbidden"
 // @Failure      404      {object}  middleware.ErrorResponse "Not Found - Location not found"
+// @Failure      412      {object}  types.Location           "Precondition Failed - The location has been changed since; this is its current version"
 // @Failure      500      {object}  middleware.ErrorResponse "Internal Server Error"
 // @Security     AppTokenAuth
 // @Router       /locations/{id} [put]
@@ -51,12 +56,9 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Admins can update any location.
-	// Non-admins can only update locations in their own company.
-	var companyID int64
-	if !authUser.HasRole(types.RoleAdmin) {
-		companyID = authUser.CompanyID
-	}
+	// Users who manage all companies can update any location.
+	// Everyone else can only update locations in their own company.
+	companyID := middleware.CompanyScope(authUser)
 
 	loc, found, err := gr.Locations().Get(r.Context(), companyID, id)
 	if err != nil {
@@ -67,6 +69,10 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		middleware.WriteError(w, http.StatusNotFound, "location not found")
 		return
 	}
+	if !middleware.IfMatch(r, loc.Version) {
+		middleware.WritePreconditionFailed(w, loc.Version, loc)
+		return
+	}
 
 	// No manual validation check needed here, as it's handled by types.Validate(payload)
 
@@ -89,10 +95,19 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := gr.Locations().Update(r.Context(), loc); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the location between reading and writing it.
+			current, found, err := gr.Locations().Get(r.Context(), companyID, id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update location")
 		return
 	}
 
+	middleware.SetETag(w, loc.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(loc)
@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/locations"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -32,7 +33,7 @@ var _ = Describe("Update Location Endpoint", func() {
 		address = &types.Address{ID: 1, Line1: "123 Test St"}
 		newAddress = &types.Address{ID: 2, Line1: "456 New St"}
 		locationID = 1
-		location = &types.Location{ID: locationID, Name: "Old Name", CompanyID: company.ID, AddressID: address.ID}
+		location = &types.Location{ID: locationID, Name: "Old Name", CompanyID: company.ID, AddressID: address.ID, Version: 3}
 		payload = locations.UpdateLocationPayload{
 			Name:      utils.Ref("New Name"),
 			AddressID: utils.Ref(newAddress.ID),
@@ -61,11 +62,39 @@ var _ = Describe("Update Location Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
 
-		It("should update a location successfully for an admin", func() {
+		It("should update a location whose version matches If-Match", func() {
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, locationID).Return(location, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
+			mockLocationsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, loc *types.Location) error {
+				loc.Version++
+				return nil
+			})
+
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/locations/"+strconv.FormatInt(locationID, 10), body, normalUser)
+			req.Header.Set("If-Match", `"3"`)
+			router.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+		})
+
+		It("should update a location successfully for a super admin in any company", func() {
 			mockLocationsRepo.EXPECT().Get(gomock.Any(), int64(0), locationID).Return(location, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
 			mockLocationsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
 
+			performRequest(locationID, payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should limit a company admin to their own company", func() {
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), company.ID, locationID).Return(location, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
+			mockLocationsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
 			performRequest(locationID, payload, adminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
@@ -148,6 +177,36 @@ var _ = Describe("Update Location Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
 
+		It("should return 412 with the current location when If-Match is stale", func() {
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, locationID).Return(location, true, nil)
+
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/locations/"+strconv.FormatInt(locationID, 10), body, normalUser)
+			req.Header.Set("If-Match", `"2"`)
+			router.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+			var current types.Location
+			Expect(json.Unmarshal(rec.Body.Bytes(), &current)).To(Succeed())
+			Expect(current.Name).To(Equal("Old Name"))
+		})
+
+		It("should return 412 with the current location when it changes during the update", func() {
+			current := &types.Location{ID: locationID, Name: "Their Name", CompanyID: company.ID, AddressID: address.ID, Version: 4}
+			first := mockLocationsRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, locationID).Return(location, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(newAddress, true, nil)
+			mockLocationsRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockLocationsRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, locationID).Return(current, true, nil).After(first)
+
+			performRequest(locationID, payload, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+			Expect(rec.Body.String()).To(ContainSubstring("Their Name"))
+		})
+
 		It("should fail with a malformed JSON body", func() {
 			req := newAuthenticatedRequest(http.MethodPut, "/locations/"+strconv.FormatInt(locationID, 10), []byte(`{`), normalUser)
 			router.ServeHTTP(rec, req)
@@ -0,0 +1,91 @@
+package productpacks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Create a product pack
+// @Description  Adds a pack configuration (pack style, count, weights, case dimensions and ti/hi) to a product.
+// @Description  Cases per pallet defaults to ti x hi when not provided.
+// @Tags         product-packs
+// @Accept       json
+// @Produce      json
+// @Param        pack body      CreateProductPackPayload true  "Product Pack Creation Payload"
+// @Success      201  {object}  types.ProductPack
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid input or product not found"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /product-packs [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateProductPackPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	// Validate product exists
+	product, found, err := gr.Products().Get(r.Context(), payload.ProductID)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to validate product")
+		return
+	}
+	if !found || !product.Visible {
+		middleware.WriteError(w, http.StatusBadRequest, "product not found")
+		return
+	}
+
+	// Only users who manage all companies can add packs to other companies' products.
+	if !authUser.CanAccessCompany(product.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to add packs to this product")
+		return
+	}
+
+	pack := &types.ProductPack{
+		ProductID:      payload.ProductID,
+		PackStyle:      payload.PackStyle,
+		CountPerCase:   payload.CountPerCase,
+		UnitWeight:     payload.UnitWeight,
+		NetWeight:      payload.NetWeight,
+		GrossWeight:    payload.GrossWeight,
+		WeightUnit:     payload.WeightUnit,
+		CaseLength:     payload.CaseLength,
+		CaseWidth:      payload.CaseWidth,
+		CaseHeight:     payload.CaseHeight,
+		DimensionUnit:  payload.DimensionUnit,
+		Ti:             payload.Ti,
+		Hi:             payload.Hi,
+		CasesPerPallet: payload.CasesPerPallet,
+	}
+
+	if err := gr.ProductPacks().Create(r.Context(), pack); err != nil {
+		if types.IsNotFoundError(err) {
+			middleware.WriteError(w, http.StatusBadRequest, "product not found")
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create product pack")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(pack)
+}
@@ -0,0 +1,169 @@
+package productpacks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/productpacks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Create Product Pack Endpoint", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		product *types.Product
+		payload productpacks.CreateProductPackPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		product = &types.Product{ID: 3, CompanyID: company.ID, CommodityID: 1, Visible: true}
+		payload = productpacks.CreateProductPackPayload{
+			ProductID:    product.ID,
+			PackStyle:    "bag",
+			CountPerCase: 10,
+			UnitWeight:   5,
+			NetWeight:    50,
+			GrossWeight:  51,
+			WeightUnit:   types.WeightUnitPound,
+			Ti:           8,
+			Hi:           5,
+		}
+	})
+
+	performRequest := func(payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPost, "/product-packs", body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should create a pack for a product of the user's company", func() {
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+			mockProductPacksRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *types.ProductPack) error {
+				Expect(p.ProductID).To(Equal(product.ID))
+				Expect(p.PackStyle).To(Equal("bag"))
+				Expect(p.CountPerCase).To(Equal(10))
+				Expect(p.UnitWeight).To(Equal(5.0))
+				Expect(p.NetWeight).To(Equal(50.0))
+				Expect(p.Ti).To(Equal(8))
+				Expect(p.Hi).To(Equal(5))
+				p.ID = 7
+				p.CompanyID = product.CompanyID
+				p.CasesPerPallet = 40
+				return nil
+			})
+
+			performRequest(payload, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+			var result types.ProductPack
+			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+			Expect(result.ID).To(Equal(int64(7)))
+			Expect(result.CasesPerPallet).To(Equal(40))
+		})
+
+		It("should allow a super admin to add a pack to another company's product", func() {
+			product.CompanyID = 99
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+			mockProductPacksRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			performRequest(payload, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest(payload, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if a non-admin adds a pack to another company's product", func() {
+			product.CompanyID = 99
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+
+			performRequest(payload, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail if a company admin adds a pack to another company's product", func() {
+			product.CompanyID = 99
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+
+			performRequest(payload, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail with a malformed JSON body", func() {
+			req := newAuthenticatedRequest(http.MethodPost, "/product-packs", []byte(`{`), normalUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the net weight is missing", func() {
+			payload.NetWeight = 0
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the gross weight is below the net weight", func() {
+			payload.GrossWeight = 49
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with an unknown weight unit", func() {
+			payload.WeightUnit = "oz"
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if only ti is provided", func() {
+			payload.Hi = 0
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Dependency and Repository Errors", func() {
+		It("should return 400 if the product does not exist", func() {
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(nil, false, nil)
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 400 if the product was deleted", func() {
+			product.Visible = false
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 500 on get product db error", func() {
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(nil, false, errors.New("db error"))
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 500 on create db error", func() {
+			mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+			mockProductPacksRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			performRequest(payload, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
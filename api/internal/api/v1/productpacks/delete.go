@@ -0,0 +1,60 @@
+package productpacks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// @Summary      Delete a product pack
+// @Description  Deletes a product pack by its ID.
+// @Tags         product-packs
+// @Param        id   path      int  true  "Product Pack ID"
+// @Success      204  "No Content"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Product pack not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /product-packs/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid product pack ID")
+		return
+	}
+
+	pack, found, err := gr.ProductPacks().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get product pack")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "product pack not found")
+		return
+	}
+
+	// Only users who manage all companies can delete packs of other companies.
+	if !authUser.CanAccessCompany(pack.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to delete this product pack")
+		return
+	}
+
+	if err := gr.ProductPacks().Delete(r.Context(), id); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete product pack")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
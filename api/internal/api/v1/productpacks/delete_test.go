@@ -0,0 +1,64 @@
+package productpacks_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Delete Product Pack Endpoint", func() {
+	var (
+		rec  *httptest.ResponseRecorder
+		pack *types.ProductPack
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		pack = &types.ProductPack{ID: 7, CompanyID: company.ID, ProductID: 3}
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodDelete, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should delete a pack of the user's company", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		mockProductPacksRepo.EXPECT().Delete(gomock.Any(), pack.ID).Return(nil)
+
+		performRequest("/product-packs/7", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/product-packs/7", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should return 403 if a non-admin deletes a pack of another company", func() {
+		pack.CompanyID = 99
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 404 if the pack is not found", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(nil, false, nil)
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 on delete db error", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		mockProductPacksRepo.EXPECT().Delete(gomock.Any(), pack.ID).Return(errors.New("db error"))
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
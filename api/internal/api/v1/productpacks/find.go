@@ -0,0 +1,80 @@
+package productpacks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// @Summary      Find product packs
+// @Description  Finds the product packs of the user's company, optionally filtered by product.
+// @Tags         product-packs
+// @Produce      json
+// @Param        product_id query []int false "Only return packs of these products" collectionFormat(multi)
+// @Param        limit query int false "Number of records to return"
+// @Param        offset query int false "Number of records to skip"
+// @Param        company_id query int false "Company to list (super admins only, defaults to your company)"
+// @Success      200  {object}  object{data=[]types.ProductPack,total=int} "A list of product packs"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /product-packs/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	productIDs, err := utils.GetQueryInt64Slice(r, "product_id")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid product_id format")
+		return
+	}
+
+	// Users see their own company; super admins may ask for another one with company_id.
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	opts := &repos.ProductPackFindOpts{
+		CompanyIDs: []int64{companyID},
+		ProductIDs: productIDs,
+		Limit:      limit,
+		Offset:     offset,
+	}
+
+	packs, count, err := gr.ProductPacks().Find(r.Context(), opts)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find product packs")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(types.NewFindResult(packs, count))
+}
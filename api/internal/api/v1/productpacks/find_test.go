@@ -0,0 +1,63 @@
+package productpacks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Find Product Packs Endpoint", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should find the packs of a product in the user's company", func() {
+		packs := []*types.ProductPack{{ID: 1, CompanyID: company.ID, ProductID: 3}, {ID: 2, CompanyID: company.ID, ProductID: 3}}
+		mockProductPacksRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.ProductPackFindOpts) ([]*types.ProductPack, int64, error) {
+			Expect(opts.CompanyIDs).To(Equal([]int64{normalUser.CompanyID}))
+			Expect(opts.ProductIDs).To(Equal([]int64{3}))
+			Expect(opts.Limit).To(Equal(10))
+			return packs, int64(len(packs)), nil
+		})
+
+		performRequest("/product-packs/find?product_id=3", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		var result types.FindResult[*types.ProductPack]
+		Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(2)))
+		Expect(result.Data).To(HaveLen(2))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/product-packs/find", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should fail with an invalid product_id", func() {
+		performRequest("/product-packs/find?product_id=abc", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should return 500 on a database error", func() {
+		mockProductPacksRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+		performRequest("/product-packs/find", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,61 @@
+package productpacks
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// @Summary      Get a product pack
+// @Description  Gets a product pack by its ID.
+// @Tags         product-packs
+// @Produce      json
+// @Param        id   path      int  true  "Product Pack ID"
+// @Success      200  {object}  types.ProductPack
+// @Header       200  {string}  ETag "Version of the product pack, for If-Match"
+// @Failure      400  {object}  middleware.ErrorResponse "Invalid ID"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Product pack not found"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /product-packs/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid product pack ID")
+		return
+	}
+
+	pack, found, err := gr.ProductPacks().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get product pack")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "product pack not found")
+		return
+	}
+
+	// Normal users can only view packs of their own company. Users who manage all companies can view any pack.
+	if !authUser.CanAccessCompany(pack.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to view this product pack")
+		return
+	}
+
+	middleware.SetETag(w, pack.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pack)
+}
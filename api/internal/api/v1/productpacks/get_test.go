@@ -0,0 +1,81 @@
+package productpacks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+var _ = Describe("Get Product Pack Endpoint", func() {
+	var (
+		rec  *httptest.ResponseRecorder
+		pack *types.ProductPack
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		pack = &types.ProductPack{ID: 7, CompanyID: company.ID, ProductID: 3, PackStyle: "carton", CountPerCase: 88, NetWeight: 40, WeightUnit: types.WeightUnitPound, Version: 1}
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	It("should get a pack of the user's own company", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+
+		performRequest("/product-packs/7", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
+		var result types.ProductPack
+		Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
+		Expect(result.CountPerCase).To(Equal(88))
+	})
+
+	It("should allow a super admin to get a pack of another company", func() {
+		pack.CompanyID = 99
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		performRequest("/product-packs/7", superAdminUser)
+		Expect(rec.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/product-packs/7", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should return 403 if a non-admin gets a pack of another company", func() {
+		pack.CompanyID = 99
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 403 if a company admin gets a pack of another company", func() {
+		pack.CompanyID = 99
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+		performRequest("/product-packs/7", adminUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 404 if the pack is not found", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(nil, false, nil)
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should return 500 on a database error", func() {
+		mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(nil, false, errors.New("db error"))
+		performRequest("/product-packs/7", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
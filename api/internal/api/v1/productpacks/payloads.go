@@ -0,0 +1,44 @@
+package productpacks
+
+// CreateProductPackPayload defines the structure for adding a pack configuration to a product.
+type CreateProductPackPayload struct {
+	ProductID      int64   `json:"product_id" validate:"required" example:"1"`
+	PackStyle      string  `json:"pack_style" validate:"required,max=100" example:"carton"`
+	CountPerCase   int     `json:"count_per_case" validate:"required,min=1" example:"88"`
+	UnitWeight     float64 `json:"unit_weight" validate:"gte=0" example:"0"`
+	NetWeight      float64 `json:"net_weight" validate:"required,gt=0" example:"40"`
+	GrossWeight    float64 `json:"gross_weight" validate:"omitempty,gtefield=NetWeight" example:"42.5"`
+	WeightUnit     string  `json:"weight_unit" validate:"required,oneof=lb kg" example:"lb"`
+	CaseLength     float64 `json:"case_length" validate:"gte=0" example:"19.5"`
+	CaseWidth      float64 `json:"case_width" validate:"gte=0" example:"12.5"`
+	CaseHeight     float64 `json:"case_height" validate:"gte=0" example:"10.5"`
+	DimensionUnit  string  `json:"dimension_unit" validate:"omitempty,oneof=in cm" example:"in"`
+	Ti             int     `json:"ti" validate:"gte=0,required_with=Hi" example:"10"`
+	Hi             int     `json:"hi" validate:"gte=0,required_with=Ti" example:"6"`
+	CasesPerPallet int     `json:"cases_per_pallet" validate:"gte=0" example:"60"`
+}
+
+// UpdateProductPackPayload defines the structure for updating a product pack.
+// Only the provided fields are changed; at least one must be provided.
+type UpdateProductPackPayload struct {
+	PackStyle      *string  `json:"pack_style,omitempty" validate:"omitempty,max=100"`
+	CountPerCase   *int     `json:"count_per_case,omitempty" validate:"omitempty,min=1"`
+	UnitWeight     *float64 `json:"unit_weight,omitempty" validate:"omitempty,gte=0"`
+	NetWeight      *float64 `json:"net_weight,omitempty" validate:"omitempty,gt=0"`
+	GrossWeight    *float64 `json:"gross_weight,omitempty" validate:"omitempty,gte=0"`
+	WeightUnit     *string  `json:"weight_unit,omitempty" validate:"omitempty,oneof=lb kg"`
+	CaseLength     *float64 `json:"case_length,omitempty" validate:"omitempty,gte=0"`
+	CaseWidth      *float64 `json:"case_width,omitempty" validate:"omitempty,gte=0"`
+	CaseHeight     *float64 `json:"case_height,omitempty" validate:"omitempty,gte=0"`
+	DimensionUnit  *string  `json:"dimension_unit,omitempty" validate:"omitempty,oneof=in cm"`
+	Ti             *int     `json:"ti,omitempty" validate:"omitempty,gte=0"`
+	Hi             *int     `json:"hi,omitempty" validate:"omitempty,gte=0"`
+	CasesPerPallet *int     `json:"cases_per_pallet,omitempty" validate:"omitempty,gte=0"`
+}
+
+// empty reports whether no field was provided.
+func (p UpdateProductPackPayload) empty() bool {
+	return p.PackStyle == nil && p.CountPerCase == nil && p.UnitWeight == nil && p.NetWeight == nil &&
+		p.GrossWeight == nil && p.WeightUnit == nil && p.CaseLength == nil && p.CaseWidth == nil &&
+		p.CaseHeight == nil && p.DimensionUnit == nil && p.Ti == nil && p.Hi == nil && p.CasesPerPallet == nil
+}
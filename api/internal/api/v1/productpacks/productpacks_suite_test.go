@@ -0,0 +1,72 @@
+package productpacks_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/productpacks"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductPacks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Product Packs Handler Suite")
+}
+
+var (
+	mockCtrl             *gomock.Controller
+	mockGlobalRepo       *mock_repos.MockGlobalRepo
+	mockProductsRepo     *mock_repos.MockProductsRepo
+	mockProductPacksRepo *mock_repos.MockProductPacksRepo
+	router               *mux.Router
+	adminUser            *types.User
+	superAdminUser       *types.User
+	normalUser           *types.User
+	company              *types.Company
+)
+
+var _ = BeforeEach(func() {
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
+	mockProductsRepo = mock_repos.NewMockProductsRepo(mockCtrl)
+	mockProductPacksRepo = mock_repos.NewMockProductPacksRepo(mockCtrl)
+
+	// Set up the mock chain
+	mockGlobalRepo.EXPECT().Products().Return(mockProductsRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().ProductPacks().Return(mockProductPacksRepo).AnyTimes()
+
+	// Set up the router
+	router = mux.NewRouter()
+	productpacks.AddRoutes(router)
+
+	// Set up common test data
+	company = &types.Company{ID: 1, Name: "Test Company"}
+	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
+	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
+})
+
+var _ = AfterEach(func() {
+	mockCtrl.Finish()
+})
+
+// newAuthenticatedRequest creates a new http.Request with the mocked GlobalRepo
+// and an optional authenticated user in the context.
+func newAuthenticatedRequest(method, url string, body []byte, user *types.User) *http.Request {
+	req := httptest.NewRequest(method, url, bytes.NewReader(body))
+	ctxWithRepo := context.WithValue(req.Context(), middleware.RepoKey, mockGlobalRepo)
+	if user != nil {
+		ctxWithAuth := context.WithValue(ctxWithRepo, middleware.AuthUserKey, user)
+		return req.WithContext(ctxWithAuth)
+	}
+	return req.WithContext(ctxWithRepo)
+}
@@ -0,0 +1,27 @@
+package productpacks
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the product pack routes on the given subrouter.
+// All routes require authentication and changes require the products:manage permission. Only users
+// who manage all companies can reach packs of other companies' products.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/product-packs").Subrouter()
+
+	// Routes for any authenticated user
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+
+	// Routes for users who manage products
+	manageRouter := s.NewRoute().Subrouter()
+	manageRouter.Use(middleware.RequirePermission(types.PermissionProductsManage))
+	manageRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	manageRouter.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	manageRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+}
@@ -0,0 +1,151 @@
+package productpacks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Update a product pack
+// @Description  Updates the provided fields of a product pack. When ti or hi change and cases per pallet
+// @Description  is not provided, cases per pallet is recalculated as ti x hi.
+// @Tags         product-packs
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int                      true  "Product Pack ID"
+// @Param        pack body      UpdateProductPackPayload true  "Product Pack Update Payload"
+// @Param        If-Match header string                  false "ETag of the product pack the update is based on"
+// @Success      200  {object}  types.ProductPack
+// @Header       200  {string}  ETag                     "Version of the updated product pack"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Invalid input or validation failed"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      404  {object}  middleware.ErrorResponse "Product pack not found"
+// @Failure      412  {object}  types.ProductPack        "Precondition Failed - The product pack has been changed since; this is its current version"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /product-packs/{id} [put]
+func Update(w http.ResponseWriter, r *http.Request) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid product pack ID")
+		return
+	}
+
+	var payload UpdateProductPackPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if payload.empty() {
+		middleware.WriteError(w, http.StatusBadRequest, "at least one field must be provided")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	pack, found, err := gr.ProductPacks().Get(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get product pack")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "product pack not found")
+		return
+	}
+
+	// Only users who manage all companies can update packs of other companies.
+	if !authUser.CanAccessCompany(pack.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to update this product pack")
+		return
+	}
+	if !middleware.IfMatch(r, pack.Version) {
+		middleware.WritePreconditionFailed(w, pack.Version, pack)
+		return
+	}
+
+	if payload.PackStyle != nil {
+		pack.PackStyle = *payload.PackStyle
+	}
+	if payload.CountPerCase != nil {
+		pack.CountPerCase = *payload.CountPerCase
+	}
+	if payload.UnitWeight != nil {
+		pack.UnitWeight = *payload.UnitWeight
+	}
+	if payload.NetWeight != nil {
+		pack.NetWeight = *payload.NetWeight
+	}
+	if payload.GrossWeight != nil {
+		pack.GrossWeight = *payload.GrossWeight
+	}
+	if payload.WeightUnit != nil {
+		pack.WeightUnit = *payload.WeightUnit
+	}
+	if payload.CaseLength != nil {
+		pack.CaseLength = *payload.CaseLength
+	}
+	if payload.CaseWidth != nil {
+		pack.CaseWidth = *payload.CaseWidth
+	}
+	if payload.CaseHeight != nil {
+		pack.CaseHeight = *payload.CaseHeight
+	}
+	if payload.DimensionUnit != nil {
+		pack.DimensionUnit = *payload.DimensionUnit
+	}
+	if payload.Ti != nil {
+		pack.Ti = *payload.Ti
+	}
+	if payload.Hi != nil {
+		pack.Hi = *payload.Hi
+	}
+	if payload.CasesPerPallet != nil {
+		pack.CasesPerPallet = *payload.CasesPerPallet
+	} else if payload.Ti != nil || payload.Hi != nil {
+		// Let the repo recalculate it from the new ti/hi.
+		pack.CasesPerPallet = 0
+	}
+
+	// The merged pack must still be valid, e.g. gross weight cannot drop below net weight.
+	if err := types.Validate(pack); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	if err := gr.ProductPacks().Update(r.Context(), pack); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the product pack between reading and writing it.
+			current, found, err := gr.ProductPacks().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update product pack")
+		return
+	}
+
+	middleware.SetETag(w, pack.Version)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pack)
+}
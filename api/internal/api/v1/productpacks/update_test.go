@@ -0,0 +1,137 @@
+package productpacks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/productpacks"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+var _ = Describe("Update Product Pack Endpoint", func() {
+	var (
+		rec  *httptest.ResponseRecorder
+		pack *types.ProductPack
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		pack = &types.ProductPack{
+			ID: 7, CompanyID: company.ID, ProductID: 3, PackStyle: "carton", CountPerCase: 88,
+			NetWeight: 40, GrossWeight: 42, WeightUnit: types.WeightUnitPound, Ti: 10, Hi: 6, CasesPerPallet: 60,
+			Version: 3,
+		}
+	})
+
+	performRequest := func(path string, payload interface{}, user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, path, body, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should update only the provided fields", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			mockProductPacksRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *types.ProductPack) error {
+				Expect(p.CountPerCase).To(Equal(72))
+				Expect(p.PackStyle).To(Equal("carton"))
+				Expect(p.CasesPerPallet).To(Equal(60))
+				return nil
+			})
+
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should reset cases per pallet when ti or hi change", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			mockProductPacksRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p *types.ProductPack) error {
+				Expect(p.Hi).To(Equal(5))
+				Expect(p.CasesPerPallet).To(BeZero())
+				return nil
+			})
+
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{Hi: utils.Ref(5)}, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("Error Paths", func() {
+		It("should fail if not authenticated", func() {
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should fail if no fields are provided", func() {
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with an invalid field value", func() {
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{WeightUnit: utils.Ref("oz")}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail if the merged pack is invalid", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{NetWeight: utils.Ref(50.0)}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 403 if a non-admin updates a pack of another company", func() {
+			pack.CompanyID = 99
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 404 if the pack is not found", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(nil, false, nil)
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 on update db error", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			mockProductPacksRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 412 with the current pack if If-Match is stale", func() {
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			body, err := json.Marshal(productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)})
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/product-packs/7", body, normalUser)
+			req.Header.Set("If-Match", `"2"`)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+			var current types.ProductPack
+			Expect(json.NewDecoder(rec.Body).Decode(&current)).To(Succeed())
+			Expect(current.CountPerCase).To(Equal(88))
+		})
+
+		It("should return 412 with the current pack if it was changed during the update", func() {
+			current := &types.ProductPack{ID: 7, CompanyID: company.ID, ProductID: 3, PackStyle: "carton", CountPerCase: 96, Version: 4}
+			first := mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(pack, true, nil)
+			mockProductPacksRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockProductPacksRepo.EXPECT().Get(gomock.Any(), pack.ID).Return(current, true, nil).After(first)
+			performRequest("/product-packs/7", productpacks.UpdateProductPackPayload{CountPerCase: utils.Ref(72)}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+		})
+	})
+})
@@ -2,9 +2,11 @@ package products
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
@@ -18,6 +20,7 @@ import (
 // @Failure      400     {object}  middleware.ErrorResponse "Bad Request - Invalid input or validation failed"
 // @Failure      401     {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      403     {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      409     {object}  ProductConflictResponse "Conflict - A product with the same commodity and attributes already exists"
 // @Failure      500     {object}  middleware.ErrorResponse "Internal Server Error"
 // @Security     AppTokenAuth
 // @Router       /products [post]
@@ -41,9 +44,9 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Only admins can create products for other companies.
-	// Non-admins can only create products for their own company.
-	if !authUser.HasRole(types.RoleAdmin) && authUser.CompanyID != payload.CompanyID {
+	// Only users who manage all companies can create products for other companies.
+	// Everyone else can only create products for their own company.
+	if !authUser.CanAccessCompany(payload.CompanyID) {
 		middleware.WriteError(w, http.StatusForbidden, "user not authorized to create products for this company")
 		return
 	}
@@ -65,6 +68,14 @@ func Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := gr.Products().Create(r.Context(), product, payload.Attributes); err != nil {
+		var existsErr *repos.ProductExistsError
+		if errors.As(err, &existsErr) {
+			middleware.WriteJSON(w, http.StatusConflict, ProductConflictResponse{
+				Error:             "a product with the same commodity and attributes already exists",
+				ExistingProductID: existsErr.ExistingID,
+			})
+			return
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to create product")
 		return
 	}
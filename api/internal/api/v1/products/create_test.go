@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/products"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -77,6 +78,23 @@ var _ = Describe("POST /products", func() {
 			})
 		})
 
+		Context("and a product with the same attributes exists", func() {
+			It("should return 409 Conflict with the existing product ID", func() {
+				mockCommoditiesRepo.EXPECT().Get(gomock.Any(), pld.CommodityID).Return(&types.Commodity{ID: pld.CommodityID}, true, nil)
+				mockProductsRepo.EXPECT().Create(gomock.Any(), gomock.Any(), pld.Attributes).Return(&repos.ProductExistsError{ExistingID: 42})
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPost, "/products", bytes.NewReader(body), adminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusConflict))
+				var resp products.ProductConflictResponse
+				Expect(json.NewDecoder(rr.Body).Decode(&resp)).To(Succeed())
+				Expect(resp.ExistingProductID).To(Equal(int64(42)))
+			})
+		})
+
 		Context("and repository error", func() {
 			It("should return 500 Internal Server Error", func() {
 				mockCommoditiesRepo.EXPECT().Get(gomock.Any(), pld.CommodityID).Return(&types.Commodity{ID: pld.CommodityID}, true, nil)
@@ -0,0 +1,37 @@
+package products
+
+import "strings"
+
+// Fixed CSV columns. Every other column is a commodity attribute, matched by name. The id and name
+// columns are written by the export and ignored by the import, so an exported file can be edited and
+// imported into another company.
+const (
+	csvColumnID        = "id"
+	csvColumnCommodity = "commodity"
+	csvColumnName      = "name"
+)
+
+// maxImportSize is the largest CSV body accepted by the import.
+const maxImportSize = 10 << 20
+
+
+// csvFormulaPrefixes are the characters that make spreadsheet programs read a cell as a formula.
+const csvFormulaPrefixes = "=+-@"
+
+// csvCell escapes a value written by the export so that spreadsheet programs show it as text
+// instead of running it as a formula: values starting with a formula character get a leading '.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// csvValue reads a cell of an imported file, undoing the escaping of csvCell.
+func csvValue(cell string) string {
+	cell = strings.TrimSpace(cell)
+	if len(cell) > 1 && cell[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(cell[1])) {
+		return cell[1:]
+	}
+	return cell
+}
@@ -17,15 +17,15 @@ import (
 // @Param        id   path      int  true  "Product ID"
 // @Success      204  "No Content"
 // @Failure      400  {object}  middleware.ErrorResponse "Invalid Product ID"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
 // @Failure      404  {object}  middleware.ErrorResponse "Product not found"
 // @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
 // @Security     AppTokenAuth
 // @Router       /products/{id} [delete]
 func Delete(w http.ResponseWriter, r *http.Request) {
 	// Get the authenticated user from the context (cached by AuthMiddleware).
-	var found bool
 	var err error
-	_, found = middleware.GetAuthUserFromContext(r.Context())
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
 	if !found { // Should be caught by middleware, but good practice to check
 		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
 		return
@@ -41,7 +41,7 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check if product exists
-	_, found, err = gr.Products().Get(r.Context(), id)
+	product, found, err := gr.Products().Get(r.Context(), id)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to get product")
 		return
@@ -51,6 +51,11 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authUser.CanAccessCompany(product.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to delete this product")
+		return
+	}
+
 	if err := gr.Products().Delete(r.Context(), id); err != nil {
 		if types.IsNotFoundError(err) {
 			middleware.WriteError(w, http.StatusNotFound, "product not found")
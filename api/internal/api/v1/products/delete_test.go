@@ -34,6 +34,31 @@ var _ = Describe("DELETE /products/{id}", func() {
 			})
 		})
 
+		Context("and product belongs to another company", func() {
+			It("should return 403 Forbidden", func() {
+				product.CompanyID = 99
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+
+				req := newAuthenticatedRequest(http.MethodDelete, "/products/1", nil, adminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("should return 204 No Content for a super admin", func() {
+				product.CompanyID = 99
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+				mockProductsRepo.EXPECT().Delete(gomock.Any(), product.ID).Return(nil)
+
+				req := newAuthenticatedRequest(http.MethodDelete, "/products/1", nil, superAdminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusNoContent))
+			})
+		})
+
 		Context("and product does not exist", func() {
 			It("should return 404 Not Found", func() {
 				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(nil, false, nil)
@@ -0,0 +1,144 @@
+package products
+
+import (
+	"encoding/csv"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// exportPageSize is the number of products loaded and written per page of the export.
+const exportPageSize = 500
+
+// @Summary      Export products as CSV
+// @Description  Streams the company's products as CSV. The columns are id, commodity and name, followed by one column per commodity attribute. Cells starting with =, +, - or @ are prefixed with ' so that spreadsheet programs do not run them as formulas.
+// @Tags         products
+// @Produce      text/csv
+// @Param        company_id query int false "Company to export. Defaults to the user's company; other companies require the companies:manage permission."
+// @Success      200  {string}  string "CSV file"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /products/export [get]
+func Export(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	attributes, _, err := gr.CommodityAttributes().Find(r.Context(), nil)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodity attributes")
+		return
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].ID < attributes[j].ID })
+
+	commodities, _, err := gr.Commodities().Find(r.Context(), nil)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodities")
+		return
+	}
+	commodityNames := make(map[int64]string, len(commodities))
+	for _, commodity := range commodities {
+		commodityNames[commodity.ID] = commodity.Name
+	}
+
+	// The first page is loaded before anything is written so that a failure can still be reported
+	// with a proper status code.
+	page, err := exportPage(r, gr, companyID, 0)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find products")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="products.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	header := []string{csvColumnID, csvColumnCommodity, csvColumnName}
+	for _, attr := range attributes {
+		header = append(header, csvCell(attr.Name))
+	}
+	cw.Write(header)
+
+	for offset := 0; ; offset += exportPageSize {
+		if offset > 0 {
+			if page, err = exportPage(r, gr, companyID, offset); err != nil {
+				// Headers are already sent, so the export can only be cut short.
+				log.Printf("unable to export products for company %d: %s", companyID, err.Error())
+				break
+			}
+		}
+
+		for _, product := range page {
+			values := make(map[int64]string, len(product.ProductAttributeValues))
+			for _, pav := range product.ProductAttributeValues {
+				values[pav.CommodityAttributeID] = pav.Value
+			}
+
+			record := []string{strconv.FormatInt(product.ID, 10), csvCell(commodityNames[product.CommodityID]), csvCell(product.Name)}
+			for _, attr := range attributes {
+				record = append(record, csvCell(values[attr.ID]))
+			}
+			cw.Write(record)
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			// The client is most likely gone, so there is no point in loading further pages.
+			log.Printf("unable to write products export for company %d: %s", companyID, err.Error())
+			return
+		}
+
+		if len(page) < exportPageSize {
+			break
+		}
+	}
+}
+
+// exportPage loads a page of the company's products together with their attribute values.
+func exportPage(r *http.Request, gr repos.GlobalRepo, companyID int64, offset int) ([]*types.Product, error) {
+	products, _, err := gr.Products().Find(r.Context(), &repos.ProductFindOpts{
+		CompanyID: companyID,
+		Limit:     exportPageSize,
+		Offset:    offset,
+	})
+	if err != nil || len(products) == 0 {
+		return products, err
+	}
+
+	productIDs := make([]int64, len(products))
+	byID := make(map[int64]*types.Product, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+		byID[product.ID] = product
+	}
+
+	values, _, err := gr.ProductAttributeValues().Find(r.Context(), &repos.ProductAttributeValueFindOpts{ProductIDs: productIDs})
+	if err != nil {
+		return nil, err
+	}
+	for _, pav := range values {
+		if product, ok := byID[pav.ProductID]; ok {
+			product.ProductAttributeValues = append(product.ProductAttributeValues, pav)
+		}
+	}
+
+	return products, nil
+}
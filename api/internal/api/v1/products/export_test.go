@@ -0,0 +1,116 @@
+package products_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Export Products Endpoint", func() {
+	var rec *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+	})
+
+	performRequest := func(path string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodGet, path, nil, user)
+		router.ServeHTTP(rec, req)
+	}
+
+	expectLookups := func() {
+		mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return([]*types.CommodityAttribute{
+			{ID: 2, Name: "Size"},
+			{ID: 1, Name: "Variety"},
+		}, int64(2), nil)
+		mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return([]*types.Commodity{
+			{ID: 10, Name: "Potatoes"},
+		}, int64(1), nil)
+	}
+
+	It("should stream one row per product with one column per attribute", func() {
+		expectLookups()
+		mockProductsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.ProductFindOpts) ([]*types.Product, int64, error) {
+			Expect(opts.CompanyID).To(Equal(normalUser.CompanyID))
+			Expect(opts.Offset).To(BeZero())
+			return []*types.Product{
+				{ID: 5, CommodityID: 10, CompanyID: 1, Name: "Russet 50 lb Potatoes"},
+				{ID: 6, CommodityID: 10, CompanyID: 1, Name: "Yukon Potatoes"},
+			}, int64(2), nil
+		})
+		mockProductAttributeValuesRepo.EXPECT().Find(gomock.Any(), gomock.Eq(&repos.ProductAttributeValueFindOpts{ProductIDs: []int64{5, 6}})).Return([]*types.ProductAttributeValue{
+			{ProductID: 5, CommodityAttributeID: 1, Value: "Russet"},
+			{ProductID: 5, CommodityAttributeID: 2, Value: "50 lb"},
+			{ProductID: 6, CommodityAttributeID: 1, Value: "Yukon"},
+		}, int64(3), nil)
+
+		performRequest("/products/export", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Header().Get("Content-Type")).To(Equal("text/csv"))
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([][]string{
+			{"id", "commodity", "name", "Variety", "Size"},
+			{"5", "Potatoes", "Russet 50 lb Potatoes", "Russet", "50 lb"},
+			{"6", "Potatoes", "Yukon Potatoes", "Yukon", ""},
+		}))
+	})
+
+	It("should escape cells that spreadsheet programs would run as formulas", func() {
+		expectLookups()
+		mockProductsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return([]*types.Product{
+			{ID: 5, CommodityID: 10, CompanyID: 1, Name: "=HYPERLINK(\"http://evil\") Potatoes"},
+		}, int64(1), nil)
+		mockProductAttributeValuesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return([]*types.ProductAttributeValue{
+			{ProductID: 5, CommodityAttributeID: 1, Value: "=HYPERLINK(\"http://evil\")"},
+			{ProductID: 5, CommodityAttributeID: 2, Value: "-5"},
+		}, int64(2), nil)
+
+		performRequest("/products/export", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records[1]).To(Equal([]string{"5", "Potatoes", "'=HYPERLINK(\"http://evil\") Potatoes", "'=HYPERLINK(\"http://evil\")", "'-5"}))
+	})
+
+	It("should write only the header when the company has no products", func() {
+		expectLookups()
+		mockProductsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return([]*types.Product{}, int64(0), nil)
+
+		performRequest("/products/export", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		records, err := csv.NewReader(rec.Body).ReadAll()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+	})
+
+	It("should fail if not authenticated", func() {
+		performRequest("/products/export", nil)
+		Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("should forbid a non-admin from exporting another company", func() {
+		performRequest("/products/export?company_id=99", normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 500 when the first page of products cannot be loaded", func() {
+		expectLookups()
+		mockProductsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), errors.New("db error"))
+
+		performRequest("/products/export", normalUser)
+
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -3,6 +3,7 @@ package products
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
@@ -18,6 +19,8 @@ import (
 // @Param        limit query int false "Number of records to return"
 // @Param        offset query int false "Number of records to skip"
 // @Param        name query string false "Product name filter"
+// @Param        company_id query int false "Company to list (super admins only, defaults to your company)"
+// @Param        q query string false "Full-text search over the product name, commodity and attribute values. Results are ordered by relevance."
 // @Success      200  {object}  object{data=[]types.Product,total=int} "A list of products"
 // @Failure      400  {object}  middleware.ErrorResponse "Bad Request"
 // @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
@@ -45,6 +48,7 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		Limit:  limit,
 		Offset: offset,
 		Name:  r.URL.Query().Get("name"),
+		Query: strings.TrimSpace(r.URL.Query().Get("q")),
 	}
 
 	// Get the authenticated user from the context (cached by AuthMiddleware).
@@ -54,8 +58,12 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// For now we force everyone to only see products in their own company.
-	opts.CompanyID = authUser.CompanyID
+	// Users see their own company; super admins may ask for another one with company_id.
+	opts.CompanyID, err = middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
 
 	products, count, err := gr.Products().Find(r.Context(), &opts)
 	if err != nil {
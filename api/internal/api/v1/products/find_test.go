@@ -105,6 +105,20 @@ var _ = Describe("Find Products Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
 
+		It("should pass the full-text search query to the repo", func() {
+			mockProductsRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.ProductFindOpts) ([]*types.Product, int64, error) {
+				Expect(opts.Query).To(Equal("granny apple organic"))
+				Expect(opts.CompanyID).To(Equal(normalUser.CompanyID))
+				return []*types.Product{}, int64(0), nil
+			})
+
+			params := url.Values{}
+			params.Add("q", "  granny apple organic ")
+			performRequest(params, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
 		It("should return StatusBadRequest for invalid limit parameter", func() {
 			params := url.Values{}
 			params.Add("limit", "invalid")
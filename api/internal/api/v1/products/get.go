@@ -7,7 +7,6 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
-	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // @Summary      Get a product by ID
@@ -16,6 +15,7 @@ import (
 // @Produce      json
 // @Param        id  path      int                      true  "Product ID"
 // @Success      200 {object}  types.Product           "Successfully retrieved product"
+// @Header       200 {string}  ETag                    "Version of the product, for If-Match"
 // @Failure      400 {object}  middleware.ErrorResponse "Bad Request - Invalid ID"
 // @Failure      401 {object}  middleware.ErrorResponse "Unauthorized"
 // @Failure      404 {object}  middleware.ErrorResponse "Not Found - Product not found"
@@ -49,12 +49,13 @@ func Get(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Authorization check: Normal users can only get products from their own company.
-	// Admins can get any product.
-	if !authUser.HasRole(types.RoleAdmin) && product.CompanyID != authUser.CompanyID {
+	// Users who manage all companies can get any product.
+	if !authUser.CanAccessCompany(product.CompanyID) {
 		middleware.WriteError(w, http.StatusForbidden, "user not authorized to view this product")
 		return
 	}
 
+	middleware.SetETag(w, product.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(product)
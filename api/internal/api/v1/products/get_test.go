@@ -20,7 +20,7 @@ var _ = Describe("GET /products/{id}", func() {
 	)
 
 	BeforeEach(func() {
-		product = &types.Product{ID: 1, CompanyID: company.ID, Name: "Test Product"}
+		product = &types.Product{ID: 1, CompanyID: company.ID, Name: "Test Product", Version: 1}
 		otherCompanyProduct = &types.Product{ID: 2, CompanyID: 99, Name: "Other Company Product"}
 		rec = httptest.NewRecorder() // Initialize rec here
 	})
@@ -34,6 +34,7 @@ var _ = Describe("GET /products/{id}", func() {
 				router.ServeHTTP(rec, req)
 
 				Expect(rec.Code).To(Equal(http.StatusOK))
+				Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 				var respProduct types.Product
 				Expect(json.NewDecoder(rec.Body).Decode(&respProduct)).To(Succeed())
 				Expect(respProduct.ID).To(Equal(product.ID))
@@ -41,16 +42,13 @@ var _ = Describe("GET /products/{id}", func() {
 		})
 
 		Context("and product from another company exists", func() {
-			It("should return 200 OK with the product", func() {
+			It("should return 403 Forbidden", func() {
 				mockProductsRepo.EXPECT().Get(gomock.Any(), otherCompanyProduct.ID).Return(otherCompanyProduct, true, nil)
 
 				req := newAuthenticatedRequest(http.MethodGet, "/products/2", nil, adminUser)
 				router.ServeHTTP(rec, req)
 
-				Expect(rec.Code).To(Equal(http.StatusOK))
-				var respProduct types.Product
-				Expect(json.NewDecoder(rec.Body).Decode(&respProduct)).To(Succeed())
-				Expect(respProduct.ID).To(Equal(otherCompanyProduct.ID))
+				Expect(rec.Code).To(Equal(http.StatusForbidden))
 			})
 		})
 
@@ -86,6 +84,22 @@ var _ = Describe("GET /products/{id}", func() {
 		})
 	})
 
+	Context("when authenticated as super admin", func() {
+		Context("and product from another company exists", func() {
+			It("should return 200 OK with the product", func() {
+				mockProductsRepo.EXPECT().Get(gomock.Any(), otherCompanyProduct.ID).Return(otherCompanyProduct, true, nil)
+
+				req := newAuthenticatedRequest(http.MethodGet, "/products/2", nil, superAdminUser)
+				router.ServeHTTP(rec, req)
+
+				Expect(rec.Code).To(Equal(http.StatusOK))
+				var respProduct types.Product
+				Expect(json.NewDecoder(rec.Body).Decode(&respProduct)).To(Succeed())
+				Expect(respProduct.ID).To(Equal(otherCompanyProduct.ID))
+			})
+		})
+	})
+
 	Context("when authenticated as normal user", func() {
 		Context("and product exists in own company", func() {
 			It("should return 200 OK with the product", func() {
@@ -0,0 +1,222 @@
+package products
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// @Summary      Import products from CSV
+// @Description  Creates products from a CSV body. The header must contain a commodity column; every other column except id and name is a commodity attribute, matched by name (case-insensitive). All rows are created in one transaction: if any row fails nothing is written and every failing row is reported. With dry_run=true the import is fully checked but never written.
+// @Tags         products
+// @Accept       text/csv
+// @Produce      json
+// @Param        company_id query int    false "Company to import into. Defaults to the user's company; other companies require the companies:manage permission."
+// @Param        dry_run    query bool   false "Check the import without writing it"
+// @Param        file       body  string true  "CSV file"
+// @Success      200  {object}  ProductImportResponse "Dry run passed"
+// @Success      201  {object}  ProductImportResponse "Products created"
+// @Failure      400  {object}  middleware.ErrorResponse "Bad Request - Malformed CSV or unknown column"
+// @Failure      401  {object}  middleware.ErrorResponse "Unauthorized"
+// @Failure      403  {object}  middleware.ErrorResponse "Forbidden"
+// @Failure      422  {object}  ProductImportResponse "One or more rows failed; nothing was created"
+// @Failure      500  {object}  middleware.ErrorResponse "Internal Server Error"
+// @Security     AppTokenAuth
+// @Router       /products/import [post]
+func Import(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	companyID, err := middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid dry_run format")
+			return
+		}
+	}
+
+	cr := csv.NewReader(http.MaxBytesReader(w, r.Body, maxImportSize))
+	cr.FieldsPerRecord = -1 // Rows with the wrong number of fields are reported per row.
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err == io.EOF {
+		middleware.WriteError(w, http.StatusBadRequest, "csv is empty")
+		return
+	}
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid csv: "+err.Error())
+		return
+	}
+
+	attributes, _, err := gr.CommodityAttributes().Find(r.Context(), nil)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodity attributes")
+		return
+	}
+	attributesByName := make(map[string]*types.CommodityAttribute, len(attributes))
+	for _, attr := range attributes {
+		attributesByName[strings.ToLower(attr.Name)] = attr
+	}
+
+	// Map the header to the commodity column and one attribute per remaining column.
+	commodityCol := -1
+	columnAttrs := make([]*types.CommodityAttribute, len(header))
+	for i, col := range header {
+		name := strings.ToLower(csvValue(col))
+		switch name {
+		case csvColumnCommodity:
+			commodityCol = i
+		case csvColumnID, csvColumnName:
+		default:
+			attr, ok := attributesByName[name]
+			if !ok {
+				middleware.WriteError(w, http.StatusBadRequest, fmt.Sprintf("unknown commodity attribute column %q", col))
+				return
+			}
+			columnAttrs[i] = attr
+		}
+	}
+	if commodityCol < 0 {
+		middleware.WriteError(w, http.StatusBadRequest, "csv is missing the commodity column")
+		return
+	}
+
+	commodities, _, err := gr.Commodities().Find(r.Context(), nil)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find commodities")
+		return
+	}
+	commoditiesByName := make(map[string]*types.Commodity, len(commodities))
+	for _, commodity := range commodities {
+		commoditiesByName[strings.ToLower(commodity.Name)] = commodity
+	}
+
+	var (
+		rows    []*repos.ProductImportRow
+		rowErrs []ProductImportRowError
+		total   int
+		// Duplicates within the file are reported against the first row rather than the product
+		// that row would have created.
+		seen = make(map[string]int)
+	)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			middleware.WriteError(w, http.StatusBadRequest, "invalid csv: "+err.Error())
+			return
+		}
+		total++
+		line, _ := cr.FieldPos(0)
+
+		row, rowErr := importRow(record, commodityCol, columnAttrs, commoditiesByName)
+		if rowErr != "" {
+			rowErrs = append(rowErrs, ProductImportRowError{Row: line, Error: rowErr})
+			continue
+		}
+		fingerprint := types.ProductFingerprint(row.Product.CommodityID, row.Attributes)
+		if first, ok := seen[fingerprint]; ok {
+			rowErrs = append(rowErrs, ProductImportRowError{Row: line, Error: fmt.Sprintf("duplicate of row %d", first)})
+			continue
+		}
+		seen[fingerprint] = line
+
+		row.Row = line
+		row.Product.CompanyID = companyID
+		rows = append(rows, row)
+	}
+	if total == 0 {
+		middleware.WriteError(w, http.StatusBadRequest, "csv has no rows")
+		return
+	}
+
+	// Rows that passed the checks above still go through the import when others failed, so that
+	// every problem in the file is reported at once. Nothing is written in that case.
+	repoErrs, err := gr.Products().Import(r.Context(), rows, dryRun || len(rowErrs) > 0)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to import products")
+		return
+	}
+	for _, repoErr := range repoErrs {
+		rowErrs = append(rowErrs, ProductImportRowError{Row: repoErr.Row, Error: importErrorMessage(repoErr.Err)})
+	}
+	sort.SliceStable(rowErrs, func(i, j int) bool { return rowErrs[i].Row < rowErrs[j].Row })
+
+	res := ProductImportResponse{DryRun: dryRun, Rows: total, Errors: rowErrs}
+	switch {
+	case len(rowErrs) > 0:
+		middleware.WriteJSON(w, http.StatusUnprocessableEntity, res)
+	case dryRun:
+		res.Errors = []ProductImportRowError{}
+		middleware.WriteJSON(w, http.StatusOK, res)
+	default:
+		res.Created = len(rows)
+		res.Errors = []ProductImportRowError{}
+		middleware.WriteJSON(w, http.StatusCreated, res)
+	}
+}
+
+// importRow builds the product for a CSV record, or returns why the record is invalid.
+func importRow(record []string, commodityCol int, columnAttrs []*types.CommodityAttribute, commoditiesByName map[string]*types.Commodity) (*repos.ProductImportRow, string) {
+	if len(record) != len(columnAttrs) {
+		return nil, fmt.Sprintf("expected %d fields, got %d", len(columnAttrs), len(record))
+	}
+
+	commodityName := csvValue(record[commodityCol])
+	if commodityName == "" {
+		return nil, "commodity is required"
+	}
+	commodity, ok := commoditiesByName[strings.ToLower(commodityName)]
+	if !ok {
+		return nil, fmt.Sprintf("commodity %q not found", commodityName)
+	}
+
+	row := &repos.ProductImportRow{Product: &types.Product{CommodityID: commodity.ID}}
+	for i, attr := range columnAttrs {
+		value := csvValue(record[i])
+		if attr == nil || value == "" {
+			continue
+		}
+		if attr.CommodityType != commodity.CommodityType {
+			return nil, fmt.Sprintf("attribute %q does not apply to commodity %q", attr.Name, commodity.Name)
+		}
+		row.Attributes = append(row.Attributes, &types.ProductAttributeValue{
+			CommodityAttributeID: attr.ID,
+			Value:                value,
+		})
+	}
+
+	return row, ""
+}
+
+// importErrorMessage turns an error returned for an import row into a message for the response.
+func importErrorMessage(err error) string {
+	var existsErr *repos.ProductExistsError
+	if errors.As(err, &existsErr) {
+		return fmt.Sprintf("duplicate of existing product %d", existsErr.ExistingID)
+	}
+	return err.Error()
+}
@@ -0,0 +1,208 @@
+package products_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/products"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Import Products Endpoint", func() {
+	var (
+		rec         *httptest.ResponseRecorder
+		attributes  []*types.CommodityAttribute
+		commodities []*types.Commodity
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		attributes = []*types.CommodityAttribute{
+			{ID: 1, Name: "Variety", CommodityType: types.CommodityTypeProduce},
+			{ID: 2, Name: "Size", CommodityType: types.CommodityTypeProduce},
+			{ID: 3, Name: "Grade", CommodityType: types.CommodityType(2)},
+		}
+		commodities = []*types.Commodity{
+			{ID: 10, Name: "Potatoes", CommodityType: types.CommodityTypeProduce},
+		}
+	})
+
+	performRequest := func(path, body string, user *types.User) {
+		req := newAuthenticatedRequest(http.MethodPost, path, strings.NewReader(body), user)
+		router.ServeHTTP(rec, req)
+	}
+
+	expectLookups := func() {
+		mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(attributes, int64(len(attributes)), nil)
+		mockCommoditiesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(commodities, int64(len(commodities)), nil)
+	}
+
+	decode := func() products.ProductImportResponse {
+		var res products.ProductImportResponse
+		Expect(json.NewDecoder(rec.Body).Decode(&res)).To(Succeed())
+		return res
+	}
+
+	Context("Happy Path", func() {
+		It("should create every row in one import, matching names case-insensitively", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, rows []*repos.ProductImportRow, _ bool) ([]*repos.ProductImportRowError, error) {
+				Expect(rows).To(HaveLen(2))
+				Expect(rows[0].Row).To(Equal(2))
+				Expect(rows[0].Product.CompanyID).To(Equal(normalUser.CompanyID))
+				Expect(rows[0].Product.CommodityID).To(Equal(int64(10)))
+				Expect(rows[0].Attributes).To(HaveLen(2))
+				Expect(rows[0].Attributes[0].CommodityAttributeID).To(Equal(int64(1)))
+				Expect(rows[0].Attributes[0].Value).To(Equal("Russet"))
+				Expect(rows[1].Attributes).To(HaveLen(1))
+				return nil, nil
+			})
+
+			performRequest("/products/import", "id,commodity,name,variety,SIZE\n,potatoes,,Russet,50 lb\n5,Potatoes,old name,Yukon,\n", normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+			res := decode()
+			Expect(res.Rows).To(Equal(2))
+			Expect(res.Created).To(Equal(2))
+			Expect(res.Errors).To(BeEmpty())
+		})
+
+		It("should undo the formula escaping of the export", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, rows []*repos.ProductImportRow, _ bool) ([]*repos.ProductImportRowError, error) {
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].Attributes).To(HaveLen(2))
+				Expect(rows[0].Attributes[0].Value).To(Equal("-5"))
+				Expect(rows[0].Attributes[1].Value).To(Equal("'Russet"))
+				return nil, nil
+			})
+
+			performRequest("/products/import", "commodity,size,variety\nPotatoes,'-5,'Russet\n", normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+
+		It("should not write anything for a dry run", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Len(1), true).Return(nil, nil)
+
+			performRequest("/products/import?dry_run=true", "commodity,variety\nPotatoes,Russet\n", normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			res := decode()
+			Expect(res.DryRun).To(BeTrue())
+			Expect(res.Created).To(BeZero())
+		})
+
+		It("should allow a super admin to import into another company", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Any(), false).DoAndReturn(func(_ context.Context, rows []*repos.ProductImportRow, _ bool) ([]*repos.ProductImportRowError, error) {
+				Expect(rows[0].Product.CompanyID).To(Equal(int64(99)))
+				return nil, nil
+			})
+
+			performRequest("/products/import?company_id=99", "commodity\nPotatoes\n", superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusCreated))
+		})
+	})
+
+	Context("Row Errors", func() {
+		It("should report every failing row and write nothing", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Len(1), true).DoAndReturn(func(_ context.Context, rows []*repos.ProductImportRow, _ bool) ([]*repos.ProductImportRowError, error) {
+				return []*repos.ProductImportRowError{{Row: rows[0].Row, Err: &repos.ProductExistsError{ExistingID: 7}}}, nil
+			})
+
+			body := "commodity,variety,grade\n" +
+				"Potatoes,Russet,\n" + // exists already
+				"Carrots,,\n" + // unknown commodity
+				"Potatoes,Yukon,US No. 1\n" + // attribute of another commodity type
+				"Potatoes\n" + // wrong number of fields
+				",Russet,\n" + // missing commodity
+				"potatoes,Russet,\n" // duplicate of row 2
+			performRequest("/products/import", body, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusUnprocessableEntity))
+			res := decode()
+			Expect(res.Rows).To(Equal(6))
+			Expect(res.Created).To(BeZero())
+			Expect(res.Errors).To(Equal([]products.ProductImportRowError{
+				{Row: 2, Error: "duplicate of existing product 7"},
+				{Row: 3, Error: `commodity "Carrots" not found`},
+				{Row: 4, Error: `attribute "Grade" does not apply to commodity "Potatoes"`},
+				{Row: 5, Error: "expected 3 fields, got 1"},
+				{Row: 6, Error: "commodity is required"},
+				{Row: 7, Error: "duplicate of row 2"},
+			}))
+		})
+	})
+
+	Context("Invalid Input", func() {
+		It("should fail if not authenticated", func() {
+			performRequest("/products/import", "commodity\nPotatoes\n", nil)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should forbid a non-admin from importing into another company", func() {
+			performRequest("/products/import?company_id=99", "commodity\nPotatoes\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should forbid a company admin from importing into another company", func() {
+			performRequest("/products/import?company_id=99", "commodity\nPotatoes\n", adminUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should fail with an invalid dry_run value", func() {
+			performRequest("/products/import?dry_run=maybe", "commodity\nPotatoes\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with an empty body", func() {
+			performRequest("/products/import", "", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail with an unknown attribute column", func() {
+			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(attributes, int64(len(attributes)), nil)
+			performRequest("/products/import", "commodity,colour\nPotatoes,red\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail without a commodity column", func() {
+			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(attributes, int64(len(attributes)), nil)
+			performRequest("/products/import", "variety\nRusset\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail without any rows", func() {
+			expectLookups()
+			performRequest("/products/import", "commodity,variety\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Repository Errors", func() {
+		It("should return 500 when the import fails", func() {
+			expectLookups()
+			mockProductsRepo.EXPECT().Import(gomock.Any(), gomock.Any(), false).Return(nil, errors.New("db error"))
+			performRequest("/products/import", "commodity\nPotatoes\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+
+		It("should return 500 when commodity attributes cannot be loaded", func() {
+			mockCommodityAttributesRepo.EXPECT().Find(gomock.Any(), gomock.Nil()).Return(nil, int64(0), errors.New("db error"))
+			performRequest("/products/import", "commodity\nPotatoes\n", normalUser)
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -16,3 +16,26 @@ type UpdateProductPayload struct {
 	CommodityID *int64  `json:"commodity_id" validate:"required_without_all=Attributes,gt=0"`
 	Attributes  []*types.ProductAttributeValue `json:"attributes" validate:"required_without_all=CommodityID"`
 }
+
+// ProductConflictResponse is returned with a 409 when a product with the same commodity and
+// attribute values already exists for the company.
+type ProductConflictResponse struct {
+	Error             string `json:"error" example:"a product with the same commodity and attributes already exists"`
+	ExistingProductID int64  `json:"existing_product_id" example:"1"`
+}
+
+// ProductImportResponse is returned by the CSV import. Created is the number of products written, which
+// is always zero for a dry run or when any row failed.
+type ProductImportResponse struct {
+	DryRun  bool                    `json:"dry_run" example:"false"`
+	Rows    int                     `json:"rows" example:"120"`
+	Created int                     `json:"created" example:"120"`
+	Errors  []ProductImportRowError `json:"errors"`
+}
+
+// ProductImportRowError describes why a row of an import was rejected. Row is the line of the CSV file,
+// where the header is line 1.
+type ProductImportRowError struct {
+	Row   int    `json:"row" example:"2"`
+	Error string `json:"error" example:"commodity \"Potatoes\" not found"`
+}
@@ -26,8 +26,11 @@ var (
 	mockGlobalRepo    *mock_repos.MockGlobalRepo
 	mockProductsRepo  *mock_repos.MockProductsRepo
 	mockCommoditiesRepo *mock_repos.MockCommoditiesRepo
+	mockCommodityAttributesRepo    *mock_repos.MockCommodityAttributesRepo
+	mockProductAttributeValuesRepo *mock_repos.MockProductAttributeValuesRepo
 	router            *mux.Router
 	adminUser         *types.User
+	superAdminUser    *types.User
 	normalUser        *types.User
 	company           *types.Company
 )
@@ -37,10 +40,14 @@ var _ = BeforeEach(func() {
 	mockGlobalRepo = mock_repos.NewMockGlobalRepo(mockCtrl)
 	mockProductsRepo = mock_repos.NewMockProductsRepo(mockCtrl)
 	mockCommoditiesRepo = mock_repos.NewMockCommoditiesRepo(mockCtrl)
+	mockCommodityAttributesRepo = mock_repos.NewMockCommodityAttributesRepo(mockCtrl)
+	mockProductAttributeValuesRepo = mock_repos.NewMockProductAttributeValuesRepo(mockCtrl)
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Products().Return(mockProductsRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Commodities().Return(mockCommoditiesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().CommodityAttributes().Return(mockCommodityAttributesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().ProductAttributeValues().Return(mockProductAttributeValuesRepo).AnyTimes()
 
 	// Set up the router
 	router = mux.NewRouter()
@@ -50,6 +57,7 @@ var _ = BeforeEach(func() {
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
 	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
@@ -4,21 +4,27 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // AddRoutes configures the product-related routes on the given subrouter.
+// All routes require authentication. Creating, changing, importing and deleting products requires
+// the products:manage permission.
 func AddRoutes(r *mux.Router) {
 	// Create a subrouter for the /products resource.
 	s := r.PathPrefix("/products").Subrouter()
 
 	// Routes accessible to any authenticated user
-	s.HandleFunc("", Create).Methods(http.MethodPost)
 	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
-	s.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
 	s.HandleFunc("/find", Find).Methods(http.MethodGet)
-	s.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+	s.HandleFunc("/export", Export).Methods(http.MethodGet)
 
-	// Admin-only routes (if any, add here)
-	// adminRouter := s.NewRoute().Subrouter()
-	// adminRouter.Use(middleware.AuthUserAdminRequiredMuxMiddleware())
+	// Routes for users who manage products
+	manageRouter := s.NewRoute().Subrouter()
+	manageRouter.Use(middleware.RequirePermission(types.PermissionProductsManage))
+	manageRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	manageRouter.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	manageRouter.HandleFunc("/import", Import).Methods(http.MethodPost)
+	manageRouter.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
 }
@@ -2,11 +2,13 @@ package products
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
@@ -17,15 +19,20 @@ import (
 // @Produce      json
 // @Param        id        path      int                      true  "Product ID"
 // @Param        product body      UpdateProductPayload   true  "Product Update Payload"
+// @Param        If-Match header   string                 false "ETag of the product the update is based on"
 // @Success      200       {object}  types.Product          "Successfully updated product"
+// @Header       200       {string}  ETag                   "Version of the updated product"
 // @Failure      400       {object}  middleware.ErrorResponse "Bad Request - Invalid input or validation failed"
+// @Failure      403       {object}  middleware.ErrorResponse "Forbidden - Product belongs to another company"
 // @Failure      404       {object}  middleware.ErrorResponse "Not Found - Product not found"
+// @Failure      409       {object}  ProductConflictResponse "Conflict - A product with the same commodity and attributes already exists"
+// @Failure      412       {object}  types.Product          "Precondition Failed - The product has been changed since; this is its current version"
 // @Failure      500       {object}  middleware.ErrorResponse "Internal Server Error"
 // @Security     AppTokenAuth
 // @Router       /products/{id} [put]
 func Update(w http.ResponseWriter, r *http.Request) {
 	// Get the authenticated user from the context (cached by AuthMiddleware).
-	_, found := middleware.GetAuthUserFromContext(r.Context())
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
 	if !found { // Should be caught by middleware, but good practice to check
 		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
 		return
@@ -60,6 +67,15 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authUser.CanAccessCompany(product.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to update this product")
+		return
+	}
+	if !middleware.IfMatch(r, product.Version) {
+		middleware.WritePreconditionFailed(w, product.Version, product)
+		return
+	}
+
 	if payload.CommodityID != nil {
 		// Validate commodity exists
 		_, found, err := gr.Commodities().Get(r.Context(), *payload.CommodityID)
@@ -75,10 +91,27 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := gr.Products().Update(r.Context(), product, payload.Attributes); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the product between reading and writing it.
+			current, found, err := gr.Products().Get(r.Context(), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		var existsErr *repos.ProductExistsError
+		if errors.As(err, &existsErr) {
+			middleware.WriteJSON(w, http.StatusConflict, ProductConflictResponse{
+				Error:             "a product with the same commodity and attributes already exists",
+				ExistingProductID: existsErr.ExistingID,
+			})
+			return
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update product")
 		return
 	}
 
+	middleware.SetETag(w, product.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(product)
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/products"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 	. "github.com/onsi/ginkgo/v2"
@@ -22,7 +23,7 @@ var _ = Describe("PUT /products/{id}", func() {
 	)
 
 	BeforeEach(func() {
-		product = &types.Product{ID: 1, CompanyID: company.ID, CommodityID: 1, Name: "Old Product Name"}
+		product = &types.Product{ID: 1, CompanyID: company.ID, CommodityID: 1, Name: "Old Product Name", Version: 3}
 		pld = products.UpdateProductPayload{
 			CommodityID: utils.Ref[int64](2),
 			Attributes: []*types.ProductAttributeValue{
@@ -47,6 +48,34 @@ var _ = Describe("PUT /products/{id}", func() {
 			})
 		})
 
+		Context("and product belongs to another company", func() {
+			It("should return 403 Forbidden", func() {
+				product.CompanyID = 99
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPut, "/products/1", bytes.NewReader(body), adminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusForbidden))
+			})
+
+			It("should update the product for a super admin", func() {
+				product.CompanyID = 99
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+				mockCommoditiesRepo.EXPECT().Get(gomock.Any(), *pld.CommodityID).Return(&types.Commodity{ID: *pld.CommodityID}, true, nil)
+				mockProductsRepo.EXPECT().Update(gomock.Any(), gomock.Any(), pld.Attributes).Return(nil)
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPut, "/products/1", bytes.NewReader(body), superAdminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusOK))
+			})
+		})
+
 		Context("and product does not exist", func() {
 			It("should return 404 Not Found", func() {
 				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(nil, false, nil)
@@ -86,6 +115,58 @@ var _ = Describe("PUT /products/{id}", func() {
 			})
 		})
 
+		Context("and a product with the same attributes exists", func() {
+			It("should return 409 Conflict with the existing product ID", func() {
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+				mockCommoditiesRepo.EXPECT().Get(gomock.Any(), *pld.CommodityID).Return(&types.Commodity{ID: *pld.CommodityID}, true, nil)
+				mockProductsRepo.EXPECT().Update(gomock.Any(), gomock.Any(), pld.Attributes).Return(&repos.ProductExistsError{ExistingID: 42})
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPut, "/products/1", bytes.NewReader(body), adminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusConflict))
+				var resp products.ProductConflictResponse
+				Expect(json.NewDecoder(rr.Body).Decode(&resp)).To(Succeed())
+				Expect(resp.ExistingProductID).To(Equal(int64(42)))
+			})
+		})
+
+		Context("and the product has been changed since it was read", func() {
+			It("should return 412 Precondition Failed with the current product if If-Match is stale", func() {
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPut, "/products/1", bytes.NewReader(body), adminUser)
+				req.Header.Set("If-Match", `"2"`)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusPreconditionFailed))
+				Expect(rr.Header().Get("ETag")).To(Equal(`"3"`))
+				var current types.Product
+				Expect(json.NewDecoder(rr.Body).Decode(&current)).To(Succeed())
+				Expect(current.Name).To(Equal("Old Product Name"))
+			})
+
+			It("should return 412 Precondition Failed with the current product if it changes during the update", func() {
+				current := &types.Product{ID: 1, CompanyID: company.ID, CommodityID: 3, Name: "Their Product Name", Version: 4}
+				first := mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
+				mockCommoditiesRepo.EXPECT().Get(gomock.Any(), *pld.CommodityID).Return(&types.Commodity{ID: *pld.CommodityID}, true, nil)
+				mockProductsRepo.EXPECT().Update(gomock.Any(), gomock.Any(), pld.Attributes).Return(repos.ErrVersionConflict)
+				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(current, true, nil).After(first)
+
+				body, _ := json.Marshal(pld)
+				req := newAuthenticatedRequest(http.MethodPut, "/products/1", bytes.NewReader(body), adminUser)
+				rr := httptest.NewRecorder()
+				router.ServeHTTP(rr, req)
+
+				Expect(rr.Code).To(Equal(http.StatusPreconditionFailed))
+				Expect(rr.Header().Get("ETag")).To(Equal(`"4"`))
+			})
+		})
+
 		Context("and repository error", func() {
 			It("should return 500 Internal Server Error", func() {
 				mockProductsRepo.EXPECT().Get(gomock.Any(), product.ID).Return(product, true, nil)
@@ -8,7 +8,9 @@ import (
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
-// Create handles the creation of a new user.
+// Create handles the creation of a new user with a password chosen by the creator. It requires the
+// users:manage permission; new users are normally invited through /invitations instead so that they
+// choose their own password.
 func Create(w http.ResponseWriter, r *http.Request) {
 	gr := middleware.GetRepo(r.Context())
 
@@ -23,6 +25,11 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := types.ActivePasswordPolicy.Check(payload.Password, payload.Email); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Get the authenticated user from the context (cached by AuthMiddleware).
 	authUser, found := middleware.GetAuthUserFromContext(r.Context())
 	if !found { // Should be caught by middleware, but good practice to check
@@ -30,13 +37,11 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Admins can create users in any company.
-	// Non-admins can only create users in their own company.
-	if !authUser.HasRole(types.RoleAdmin) {
-		if authUser.CompanyID != payload.CompanyID {
-			middleware.WriteError(w, http.StatusForbidden, "user not authorized to create users for this company")
-			return
-		}
+	// Users who manage all companies can create users in any company.
+	// Everyone else can only create users in their own company.
+	if !authUser.CanAccessCompany(payload.CompanyID) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to create users for this company")
+		return
 	}
 
 	// Check if user with that email already exists
@@ -41,7 +41,7 @@ var _ = Describe("Create User Endpoint", func() {
 	})
 
 	Context("Happy Path", func() {
-		It("should create a user successfully for a non-admin in their own company", func() {
+		It("should create a user successfully for a company admin in their own company", func() {
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), payload.Email).Return(nil, false, nil)
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(company, true, nil)
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(address, true, nil)
@@ -52,7 +52,7 @@ var _ = Describe("Create User Endpoint", func() {
 
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 
 			Expect(rec.Code).To(Equal(http.StatusCreated))
@@ -62,7 +62,7 @@ var _ = Describe("Create User Endpoint", func() {
 			Expect(result.Password).To(BeEmpty())
 		})
 
-		It("should create a user successfully for an admin in another company", func() {
+		It("should create a user successfully for a super admin in another company", func() {
 			payload.CompanyID = 99 // Different company
 			otherCompany := &types.Company{ID: 99, Name: "Admin-Created Company"}
 
@@ -73,7 +73,7 @@ var _ = Describe("Create User Endpoint", func() {
 
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), superAdminUser)
 			router.ServeHTTP(rec, req)
 
 			Expect(rec.Code).To(Equal(http.StatusCreated))
@@ -89,14 +89,22 @@ var _ = Describe("Create User Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
 		})
 
-		It("should fail if a non-admin tries to create a user in another company", func() {
-			payload.CompanyID = 99 // Different company
+		It("should fail if a user without users:manage tries to create a user in their own company", func() {
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
 			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusForbidden))
 		})
+
+		It("should fail if a company admin tries to create a user in another company", func() {
+			payload.CompanyID = 99 // Different company
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
 	})
 
 	Context("Invalid Input", func() {
@@ -110,16 +118,27 @@ var _ = Describe("Create User Endpoint", func() {
 			payload.ConfirmPassword = "wrongpassword"
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
 
+		It("should fail if the password is the email address", func() {
+			payload.Password = payload.Email
+			payload.ConfirmPassword = payload.Email
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Body.String()).To(ContainSubstring("password must not be the email address"))
+		})
+
 		It("should fail if a required field is missing", func() {
 			payload.FirstName = ""
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -130,7 +149,7 @@ var _ = Describe("Create User Endpoint", func() {
 			mockUsersRepo.EXPECT().GetByEmail(gomock.Any(), payload.Email).Return(&types.User{}, true, nil)
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -140,7 +159,7 @@ var _ = Describe("Create User Endpoint", func() {
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), payload.CompanyID).Return(nil, false, nil)
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -151,7 +170,7 @@ var _ = Describe("Create User Endpoint", func() {
 			mockAddressesRepo.EXPECT().Get(gomock.Any(), payload.AddressID).Return(nil, false, nil)
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
@@ -165,7 +184,7 @@ var _ = Describe("Create User Endpoint", func() {
 
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
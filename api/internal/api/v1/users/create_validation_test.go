@@ -52,7 +52,7 @@ var _ = Describe("Create User Validation", func() {
 			body, err := json.Marshal(payload)
 			Expect(err).NotTo(HaveOccurred())
 
-			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+			req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 			router.ServeHTTP(rec, req)
 
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
@@ -86,7 +86,7 @@ var _ = Describe("Create User Validation", func() {
 		body, err := json.Marshal(payloadMap)
 		Expect(err).NotTo(HaveOccurred())
 
-		req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), normalUser)
+		req := newAuthenticatedRequest(http.MethodPost, "/users", bytes.NewBuffer(body), adminUser)
 		router.ServeHTTP(rec, req)
 
 		Expect(rec.Code).To(Equal(http.StatusBadRequest))
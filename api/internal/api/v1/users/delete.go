@@ -11,7 +11,7 @@ import (
 
 // Delete handles the deletion of a user.
 //	@Summary	Delete a user
-//	@Description	Deletes a user by their ID. A user can delete themselves, or an admin can delete any user within the same company.
+//	@Description	Deletes a user by their ID. A user can delete themselves; users with the users:manage permission can delete any user within the same company.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -38,9 +38,8 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get the user to be deleted
-	// When getting a user, the company id must be passed in
-	userToDelete, has, err := gr.Users().Get(r.Context(), authUser.CompanyID, id)
+	// Get the user to be deleted. Only super admins can reach users of other companies.
+	userToDelete, has, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
 		return
@@ -51,16 +50,18 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Authorization check:
-	// 1. An admin can delete any user in their own company.
-	// 2. A non-admin user can only delete themselves.
-	isSameCompany := authUser.CompanyID == userToDelete.CompanyID
-	isAdmin := authUser.HasRole(types.RoleAdmin)
+	// 1. A user with the users:manage permission can delete any user in a company they can access.
+	// 2. Everyone else can only delete themselves.
+	canManage := authUser.HasPermission(types.PermissionUsersManage)
 	isSelf := authUser.ID == userToDelete.ID
 
-	if !isSelf && (!isAdmin || !isSameCompany) {
+	if !isSelf && (!canManage || !authUser.CanAccessCompany(userToDelete.CompanyID)) {
 		middleware.WriteError(w, http.StatusForbidden, "you are not authorized to delete this user")
 		return
 	}
+	if middleware.CheckCanActOnUserAndWriteError(w, r, userToDelete) {
+		return
+	}
 
 	if err = gr.Users().Delete(r.Context(), id); err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete user")
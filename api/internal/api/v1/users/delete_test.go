@@ -32,6 +32,7 @@ var _ = Describe("DELETE /users/{id}", func() {
 			targetUser := &types.User{ID: normalUser.ID, CompanyID: adminUser.CompanyID}
 
 			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), normalUser.ID).Return(nil, nil)
 			mockUsersRepo.EXPECT().Delete(gomock.Any(), normalUser.ID).Return(nil)
 
 			req := newAuthenticatedRequest("DELETE", "/users/1", nil, adminUser)
@@ -56,6 +57,21 @@ var _ = Describe("DELETE /users/{id}", func() {
 		})
 	})
 
+	Context("when an admin tries to delete a super admin of their company", func() {
+		It("should return 403 Forbidden", func() {
+			targetUser := &types.User{ID: superAdminUser.ID, CompanyID: adminUser.CompanyID, Roles: types.Roles{types.RoleSuperAdmin}}
+
+			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, targetUser.ID).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), targetUser.ID).Return(nil, nil)
+
+			req := newAuthenticatedRequest("DELETE", "/users/3", nil, adminUser)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
 	Context("when an admin tries to delete a user in another company", func() {
 		It("should return 403 Forbidden", func() {
 			otherCompany := &types.Company{ID: 99, Name: "Other Company"}
@@ -89,6 +105,7 @@ var _ = Describe("DELETE /users/{id}", func() {
 			dbErr := errors.New("db delete error")
 
 			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), normalUser.ID).Return(nil, nil)
 			mockUsersRepo.EXPECT().Delete(gomock.Any(), normalUser.ID).Return(dbErr)
 
 			req := newAuthenticatedRequest("DELETE", "/users/1", nil, adminUser)
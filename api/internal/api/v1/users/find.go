@@ -17,7 +17,7 @@ import (
 // @Produce      json
 // @Param        limit query int false "Number of records to return"
 // @Param        offset query int false "Number of records to skip"
-// @Param        company_id query int false "Filter by Company ID"
+// @Param        company_id query int false "Company to list (super admins only, defaults to your company)"
 // @Param        id query []int false "Filter by User IDs"
 // @Param        email query []string false "Filter by Emails"
 // @Param        first_name query []string false "Filter by First Names"
@@ -45,12 +45,6 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	companyID, err := utils.GetQueryInt64(r, "company_id")
-	if err != nil {
-		middleware.WriteError(w, http.StatusBadRequest, "invalid company_id format")
-		return
-	}
-
 	ids, err := utils.GetQueryInt64Slice(r, "id")
 	if err != nil {
 		middleware.WriteError(w, http.StatusBadRequest, "invalid id format")
@@ -60,7 +54,6 @@ func Find(w http.ResponseWriter, r *http.Request) {
 	opts := repos.UserFindOpts{
 		Limit:      limit,
 		Offset:     offset,
-		CompanyID:  companyID,
 		IDs:        ids,
 		Emails:     r.URL.Query()["email"],
 		FirstNames: r.URL.Query()["first_name"],
@@ -74,8 +67,12 @@ func Find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// All users, including admins, are restricted to their current company context for Find operations.
-	opts.CompanyID = authUser.CompanyID
+	// Users see their own company; super admins may ask for another one with company_id.
+	opts.CompanyID, err = middleware.RequestedCompanyID(r, authUser)
+	if err != nil {
+		middleware.WriteRequestedCompanyIDError(w, err)
+		return
+	}
 
 	users, count, err := repo.Users().Find(r.Context(), &opts)
 	if err != nil {
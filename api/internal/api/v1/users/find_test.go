@@ -90,23 +90,31 @@ var _ = Describe("Find Users Endpoint", func() {
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
 
-		It("should ignore company_id filter for admin users and use their own company", func() {
+		It("should let a super admin find the users of another company", func() {
 			otherCompanyID := int64(99)
 			expectedUsers := []*types.User{
-				{ID: 1, CompanyID: adminUser.CompanyID, Email: "user1@example.com"},
+				{ID: 1, CompanyID: otherCompanyID, Email: "user1@example.com"},
 			}
 			mockUsersRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, opts *repos.UserFindOpts) ([]*types.User, int64, error) {
-				Expect(opts.CompanyID).To(Equal(adminUser.CompanyID))
+				Expect(opts.CompanyID).To(Equal(otherCompanyID))
 				return expectedUsers, int64(len(expectedUsers)), nil
 			})
 
 			params := url.Values{}
 			params.Add("company_id", strconv.FormatInt(otherCompanyID, 10))
-			performRequest(params, adminUser)
+			performRequest(params, superAdminUser)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
 
+		It("should forbid a company admin from finding the users of another company", func() {
+			params := url.Values{}
+			params.Add("company_id", "99")
+			performRequest(params, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
 		It("should filter by multiple IDs", func() {
 			ids := []int64{1, 2}
 			expectedUsers := []*types.User{
@@ -202,6 +210,14 @@ var _ = Describe("Find Users Endpoint", func() {
 
 			Expect(rec.Code).To(Equal(http.StatusBadRequest))
 		})
+
+		It("should return StatusBadRequest for invalid company_id parameter", func() {
+			params := url.Values{}
+			params.Add("company_id", "abc")
+			performRequest(params, normalUser)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
 	})
 
 	Context("Error Paths", func() {
@@ -26,7 +26,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, found, err := gr.Users().Get(r.Context(), authUser.CompanyID, id)
+	user, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
 		return
@@ -40,6 +40,7 @@ func Get(w http.ResponseWriter, r *http.Request) {
 	// Important: never send the password hash in the response
 	user.Password = ""
 
+	middleware.SetETag(w, user.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(user)
@@ -21,7 +21,7 @@ var _ = Describe("Get User Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		targetUser = &types.User{ID: 2, CompanyID: company.ID, FirstName: "Target", LastName: "User"}
+		targetUser = &types.User{ID: 2, CompanyID: company.ID, FirstName: "Target", LastName: "User", Version: 1}
 	})
 
 	Context("Happy Path", func() {
@@ -32,6 +32,7 @@ var _ = Describe("Get User Endpoint", func() {
 			router.ServeHTTP(rec, req)
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"1"`))
 			var result types.User
 			Expect(json.NewDecoder(rec.Body).Decode(&result)).To(Succeed())
 			Expect(result.ID).To(Equal(targetUser.ID))
@@ -46,6 +47,16 @@ var _ = Describe("Get User Endpoint", func() {
 
 			Expect(rec.Code).To(Equal(http.StatusOK))
 		})
+
+		It("should look up users of any company for a super admin", func() {
+			otherUser := &types.User{ID: 4, CompanyID: 99, FirstName: "Other"}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), otherUser.ID).Return(otherUser, true, nil)
+
+			req := newAuthenticatedRequest(http.MethodGet, "/users/4", nil, superAdminUser)
+			router.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
 	})
 
 	Context("Error Paths", func() {
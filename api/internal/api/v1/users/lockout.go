@@ -0,0 +1,128 @@
+package users
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// Unlock handles ending the login lockout of a user.
+//
+//	@Summary		Unlock a user's login
+//	@Description	Ends the lockout a user's account got from too many failed logins and forgets the failures. Requires the users:manage permission. Lockouts of client IP addresses are not affected.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path	int	true	"User ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid User ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/unlock [post]
+func Unlock(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	user, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if middleware.CheckCanActOnUserAndWriteError(w, r, user) {
+		return
+	}
+
+	if err := gr.LoginThrottles().Unlock(r.Context(), user.Email, user.ID, authUser.ID); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to unlock user")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetLockoutEvents handles listing the login lockouts of a user.
+//
+//	@Summary		Get a user's lockout events
+//	@Description	Lists when a user's account was locked by failed logins and unlocked by an admin, newest first. Requires the users:manage permission.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id		path		int	true	"User ID"
+//	@Param			limit	query		int	false	"Number of records to return"
+//	@Param			offset	query		int	false	"Number of records to skip"
+//	@Success		200		{object}	object{data=[]types.LoginLockoutEvent,total=int}
+//	@Failure		400		{object}	middleware.ErrorResponse	"Invalid User ID or query"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/lockout-events [get]
+func GetLockoutEvents(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, found, err = gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	events, count, err := gr.LoginThrottles().FindEvents(r.Context(), id, limit, offset)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get lockout events")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(events, count))
+}
@@ -0,0 +1,107 @@
+package users_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("User Lockout Endpoints", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		targetUser *types.User
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		targetUser = &types.User{ID: 5, CompanyID: company.ID, Email: "locked@example.com"}
+	})
+
+	Describe("POST /users/{id}/unlock", func() {
+		It("should unlock the user", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockThrottlesRepo.EXPECT().Unlock(gomock.Any(), targetUser.Email, targetUser.ID, adminUser.ID).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 404 for a user of another company", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should forbid unlocking a super admin", func() {
+			targetUser.Roles = types.Roles{types.RoleSuperAdmin}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should let a super admin unlock users of any company", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockThrottlesRepo.EXPECT().Unlock(gomock.Any(), targetUser.Email, targetUser.ID, superAdminUser.ID).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, superAdminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should require the users:manage permission", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 500 when unlocking fails", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockThrottlesRepo.EXPECT().Unlock(gomock.Any(), targetUser.Email, targetUser.ID, adminUser.ID).Return(errors.New("db error"))
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/users/5/unlock", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("GET /users/{id}/lockout-events", func() {
+		It("should list the user's lockout events", func() {
+			lockedUntil := time.Now().Add(time.Minute)
+			userID := targetUser.ID
+			events := []*types.LoginLockoutEvent{{
+				ID: 1, Event: types.LoginLockoutEventLocked, Scope: types.LoginThrottleScopeAccount,
+				Key: targetUser.Email, UserID: &userID, LockedUntil: &lockedUntil,
+			}}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockThrottlesRepo.EXPECT().FindEvents(gomock.Any(), int64(5), 10, 0).Return(events, int64(1), nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/lockout-events", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"event":"locked"`))
+		})
+
+		It("should return 404 for a user of another company", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/lockout-events", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should require the users:manage permission", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/lockout-events", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
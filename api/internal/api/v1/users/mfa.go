@@ -0,0 +1,60 @@
+package users
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// ResetMFA handles turning off the multi-factor authentication of a user.
+//
+//	@Summary		Reset a user's MFA
+//	@Description	Turns MFA off for a user who lost their authenticator app and recovery codes, and forgets their secret and recovery codes. If the user's company requires MFA, the user enrolls again at the next login. Requires the users:manage permission.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path	int	true	"User ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid User ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/mfa [delete]
+func ResetMFA(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	user, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if middleware.CheckCanActOnUserAndWriteError(w, r, user) {
+		return
+	}
+
+	if err := gr.MFA().Disable(r.Context(), user.ID); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to reset multi-factor authentication")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
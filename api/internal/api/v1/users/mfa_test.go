@@ -0,0 +1,75 @@
+package users_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Reset User MFA Endpoint", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		targetUser *types.User
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		targetUser = &types.User{ID: 5, CompanyID: company.ID, MFAEnabled: true}
+	})
+
+	It("should turn off the user's MFA", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+		mockMFARepo.EXPECT().Disable(gomock.Any(), int64(5)).Return(nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 404 for a user of another company", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should forbid resetting the MFA of a super admin", func() {
+		targetUser.Roles = types.Roles{types.RoleSuperAdmin}
+		mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should forbid resetting the MFA of a user whose company roles grant more permissions", func() {
+		manager := &types.User{ID: 4, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}, Permissions: types.Permissions{types.PermissionUsersManage}}
+		mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(types.Permissions{types.PermissionRolesManage}, nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, manager))
+
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should require the users:manage permission", func() {
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, normalUser))
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 500 when the reset fails", func() {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+		mockMFARepo.EXPECT().Disable(gomock.Any(), int64(5)).Return(errors.New("db error"))
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/mfa", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -22,4 +22,16 @@ type UpdateUserPayload struct {
 // UpdateUserCompanyPayload defines the structure for updating a user's company.
 type UpdateUserCompanyPayload struct {
 	CompanyID int64 `json:"company_id" validate:"required"`
-}
\ No newline at end of file
+}
+// UpdatePasswordPayload defines the structure for changing a user's password.
+type UpdatePasswordPayload struct {
+	// CurrentPassword is required unless a user with the users:manage permission is changing the password.
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// UpdateUserRolesPayload defines the structure for setting a user's company roles.
+type UpdateUserRolesPayload struct {
+	// RoleIDs replace the user's current roles. An empty list removes all of them.
+	RoleIDs []int64 `json:"role_ids" validate:"required" example:"1,2"`
+}
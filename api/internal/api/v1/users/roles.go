@@ -0,0 +1,177 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// GetRoles handles listing the company roles assigned to a user.
+//
+//	@Summary		Get a user's roles
+//	@Description	Lists the company roles assigned to a user of the caller's company, or of any company with the companies:manage permission. Requires the roles:manage permission.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		int	true	"User ID"
+//	@Success		200	{array}		types.CompanyRole
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid User ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/roles [get]
+func GetRoles(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, found, err = gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	roles, err := gr.CompanyRoles().FindForUser(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user roles")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, roles)
+}
+
+// UpdateRoles handles replacing the company roles assigned to a user.
+//
+//	@Summary		Set a user's roles
+//	@Description	Replaces the company roles assigned to a user of the caller's company, or of any company with the companies:manage permission. Requires the roles:manage permission. Callers can only assign or remove roles whose permissions they have themselves, and cannot change the roles of a user with permissions they lack.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"User ID"
+//	@Param			roles	body		UpdateUserRolesPayload	true	"Roles Payload"
+//	@Success		200		{array}		types.CompanyRole
+//	@Failure		400		{object}	middleware.ErrorResponse	"Invalid request body or unknown role"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/roles [put]
+func UpdateRoles(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var payload UpdateUserRolesPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	target, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+
+	if middleware.CheckCanActOnUserAndWriteError(w, r, target) {
+		return
+	}
+
+	// Nor can anybody take away a role they could not have handed out.
+	current, err := gr.CompanyRoles().FindForUser(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user roles")
+		return
+	}
+	for _, role := range current {
+		if slices.Contains(payload.RoleIDs, role.ID) {
+			continue
+		}
+		for _, perm := range role.Permissions {
+			if !authUser.HasPermission(perm) {
+				middleware.WriteError(w, http.StatusForbidden, fmt.Sprintf("cannot remove role %q with permission %s you do not have", role.Name, perm))
+				return
+			}
+		}
+	}
+
+	// Nobody can hand out permissions they do not have, not even to themselves.
+	if len(payload.RoleIDs) > 0 {
+		roles, _, err := gr.CompanyRoles().Find(r.Context(), &repos.CompanyRoleFindOpts{
+			IDs:       payload.RoleIDs,
+			CompanyID: target.CompanyID,
+		})
+		if err != nil {
+			middleware.WriteError(w, http.StatusInternalServerError, "unable to get roles")
+			return
+		}
+		for _, role := range roles {
+			for _, perm := range role.Permissions {
+				if !authUser.HasPermission(perm) {
+					middleware.WriteError(w, http.StatusForbidden, fmt.Sprintf("cannot assign role %q with permission %s you do not have", role.Name, perm))
+					return
+				}
+			}
+		}
+	}
+
+	if err := gr.CompanyRoles().SetUserRoles(r.Context(), target.CompanyID, id, payload.RoleIDs); err != nil {
+		if errors.Is(err, repos.ErrCompanyRoleNotFound) {
+			middleware.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update user roles")
+		return
+	}
+
+	roles, err := gr.CompanyRoles().FindForUser(r.Context(), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user roles")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, roles)
+}
@@ -0,0 +1,170 @@
+package users_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/users"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("User Roles Endpoints", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		targetUser *types.User
+		role       *types.CompanyRole
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		targetUser = &types.User{ID: 5, CompanyID: company.ID}
+		role = &types.CompanyRole{ID: 3, CompanyID: company.ID, Name: "Users", Permissions: types.Permissions{types.PermissionUsersManage}}
+	})
+
+	performUpdate := func(roleIDs []int64, user *types.User) {
+		body, err := json.Marshal(users.UpdateUserRolesPayload{RoleIDs: roleIDs})
+		Expect(err).NotTo(HaveOccurred())
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPut, "/users/5/roles", bytes.NewBuffer(body), user))
+	}
+
+	// expectTarget expects the lookup of the target user, the check of their permissions and of the
+	// roles they hold now.
+	expectTarget := func(companyID int64, current ...*types.CompanyRole) {
+		mockUsersRepo.EXPECT().Get(gomock.Any(), companyID, int64(5)).Return(targetUser, true, nil)
+		mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+		mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return(current, nil)
+	}
+
+	Describe("GET /users/{id}/roles", func() {
+		It("should list the user's roles", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return([]*types.CompanyRole{role}, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/roles", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			Expect(rec.Body.String()).To(ContainSubstring(`"users:manage"`))
+		})
+
+		It("should return 404 for a user of another company", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/roles", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should list the roles of a user of another company for a super admin", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return(nil, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/roles", nil, superAdminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should require the roles:manage permission", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/roles", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Describe("PUT /users/{id}/roles", func() {
+		It("should replace the user's roles", func() {
+			expectTarget(company.ID)
+			mockRolesRepo.EXPECT().Find(gomock.Any(), &repos.CompanyRoleFindOpts{IDs: []int64{3}, CompanyID: company.ID}).Return([]*types.CompanyRole{role}, int64(1), nil)
+			mockRolesRepo.EXPECT().SetUserRoles(gomock.Any(), company.ID, int64(5), []int64{3}).Return(nil)
+			mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return([]*types.CompanyRole{role}, nil)
+
+			performUpdate([]int64{3}, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should remove all roles with an empty list", func() {
+			expectTarget(company.ID, role)
+			mockRolesRepo.EXPECT().SetUserRoles(gomock.Any(), company.ID, int64(5), []int64{}).Return(nil)
+			mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return(nil, nil)
+
+			performUpdate([]int64{}, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should not let a user assign a role with permissions they do not have", func() {
+			roleManager := &types.User{
+				ID: 7, CompanyID: company.ID, Roles: types.Roles{types.RoleUser},
+				Permissions: types.Permissions{types.PermissionRolesManage},
+			}
+			expectTarget(company.ID)
+			mockRolesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return([]*types.CompanyRole{role}, int64(1), nil)
+
+			performUpdate([]int64{3}, roleManager)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should not let a user remove a role with permissions they do not have", func() {
+			roleManager := &types.User{
+				ID: 7, CompanyID: company.ID, Roles: types.Roles{types.RoleUser},
+				Permissions: types.Permissions{types.PermissionRolesManage},
+			}
+			expectTarget(company.ID, role)
+
+			performUpdate([]int64{}, roleManager)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+			Expect(rec.Body.String()).To(ContainSubstring("cannot remove role"))
+		})
+
+		It("should not let a user change the roles of a user with more permissions", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(types.Permissions{types.PermissionUsersManage}, nil)
+			roleManager := &types.User{
+				ID: 7, CompanyID: company.ID, Roles: types.Roles{types.RoleUser},
+				Permissions: types.Permissions{types.PermissionRolesManage},
+			}
+
+			performUpdate([]int64{}, roleManager)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should let a super admin set the roles of a user of another company", func() {
+			targetUser.CompanyID = 2
+			otherRole := &types.CompanyRole{ID: 4, CompanyID: 2, Name: "Other"}
+			expectTarget(0)
+			mockRolesRepo.EXPECT().Find(gomock.Any(), &repos.CompanyRoleFindOpts{IDs: []int64{4}, CompanyID: 2}).Return([]*types.CompanyRole{otherRole}, int64(1), nil)
+			mockRolesRepo.EXPECT().SetUserRoles(gomock.Any(), int64(2), int64(5), []int64{4}).Return(nil)
+			mockRolesRepo.EXPECT().FindForUser(gomock.Any(), int64(5)).Return([]*types.CompanyRole{otherRole}, nil)
+
+			performUpdate([]int64{4}, superAdminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should return 400 for a role of another company", func() {
+			expectTarget(company.ID)
+			mockRolesRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, int64(0), nil)
+			mockRolesRepo.EXPECT().SetUserRoles(gomock.Any(), company.ID, int64(5), []int64{9}).Return(repos.ErrCompanyRoleNotFound)
+
+			performUpdate([]int64{9}, adminUser)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should require role_ids", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPut, "/users/5/roles", bytes.NewBufferString(`{}`), adminUser))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should require the roles:manage permission", func() {
+			performUpdate([]int64{3}, normalUser)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
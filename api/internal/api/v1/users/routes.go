@@ -5,22 +5,46 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
 // AddRoutes configures the user-related routes on the given subrouter.
+// All routes require authentication. Managing other users of the company requires the users:manage
+// permission, and so does creating a user with a password (new users are normally invited).
+// Assigning roles requires roles:manage and moving users between companies requires
+// companies:manage. Users can list and end their own sessions; doing so for others requires
+// users:manage. Unlocking users, viewing their lockouts and resetting their MFA also requires
+// users:manage. Passwords, sessions, lockouts, MFA, roles and deletion of another user can only be
+// managed by users who hold every permission that user holds.
 func AddRoutes(r *mux.Router) {
 	// Create a subrouter for the /users resource.
 	s := r.PathPrefix("/users").Subrouter()
 
 	// Routes accessible to any authenticated user
-	s.HandleFunc("", Create).Methods(http.MethodPost)
 	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
 	s.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	s.HandleFunc("/{id:[0-9]+}/password", UpdatePassword).Methods(http.MethodPut)
 	s.HandleFunc("/find", Find).Methods(http.MethodGet)
 	s.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+	s.HandleFunc("/{id:[0-9]+}/sessions", GetSessions).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}/sessions/{sid:[0-9]+}", RevokeSession).Methods(http.MethodDelete)
 
-	// Routes for admin users only
-	adminRouter := s.NewRoute().Subrouter()
-	adminRouter.Use(middleware.AuthUserAdminRequiredMuxMiddleware())
-	adminRouter.HandleFunc("/{id:[0-9]+}/company", UpdateUserCompany).Methods(http.MethodPut)
+	// Routes for users who manage the company's users
+	usersRouter := s.NewRoute().Subrouter()
+	usersRouter.Use(middleware.RequirePermission(types.PermissionUsersManage))
+	usersRouter.HandleFunc("", Create).Methods(http.MethodPost)
+	usersRouter.HandleFunc("/{id:[0-9]+}/unlock", Unlock).Methods(http.MethodPost)
+	usersRouter.HandleFunc("/{id:[0-9]+}/lockout-events", GetLockoutEvents).Methods(http.MethodGet)
+	usersRouter.HandleFunc("/{id:[0-9]+}/mfa", ResetMFA).Methods(http.MethodDelete)
+
+	// Routes for users who manage roles
+	rolesRouter := s.NewRoute().Subrouter()
+	rolesRouter.Use(middleware.RequirePermission(types.PermissionRolesManage))
+	rolesRouter.HandleFunc("/{id:[0-9]+}/roles", GetRoles).Methods(http.MethodGet)
+	rolesRouter.HandleFunc("/{id:[0-9]+}/roles", UpdateRoles).Methods(http.MethodPut)
+
+	// Routes for users who manage all companies
+	managerRouter := s.NewRoute().Subrouter()
+	managerRouter.Use(middleware.RequirePermission(types.PermissionCompaniesManage))
+	managerRouter.HandleFunc("/{id:[0-9]+}/company", UpdateUserCompany).Methods(http.MethodPut)
 }
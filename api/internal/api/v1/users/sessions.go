@@ -0,0 +1,158 @@
+package users
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// GetSessions handles listing where a user is signed in.
+//
+//	@Summary		Get a user's sessions
+//	@Description	Lists the active sessions of a user with the device's user agent and IP address and when the session started and was last used, most recently used first. The session of the calling token is marked as current. Users can list their own sessions; users with the users:manage permission can list the sessions of any user in their company.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id		path		int	true	"User ID"
+//	@Param			limit	query		int	false	"Number of records to return"
+//	@Param			offset	query		int	false	"Number of records to skip"
+//	@Success		200		{object}	object{data=[]types.Session,total=int}
+//	@Failure		400		{object}	middleware.ErrorResponse	"Invalid User ID or query"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/sessions [get]
+func GetSessions(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	user, ok := sessionsUser(w, r, id)
+	if !ok {
+		return
+	}
+
+	sessions, count, err := gr.Sessions().FindActiveForUser(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get sessions")
+		return
+	}
+
+	if claims, found := middleware.GetAuthClaimsFromContext(r.Context()); found {
+		for _, session := range sessions {
+			session.Current = session.ID == claims.SessionID
+		}
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(sessions, count))
+}
+
+// RevokeSession handles signing a user out of one session.
+//
+//	@Summary		End a user's session
+//	@Description	Signs a user out of one device. The session's refresh token and access tokens stop working immediately. Users can end their own sessions; users with the users:manage permission can end the sessions of any user in their company.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path	int	true	"User ID"
+//	@Param			sid	path	int	true	"Session ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid User ID or Session ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"User or session not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/sessions/{sid} [delete]
+func RevokeSession(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	sid, err := strconv.ParseInt(mux.Vars(r)["sid"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid session ID")
+		return
+	}
+
+	user, ok := sessionsUser(w, r, id)
+	if !ok {
+		return
+	}
+
+	session, found, err := gr.Sessions().Get(r.Context(), sid)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get session")
+		return
+	}
+	// Sessions that already ended are not listed, so they are not found either.
+	if !found || session.UserID != user.ID || !session.IsActive(time.Now()) {
+		middleware.WriteError(w, http.StatusNotFound, "session not found")
+		return
+	}
+
+	if err := gr.Sessions().Revoke(r.Context(), session.ID); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to end session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionsUser returns the user whose sessions are requested. Users may manage their own sessions;
+// the sessions of other users of the company require the users:manage permission. It writes the
+// error response and returns false when the user cannot be used.
+func sessionsUser(w http.ResponseWriter, r *http.Request, id int64) (*types.User, bool) {
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	if authUser.ID != id && !authUser.HasPermission(types.PermissionUsersManage) {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to manage this user's sessions")
+		return nil, false
+	}
+
+	user, found, err := middleware.GetRepo(r.Context()).Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return nil, false
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return nil, false
+	}
+	if middleware.CheckCanActOnUserAndWriteError(w, r, user) {
+		return nil, false
+	}
+	return user, true
+}
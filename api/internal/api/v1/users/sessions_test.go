@@ -0,0 +1,159 @@
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("User Sessions Endpoints", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		targetUser *types.User
+		session    *types.Session
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		targetUser = &types.User{ID: 5, CompanyID: company.ID}
+		session = &types.Session{ID: 9, UserID: 5, CompanyID: company.ID, UserAgent: "test-agent", IPAddress: "127.0.0.1", ExpiresAt: time.Now().Add(time.Hour)}
+	})
+
+	Describe("GET /users/{id}/sessions", func() {
+		It("should list the sessions of a user and mark the caller's own", func() {
+			normalUser.ID = 5
+			other := &types.Session{ID: 10, UserID: 5, CompanyID: company.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockSessionsRepo.EXPECT().FindActiveForUser(gomock.Any(), int64(5), 10, 0).Return([]*types.Session{session, other}, int64(2), nil)
+
+			req := newAuthenticatedRequest(http.MethodGet, "/users/5/sessions", nil, normalUser)
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthClaimsKey, &jwtpkg.CustomClaims{UserID: 5, SessionID: 9}))
+			router.ServeHTTP(rec, req)
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result struct {
+				Data  []types.Session `json:"data"`
+				Total int64           `json:"total"`
+			}
+			Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Total).To(Equal(int64(2)))
+			Expect(result.Data[0].UserAgent).To(Equal("test-agent"))
+			Expect(result.Data[0].Current).To(BeTrue())
+			Expect(result.Data[1].Current).To(BeFalse())
+		})
+
+		It("should let a user with users:manage list another user's sessions", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().FindActiveForUser(gomock.Any(), int64(5), 10, 0).Return([]*types.Session{session}, int64(1), nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/sessions", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+		})
+
+		It("should forbid listing another user's sessions without users:manage", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/sessions", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 404 for a user of another company", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/sessions", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 when the sessions cannot be listed", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().FindActiveForUser(gomock.Any(), int64(5), 10, 0).Return(nil, int64(0), errors.New("db error"))
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/users/5/sessions", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+
+	Describe("DELETE /users/{id}/sessions/{sid}", func() {
+		It("should end the session", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().Get(gomock.Any(), int64(9)).Return(session, true, nil)
+			mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(9)).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should let users end their own sessions", func() {
+			normalUser.ID = 5
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockSessionsRepo.EXPECT().Get(gomock.Any(), int64(9)).Return(session, true, nil)
+			mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(9)).Return(nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, normalUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should return 404 for a session of another user", func() {
+			session.UserID = 6
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().Get(gomock.Any(), int64(9)).Return(session, true, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 404 for a session that already ended", func() {
+			revokedAt := time.Now()
+			session.RevokedAt = &revokedAt
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().Get(gomock.Any(), int64(9)).Return(session, true, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should forbid ending another user's session without users:manage", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, normalUser))
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should forbid ending a super admin's session", func() {
+			targetUser.Roles = types.Roles{types.RoleSuperAdmin}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should return 500 when the session cannot be ended", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), company.ID, int64(5)).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), int64(5)).Return(nil, nil)
+			mockSessionsRepo.EXPECT().Get(gomock.Any(), int64(9)).Return(session, true, nil)
+			mockSessionsRepo.EXPECT().Revoke(gomock.Any(), int64(9)).Return(errors.New("db error"))
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/users/5/sessions/9", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -2,11 +2,13 @@ package users
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 )
 
@@ -39,7 +41,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the user to be updated
-	targetUser, found, err := gr.Users().Get(r.Context(), authUser.CompanyID, id)
+	targetUser, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
 		return
@@ -49,14 +51,18 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Admins can update any user.
-	// Non-admins can only update themselves.
-	if !authUser.Roles.HasRole(types.RoleAdmin) {
+	// Users with the users:manage permission can update any user of their company.
+	// Everyone else can only update themselves.
+	if !authUser.HasPermission(types.PermissionUsersManage) {
 		if authUser.ID != targetUser.ID {
 			middleware.WriteError(w, http.StatusForbidden, "user not authorized to update this user")
 			return
 		}
 	}
+	if !middleware.IfMatch(r, targetUser.Version) {
+		middleware.WritePreconditionFailed(w, targetUser.Version, targetUser)
+		return
+	}
 
 	// Validate the new address exists before updating the user
 	if payload.AddressID != nil {
@@ -81,10 +87,19 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := gr.Users().Update(r.Context(), targetUser); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the user between reading and writing it.
+			current, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to update user")
 		return
 	}
 
+	middleware.SetETag(w, targetUser.Version)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(targetUser)
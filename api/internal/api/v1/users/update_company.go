@@ -12,8 +12,8 @@ import (
 
 // UpdateUserCompany handles updating a user's company.
 //
-//	@Summary	Update a user's company (Admin only)
-//	@Description	Moves a user to a new company. This is an admin-only endpoint.
+//	@Summary	Update a user's company
+//	@Description	Moves a user to a new company. Requires the companies:manage permission.
 //	@Tags			users
 //	@Accept			json
 //	@Produce		json
@@ -53,7 +53,7 @@ func UpdateUserCompany(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get the user to be updated
-	// The route is admin-only, so we can fetch the user without a companyID scope.
+	// The route requires companies:manage, so we can fetch the user without a companyID scope.
 	_, has, err := gr.Users().Get(r.Context(), 0, id)
 	if err != nil {
 		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
@@ -23,9 +23,9 @@ var _ = Describe("PUT /users/{id}/company", func() {
 		pld = users.UpdateUserCompanyPayload{CompanyID: company.ID}
 	})
 
-	Context("when an admin updates a user in their own company", func() {
+	Context("when a super admin updates a user in their own company", func() {
 		It("should return 204 No Content", func() {
-			targetUser := &types.User{ID: normalUser.ID, CompanyID: adminUser.CompanyID}
+			targetUser := &types.User{ID: normalUser.ID, CompanyID: superAdminUser.CompanyID}
 			newCompany := &types.Company{ID: pld.CompanyID}
 
 			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), targetUser.ID).Return(targetUser, true, nil)
@@ -33,7 +33,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 			mockUsersRepo.EXPECT().UpdateUserCompany(gomock.Any(), targetUser.ID, newCompany.ID).Return(nil)
 
 			body, _ := json.Marshal(pld)
-			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
@@ -41,7 +41,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 		})
 	})
 
-	Context("when an admin updates a user in another company", func() {
+	Context("when a super admin updates a user in another company", func() {
 		It("should return 204 No Content", func() {
 			otherCompanyUser := &types.User{ID: 3, CompanyID: 99}
 			newCompany := &types.Company{ID: pld.CompanyID}
@@ -51,7 +51,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 			mockUsersRepo.EXPECT().UpdateUserCompany(gomock.Any(), otherCompanyUser.ID, newCompany.ID).Return(nil)
 
 			body, _ := json.Marshal(pld)
-			req := newAuthenticatedRequest("PUT", "/users/3/company", bytes.NewReader(body), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/3/company", bytes.NewReader(body), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
@@ -64,7 +64,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), gomock.Any()).Return(nil, false, nil)
 
 			body, _ := json.Marshal(pld)
-			req := newAuthenticatedRequest("PUT", "/users/999/company", bytes.NewReader(body), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/999/company", bytes.NewReader(body), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
@@ -75,13 +75,13 @@ var _ = Describe("PUT /users/{id}/company", func() {
 	Context("when the new company does not exist", func() {
 		It("should return 400 Bad Request", func() {
 			pld.CompanyID = 999
-			targetUser := &types.User{ID: normalUser.ID, CompanyID: adminUser.CompanyID}
+			targetUser := &types.User{ID: normalUser.ID, CompanyID: superAdminUser.CompanyID}
 
 			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), targetUser.ID).Return(targetUser, true, nil)
 			mockCompaniesRepo.EXPECT().Get(gomock.Any(), pld.CompanyID).Return(nil, false, nil)
 
 			body, _ := json.Marshal(pld)
-			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
@@ -91,7 +91,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 
 	Context("when the request body is invalid", func() {
 		It("should return 400 Bad Request", func() {
-			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader([]byte(`{`)), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader([]byte(`{`)), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
@@ -102,7 +102,7 @@ var _ = Describe("PUT /users/{id}/company", func() {
 	Context("when the database update fails", func() {
 		It("should return 500 Internal Server Error", func() {
 			dbErr := errors.New("db update error")
-			targetUser := &types.User{ID: normalUser.ID, CompanyID: adminUser.CompanyID}
+			targetUser := &types.User{ID: normalUser.ID, CompanyID: superAdminUser.CompanyID}
 			newCompany := &types.Company{ID: pld.CompanyID}
 
 			mockUsersRepo.EXPECT().Get(gomock.Any(), int64(0), targetUser.ID).Return(targetUser, true, nil)
@@ -110,11 +110,22 @@ var _ = Describe("PUT /users/{id}/company", func() {
 			mockUsersRepo.EXPECT().UpdateUserCompany(gomock.Any(), targetUser.ID, newCompany.ID).Return(dbErr)
 
 			body, _ := json.Marshal(pld)
-			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), adminUser)
+			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), superAdminUser)
 			rr := httptest.NewRecorder()
 			router.ServeHTTP(rr, req)
 
 			Expect(rr.Code).To(Equal(http.StatusInternalServerError))
 		})
 	})
+
+	Context("when a company admin moves a user", func() {
+		It("should return 403 Forbidden", func() {
+			body, _ := json.Marshal(pld)
+			req := newAuthenticatedRequest("PUT", "/users/1/company", bytes.NewReader(body), adminUser)
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			Expect(rr.Code).To(Equal(http.StatusForbidden))
+		})
+	})
 })
@@ -0,0 +1,104 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// UpdatePassword handles changing a user's password.
+//
+//	@Summary	Change a user's password
+//	@Description	Sets a new password that meets the password policy. Users can only change their own password and must give the current one; users with the users:manage permission can change any password in their company without it. All of the user's other sessions are ended.
+//	@Tags			users
+//	@Accept			json
+//	@Param			id	path	int	true	"User ID"
+//	@Param			body	body	UpdatePasswordPayload	true	"Update Password Payload"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid request body or password policy not met"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden or current password incorrect"
+//	@Failure		404	{object}	middleware.ErrorResponse	"User not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/users/{id}/password	[put]
+func UpdatePassword(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var payload UpdatePasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found { // Should be caught by middleware, but good practice to check
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	canManage := authUser.HasPermission(types.PermissionUsersManage)
+	if !canManage && authUser.ID != id {
+		middleware.WriteError(w, http.StatusForbidden, "user not authorized to change this user's password")
+		return
+	}
+
+	targetUser, found, err := gr.Users().Get(r.Context(), middleware.CompanyScope(authUser), id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get user")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "user not found")
+		return
+	}
+	if middleware.CheckCanActOnUserAndWriteError(w, r, targetUser) {
+		return
+	}
+
+	if !canManage {
+		if payload.CurrentPassword == "" {
+			middleware.WriteError(w, http.StatusBadRequest, "current password is required")
+			return
+		}
+		if bcrypt.CompareHashAndPassword([]byte(targetUser.Password), []byte(payload.CurrentPassword)) != nil {
+			middleware.WriteError(w, http.StatusForbidden, "current password is incorrect")
+			return
+		}
+	}
+
+	if err := types.ActivePasswordPolicy.Check(payload.NewPassword, targetUser.Email); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Users changing their own password stay signed in on the session they used; every other
+	// session of the user is ended.
+	var keepSessionID int64
+	if claims, found := middleware.GetAuthClaimsFromContext(r.Context()); found && authUser.ID == targetUser.ID {
+		keepSessionID = claims.SessionID
+	}
+
+	if err := gr.Users().ChangePassword(r.Context(), targetUser.ID, payload.NewPassword, keepSessionID); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to change password")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,154 @@
+package users_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/users"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("Update Password Endpoint", func() {
+	var (
+		rec        *httptest.ResponseRecorder
+		payload    users.UpdatePasswordPayload
+		targetUser *types.User
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte("oldpassword123"), bcrypt.MinCost)
+		Expect(err).NotTo(HaveOccurred())
+		targetUser = &types.User{ID: normalUser.ID, CompanyID: normalUser.CompanyID, Email: "user@example.com", Password: string(hashedPassword)}
+
+		payload = users.UpdatePasswordPayload{CurrentPassword: "oldpassword123", NewPassword: "newpassword123"}
+	})
+
+	performRequest := func(path string, user *types.User, sessionID int64) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		req := newAuthenticatedRequest(http.MethodPut, path, bytes.NewBuffer(body), user)
+		if sessionID != 0 {
+			req = req.WithContext(context.WithValue(req.Context(), middleware.AuthClaimsKey, &jwtpkg.CustomClaims{SessionID: sessionID}))
+		}
+		router.ServeHTTP(rec, req)
+	}
+
+	Context("Happy Path", func() {
+		It("should change the user's own password and keep only the current session", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+			mockUsersRepo.EXPECT().ChangePassword(gomock.Any(), normalUser.ID, "newpassword123", int64(7)).Return(nil)
+
+			performRequest("/users/1/password", normalUser, 7)
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+
+		It("should let an admin change another user's password without the current one and end all their sessions", func() {
+			payload.CurrentPassword = ""
+			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, targetUser.ID).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), targetUser.ID).Return(nil, nil)
+			mockUsersRepo.EXPECT().ChangePassword(gomock.Any(), targetUser.ID, "newpassword123", int64(0)).Return(nil)
+
+			performRequest("/users/1/password", adminUser, 9)
+
+			Expect(rec.Code).To(Equal(http.StatusNoContent))
+		})
+	})
+
+	Context("Authorization and Authentication", func() {
+		It("should fail if the user is not authenticated", func() {
+			performRequest("/users/1/password", nil, 0)
+			Expect(rec.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should forbid a non-admin from changing another user's password", func() {
+			performRequest("/users/5/password", normalUser, 0)
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should forbid a non-admin with the wrong current password", func() {
+			payload.CurrentPassword = "wrongpassword"
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+
+			performRequest("/users/1/password", normalUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should forbid an admin from changing a super admin's password", func() {
+			payload.CurrentPassword = ""
+			targetUser.Roles = types.Roles{types.RoleSuperAdmin}
+			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, targetUser.ID).Return(targetUser, true, nil)
+			mockRolesRepo.EXPECT().GetUserPermissions(gomock.Any(), targetUser.ID).Return(nil, nil)
+
+			performRequest("/users/1/password", adminUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("Validation Errors", func() {
+		It("should require the current password from a non-admin", func() {
+			payload.CurrentPassword = ""
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+
+			performRequest("/users/1/password", normalUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should reject a new password that breaks the password policy", func() {
+			payload.NewPassword = "short"
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+
+			performRequest("/users/1/password", normalUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+			Expect(rec.Body.String()).To(ContainSubstring("password must be at least"))
+		})
+
+		It("should reject the email address as the new password", func() {
+			payload.NewPassword = targetUser.Email
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+
+			performRequest("/users/1/password", normalUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should fail without a new password", func() {
+			payload.NewPassword = ""
+			performRequest("/users/1/password", normalUser, 0)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("Repository Errors", func() {
+		It("should return 404 if the user is not found", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), adminUser.CompanyID, int64(5)).Return(nil, false, nil)
+			performRequest("/users/5/password", adminUser, 0)
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+
+		It("should return 500 if the password cannot be changed", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, normalUser.ID).Return(targetUser, true, nil)
+			mockUsersRepo.EXPECT().ChangePassword(gomock.Any(), normalUser.ID, "newpassword123", int64(0)).Return(errors.New("db error"))
+
+			performRequest("/users/1/password", normalUser, 0)
+
+			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
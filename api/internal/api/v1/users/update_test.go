@@ -13,6 +13,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/users"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
@@ -27,7 +28,7 @@ var _ = Describe("Update User Endpoint", func() {
 
 	BeforeEach(func() {
 		rec = httptest.NewRecorder()
-		targetUser = &types.User{ID: 1, CompanyID: normalUser.CompanyID, FirstName: "Old", LastName: "User", AddressID: 10}
+		targetUser = &types.User{ID: 1, CompanyID: normalUser.CompanyID, FirstName: "Old", LastName: "User", AddressID: 10, Version: 3}
 		address = &types.Address{ID: 20, Line1: "New Address"}
 
 		payload = users.UpdateUserPayload{
@@ -179,5 +180,35 @@ var _ = Describe("Update User Endpoint", func() {
 			router.ServeHTTP(rec, req)
 			Expect(rec.Code).To(Equal(http.StatusInternalServerError))
 		})
+
+		It("should return 412 with the current user if If-Match is stale", func() {
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, targetUser.ID).Return(targetUser, true, nil)
+
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/users/1", bytes.NewBuffer(body), normalUser)
+			req.Header.Set("If-Match", `"2"`)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+			var current types.User
+			Expect(json.NewDecoder(rec.Body).Decode(&current)).To(Succeed())
+			Expect(current.FirstName).To(Equal("Old"))
+		})
+
+		It("should return 412 with the current user if it was changed during the update", func() {
+			current := &types.User{ID: 1, CompanyID: normalUser.CompanyID, FirstName: "Their", LastName: "User", AddressID: 10, Version: 4}
+			first := mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, targetUser.ID).Return(targetUser, true, nil)
+			mockAddressesRepo.EXPECT().Get(gomock.Any(), *payload.AddressID).Return(address, true, nil)
+			mockUsersRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(repos.ErrVersionConflict)
+			mockUsersRepo.EXPECT().Get(gomock.Any(), normalUser.CompanyID, targetUser.ID).Return(current, true, nil).After(first)
+
+			body, err := json.Marshal(payload)
+			Expect(err).NotTo(HaveOccurred())
+			req := newAuthenticatedRequest(http.MethodPut, "/users/1", bytes.NewBuffer(body), normalUser)
+			router.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+			Expect(rec.Header().Get("ETag")).To(Equal(`"4"`))
+		})
 	})
 })
@@ -27,8 +27,13 @@ var (
 	mockUsersRepo     *mock_repos.MockUsersRepo
 	mockCompaniesRepo *mock_repos.MockCompaniesRepo
 	mockAddressesRepo *mock_repos.MockAddressesRepo
+	mockRolesRepo     *mock_repos.MockCompanyRolesRepo
+	mockThrottlesRepo *mock_repos.MockLoginThrottlesRepo
+	mockMFARepo       *mock_repos.MockMFARepo
+	mockSessionsRepo  *mock_repos.MockSessionsRepo
 	router            *mux.Router
 	adminUser         *types.User
+	superAdminUser    *types.User
 	normalUser        *types.User
 	company           *types.Company
 )
@@ -39,11 +44,19 @@ var _ = BeforeEach(func() {
 	mockUsersRepo = mock_repos.NewMockUsersRepo(mockCtrl)
 	mockCompaniesRepo = mock_repos.NewMockCompaniesRepo(mockCtrl)
 	mockAddressesRepo = mock_repos.NewMockAddressesRepo(mockCtrl)
+	mockRolesRepo = mock_repos.NewMockCompanyRolesRepo(mockCtrl)
+	mockThrottlesRepo = mock_repos.NewMockLoginThrottlesRepo(mockCtrl)
+	mockMFARepo = mock_repos.NewMockMFARepo(mockCtrl)
+	mockSessionsRepo = mock_repos.NewMockSessionsRepo(mockCtrl)
 
 	// Set up the mock chain
 	mockGlobalRepo.EXPECT().Users().Return(mockUsersRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Companies().Return(mockCompaniesRepo).AnyTimes()
 	mockGlobalRepo.EXPECT().Addresses().Return(mockAddressesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().CompanyRoles().Return(mockRolesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().LoginThrottles().Return(mockThrottlesRepo).AnyTimes()
+	mockGlobalRepo.EXPECT().MFA().Return(mockMFARepo).AnyTimes()
+	mockGlobalRepo.EXPECT().Sessions().Return(mockSessionsRepo).AnyTimes()
 
 	// Set up the router
 	router = mux.NewRouter()
@@ -53,6 +66,7 @@ var _ = BeforeEach(func() {
 	company = &types.Company{ID: 1, Name: "Test Company"}
 	normalUser = &types.User{ID: 1, CompanyID: company.ID, Roles: types.Roles{types.RoleUser}}
 	adminUser = &types.User{ID: 2, CompanyID: company.ID, Roles: types.Roles{types.RoleAdmin}}
+	superAdminUser = &types.User{ID: 3, CompanyID: company.ID, Roles: types.Roles{types.RoleSuperAdmin}}
 })
 
 var _ = AfterEach(func() {
@@ -2,23 +2,41 @@ package v1
 
 import (
 	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/apikeys"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/auditlog"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/addresses"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commodities"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commodityattributes"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/commoditytypes"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companies"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyattributesettings"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/companyroles"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/events"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/invitations"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/locations"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/productpacks"
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/products" // Added
 	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/users"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/webhooks"
 )
 
 // AddAuthRoutes configures the v1 API routes on the given subrouter.
 func AddAuthRoutes(r *mux.Router) {
 	// Gemini order all routes
 	addresses.AddRoutes(r)
+	apikeys.AddRoutes(r)
+	auditlog.AddRoutes(r)
 	commodities.AddRoutes(r)
 	commodityattributes.AddRoutes(r)
+	commoditytypes.AddRoutes(r)
 	companies.AddRoutes(r)
+	companyattributesettings.AddRoutes(r)
+	companyroles.AddRoutes(r)
+	events.AddRoutes(r)
+	invitations.AddRoutes(r)
 	locations.AddRoutes(r)
+	productpacks.AddRoutes(r)
 	products.AddRoutes(r)
 	users.AddRoutes(r)
+	webhooks.AddRoutes(r)
 }
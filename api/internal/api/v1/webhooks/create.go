@@ -0,0 +1,80 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// secretPrefix marks a string as a webhook signing secret of this service.
+const secretPrefix = "whsec_"
+
+// Create handles registering a webhook endpoint for the user's company.
+//
+//	@Summary		Register a webhook endpoint
+//	@Description	Registers a URL the company's changes are posted to. The URL must use https and reach a public address. The signing secret is only returned in this response. Every delivery carries an X-Webhook-Signature header "t=<unix time>,v1=<hex>", the HMAC-SHA256 of the time, a dot and the body keyed with the secret.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			endpoint	body		CreateWebhookEndpointPayload	true	"Webhook Endpoint Payload"
+//	@Success		201			{object}	CreateWebhookEndpointResponse
+//	@Failure		400			{object}	middleware.ErrorResponse	"Invalid request body, URL or event type"
+//	@Failure		401			{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500			{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks [post]
+func Create(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	var payload CreateWebhookEndpointPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	if message := checkEndpoint(payload.URL, payload.EventTypes); message != "" {
+		middleware.WriteError(w, http.StatusBadRequest, message)
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	token, _, err := jwtpkg.GenerateOpaqueToken()
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to generate webhook secret")
+		return
+	}
+	secret := secretPrefix + token
+
+	endpoint := &types.WebhookEndpoint{
+		CompanyID:   authUser.CompanyID,
+		URL:         payload.URL,
+		Description: payload.Description,
+		Secret:      secret,
+		EventTypes:  types.WebhookEventTypes(payload.EventTypes),
+		Active:      true,
+		CreatedBy:   authUser.ID,
+	}
+	if endpoint.EventTypes == nil {
+		endpoint.EventTypes = types.WebhookEventTypes{}
+	}
+	if err := gr.Webhooks().CreateEndpoint(r.Context(), endpoint); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to create webhook endpoint")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusCreated, CreateWebhookEndpointResponse{WebhookEndpoint: endpoint, Secret: secret})
+}
@@ -0,0 +1,100 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/v1/webhooks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Create Webhook Endpoint Handler", func() {
+	var (
+		rec     *httptest.ResponseRecorder
+		payload webhooks.CreateWebhookEndpointPayload
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		payload = webhooks.CreateWebhookEndpointPayload{
+			URL:        "https://erp.example.com/hooks",
+			EventTypes: []types.WebhookEventType{"product.created", "product.updated"},
+		}
+	})
+
+	performRequest := func(user *types.User) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/webhooks", body, user))
+	}
+
+	It("should register an endpoint for the user's company and return its secret once", func() {
+		var created *types.WebhookEndpoint
+		mockWebhooksRepo.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, endpoint *types.WebhookEndpoint) error {
+			endpoint.ID = 9
+			created = endpoint
+			return nil
+		})
+
+		performRequest(adminUser)
+
+		Expect(rec.Code).To(Equal(http.StatusCreated))
+		var resp map[string]interface{}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &resp)).To(Succeed())
+		secret, ok := resp["secret"].(string)
+		Expect(ok).To(BeTrue())
+		Expect(secret).To(HavePrefix("whsec_"))
+		Expect(resp["id"]).To(BeNumerically("==", 9))
+
+		Expect(created.CompanyID).To(Equal(company.ID))
+		Expect(created.CreatedBy).To(Equal(adminUser.ID))
+		Expect(created.Secret).To(Equal(secret))
+		Expect(created.Active).To(BeTrue())
+		Expect(created.EventTypes).To(Equal(types.WebhookEventTypes{"product.created", "product.updated"}))
+	})
+
+	It("should reject an unknown event type", func() {
+		payload.EventTypes = []types.WebhookEventType{"api_key.created"}
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject URLs that are not http or https", func() {
+		payload.URL = "ftp://erp.example.com/hooks"
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject http URLs", func() {
+		payload.URL = "http://erp.example.com/hooks"
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		Expect(rec.Body.String()).To(ContainSubstring("https"))
+	})
+
+	It("should reject URLs of internal addresses", func() {
+		for _, url := range []string{"https://localhost/hooks", "https://127.0.0.1/hooks", "https://169.254.169.254/latest/meta-data", "https://192.168.1.10/hooks"} {
+			rec = httptest.NewRecorder()
+			payload.URL = url
+			performRequest(adminUser)
+			Expect(rec.Code).To(Equal(http.StatusBadRequest), url)
+			Expect(rec.Body.String()).To(ContainSubstring("internal address"), url)
+		}
+	})
+
+	It("should forbid users without the webhooks:manage permission", func() {
+		performRequest(normalUser)
+		Expect(rec.Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("should return 500 when the endpoint cannot be created", func() {
+		mockWebhooksRepo.EXPECT().CreateEndpoint(gomock.Any(), gomock.Any()).Return(errors.New("db error"))
+		performRequest(adminUser)
+		Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
@@ -0,0 +1,56 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Delete handles removing one of the user's company's webhook endpoints.
+//
+//	@Summary		Delete a webhook endpoint
+//	@Description	Removes a webhook endpoint of the user's company together with its delivery log. Pending deliveries are not sent.
+//	@Tags			webhooks
+//	@Param			id	path	int	true	"Webhook Endpoint ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Webhook Endpoint ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Webhook endpoint not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/{id} [delete]
+func Delete(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid webhook endpoint ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, found, err = gr.Webhooks().GetEndpoint(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get webhook endpoint")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "webhook endpoint not found")
+		return
+	}
+
+	if err := gr.Webhooks().DeleteEndpoint(r.Context(), authUser.CompanyID, id); err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to delete webhook endpoint")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
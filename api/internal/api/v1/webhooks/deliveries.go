@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// FindDeliveries handles listing the delivery log of one of the user's company's webhook endpoints.
+//
+//	@Summary		Find webhook deliveries
+//	@Description	Lists the deliveries of a webhook endpoint, newest first, with the number of attempts, the outcome of the latest one and when the next one is due.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id		path		int		true	"Webhook Endpoint ID"
+//	@Param			status	query		string	false	"Only deliveries in this state"	Enums(pending, succeeded, failed)
+//	@Param			limit	query		int		false	"Number of records to return"
+//	@Param			offset	query		int		false	"Number of records to skip"
+//	@Success		200		{object}	object{data=[]types.WebhookDelivery,total=int}	"A list of webhook deliveries"
+//	@Failure		400		{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404		{object}	middleware.ErrorResponse	"Webhook endpoint not found"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/{id}/deliveries [get]
+func FindDeliveries(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid webhook endpoint ID")
+		return
+	}
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	status := types.WebhookDeliveryStatus(r.URL.Query().Get("status"))
+	switch status {
+	case "", types.WebhookDeliveryStatusPending, types.WebhookDeliveryStatusSucceeded, types.WebhookDeliveryStatusFailed:
+	default:
+		middleware.WriteError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	_, found, err = gr.Webhooks().GetEndpoint(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get webhook endpoint")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "webhook endpoint not found")
+		return
+	}
+
+	deliveries, count, err := gr.Webhooks().FindDeliveries(r.Context(), &repos.WebhookDeliveryFindOpts{
+		CompanyID:  authUser.CompanyID,
+		EndpointID: id,
+		Status:     status,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find webhook deliveries")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(deliveries, count))
+}
@@ -0,0 +1,81 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Webhook Delivery Handlers", func() {
+	var (
+		rec      *httptest.ResponseRecorder
+		delivery *types.WebhookDelivery
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		delivery = &types.WebhookDelivery{
+			ID: 7, CompanyID: company.ID, EndpointID: 4, EventID: 3, EventType: "product.created",
+			Status: types.WebhookDeliveryStatusFailed, Attempts: 8, ResponseStatus: 500,
+		}
+	})
+
+	Describe("GET /webhooks/{id}/deliveries", func() {
+		It("should list the deliveries of an endpoint by status", func() {
+			mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(&types.WebhookEndpoint{ID: 4}, true, nil)
+			mockWebhooksRepo.EXPECT().FindDeliveries(gomock.Any(), &repos.WebhookDeliveryFindOpts{
+				CompanyID:  company.ID,
+				EndpointID: 4,
+				Status:     types.WebhookDeliveryStatusFailed,
+				Limit:      10,
+			}).Return([]*types.WebhookDelivery{delivery}, int64(1), nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/4/deliveries?status=failed", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusOK))
+			var result struct {
+				Data  []types.WebhookDelivery `json:"data"`
+				Total int64                   `json:"total"`
+			}
+			Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Data[0].Attempts).To(Equal(8))
+		})
+
+		It("should fail with an unknown status", func() {
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/4/deliveries?status=lost", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusBadRequest))
+		})
+
+		It("should return 404 for an endpoint of another company", func() {
+			mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/4/deliveries", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Describe("POST /webhooks/deliveries/{id}/redeliver", func() {
+		It("should queue a new delivery of the event", func() {
+			mockWebhooksRepo.EXPECT().GetDelivery(gomock.Any(), company.ID, int64(7)).Return(delivery, true, nil)
+			mockWebhooksRepo.EXPECT().Redeliver(gomock.Any(), delivery).Return(&types.WebhookDelivery{
+				ID: 8, CompanyID: company.ID, EndpointID: 4, EventID: 3, Status: types.WebhookDeliveryStatusPending,
+			}, nil)
+
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/webhooks/deliveries/7/redeliver", nil, adminUser))
+
+			Expect(rec.Code).To(Equal(http.StatusAccepted))
+			Expect(rec.Body.String()).To(ContainSubstring(`"status":"pending"`))
+		})
+
+		It("should return 404 for a delivery of another company", func() {
+			mockWebhooksRepo.EXPECT().GetDelivery(gomock.Any(), company.ID, int64(7)).Return(nil, false, nil)
+			router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPost, "/webhooks/deliveries/7/redeliver", nil, adminUser))
+			Expect(rec.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+})
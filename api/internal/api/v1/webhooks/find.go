@@ -0,0 +1,62 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// Find handles listing the webhook endpoints of the user's company.
+//
+//	@Summary		Find webhook endpoints
+//	@Description	Lists the webhook endpoints of the user's company, newest first. Their secrets are never returned.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			limit	query		int	false	"Number of records to return"
+//	@Param			offset	query		int	false	"Number of records to skip"
+//	@Success		200		{object}	object{data=[]types.WebhookEndpoint,total=int}	"A list of webhook endpoints"
+//	@Failure		400		{object}	middleware.ErrorResponse	"Bad Request"
+//	@Failure		401		{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403		{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		500		{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/find [get]
+func Find(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	limit, err := utils.GetQueryInt(r, "limit")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid limit format")
+		return
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	offset, err := utils.GetQueryInt(r, "offset")
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid offset format")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	endpoints, count, err := gr.Webhooks().FindEndpoints(r.Context(), &repos.WebhookEndpointFindOpts{
+		CompanyID: authUser.CompanyID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to find webhook endpoints")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusOK, types.NewFindResult(endpoints, count))
+}
@@ -0,0 +1,57 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Find and Get Webhook Endpoint Handlers", func() {
+	var (
+		rec      *httptest.ResponseRecorder
+		endpoint *types.WebhookEndpoint
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		endpoint = &types.WebhookEndpoint{ID: 4, CompanyID: company.ID, URL: "https://erp.example.com/hooks", Secret: "whsec_secret", Active: true}
+	})
+
+	It("should list the endpoints of the user's company without their secrets", func() {
+		mockWebhooksRepo.EXPECT().FindEndpoints(gomock.Any(), &repos.WebhookEndpointFindOpts{CompanyID: company.ID, Limit: 10}).
+			Return([]*types.WebhookEndpoint{endpoint}, int64(1), nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/find", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("whsec_secret"))
+		var result struct {
+			Data  []types.WebhookEndpoint `json:"data"`
+			Total int64                   `json:"total"`
+		}
+		Expect(json.Unmarshal(rec.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Total).To(Equal(int64(1)))
+		Expect(result.Data[0].URL).To(Equal(endpoint.URL))
+	})
+
+	It("should return an endpoint of the user's company", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(endpoint, true, nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("whsec_secret"))
+	})
+
+	It("should return 404 for an endpoint of another company", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodGet, "/webhooks/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+})
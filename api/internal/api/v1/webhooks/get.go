@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Get handles retrieving one of the user's company's webhook endpoints.
+//
+//	@Summary		Get a webhook endpoint
+//	@Description	Gets a webhook endpoint of the user's company by its ID. The secret is never returned.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id	path		int	true	"Webhook Endpoint ID"
+//	@Success		200	{object}	types.WebhookEndpoint
+//	@Header			200	{string}	ETag	"Version of the webhook endpoint, for If-Match"
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Webhook Endpoint ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Webhook endpoint not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/{id} [get]
+func Get(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid webhook endpoint ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	endpoint, found, err := gr.Webhooks().GetEndpoint(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get webhook endpoint")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "webhook endpoint not found")
+		return
+	}
+
+	middleware.SetETag(w, endpoint.Version)
+	middleware.WriteJSON(w, http.StatusOK, endpoint)
+}
@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/webhooks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// CreateWebhookEndpointPayload defines the structure for registering a webhook endpoint.
+type CreateWebhookEndpointPayload struct {
+	URL         string `json:"url" validate:"required,url,max=2048" example:"https://erp.example.com/hooks/orders"`
+	Description string `json:"description" validate:"max=255" example:"ERP sync"`
+	// EventTypes are the events to send, such as "product.updated". All events are sent when it is empty.
+	EventTypes []types.WebhookEventType `json:"event_types,omitempty" example:"product.created,product.updated"`
+}
+
+// CreateWebhookEndpointResponse is the endpoint that was registered together with its signing
+// secret. The secret is only ever returned here.
+type CreateWebhookEndpointResponse struct {
+	*types.WebhookEndpoint
+	Secret string `json:"secret" example:"whsec_2b9c..."`
+}
+
+// UpdateWebhookEndpointPayload defines the structure for updating a webhook endpoint.
+// At least one field must be provided.
+type UpdateWebhookEndpointPayload struct {
+	URL         *string                  `json:"url,omitempty" validate:"required_without_all=Description EventTypes Active,omitempty,url,max=2048"`
+	Description *string                  `json:"description,omitempty" validate:"required_without_all=URL EventTypes Active,omitempty,max=255"`
+	EventTypes  []types.WebhookEventType `json:"event_types,omitempty" validate:"required_without_all=URL Description Active"`
+	Active      *bool                    `json:"active,omitempty" validate:"required_without_all=URL Description EventTypes"`
+}
+
+// checkEndpoint returns an error message if deliveries could not be sent to the URL or an event
+// type is unknown. Outside development (DEV=true), the URL must use https and must not name an
+// internal host; host names are checked again after DNS resolution each time a delivery is sent.
+func checkEndpoint(rawURL string, eventTypes []types.WebhookEventType) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "url must be an http or https URL"
+	}
+	if utils.GetEnv("DEV", "") != "true" {
+		if u.Scheme != "https" {
+			return "url must be an https URL"
+		}
+		host := u.Hostname()
+		if ip := net.ParseIP(host); strings.EqualFold(host, "localhost") || (ip != nil && !webhooks.IsPublicIP(ip)) {
+			return "url must not point to an internal address"
+		}
+	}
+	for _, eventType := range eventTypes {
+		if !types.IsValidWebhookEventType(eventType) {
+			return fmt.Sprintf("invalid event type %q", eventType)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+)
+
+// Redeliver handles sending the event of a delivery to its endpoint again.
+//
+//	@Summary		Redeliver a webhook event
+//	@Description	Sends the event of a delivery to its endpoint again, as a new delivery that is due immediately and retried like any other. The original delivery stays in the log. Receivers can tell redeliveries apart by the event ID in the body.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id	path		int	true	"Webhook Delivery ID"
+//	@Success		202	{object}	types.WebhookDelivery
+//	@Failure		400	{object}	middleware.ErrorResponse	"Invalid Webhook Delivery ID"
+//	@Failure		401	{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403	{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404	{object}	middleware.ErrorResponse	"Webhook delivery not found"
+//	@Failure		500	{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/deliveries/{id}/redeliver [post]
+func Redeliver(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid webhook delivery ID")
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	delivery, found, err := gr.Webhooks().GetDelivery(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get webhook delivery")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "webhook delivery not found")
+		return
+	}
+
+	redelivery, err := gr.Webhooks().Redeliver(r.Context(), delivery)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to redeliver webhook event")
+		return
+	}
+
+	middleware.WriteJSON(w, http.StatusAccepted, redelivery)
+}
@@ -0,0 +1,26 @@
+package webhooks
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// AddRoutes configures the webhook routes on the given subrouter.
+// All routes require the webhooks:manage permission and only reach the user's own company's
+// endpoints and deliveries.
+func AddRoutes(r *mux.Router) {
+	s := r.PathPrefix("/webhooks").Subrouter()
+	s.Use(middleware.RequirePermission(types.PermissionWebhooksManage))
+
+	// The response holds the only copy of the secret, so it is not stored for idempotent retries.
+	s.HandleFunc("", middleware.WithholdIdempotentResponse(Create)).Methods(http.MethodPost)
+	s.HandleFunc("/find", Find).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Get).Methods(http.MethodGet)
+	s.HandleFunc("/{id:[0-9]+}", Update).Methods(http.MethodPut)
+	s.HandleFunc("/{id:[0-9]+}", Delete).Methods(http.MethodDelete)
+	s.HandleFunc("/{id:[0-9]+}/deliveries", FindDeliveries).Methods(http.MethodGet)
+	s.HandleFunc("/deliveries/{id:[0-9]+}/redeliver", Redeliver).Methods(http.MethodPost)
+}
@@ -0,0 +1,108 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/api/middleware"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// Update handles changing one of the user's company's webhook endpoints.
+//
+//	@Summary		Update a webhook endpoint
+//	@Description	Changes a webhook endpoint's URL, description or event types, or pauses it with active=false. Deliveries to an inactive endpoint fail without being sent. The secret stays the same.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"Webhook Endpoint ID"
+//	@Param			endpoint	body		UpdateWebhookEndpointPayload	true	"Webhook Endpoint Payload"
+//	@Param			If-Match	header		string							false	"ETag of the webhook endpoint the update is based on"
+//	@Success		200			{object}	types.WebhookEndpoint
+//	@Header			200			{string}	ETag							"Version of the updated webhook endpoint"
+//	@Failure		400			{object}	middleware.ErrorResponse	"Invalid request body, URL or event type"
+//	@Failure		401			{object}	middleware.ErrorResponse	"Unauthorized"
+//	@Failure		403			{object}	middleware.ErrorResponse	"Forbidden"
+//	@Failure		404			{object}	middleware.ErrorResponse	"Webhook endpoint not found"
+//	@Failure		412			{object}	types.WebhookEndpoint		"The webhook endpoint has been changed since; this is its current version"
+//	@Failure		500			{object}	middleware.ErrorResponse	"Internal Server Error"
+//	@Security		AppTokenAuth
+//	@Router			/webhooks/{id} [put]
+func Update(w http.ResponseWriter, r *http.Request) {
+	gr := middleware.GetRepo(r.Context())
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid webhook endpoint ID")
+		return
+	}
+
+	var payload UpdateWebhookEndpointPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := types.Validate(payload); err != nil {
+		middleware.WriteError(w, http.StatusBadRequest, middleware.FormatValidationErrors(err))
+		return
+	}
+
+	authUser, found := middleware.GetAuthUserFromContext(r.Context())
+	if !found {
+		middleware.WriteError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	endpoint, found, err := gr.Webhooks().GetEndpoint(r.Context(), authUser.CompanyID, id)
+	if err != nil {
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to get webhook endpoint")
+		return
+	}
+	if !found {
+		middleware.WriteError(w, http.StatusNotFound, "webhook endpoint not found")
+		return
+	}
+	if !middleware.IfMatch(r, endpoint.Version) {
+		middleware.WritePreconditionFailed(w, endpoint.Version, endpoint)
+		return
+	}
+
+	if payload.URL != nil {
+		endpoint.URL = *payload.URL
+	}
+	if payload.Description != nil {
+		endpoint.Description = *payload.Description
+	}
+	if payload.EventTypes != nil {
+		endpoint.EventTypes = types.WebhookEventTypes(payload.EventTypes)
+	}
+	if payload.Active != nil {
+		endpoint.Active = *payload.Active
+	}
+
+	if message := checkEndpoint(endpoint.URL, endpoint.EventTypes); message != "" {
+		middleware.WriteError(w, http.StatusBadRequest, message)
+		return
+	}
+
+	if err := gr.Webhooks().UpdateEndpoint(r.Context(), endpoint); err != nil {
+		if errors.Is(err, repos.ErrVersionConflict) {
+			// Someone else changed the endpoint between reading and writing it.
+			current, found, err := gr.Webhooks().GetEndpoint(r.Context(), authUser.CompanyID, id)
+			if err == nil && found {
+				middleware.WritePreconditionFailed(w, current.Version, current)
+				return
+			}
+		}
+		middleware.WriteError(w, http.StatusInternalServerError, "unable to update webhook endpoint")
+		return
+	}
+
+	middleware.SetETag(w, endpoint.Version)
+	middleware.WriteJSON(w, http.StatusOK, endpoint)
+}
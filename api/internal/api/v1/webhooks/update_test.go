@@ -0,0 +1,81 @@
+package webhooks_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Update and Delete Webhook Endpoint Handlers", func() {
+	var (
+		rec      *httptest.ResponseRecorder
+		endpoint *types.WebhookEndpoint
+	)
+
+	BeforeEach(func() {
+		rec = httptest.NewRecorder()
+		endpoint = &types.WebhookEndpoint{ID: 4, CompanyID: company.ID, URL: "https://erp.example.com/hooks", Secret: "whsec_secret", Active: true, Version: 3}
+	})
+
+	performUpdate := func(payload map[string]interface{}) {
+		body, err := json.Marshal(payload)
+		Expect(err).NotTo(HaveOccurred())
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodPut, "/webhooks/4", body, adminUser))
+	}
+
+	It("should pause an endpoint and change its event types", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(endpoint, true, nil)
+		mockWebhooksRepo.EXPECT().UpdateEndpoint(gomock.Any(), endpoint).Return(nil)
+
+		performUpdate(map[string]interface{}{"active": false, "event_types": []string{"user.deleted"}})
+
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(endpoint.Active).To(BeFalse())
+		Expect(endpoint.EventTypes).To(Equal(types.WebhookEventTypes{"user.deleted"}))
+		Expect(endpoint.Secret).To(Equal("whsec_secret"))
+	})
+
+	It("should reject an empty update", func() {
+		performUpdate(map[string]interface{}{})
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should reject an unknown event type", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(endpoint, true, nil)
+		performUpdate(map[string]interface{}{"event_types": []string{"user.renamed"}})
+		Expect(rec.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("should not change an endpoint that has been changed since the If-Match version", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(endpoint, true, nil)
+		req := newAuthenticatedRequest(http.MethodPut, "/webhooks/4", []byte(`{"active":false}`), adminUser)
+		req.Header.Set("If-Match", `"2"`)
+
+		router.ServeHTTP(rec, req)
+
+		Expect(rec.Code).To(Equal(http.StatusPreconditionFailed))
+		Expect(rec.Header().Get("ETag")).To(Equal(`"3"`))
+		Expect(rec.Body.String()).To(ContainSubstring(`"active":true`))
+		Expect(rec.Body.String()).NotTo(ContainSubstring("whsec_secret"))
+	})
+
+	It("should delete an endpoint of the user's company", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(endpoint, true, nil)
+		mockWebhooksRepo.EXPECT().DeleteEndpoint(gomock.Any(), company.ID, int64(4)).Return(nil)
+
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/webhooks/4", nil, adminUser))
+
+		Expect(rec.Code).To(Equal(http.StatusNoContent))
+	})
+
+	It("should return 404 when deleting an endpoint of another company", func() {
+		mockWebhooksRepo.EXPECT().GetEndpoint(gomock.Any(), company.ID, int64(4)).Return(nil, false, nil)
+		router.ServeHTTP(rec, newAuthenticatedRequest(http.MethodDelete, "/webhooks/4", nil, adminUser))
+		Expect(rec.Code).To(Equal(http.StatusNotFound))
+	})
+})
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/jackc/pgx/v5"
+)
+
+// reconnectDelay is how long the listener waits before connecting to the database again.
+const reconnectDelay = 5 * time.Second
+
+// Broker wakes up the event streams of a company when it has new events. It only says that there
+// is something new; the streams read the events themselves, so a wakeup that is missed or repeated
+// does no harm.
+type Broker struct {
+	mu   sync.Mutex
+	subs map[int64]map[chan struct{}]struct{}
+}
+
+// NewBroker creates a Broker without subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[int64]map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives a value when the company has new events, and a function
+// that ends the subscription.
+func (b *Broker) Subscribe(companyID int64) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subs[companyID] == nil {
+		b.subs[companyID] = make(map[chan struct{}]struct{})
+	}
+	b.subs[companyID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs[companyID], ch)
+		if len(b.subs[companyID]) == 0 {
+			delete(b.subs, companyID)
+		}
+	}
+}
+
+// Publish wakes up the subscribers of a company. It never blocks: subscribers that have not
+// handled their last wakeup yet are not woken up twice.
+func (b *Broker) Publish(companyID int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[companyID] {
+		wake(ch)
+	}
+}
+
+// publishAll wakes up every subscriber.
+func (b *Broker) publishAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subs := range b.subs {
+		for ch := range subs {
+			wake(ch)
+		}
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// Listen publishes the notifications on repos.EventsChannel until the context is cancelled, so
+// that streams on this instance hear about events written by any instance. It holds its own
+// database connection and reconnects when it is lost; every subscriber is woken up after a
+// reconnect, as notifications may have been missed meanwhile.
+func (b *Broker) Listen(ctx context.Context, dsn string, logger *log.Logger) {
+	for {
+		err := b.listen(ctx, dsn)
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Printf("events: listening for notifications failed, reconnecting in %s: %v", reconnectDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (b *Broker) listen(ctx context.Context, dsn string) error {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{repos.EventsChannel}.Sanitize()); err != nil {
+		return err
+	}
+	b.publishAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		companyID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+		b.Publish(companyID)
+	}
+}
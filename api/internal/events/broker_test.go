@@ -0,0 +1,50 @@
+package events_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Broker", func() {
+	var broker *events.Broker
+
+	BeforeEach(func() {
+		broker = events.NewBroker()
+	})
+
+	It("should wake up the subscribers of the company only", func() {
+		first, unsubscribeFirst := broker.Subscribe(1)
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := broker.Subscribe(1)
+		defer unsubscribeSecond()
+		other, unsubscribeOther := broker.Subscribe(2)
+		defer unsubscribeOther()
+
+		broker.Publish(1)
+
+		Expect(first).To(Receive())
+		Expect(second).To(Receive())
+		Expect(other).NotTo(Receive())
+	})
+
+	It("should wake up a subscriber once for several events it has not handled yet", func() {
+		wakeups, unsubscribe := broker.Subscribe(1)
+		defer unsubscribe()
+
+		broker.Publish(1)
+		broker.Publish(1)
+
+		Expect(wakeups).To(Receive())
+		Expect(wakeups).NotTo(Receive())
+	})
+
+	It("should stop waking up a subscriber once it unsubscribes", func() {
+		wakeups, unsubscribe := broker.Subscribe(1)
+		unsubscribe()
+
+		broker.Publish(1)
+
+		Expect(wakeups).NotTo(Receive())
+	})
+})
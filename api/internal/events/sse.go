@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// retryInterval is how long browsers wait before reconnecting a dropped stream.
+const retryInterval = 5 * time.Second
+
+// Writer sends events to a client of the event stream.
+type Writer interface {
+	// WriteEvent sends an event.
+	WriteEvent(event *types.OutboxEvent) error
+	// WriteHeartbeat sends a message without an event, which keeps idle connections open through
+	// proxies and finds clients that are gone.
+	WriteHeartbeat() error
+	// Done is closed when the client ends the stream. It is nil when only the request context tells.
+	Done() <-chan struct{}
+}
+
+// SSEWriter sends events as Server-Sent Events. Every event carries its ID, so browsers resume
+// after it with the Last-Event-ID header when they reconnect.
+type SSEWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewSSEWriter starts an event stream response. The server's write timeout no longer applies to it.
+func NewSSEWriter(w http.ResponseWriter) (*SSEWriter, error) {
+	s := &SSEWriter{w: w, rc: http.NewResponseController(w)}
+	// Recorders used in tests cannot lift the deadline, which they do not have anyway.
+	_ = s.rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryInterval.Milliseconds()); err != nil {
+		return nil, err
+	}
+	return s, s.rc.Flush()
+}
+
+// WriteEvent sends an event named after its type, with the event as JSON data.
+func (s *SSEWriter) WriteEvent(event *types.OutboxEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, data); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// WriteHeartbeat sends a comment, which clients ignore.
+func (s *SSEWriter) WriteHeartbeat() error {
+	if _, err := fmt.Fprint(s.w, ": heartbeat\n\n"); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// Done returns nil; the request context is cancelled when the client goes away.
+func (s *SSEWriter) Done() <-chan struct{} {
+	return nil
+}
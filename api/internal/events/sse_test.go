@@ -0,0 +1,27 @@
+package events_test
+
+import (
+	"net/http/httptest"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSEWriter", func() {
+	It("should write events and heartbeats in the event stream format", func() {
+		rec := httptest.NewRecorder()
+
+		stream, err := events.NewSSEWriter(rec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stream.WriteEvent(&types.OutboxEvent{ID: 3, EventType: "location.deleted", Data: types.EventData(`{"id":2}`)})).To(Succeed())
+		Expect(stream.WriteHeartbeat()).To(Succeed())
+
+		Expect(rec.Header().Get("Content-Type")).To(Equal("text/event-stream"))
+		Expect(rec.Header().Get("Cache-Control")).To(Equal("no-cache"))
+		Expect(rec.Flushed).To(BeTrue())
+		Expect(rec.Body.String()).To(MatchRegexp(
+			`^retry: 5000\n\nid: 3\nevent: location.deleted\ndata: \{"id":3,.*"data":\{"id":2\}.*\}\n\n: heartbeat\n\n$`))
+	})
+})
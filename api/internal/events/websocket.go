@@ -0,0 +1,224 @@
+package events
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+)
+
+// websocketGUID is appended to the client's key to compute the handshake's accept value (RFC 6455).
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// writeTimeout is how long a client has to take a message before the connection is dropped.
+const writeTimeout = 10 * time.Second
+
+// maxClientMessage is the largest message a client may send. The stream does not expect any, so
+// messages are read and dropped.
+const maxClientMessage = 4096
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// ErrBadHandshake is returned when a request is not a valid WebSocket handshake.
+var ErrBadHandshake = errors.New("invalid websocket handshake")
+
+// IsWebSocketRequest reports whether the client asks to upgrade the connection to a WebSocket.
+func IsWebSocketRequest(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") && headerHasToken(r.Header, "Connection", "upgrade")
+}
+
+// WebSocketWriter sends events as WebSocket text messages, one event as JSON per message. The
+// server only sends; messages from the client are dropped, apart from pings and the close
+// handshake.
+type WebSocketWriter struct {
+	conn net.Conn
+	buf  *bufio.ReadWriter
+	mu   sync.Mutex
+	done chan struct{}
+	once sync.Once
+}
+
+// UpgradeWebSocket completes the WebSocket handshake and takes over the connection. Nothing has
+// been written when it returns ErrBadHandshake, so the caller can still respond with an error.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocketWriter, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || !IsWebSocketRequest(r) || r.Header.Get("Sec-WebSocket-Version") != "13" || key == "" {
+		return nil, ErrBadHandshake
+	}
+
+	conn, buf, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	// The server's timeouts stop applying to the connection; writes get their own deadline.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	fmt.Fprintf(buf, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		base64.StdEncoding.EncodeToString(sum[:]))
+	if err := buf.Flush(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ws := &WebSocketWriter{conn: conn, buf: buf, done: make(chan struct{})}
+	go ws.readLoop()
+	return ws, nil
+}
+
+// WriteEvent sends an event as a JSON text message.
+func (ws *WebSocketWriter) WriteEvent(event *types.OutboxEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return ws.writeFrame(opText, data)
+}
+
+// WriteHeartbeat sends a ping, which clients answer on their own.
+func (ws *WebSocketWriter) WriteHeartbeat() error {
+	return ws.writeFrame(opPing, nil)
+}
+
+// Done is closed when the client closes the connection or it breaks.
+func (ws *WebSocketWriter) Done() <-chan struct{} {
+	return ws.done
+}
+
+// Close ends the stream with a normal closure and closes the connection.
+func (ws *WebSocketWriter) Close() error {
+	ws.writeFrame(opClose, []byte{0x03, 0xE8}) // 1000: normal closure
+	ws.finish()
+	return ws.conn.Close()
+}
+
+func (ws *WebSocketWriter) finish() {
+	ws.once.Do(func() { close(ws.done) })
+}
+
+// writeFrame sends one unmasked, unfragmented frame, as servers do.
+func (ws *WebSocketWriter) writeFrame(opcode byte, payload []byte) error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(n))
+	default:
+		header = append(header, 127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(n))
+	}
+
+	if err := ws.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	if _, err := ws.buf.Write(header); err != nil {
+		return err
+	}
+	if _, err := ws.buf.Write(payload); err != nil {
+		return err
+	}
+	return ws.buf.Flush()
+}
+
+// readLoop reads the client's frames until the connection is closed. Pings are answered and a
+// close frame is echoed, as the protocol requires.
+func (ws *WebSocketWriter) readLoop() {
+	defer ws.finish()
+	for {
+		opcode, payload, err := ws.readFrame()
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case opPing:
+			if ws.writeFrame(opPong, payload) != nil {
+				return
+			}
+		case opClose:
+			ws.writeFrame(opClose, payload)
+			return
+		}
+	}
+}
+
+// readFrame reads one frame from the client, whose frames are always masked.
+func (ws *WebSocketWriter) readFrame() (byte, []byte, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(ws.buf, head[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := head[0] & 0x0F
+	if head[1]&0x80 == 0 {
+		return 0, nil, errors.New("client frame is not masked")
+	}
+
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(ws.buf, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(ws.buf, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxClientMessage {
+		return 0, nil, errors.New("client frame is too large")
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(ws.buf, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(ws.buf, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
+
+// headerHasToken reports whether a comma-separated header contains the token, ignoring case.
+func headerHasToken(h http.Header, name, token string) bool {
+	for _, value := range h.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package events_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/events"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebSocketWriter", func() {
+	var (
+		server *httptest.Server
+		conn   net.Conn
+		reader *bufio.Reader
+		closed chan struct{}
+	)
+
+	BeforeEach(func() {
+		closed = make(chan struct{})
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ws, err := events.UpgradeWebSocket(w, r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer close(closed)
+			Expect(ws.WriteEvent(&types.OutboxEvent{ID: 7, EventType: "product.updated"})).To(Succeed())
+			Expect(ws.WriteHeartbeat()).To(Succeed())
+			<-ws.Done()
+		}))
+
+		var err error
+		conn, err = net.Dial("tcp", strings.TrimPrefix(server.URL, "http://"))
+		Expect(err).NotTo(HaveOccurred())
+		reader = bufio.NewReader(conn)
+	})
+
+	AfterEach(func() {
+		conn.Close()
+		server.Close()
+	})
+
+	handshake := func(key string) *http.Response {
+		_, err := io.WriteString(conn, "GET / HTTP/1.1\r\nHost: example.com\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n"+
+			"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: "+key+"\r\n\r\n")
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.ReadResponse(reader, nil)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	readFrame := func() (byte, []byte) {
+		var head [2]byte
+		_, err := io.ReadFull(reader, head[:])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(head[1]&0x80).To(BeZero(), "server frames are not masked")
+		payload := make([]byte, head[1]&0x7F)
+		_, err = io.ReadFull(reader, payload)
+		Expect(err).NotTo(HaveOccurred())
+		return head[0] & 0x0F, payload
+	}
+
+	writeClose := func() {
+		mask := []byte{1, 2, 3, 4}
+		payload := make([]byte, 2)
+		binary.BigEndian.PutUint16(payload, 1000)
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+		_, err := conn.Write(append(append([]byte{0x88, 0x80 | byte(len(payload))}, mask...), payload...))
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("should complete the handshake and send events as text messages", func() {
+		// The key and accept value are the example of RFC 6455.
+		resp := handshake("dGhlIHNhbXBsZSBub25jZQ==")
+		Expect(resp.StatusCode).To(Equal(http.StatusSwitchingProtocols))
+		Expect(resp.Header.Get("Sec-WebSocket-Accept")).To(Equal("s3pPLMBiTxaQ9kYGzzhZRbK+xOo="))
+
+		opcode, payload := readFrame()
+		Expect(opcode).To(Equal(byte(0x1)))
+		var event types.OutboxEvent
+		Expect(json.Unmarshal(payload, &event)).To(Succeed())
+		Expect(event.ID).To(Equal(int64(7)))
+		Expect(event.EventType).To(Equal(types.WebhookEventType("product.updated")))
+
+		opcode, _ = readFrame()
+		Expect(opcode).To(Equal(byte(0x9)))
+
+		writeClose()
+		opcode, _ = readFrame()
+		Expect(opcode).To(Equal(byte(0x8)))
+		Eventually(closed).Should(BeClosed())
+	})
+
+	It("should reject a request that is not a handshake", func() {
+		_, err := io.WriteString(conn, "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n")
+		Expect(err).NotTo(HaveOccurred())
+		resp, err := http.ReadResponse(reader, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	})
+})
@@ -1,7 +1,12 @@
 package jwtpkg
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,8 +14,51 @@ import (
 	"github.com/happilymarrieddad/order-management-v3/api/utils"
 )
 
-// For production, this should be loaded from a secure configuration management system or environment variables.
-var jwtSecret = []byte(utils.GetEnv("JWT_SECRET", "a-default-secret-for-dev-only"))
+// The token lifetimes below are the defaults; the server sets them with SetTTLs at startup.
+var (
+	// AccessTokenTTL is how long an access token is valid. Access tokens are short-lived and renewed
+	// with a refresh token.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is how long a refresh token is valid. Every refresh issues a new refresh token,
+	// so a session only ends after this long without being used.
+	RefreshTokenTTL = 30 * 24 * time.Hour
+	// MFAChallengeTTL is how long a user has after entering their password to enter their second
+	// factor.
+	MFAChallengeTTL = 5 * time.Minute
+	// ImpersonationTTL is how long an impersonation token is valid. Impersonation tokens cannot be
+	// refreshed, so the admin has to start impersonating again once it expires.
+	ImpersonationTTL = 15 * time.Minute
+)
+
+// TTLConfig holds the token lifetimes.
+type TTLConfig struct {
+	AccessToken   time.Duration
+	RefreshToken  time.Duration
+	MFAChallenge  time.Duration
+	Impersonation time.Duration
+}
+
+// TTLConfigFromEnv reads the token lifetimes from JWT_ACCESS_TOKEN_TTL, JWT_REFRESH_TOKEN_TTL,
+// MFA_CHALLENGE_TTL and IMPERSONATION_TTL, falling back to the defaults.
+func TTLConfigFromEnv() TTLConfig {
+	return TTLConfig{
+		AccessToken:   utils.GetEnvDuration("JWT_ACCESS_TOKEN_TTL", AccessTokenTTL),
+		RefreshToken:  utils.GetEnvDuration("JWT_REFRESH_TOKEN_TTL", RefreshTokenTTL),
+		MFAChallenge:  utils.GetEnvDuration("MFA_CHALLENGE_TTL", MFAChallengeTTL),
+		Impersonation: utils.GetEnvDuration("IMPERSONATION_TTL", ImpersonationTTL),
+	}
+}
+
+// SetTTLs sets the lifetimes of the tokens issued from now on.
+func SetTTLs(cfg TTLConfig) {
+	AccessTokenTTL = cfg.AccessToken
+	RefreshTokenTTL = cfg.RefreshToken
+	MFAChallengeTTL = cfg.MFAChallenge
+	ImpersonationTTL = cfg.Impersonation
+}
+
+// mfaChallengeAudience marks MFA challenge tokens so that they are never accepted as access tokens.
+const mfaChallengeAudience = "mfa-challenge"
 
 // CustomClaims includes custom data for the JWT, embedding standard claims.
 type CustomClaims struct {
@@ -18,44 +66,131 @@ type CustomClaims struct {
 	Email     string      `json:"email"`
 	CompanyID int64       `json:"companyId"`
 	Roles     types.Roles `json:"roles"`
+	// SessionID is the login session the token was issued for. Revoking the session revokes the token.
+	SessionID int64 `json:"sid"`
+	// ImpersonatorID is the platform admin acting as the user. It is only set on impersonation
+	// tokens, whose session is the admin's.
+	ImpersonatorID int64 `json:"impersonatorId,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a new JWT for a given user.
-func GenerateToken(user *types.User) (string, error) {
+// GenerateToken creates a new short-lived access token for a given user and session. The token has
+// a unique ID (jti) so that it can be revoked on its own.
+func GenerateToken(user *types.User, sessionID int64) (string, error) {
+	return generateAccessToken(user, sessionID, 0, AccessTokenTTL)
+}
+
+// GenerateImpersonationToken creates an access token that lets an impersonator act as a user. The
+// token belongs to the impersonator's session, so ending that session ends the impersonation too.
+func GenerateImpersonationToken(user, impersonator *types.User, sessionID int64) (string, error) {
+	return generateAccessToken(user, sessionID, impersonator.ID, ImpersonationTTL)
+}
+
+// generateAccessToken signs the claims of an access token.
+func generateAccessToken(user *types.User, sessionID, impersonatorID int64, ttl time.Duration) (string, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	// Set custom claims
 	claims := &CustomClaims{
-		UserID:    user.ID,
-		Email:     user.Email,
-		CompanyID: user.CompanyID,
-		Roles:     user.Roles,
+		UserID:         user.ID,
+		Email:          user.Email,
+		CompanyID:      user.CompanyID,
+		Roles:          user.Roles,
+		SessionID:      sessionID,
+		ImpersonatorID: impersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 72)), // Token expires in 3 days
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
 			Issuer:    "order-management-api",
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Generate encoded token and return it as a string.
-	return token.SignedString(jwtSecret)
+	// Sign the token with the active signing key and return it as a string.
+	return Keys().sign(claims)
 }
 
 // ValidateToken parses and validates a token string, returning the custom claims if valid.
 func ValidateToken(tokenString string) (*CustomClaims, error) {
 	claims := &CustomClaims{}
 
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, Keys().keyFunc, parserOptions...)
 
 	if err != nil || !token.Valid {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
+	if slices.Contains(claims.Audience, mfaChallengeAudience) {
+		return nil, fmt.Errorf("invalid token: MFA challenge tokens are not access tokens")
+	}
 
 	return claims, nil
 }
+
+// MFAChallengeClaims identify a user who entered the right password and still has to enter their
+// second factor.
+type MFAChallengeClaims struct {
+	UserID    int64 `json:"userId"`
+	CompanyID int64 `json:"companyId"`
+	jwt.RegisteredClaims
+}
+
+// GenerateMFAChallengeToken creates a short-lived token that proves the user entered the right
+// password. It is exchanged for an access token at /login/mfa.
+func GenerateMFAChallengeToken(user *types.User) (string, error) {
+	now := time.Now()
+	claims := &MFAChallengeClaims{
+		UserID:    user.ID,
+		CompanyID: user.CompanyID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(MFAChallengeTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    "order-management-api",
+		},
+	}
+
+	return Keys().sign(claims)
+}
+
+// ValidateMFAChallengeToken parses and validates an MFA challenge token, returning its claims if
+// valid.
+func ValidateMFAChallengeToken(tokenString string) (*MFAChallengeClaims, error) {
+	claims := &MFAChallengeClaims{}
+
+	token, err := jwt.ParseWithClaims(tokenString, claims, Keys().keyFunc, append([]jwt.ParserOption{jwt.WithAudience(mfaChallengeAudience)}, parserOptions...)...)
+
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid MFA challenge token: %w", err)
+	}
+
+	return claims, nil
+}
+
+// GenerateOpaqueToken creates a new random token, such as a refresh or password reset token. The
+// token is given to the client; only its hash is stored.
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	token, err = randomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken returns the hash under which an opaque token is stored.
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomString returns n random bytes encoded as URL-safe base64.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
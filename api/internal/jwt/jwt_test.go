@@ -0,0 +1,42 @@
+package jwtpkg_test
+
+import (
+	"time"
+
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Access Tokens", func() {
+	var user *types.User
+
+	BeforeEach(func() {
+		user = &types.User{ID: 5, Email: "user@example.com", CompanyID: 3}
+	})
+
+	It("should not name an impersonator on regular tokens", func() {
+		token, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims, err := jwtpkg.ValidateToken(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims.UserID).To(Equal(int64(5)))
+		Expect(claims.ImpersonatorID).To(BeZero())
+	})
+
+	It("should carry both identities on impersonation tokens", func() {
+		admin := &types.User{ID: 1, CompanyID: 1}
+		token, err := jwtpkg.GenerateImpersonationToken(user, admin, 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		claims, err := jwtpkg.ValidateToken(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims.UserID).To(Equal(int64(5)))
+		Expect(claims.CompanyID).To(Equal(int64(3)))
+		Expect(claims.ImpersonatorID).To(Equal(int64(1)))
+		Expect(claims.SessionID).To(Equal(int64(7)))
+		Expect(claims.ExpiresAt.Time).To(BeTemporally("~", time.Now().Add(jwtpkg.ImpersonationTTL), 5*time.Second))
+	})
+})
@@ -0,0 +1,365 @@
+package jwtpkg
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoSigningKey is returned by LoadKeySet when no signing key is configured.
+var ErrNoSigningKey = errors.New("no JWT signing key configured")
+
+// minRSAKeyBits is the smallest RSA key tokens are signed or verified with.
+const minRSAKeyBits = 2048
+
+// KeyConfig names the PEM files of the keys tokens are signed and verified with.
+type KeyConfig struct {
+	// SigningKeyFile is the private key new tokens are signed with: an RSA key of at least 2048
+	// bits (RS256) or a P-256 ECDSA key (ES256).
+	SigningKeyFile string
+	// VerificationKeyFiles are public or private keys whose tokens are still accepted, such as the
+	// previous signing key during a rotation.
+	VerificationKeyFiles []string
+}
+
+// KeyConfigFromEnv reads the key files from JWT_SIGNING_KEY_FILE and the comma-separated
+// JWT_VERIFICATION_KEY_FILES.
+func KeyConfigFromEnv() KeyConfig {
+	cfg := KeyConfig{SigningKeyFile: strings.TrimSpace(os.Getenv("JWT_SIGNING_KEY_FILE"))}
+	for _, file := range strings.Split(os.Getenv("JWT_VERIFICATION_KEY_FILES"), ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			cfg.VerificationKeyFiles = append(cfg.VerificationKeyFiles, file)
+		}
+	}
+	return cfg
+}
+
+// Key is a public key tokens are verified with.
+type Key struct {
+	// ID is the key's RFC 7638 thumbprint. Tokens name the key they were signed with in their kid
+	// header.
+	ID     string
+	Method jwt.SigningMethod
+	Public crypto.PublicKey
+}
+
+// KeySet holds the key new tokens are signed with and every key tokens are verified with. The
+// signing key is always one of the verification keys.
+type KeySet struct {
+	signer  crypto.PrivateKey
+	signing *Key
+	// verification is in the order the keys were added, so that the JWKS is stable.
+	verification []*Key
+}
+
+// NewKeySet creates a key set that signs with signer and also accepts tokens signed with the
+// private keys of the other public keys.
+func NewKeySet(signer crypto.Signer, others ...crypto.PublicKey) (*KeySet, error) {
+	signing, err := newKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+
+	ks := &KeySet{signer: signer, signing: signing, verification: []*Key{signing}}
+	for _, pub := range others {
+		key, err := newKey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key: %w", err)
+		}
+		if _, found := ks.Key(key.ID); !found {
+			ks.verification = append(ks.verification, key)
+		}
+	}
+	return ks, nil
+}
+
+// LoadKeySet reads the keys named by the config. It returns ErrNoSigningKey when no signing key
+// file is configured.
+func LoadKeySet(cfg KeyConfig) (*KeySet, error) {
+	if cfg.SigningKeyFile == "" {
+		return nil, ErrNoSigningKey
+	}
+
+	signingKey, err := readPEMKey(cfg.SigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := signingKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: signing key must be a private key", cfg.SigningKeyFile)
+	}
+
+	var others []crypto.PublicKey
+	for _, file := range cfg.VerificationKeyFiles {
+		key, err := readPEMKey(file)
+		if err != nil {
+			return nil, err
+		}
+		if private, ok := key.(crypto.Signer); ok {
+			key = private.Public()
+		}
+		others = append(others, key)
+	}
+
+	return NewKeySet(signer, others...)
+}
+
+// GenerateKeySet creates a key set with a new random ES256 key. Tokens signed with it stop working
+// once the process exits, so it is meant for development and tests only.
+func GenerateKeySet() (*KeySet, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return NewKeySet(key)
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (ks *KeySet) SigningKey() *Key {
+	return ks.signing
+}
+
+// Key returns the verification key with the given ID.
+func (ks *KeySet) Key(id string) (*Key, bool) {
+	for _, key := range ks.verification {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// sign signs the claims with the signing key and names it in the kid header.
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.Method, claims)
+	token.Header["kid"] = ks.signing.ID
+	return token.SignedString(ks.signer)
+}
+
+// keyFunc returns the verification key a token names in its kid header.
+func (ks *KeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, found := ks.Key(kid)
+	if !found {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Public, nil
+}
+
+// parserOptions restricts tokens to the algorithms of the supported keys.
+var parserOptions = []jwt.ParserOption{jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()})}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty" example:"EC"`
+	Kid string `json:"kid" example:"NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"ES256"`
+	// N and E are the modulus and exponent of RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv, X and Y are the curve and coordinates of ECDSA keys.
+	Crv string `json:"crv,omitempty" example:"P-256"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// Key parses the public key of a JWK, such as one published by an identity provider. Its ID is the
+// key's thumbprint, not the JWK's kid.
+func (k JWK) Key() (*Key, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return newKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())})
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q, use P-256", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil || len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("invalid P-256 coordinates")
+		}
+		// Parsing the uncompressed point checks that it is on the curve.
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return nil, fmt.Errorf("invalid P-256 point: %w", err)
+		}
+		return newKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)})
+	default:
+		return nil, fmt.Errorf("unsupported key type %q, use an RSA or EC key", k.Kty)
+	}
+}
+
+// JWKS is a JSON Web Key Set.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns every verification key, so that other services can verify tokens without sharing a
+// secret.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: make([]JWK, 0, len(ks.verification))}
+	for _, key := range ks.verification {
+		jwk := publicJWK(key.Public)
+		jwk.Kid = key.ID
+		jwk.Use = "sig"
+		jwk.Alg = key.Method.Alg()
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+var (
+	activeKeys    atomic.Pointer[KeySet]
+	generatedOnce sync.Once
+)
+
+// SetKeySet makes the server sign and verify tokens with a key set. It is called once at startup.
+func SetKeySet(ks *KeySet) {
+	activeKeys.Store(ks)
+}
+
+// Keys returns the key set tokens are signed and verified with. Tests and tools that never call
+// SetKeySet get a generated key.
+func Keys() *KeySet {
+	if ks := activeKeys.Load(); ks != nil {
+		return ks
+	}
+	generatedOnce.Do(func() {
+		ks, err := GenerateKeySet()
+		if err != nil {
+			panic(err)
+		}
+		activeKeys.CompareAndSwap(nil, ks)
+	})
+	return activeKeys.Load()
+}
+
+// newKey identifies the signing method and thumbprint of a supported public key.
+func newKey(pub crypto.PublicKey) (*Key, error) {
+	key := &Key{Public: pub}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must have at least %d bits, got %d", minRSAKeyBits, k.N.BitLen())
+		}
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ECDSA keys must use the P-256 curve, got %s", k.Curve.Params().Name)
+		}
+		key.Method = jwt.SigningMethodES256
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use an RSA or P-256 ECDSA key", pub)
+	}
+
+	// The thumbprint hashes the required members in lexicographic order (RFC 7638).
+	jwk := publicJWK(pub)
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	}
+	b, err := json.Marshal(members)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	key.ID = base64.RawURLEncoding.EncodeToString(sum[:])
+	return key, nil
+}
+
+// publicJWK returns the key type and public members of a supported public key.
+func publicJWK(pub crypto.PublicKey) JWK {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	case *ecdsa.PublicKey:
+		// The uncompressed point is 0x04 followed by the X and Y coordinates of 32 bytes each.
+		point, err := k.ECDH()
+		if err != nil {
+			return JWK{}
+		}
+		b := point.Bytes()
+		return JWK{
+			Kty: "EC",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(b[1:33]),
+			Y:   base64.RawURLEncoding.EncodeToString(b[33:]),
+		}
+	}
+	return JWK{}
+}
+
+// readPEMKey reads a private or public key from a PEM file.
+func readPEMKey(file string) (interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", file)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", file, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return key, nil
+}
@@ -0,0 +1,218 @@
+package jwtpkg_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	"github.com/golang-jwt/jwt/v5"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Key Sets", func() {
+	var (
+		dir      string
+		rsaKey   *rsa.PrivateKey
+		ecKey    *ecdsa.PrivateKey
+		user     *types.User
+		original *jwtpkg.KeySet
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+
+		var err error
+		rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+
+		user = &types.User{ID: 1, Email: "test@example.com", CompanyID: 3}
+		original = jwtpkg.Keys()
+	})
+
+	AfterEach(func() {
+		jwtpkg.SetKeySet(original)
+	})
+
+	writePEM := func(name, blockType string, der []byte) string {
+		file := filepath.Join(dir, name)
+		Expect(os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600)).To(Succeed())
+		return file
+	}
+
+	writePrivateKey := func(name string, key interface{}) string {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		return writePEM(name, "PRIVATE KEY", der)
+	}
+
+	writePublicKey := func(name string, key interface{}) string {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		return writePEM(name, "PUBLIC KEY", der)
+	}
+
+	kidOf := func(token string) string {
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+		Expect(err).NotTo(HaveOccurred())
+		return parsed.Header["kid"].(string)
+	}
+
+	It("should sign with RS256 and name the key in the kid header", func() {
+		ks, err := jwtpkg.LoadKeySet(jwtpkg.KeyConfig{SigningKeyFile: writePrivateKey("rsa.pem", rsaKey)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ks.SigningKey().Method.Alg()).To(Equal("RS256"))
+		jwtpkg.SetKeySet(ks)
+
+		token, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kidOf(token)).To(Equal(ks.SigningKey().ID))
+
+		claims, err := jwtpkg.ValidateToken(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims.SessionID).To(Equal(int64(7)))
+	})
+
+	It("should sign with ES256 from a SEC 1 key file", func() {
+		der, err := x509.MarshalECPrivateKey(ecKey)
+		Expect(err).NotTo(HaveOccurred())
+		ks, err := jwtpkg.LoadKeySet(jwtpkg.KeyConfig{SigningKeyFile: writePEM("ec.pem", "EC PRIVATE KEY", der)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ks.SigningKey().Method.Alg()).To(Equal("ES256"))
+		jwtpkg.SetKeySet(ks)
+
+		token, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = jwtpkg.ValidateToken(token)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should accept tokens of the previous key during a rotation", func() {
+		oldKeys, err := jwtpkg.NewKeySet(rsaKey)
+		Expect(err).NotTo(HaveOccurred())
+		jwtpkg.SetKeySet(oldKeys)
+		oldToken, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		newKeys, err := jwtpkg.LoadKeySet(jwtpkg.KeyConfig{
+			SigningKeyFile:       writePrivateKey("new.pem", ecKey),
+			VerificationKeyFiles: []string{writePublicKey("old.pub", &rsaKey.PublicKey)},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		jwtpkg.SetKeySet(newKeys)
+
+		_, err = jwtpkg.ValidateToken(oldToken)
+		Expect(err).NotTo(HaveOccurred())
+
+		newToken, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(kidOf(newToken)).NotTo(Equal(kidOf(oldToken)))
+
+		// Once the previous key is dropped, its tokens stop working.
+		withoutOld, err := jwtpkg.NewKeySet(ecKey)
+		Expect(err).NotTo(HaveOccurred())
+		jwtpkg.SetKeySet(withoutOld)
+		_, err = jwtpkg.ValidateToken(oldToken)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject tokens signed with an unknown key", func() {
+		other, err := jwtpkg.NewKeySet(rsaKey)
+		Expect(err).NotTo(HaveOccurred())
+		jwtpkg.SetKeySet(other)
+		token, err := jwtpkg.GenerateToken(user, 7)
+		Expect(err).NotTo(HaveOccurred())
+
+		jwtpkg.SetKeySet(original)
+		_, err = jwtpkg.ValidateToken(token)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject HS256 tokens", func() {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &jwtpkg.CustomClaims{UserID: 1, SessionID: 7}).SignedString([]byte("a-default-secret-for-dev-only"))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = jwtpkg.ValidateToken(token)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not accept an MFA challenge token as an access token", func() {
+		token, err := jwtpkg.GenerateMFAChallengeToken(user)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = jwtpkg.ValidateToken(token)
+		Expect(err).To(HaveOccurred())
+
+		claims, err := jwtpkg.ValidateMFAChallengeToken(token)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claims.UserID).To(Equal(user.ID))
+	})
+
+	It("should publish every verification key in the JWKS", func() {
+		ks, err := jwtpkg.NewKeySet(ecKey, &rsaKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		set := ks.JWKS()
+		Expect(set.Keys).To(HaveLen(2))
+		Expect(set.Keys[0].Kid).To(Equal(ks.SigningKey().ID))
+		Expect(set.Keys[0].Kty).To(Equal("EC"))
+		Expect(set.Keys[0].Alg).To(Equal("ES256"))
+		Expect(set.Keys[0].X).To(HaveLen(43))
+		Expect(set.Keys[1].Kty).To(Equal("RSA"))
+		Expect(set.Keys[1].E).To(Equal("AQAB"))
+	})
+
+	It("should parse the keys of a JWKS", func() {
+		ks, err := jwtpkg.NewKeySet(ecKey, &rsaKey.PublicKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		for i, jwk := range ks.JWKS().Keys {
+			key, err := jwk.Key()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(key.ID).To(Equal(jwk.Kid))
+			Expect(key.Method.Alg()).To(Equal(jwk.Alg))
+			if i == 0 {
+				Expect(key.Public).To(Equal(&ecKey.PublicKey))
+			} else {
+				Expect(key.Public).To(Equal(&rsaKey.PublicKey))
+			}
+		}
+
+		invalid := ks.JWKS().Keys[0]
+		invalid.Y = invalid.X
+		_, err = invalid.Key()
+		Expect(err).To(HaveOccurred())
+
+		_, err = jwtpkg.JWK{Kty: "oct"}.Key()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse to load without a signing key", func() {
+		_, err := jwtpkg.LoadKeySet(jwtpkg.KeyConfig{})
+		Expect(err).To(MatchError(jwtpkg.ErrNoSigningKey))
+	})
+
+	It("should refuse weak and unsupported keys", func() {
+		weak, err := rsa.GenerateKey(rand.Reader, 1024)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = jwtpkg.NewKeySet(weak)
+		Expect(err).To(HaveOccurred())
+
+		p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = jwtpkg.NewKeySet(p384)
+		Expect(err).To(HaveOccurred())
+
+		_, err = jwtpkg.LoadKeySet(jwtpkg.KeyConfig{SigningKeyFile: writePublicKey("pub.pem", &ecKey.PublicKey)})
+		Expect(err).To(HaveOccurred())
+	})
+})
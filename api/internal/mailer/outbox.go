@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// OutboxMailer keeps sent messages in memory instead of delivering them. It is meant for tests.
+type OutboxMailer struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+// NewOutboxMailer creates an empty OutboxMailer.
+func NewOutboxMailer() *OutboxMailer {
+	return &OutboxMailer{}
+}
+
+// Send stores a copy of the message.
+func (m *OutboxMailer) Send(_ context.Context, msg *Message) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages = append(m.messages, *msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (m *OutboxMailer) Messages() []Message {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+type fileMailer struct {
+	dir   string
+	from  string
+	mutex sync.Mutex
+	count int
+}
+
+// NewFileMailer creates a Mailer that writes each message to an .eml file in dir instead of
+// delivering it. It is meant for local development.
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create outbox directory: %w", err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+// Send writes the message to a new file named after the time it was sent.
+func (m *fileMailer) Send(_ context.Context, msg *Message) error {
+	now := time.Now()
+	data, err := msg.format(m.from, now)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	m.count++
+	name := fmt.Sprintf("%s-%04d.eml", now.Format("20060102T150405"), m.count)
+	m.mutex.Unlock()
+
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o644)
+}
@@ -0,0 +1,59 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/mailer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutboxMailer", func() {
+	It("should keep every message sent", func() {
+		outbox := mailer.NewOutboxMailer()
+		Expect(outbox.Send(context.Background(), &mailer.Message{To: "a@example.com", Subject: "One"})).To(Succeed())
+		Expect(outbox.Send(context.Background(), &mailer.Message{To: "b@example.com", Subject: "Two"})).To(Succeed())
+
+		messages := outbox.Messages()
+		Expect(messages).To(HaveLen(2))
+		Expect(messages[0].To).To(Equal("a@example.com"))
+		Expect(messages[1].Subject).To(Equal("Two"))
+	})
+})
+
+var _ = Describe("FileMailer", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	It("should write each message to its own file", func() {
+		m, err := mailer.NewFileMailer(filepath.Join(dir, "outbox"), "noreply@example.com")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(m.Send(context.Background(), &mailer.Message{To: "a@example.com", Subject: "Hello", Body: "line 1\nline 2"})).To(Succeed())
+		Expect(m.Send(context.Background(), &mailer.Message{To: "b@example.com", Subject: "Again"})).To(Succeed())
+
+		files, err := filepath.Glob(filepath.Join(dir, "outbox", "*.eml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(2))
+
+		data, err := os.ReadFile(files[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("From: noreply@example.com\r\n"))
+		Expect(string(data)).To(ContainSubstring("To: a@example.com\r\n"))
+		Expect(string(data)).To(ContainSubstring("Subject: Hello\r\n"))
+		Expect(string(data)).To(HaveSuffix("\r\n\r\nline 1\r\nline 2"))
+	})
+
+	It("should reject a header containing a line break", func() {
+		m, err := mailer.NewFileMailer(dir, "noreply@example.com")
+		Expect(err).NotTo(HaveOccurred())
+
+		err = m.Send(context.Background(), &mailer.Message{To: "a@example.com\r\nBcc: c@example.com", Subject: "Hello"})
+		Expect(err).To(MatchError(mailer.ErrInvalidHeader))
+	})
+})
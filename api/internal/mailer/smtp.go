@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPConfig holds the settings of the SMTP server mail is sent through.
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password are optional. When set, PLAIN authentication is used, which net/smtp
+	// only allows over TLS or to localhost.
+	Username string
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer creates a Mailer that sends mail through an SMTP server.
+func NewSMTPMailer(cfg SMTPConfig) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+// Send delivers the message to the SMTP server. The context is only checked before sending, as
+// net/smtp does not support cancellation.
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := msg.format(m.cfg.From, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	return smtp.SendMail(net.JoinHostPort(m.cfg.Host, m.cfg.Port), auth, m.cfg.From, []string{msg.To}, data)
+}
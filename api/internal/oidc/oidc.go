@@ -0,0 +1,247 @@
+// Package oidc signs users in with an OpenID Connect identity provider, using the authorization code
+// flow with PKCE (RFC 7636). ID tokens are verified with the keys the provider publishes.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	jwtpkg "github.com/happilymarrieddad/order-management-v3/api/internal/jwt"
+)
+
+// Scopes are the scopes every sign-in asks for. The email scope is needed to match the identity to
+// a user.
+var Scopes = []string{"openid", "email", "profile"}
+
+// httpClient talks to identity providers. Requests are bounded so that a slow provider cannot hold
+// up a login for long.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// ErrInvalidIDToken is returned when the ID token of a sign-in cannot be trusted.
+var ErrInvalidIDToken = errors.New("invalid ID token")
+
+// Provider holds the endpoints of an identity provider, as published in its discovery document.
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Discover reads the discovery document of an issuer from <issuer>/.well-known/openid-configuration.
+// The document must name the same issuer.
+func Discover(ctx context.Context, issuer string) (*Provider, error) {
+	provider := new(Provider)
+	if err := getJSON(ctx, strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", provider); err != nil {
+		return nil, fmt.Errorf("failed to discover issuer %s: %w", issuer, err)
+	}
+	if provider.Issuer != issuer {
+		return nil, fmt.Errorf("discovery document of %s names issuer %s", issuer, provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document of %s is missing endpoints", issuer)
+	}
+	return provider, nil
+}
+
+// Client signs users in with a provider as one of its registered clients.
+type Client struct {
+	Provider     *Provider
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends the user back with a code. It must be registered with
+	// the provider.
+	RedirectURL string
+}
+
+// AuthCodeURL returns the URL the user is sent to for signing in. The state comes back with the
+// code, the nonce comes back in the ID token, and the challenge binds the code to the verifier it
+// was derived from.
+func (c *Client) AuthCodeURL(state, nonce, codeChallenge string) string {
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {c.ClientID},
+		"redirect_uri":          {c.RedirectURL},
+		"scope":                 {strings.Join(Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	sep := "?"
+	if strings.Contains(c.Provider.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return c.Provider.AuthorizationEndpoint + sep + params.Encode()
+}
+
+// Identity is the user the provider signed in.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+// Exchange redeems a code for the identity of the user who signed in. The ID token must carry the
+// nonce of the sign-in.
+func (c *Client) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*Identity, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.Provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// Client credentials are form-encoded before they are put in the Basic header (RFC 6749 2.3.1).
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem code: %w", err)
+	}
+	defer res.Body.Close()
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to redeem code: %s: %s %s", res.Status, tokens.Error, tokens.ErrorDescription)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("token response has no ID token")
+	}
+
+	return c.verifyIDToken(ctx, tokens.IDToken, nonce)
+}
+
+// idTokenClaims are the claims of an ID token that identify the user.
+type idTokenClaims struct {
+	Nonce           string `json:"nonce"`
+	AuthorizedParty string `json:"azp"`
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+	GivenName       string `json:"given_name"`
+	FamilyName      string `json:"family_name"`
+	jwt.RegisteredClaims
+}
+
+// verifyIDToken checks the signature, issuer, audience, expiry and nonce of an ID token.
+func (c *Client) verifyIDToken(ctx context.Context, raw, nonce string) (*Identity, error) {
+	var set jwtpkg.JWKS
+	if err := getJSON(ctx, c.Provider.JWKSURI, &set); err != nil {
+		return nil, fmt.Errorf("failed to get keys of %s: %w", c.Provider.Issuer, err)
+	}
+
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, jwk := range set.Keys {
+			if (kid != "" && jwk.Kid != kid) || (jwk.Use != "" && jwk.Use != "sig") {
+				continue
+			}
+			key, err := jwk.Key()
+			if err != nil || key.Method.Alg() != token.Method.Alg() {
+				continue
+			}
+			return key.Public, nil
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithIssuer(c.Provider.Issuer),
+		jwt.WithAudience(c.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIDToken, err)
+	}
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	}
+	// A token for several audiences must have been issued to this client.
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != c.ClientID {
+		return nil, fmt.Errorf("%w: issued to %s", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidIDToken)
+	}
+
+	return &Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		GivenName:     claims.GivenName,
+		FamilyName:    claims.FamilyName,
+	}, nil
+}
+
+// NewCodeVerifier creates a random PKCE code verifier. It stays on the server; only its challenge
+// is sent to the provider.
+func NewCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// NewNonce creates a random nonce that ties an ID token to one sign-in.
+func NewNonce() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallenge returns the S256 challenge of a code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// getJSON fetches a JSON document from the provider.
+func getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return json.NewDecoder(io.LimitReader(res.Body, 1<<20)).Decode(v)
+}
+
+// randomString returns n random bytes encoded as URL-safe base64.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,134 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/oidc/oidctest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OIDC", func() {
+	var (
+		idp      *oidctest.Server
+		client   *oidc.Client
+		verifier string
+		nonce    string
+	)
+
+	BeforeEach(func() {
+		idp = oidctest.NewServer("client-id", "client-secret")
+		DeferCleanup(idp.Close)
+
+		provider, err := oidc.Discover(context.Background(), idp.Issuer())
+		Expect(err).NotTo(HaveOccurred())
+		client = &oidc.Client{
+			Provider:     provider,
+			ClientID:     "client-id",
+			ClientSecret: "client-secret",
+			RedirectURL:  "http://localhost:3000/sso/callback",
+		}
+
+		verifier, err = oidc.NewCodeVerifier()
+		Expect(err).NotTo(HaveOccurred())
+		nonce, err = oidc.NewNonce()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	signIn := func() string {
+		code, state, err := idp.Authorize(client.AuthCodeURL("state-1", nonce, oidc.CodeChallenge(verifier)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal("state-1"))
+		return code
+	}
+
+	It("should discover the provider's endpoints", func() {
+		Expect(client.Provider.Issuer).To(Equal(idp.Issuer()))
+		Expect(client.Provider.TokenEndpoint).To(Equal(idp.Issuer() + "/token"))
+
+		_, err := oidc.Discover(context.Background(), idp.Issuer()+"/other")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should ask for the code with a PKCE challenge", func() {
+		authURL, err := url.Parse(client.AuthCodeURL("state-1", nonce, oidc.CodeChallenge(verifier)))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(authURL.Query().Get("code_challenge_method")).To(Equal("S256"))
+		Expect(authURL.Query().Get("code_challenge")).To(Equal(oidc.CodeChallenge(verifier)))
+		Expect(authURL.Query().Get("scope")).To(Equal("openid email profile"))
+		Expect(authURL.Query().Get("code_challenge")).NotTo(Equal(verifier))
+	})
+
+	It("should redeem a code for the verified identity", func() {
+		idp.SetUser(oidctest.User{Subject: "abc", Email: "jane@example.com", EmailVerified: true, GivenName: "Jane", FamilyName: "Doe"})
+
+		identity, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*identity).To(Equal(oidc.Identity{Subject: "abc", Email: "jane@example.com", EmailVerified: true, GivenName: "Jane", FamilyName: "Doe"}))
+	})
+
+	It("should not redeem a code twice", func() {
+		code := signIn()
+		_, err := client.Exchange(context.Background(), code, verifier, nonce)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Exchange(context.Background(), code, verifier, nonce)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not redeem a code without its verifier", func() {
+		other, err := oidc.NewCodeVerifier()
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = client.Exchange(context.Background(), signIn(), other, nonce)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an ID token with another nonce", func() {
+		idp.SetClaim("nonce", "replayed")
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(errors.Is(err, oidc.ErrInvalidIDToken)).To(BeTrue())
+	})
+
+	It("should reject an ID token for another client", func() {
+		idp.SetClaim("aud", "other-client")
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(errors.Is(err, oidc.ErrInvalidIDToken)).To(BeTrue())
+	})
+
+	It("should reject an ID token for several clients that was issued to another", func() {
+		idp.SetClaim("aud", []string{"client-id", "other-client"})
+		idp.SetClaim("azp", "other-client")
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(errors.Is(err, oidc.ErrInvalidIDToken)).To(BeTrue())
+	})
+
+	It("should reject an ID token of another issuer", func() {
+		idp.SetClaim("iss", "https://evil.example.com")
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(errors.Is(err, oidc.ErrInvalidIDToken)).To(BeTrue())
+	})
+
+	It("should reject an ID token signed by another provider", func() {
+		other := oidctest.NewServer("client-id", "client-secret")
+		DeferCleanup(other.Close)
+		client.Provider.JWKSURI = other.Issuer() + "/jwks"
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(errors.Is(err, oidc.ErrInvalidIDToken)).To(BeTrue())
+	})
+
+	It("should reject wrong client credentials", func() {
+		client.ClientSecret = "wrong"
+
+		_, err := client.Exchange(context.Background(), signIn(), verifier, nonce)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -29,6 +29,7 @@ type AddressesRepo interface {
 	Update(ctx context.Context, address *types.Address) error
 	UpdateTx(ctx context.Context, tx *xorm.Session, address *types.Address) error
 	Find(ctx context.Context, opts *AddressFindOpts) ([]*types.Address, int64, error)
+	IsUsedByCompany(ctx context.Context, id, companyID int64) (bool, error)
 	GeocodeAddress(ctx context.Context, addr *types.Address) (string, error)
 }
 
@@ -54,6 +55,9 @@ func (r *addressesRepo) CreateTx(ctx context.Context, tx *xorm.Session, address
 	if _, err := tx.Context(ctx).Insert(address); err != nil {
 		return nil, err
 	}
+	if err := recordAuditTx(ctx, tx, types.AuditEntityAddress, types.AuditActionCreate, address.ID, nil, address); err != nil {
+		return nil, err
+	}
 	return address, nil
 }
 
@@ -74,8 +78,9 @@ func (r *addressesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, address
 	if err := types.Validate(address); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(address.ID).Update(address)
-	return err
+	return auditedTx[types.Address](ctx, tx, types.AuditEntityAddress, types.AuditActionUpdate, address.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(address.ID).Update(address))
+	})
 }
 
 func (r *addressesRepo) Find(ctx context.Context, opts *AddressFindOpts) ([]*types.Address, int64, error) {
@@ -87,6 +92,20 @@ func (r *addressesRepo) Find(ctx context.Context, opts *AddressFindOpts) ([]*typ
 	return addresses, count, err
 }
 
+// IsUsedByCompany reports whether the address is the company's own address or that of one of its
+// users or locations.
+func (r *addressesRepo) IsUsedByCompany(ctx context.Context, id, companyID int64) (bool, error) {
+	has, err := r.db.Context(ctx).Where("id = ? AND address_id = ?", companyID, id).Exist(new(types.Company))
+	if err != nil || has {
+		return has, err
+	}
+	has, err = r.db.Context(ctx).Where("company_id = ? AND address_id = ?", companyID, id).Exist(new(types.User))
+	if err != nil || has {
+		return has, err
+	}
+	return r.db.Context(ctx).Where("company_id = ? AND address_id = ?", companyID, id).Exist(new(types.Location))
+}
+
 func (r *addressesRepo) GeocodeAddress(ctx context.Context, addr *types.Address) (string, error) {
 	res, err := r.gclient.Geocode(ctx, &maps.GeocodingRequest{
 		Address: fmt.Sprintf("%s, %s, %s %s", addr.Line1, addr.City, addr.State, addr.PostalCode),
@@ -130,4 +130,45 @@ var _ = Describe("AddressRepo Integration", func() {
 			Expect(addrs[1].ID).To(Equal(int64(3)))
 		})
 	})
+
+	Context("IsUsedByCompany", func() {
+		var (
+			company *types.Company
+			other   *types.Company
+		)
+
+		newAddress := func() *types.Address {
+			addr, err := addressRepo.Create(ctx, &types.Address{Line1: "1 Used St", City: "Usedville", State: "US", PostalCode: "12345", Country: "USA"})
+			Expect(err).NotTo(HaveOccurred())
+			return addr
+		}
+
+		BeforeEach(func() {
+			company = &types.Company{Name: "Address Owner", AddressID: newAddress().ID}
+			Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+			other = &types.Company{Name: "Other Company", AddressID: newAddress().ID}
+			Expect(gr.Companies().Create(ctx, other)).To(Succeed())
+		})
+
+		It("should report the company's own address", func() {
+			used, err := addressRepo.IsUsedByCompany(ctx, company.AddressID, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(used).To(BeTrue())
+		})
+
+		It("should report the address of one of the company's locations", func() {
+			addr := newAddress()
+			Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: addr.ID, Name: "Dock"})).To(Succeed())
+
+			used, err := addressRepo.IsUsedByCompany(ctx, addr.ID, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(used).To(BeTrue())
+		})
+
+		It("should not report the address of another company", func() {
+			used, err := addressRepo.IsUsedByCompany(ctx, other.AddressID, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(used).To(BeFalse())
+		})
+	})
 })
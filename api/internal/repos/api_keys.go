@@ -0,0 +1,135 @@
+package repos
+
+import (
+	"context"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// apiKeyLastUsedResolution is how stale last_used_at may get before a request updates it, so that
+// a busy integration does not write on every request.
+const apiKeyLastUsedResolution = time.Minute
+
+// APIKeyFindOpts provides options for finding API keys.
+type APIKeyFindOpts struct {
+	CompanyID int64
+	Limit     int
+	Offset    int
+}
+
+// APIKeysRepo defines the interface for API key data operations.
+//
+//go:generate mockgen -source=./api_keys.go -destination=./mocks/api_keys.go -package=mock_repos APIKeysRepo
+type APIKeysRepo interface {
+	Get(ctx context.Context, companyID, id int64) (*types.APIKey, bool, error)
+	GetByHash(ctx context.Context, keyHash string) (*types.APIKey, bool, error)
+	Find(ctx context.Context, opts *APIKeyFindOpts) ([]*types.APIKey, int64, error)
+	Create(ctx context.Context, key *types.APIKey) error
+	CreateTx(ctx context.Context, tx *xorm.Session, key *types.APIKey) error
+	Revoke(ctx context.Context, companyID, id int64) error
+	RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error
+	TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+type apiKeysRepo struct {
+	db *xorm.Engine
+}
+
+// NewAPIKeysRepo creates a new APIKeysRepo.
+func NewAPIKeysRepo(db *xorm.Engine) APIKeysRepo {
+	return &apiKeysRepo{db: db}
+}
+
+// Get retrieves a company's API key by ID. Revoked keys are not returned.
+func (r *apiKeysRepo) Get(ctx context.Context, companyID, id int64) (*types.APIKey, bool, error) {
+	key := new(types.APIKey)
+	has, err := r.db.Context(ctx).Where("id = ? AND company_id = ? AND revoked_at IS NULL", id, companyID).Get(key)
+	return key, has, err
+}
+
+// GetByHash retrieves the API key with the given hash. Revoked keys are not returned; expired keys
+// are, and must be checked with IsActive.
+func (r *apiKeysRepo) GetByHash(ctx context.Context, keyHash string) (*types.APIKey, bool, error) {
+	key := new(types.APIKey)
+	has, err := r.db.Context(ctx).Where("key_hash = ? AND revoked_at IS NULL", keyHash).Get(key)
+	return key, has, err
+}
+
+// Find retrieves a list of API keys that have not been revoked, newest first, and a total count.
+func (r *apiKeysRepo) Find(ctx context.Context, opts *APIKeyFindOpts) ([]*types.APIKey, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	s.Where("revoked_at IS NULL")
+	applyAPIKeyFindOpts(s, opts)
+	var keys []*types.APIKey
+	count, err := s.OrderBy("id DESC").FindAndCount(&keys)
+	return keys, count, err
+}
+
+// Create inserts a new API key.
+func (r *apiKeysRepo) Create(ctx context.Context, key *types.APIKey) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, key)
+	})
+	return err
+}
+
+// CreateTx inserts a new API key. KeyPrefix and KeyHash must already be set.
+func (r *apiKeysRepo) CreateTx(ctx context.Context, tx *xorm.Session, key *types.APIKey) error {
+	if err := types.Validate(key); err != nil {
+		return err
+	}
+
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+	if _, err := tx.Context(ctx).Insert(key); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityAPIKey, types.AuditActionCreate, key.ID, nil, key)
+}
+
+// Revoke revokes a company's API key. It stops working immediately.
+func (r *apiKeysRepo) Revoke(ctx context.Context, companyID, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RevokeTx(ctx, tx, companyID, id)
+	})
+	return err
+}
+
+// RevokeTx revokes a company's API key within a transaction.
+func (r *apiKeysRepo) RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	now := time.Now()
+	return auditedTx[types.APIKey](ctx, tx, types.AuditEntityAPIKey, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).
+			Where("id = ? AND company_id = ? AND revoked_at IS NULL", id, companyID).
+			Cols("revoked_at").
+			Update(&types.APIKey{RevokedAt: &now})
+		return err
+	})
+}
+
+// TouchLastUsed records that the key was used. The time is only written when the stored one is
+// older than a minute.
+func (r *apiKeysRepo) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := r.db.Context(ctx).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, usedAt.Add(-apiKeyLastUsedResolution)).
+		Cols("last_used_at").
+		NoAutoTime().
+		Update(&types.APIKey{LastUsedAt: &usedAt})
+	return err
+}
+
+// applyAPIKeyFindOpts is a helper function to build the query based on find options.
+func applyAPIKeyFindOpts(s *xorm.Session, opts *APIKeyFindOpts) {
+	if opts == nil {
+		return
+	}
+	if opts.CompanyID > 0 {
+		s.And("company_id = ?", opts.CompanyID)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
@@ -0,0 +1,82 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIKeysRepo", func() {
+	var (
+		repo    repos.APIKeysRepo
+		company *types.Company
+		key     *types.APIKey
+	)
+
+	BeforeEach(func() {
+		repo = gr.APIKeys()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		key = &types.APIKey{
+			CompanyID: company.ID,
+			Name:      "ERP",
+			KeyPrefix: "omk_abcdefgh",
+			KeyHash:   "hash-1",
+			Scopes:    types.Scopes{"products:read", "locations:write"},
+		}
+		Expect(repo.Create(ctx, key)).To(Succeed())
+		Expect(key.ID).NotTo(BeZero())
+	})
+
+	It("should get a key by its hash with its scopes", func() {
+		found, has, err := repo.GetByHash(ctx, "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+		Expect(found.ID).To(Equal(key.ID))
+		Expect(found.Scopes).To(Equal(types.Scopes{"products:read", "locations:write"}))
+	})
+
+	It("should only get a key within its company", func() {
+		_, has, err := repo.Get(ctx, company.ID+1, key.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeFalse())
+
+		_, has, err = repo.Get(ctx, company.ID, key.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+	})
+
+	It("should no longer return a revoked key", func() {
+		Expect(repo.Revoke(ctx, company.ID, key.ID)).To(Succeed())
+
+		_, has, err := repo.GetByHash(ctx, "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeFalse())
+
+		keys, count, err := repo.Find(ctx, &repos.APIKeyFindOpts{CompanyID: company.ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+		Expect(keys).To(BeEmpty())
+	})
+
+	It("should record when a key was last used at most once a minute", func() {
+		usedAt := time.Now()
+		Expect(repo.TouchLastUsed(ctx, key.ID, usedAt)).To(Succeed())
+		Expect(repo.TouchLastUsed(ctx, key.ID, usedAt.Add(30*time.Second))).To(Succeed())
+
+		found, _, err := repo.GetByHash(ctx, "hash-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found.LastUsedAt).NotTo(BeNil())
+		Expect(*found.LastUsedAt).To(BeTemporally("~", usedAt, time.Second))
+	})
+})
@@ -0,0 +1,220 @@
+package repos
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// AuditActor is who the changes made with a context are recorded against in the audit log. Changes
+// made with a context without an actor are recorded without one, as changes made by the system.
+type AuditActor struct {
+	// UserID is the user making the change. While a platform admin impersonates a user, it is the
+	// admin.
+	UserID int64
+	// ImpersonatedUserID is the user the admin is impersonating.
+	ImpersonatedUserID int64
+	// APIKeyID is the API key of requests made with one. They have no user.
+	APIKeyID int64
+}
+
+type auditActorKey struct{}
+
+// WithAuditActor returns a context whose changes are recorded against the actor.
+func WithAuditActor(ctx context.Context, actor AuditActor) context.Context {
+	return context.WithValue(ctx, auditActorKey{}, actor)
+}
+
+// AuditActorFromContext returns the actor changes made with the context are recorded against.
+func AuditActorFromContext(ctx context.Context) AuditActor {
+	actor, _ := ctx.Value(auditActorKey{}).(AuditActor)
+	return actor
+}
+
+// AuditLogFindOpts provides options for finding audit log entries. Zero values match everything.
+type AuditLogFindOpts struct {
+	CompanyID  int64
+	EntityType types.AuditEntityType
+	EntityID   int64
+	ActorID    int64
+	Action     types.AuditAction
+	// From and To limit the entries to the ones created in [From, To).
+	From   time.Time
+	To     time.Time
+	Limit  int
+	Offset int
+}
+
+// AuditLogRepo defines the interface for reading the audit log. Entries are written by the repos
+// that make the changes.
+//
+//go:generate mockgen -source=./audit.go -destination=./mocks/audit.go -package=mock_repos AuditLogRepo
+type AuditLogRepo interface {
+	Find(ctx context.Context, opts *AuditLogFindOpts) ([]*types.AuditLogEntry, int64, error)
+}
+
+type auditLogRepo struct {
+	db *xorm.Engine
+}
+
+// NewAuditLogRepo creates a new AuditLogRepo.
+func NewAuditLogRepo(db *xorm.Engine) AuditLogRepo {
+	return &auditLogRepo{db: db}
+}
+
+// Find retrieves a list of audit log entries, newest first, and a total count.
+func (r *auditLogRepo) Find(ctx context.Context, opts *AuditLogFindOpts) ([]*types.AuditLogEntry, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	applyAuditLogFindOpts(s, opts)
+	var entries []*types.AuditLogEntry
+	count, err := s.OrderBy("id DESC").FindAndCount(&entries)
+	return entries, count, err
+}
+
+func applyAuditLogFindOpts(s *xorm.Session, opts *AuditLogFindOpts) {
+	if opts == nil {
+		return
+	}
+	if opts.CompanyID > 0 {
+		s.And("company_id = ?", opts.CompanyID)
+	}
+	if opts.EntityType != "" {
+		s.And("entity_type = ?", opts.EntityType)
+	}
+	if opts.EntityID > 0 {
+		s.And("entity_id = ?", opts.EntityID)
+	}
+	if opts.ActorID > 0 {
+		s.And("actor_id = ?", opts.ActorID)
+	}
+	if opts.Action != "" {
+		s.And("action = ?", opts.Action)
+	}
+	if !opts.From.IsZero() {
+		s.And("created_at >= ?", opts.From)
+	}
+	if !opts.To.IsZero() {
+		s.And("created_at < ?", opts.To)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
+
+// recordAuditTx writes an audit log entry for a change to a record, against the actor of the
+// context, and the change's event to the webhook outbox. before is nil for created records and
+// after is nil for deleted ones. Changes that did not touch any audited column are not recorded.
+func recordAuditTx(ctx context.Context, tx *xorm.Session, entityType types.AuditEntityType, action types.AuditAction, entityID int64, before, after interface{}) error {
+	changes, err := types.DiffAudit(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	actor := AuditActorFromContext(ctx)
+	companyID := auditCompanyID(after)
+	if companyID == 0 {
+		companyID = auditCompanyID(before)
+	}
+	if entityType == types.AuditEntityCompany {
+		companyID = entityID
+	}
+
+	if _, err = tx.Context(ctx).Insert(&types.AuditLogEntry{
+		CompanyID:          optionalID(companyID),
+		ActorID:            optionalID(actor.UserID),
+		ImpersonatedUserID: optionalID(actor.ImpersonatedUserID),
+		APIKeyID:           optionalID(actor.APIKeyID),
+		EntityType:         entityType,
+		EntityID:           entityID,
+		Action:             action,
+		Changes:            changes,
+	}); err != nil {
+		return err
+	}
+	return enqueueEventTx(ctx, tx, companyID, entityType, action, entityID, before, after)
+}
+
+// auditedTx makes a change to the record of type T with the given ID and records how the record
+// changed. A record that is gone after the change was deleted.
+func auditedTx[T any](ctx context.Context, tx *xorm.Session, entityType types.AuditEntityType, action types.AuditAction, id int64, change func() error) error {
+	before, err := getForAuditTx[T](ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	after, err := getForAuditTx[T](ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, entityType, action, id, before, after)
+}
+
+// auditedDeleteTx deletes the records of type T with the given IDs with del and records each
+// deletion.
+func auditedDeleteTx[T any](ctx context.Context, tx *xorm.Session, entityType types.AuditEntityType, ids []int64, del func() error) error {
+	before := make([]*T, len(ids))
+	for i, id := range ids {
+		record, err := getForAuditTx[T](ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		before[i] = record
+	}
+	if err := del(); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		if err := recordAuditTx(ctx, tx, entityType, types.AuditActionDelete, id, before[i], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getForAuditTx returns the record of type T with the given ID, visible or not, or nil when there is
+// none.
+func getForAuditTx[T any](ctx context.Context, tx *xorm.Session, id int64) (*T, error) {
+	record := new(T)
+	has, err := tx.Context(ctx).ID(id).Get(record)
+	if err != nil || !has {
+		return nil, err
+	}
+	return record, nil
+}
+
+// auditCompanyID returns the company of a record, taken from its CompanyID field. Shared records
+// have none.
+func auditCompanyID(record interface{}) int64 {
+	v := reflect.ValueOf(record)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return 0
+	}
+	field := v.Elem().FieldByName("CompanyID")
+	if !field.IsValid() || field.Kind() != reflect.Int64 {
+		return 0
+	}
+	return field.Int()
+}
+
+// isNilRecord reports whether record is nil or a nil pointer.
+func isNilRecord(record interface{}) bool {
+	v := reflect.ValueOf(record)
+	return !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil())
+}
+
+// optionalID returns nil for a zero ID.
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
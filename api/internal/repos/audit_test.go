@@ -0,0 +1,100 @@
+package repos_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditLogRepo", func() {
+	var (
+		repo     repos.AuditLogRepo
+		company  *types.Company
+		location *types.Location
+		actorCtx context.Context
+	)
+
+	BeforeEach(func() {
+		repo = gr.AuditLog()
+		actorCtx = repos.WithAuditActor(ctx, repos.AuditActor{UserID: 7})
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		location = &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"}
+		Expect(gr.Locations().Create(actorCtx, location)).To(Succeed())
+	})
+
+	findLocationEntries := func() []*types.AuditLogEntry {
+		entries, _, err := repo.Find(ctx, &repos.AuditLogFindOpts{
+			EntityType: types.AuditEntityLocation,
+			EntityID:   location.ID,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return entries
+	}
+
+	It("should record creates with the actor and company", func() {
+		entries := findLocationEntries()
+		Expect(entries).To(HaveLen(1))
+		Expect(entries[0].Action).To(Equal(types.AuditActionCreate))
+		Expect(entries[0].ActorID).To(Equal(utils.Ref(int64(7))))
+		Expect(entries[0].CompanyID).To(Equal(utils.Ref(company.ID)))
+		Expect(entries[0].Changes).To(HaveKey("name"))
+		Expect(entries[0].Changes["name"].After).To(MatchJSON(`"Dock"`))
+	})
+
+	It("should record updates with the changed columns only", func() {
+		location.Name = "Yard"
+		Expect(gr.Locations().Update(actorCtx, location)).To(Succeed())
+
+		entries := findLocationEntries()
+		Expect(entries).To(HaveLen(2))
+		Expect(entries[0].Action).To(Equal(types.AuditActionUpdate))
+		Expect(entries[0].Changes).To(Equal(types.AuditChanges{
+			"name": {Before: json.RawMessage(`"Dock"`), After: json.RawMessage(`"Yard"`)},
+		}))
+	})
+
+	It("should record soft deletes", func() {
+		Expect(gr.Locations().Delete(actorCtx, location.ID)).To(Succeed())
+
+		entries := findLocationEntries()
+		Expect(entries[0].Action).To(Equal(types.AuditActionDelete))
+		Expect(entries[0].Changes).To(HaveKey("visible"))
+	})
+
+	It("should roll the entry back with the change", func() {
+		tx := db.NewSession()
+		defer tx.Close()
+		Expect(tx.Begin()).To(Succeed())
+
+		location.Name = "Yard"
+		Expect(gr.Locations().UpdateTx(actorCtx, tx, location)).To(Succeed())
+		Expect(tx.Rollback()).To(Succeed())
+
+		Expect(findLocationEntries()).To(HaveLen(1))
+	})
+
+	It("should filter entries", func() {
+		entries, count, err := repo.Find(ctx, &repos.AuditLogFindOpts{CompanyID: company.ID, ActorID: 7})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(1)))
+		Expect(entries[0].EntityType).To(Equal(types.AuditEntityLocation))
+
+		_, count, err = repo.Find(ctx, &repos.AuditLogFindOpts{CompanyID: company.ID, From: time.Now().Add(time.Hour)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+})
@@ -46,8 +46,10 @@ func (r *commoditiesRepo) CreateTx(ctx context.Context, tx *xorm.Session, commod
 	if err := types.Validate(commodity); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).Insert(commodity)
-	return err
+	if _, err := tx.Context(ctx).Insert(commodity); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCommodity, types.AuditActionCreate, commodity.ID, nil, commodity)
 }
 
 func (r *commoditiesRepo) Get(ctx context.Context, id int64) (*types.Commodity, bool, error) {
@@ -67,7 +69,13 @@ func (r *commoditiesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, commod
 	if err := types.Validate(commodity); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(commodity.ID).Update(commodity)
+	if err := auditedTx[types.Commodity](ctx, tx, types.AuditEntityCommodity, types.AuditActionUpdate, commodity.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(commodity.ID).Update(commodity))
+	}); err != nil {
+		return err
+	}
+	// The commodity name is part of its products' search vectors.
+	_, err := tx.Context(ctx).Exec(productSearchVectorSQL+"p.commodity_id = ?", commodity.ID)
 	return err
 }
 
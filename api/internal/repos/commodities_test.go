@@ -82,11 +82,14 @@ var _ = Describe("CommodityRepo Integration", func() {
 
 	Context("Find", func() {
 		BeforeEach(func() {
+			otherType := &types.CommodityTypeRecord{Name: "grain"}
+			Expect(gr.CommodityTypes().Create(ctx, otherType)).To(Succeed())
+
 			commoditiesToCreate := []*types.Commodity{
 				{Name: "Find Apple", CommodityType: types.CommodityTypeProduce, Visible: true},
 				{Name: "Find Banana", CommodityType: types.CommodityTypeProduce, Visible: true},
-				{Name: "Find Not Visible", CommodityType: types.CommodityType(99), Visible: false},
-				{Name: "Find Carrot", CommodityType: types.CommodityType(99), Visible: true}, // Different type
+				{Name: "Find Not Visible", CommodityType: otherType.ID, Visible: false},
+				{Name: "Find Carrot", CommodityType: otherType.ID, Visible: true}, // Different type
 			}
 			for _, c := range commoditiesToCreate {
 				err := commodityRepo.Create(ctx, c)
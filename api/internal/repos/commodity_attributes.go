@@ -44,8 +44,10 @@ func (r *commodityAttributesRepo) CreateTx(ctx context.Context, tx *xorm.Session
 		return err
 	}
 	// CommodityAttribute does not have a 'Visible' field, so no soft delete logic here.
-	_, err := tx.Context(ctx).Insert(ca)
-	return err
+	if _, err := tx.Context(ctx).Insert(ca); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCommodityAttribute, types.AuditActionCreate, ca.ID, nil, ca)
 }
 
 func (r *commodityAttributesRepo) Get(ctx context.Context, id int64) (*types.CommodityAttribute, bool, error) {
@@ -65,8 +67,9 @@ func (r *commodityAttributesRepo) UpdateTx(ctx context.Context, tx *xorm.Session
 	if err := types.Validate(ca); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(ca.ID).Cols("name").Update(ca)
-	return err
+	return auditedTx[types.CommodityAttribute](ctx, tx, types.AuditEntityCommodityAttribute, types.AuditActionUpdate, ca.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(ca.ID).Cols("name").Update(ca))
+	})
 }
 
 func (r *commodityAttributesRepo) Find(ctx context.Context, opts *CommodityAttributeFindOpts) ([]*types.CommodityAttribute, int64, error) {
@@ -0,0 +1,158 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+var (
+	// ErrCommodityTypeNameExists is returned when a commodity type with the same name already exists.
+	ErrCommodityTypeNameExists = errors.New("a commodity type with this name already exists")
+)
+
+// CommodityTypeFindOpts defines the options for finding commodity types.
+type CommodityTypeFindOpts struct {
+	IDs    []types.CommodityType
+	Names  []string
+	Limit  int
+	Offset int
+}
+
+//go:generate mockgen -source=./commodity_types.go -destination=./mocks/commodity_types.go -package=mock_repos CommodityTypesRepo
+type CommodityTypesRepo interface {
+	Get(ctx context.Context, id types.CommodityType) (*types.CommodityTypeRecord, bool, error)
+	Find(ctx context.Context, opts *CommodityTypeFindOpts) ([]*types.CommodityTypeRecord, int64, error)
+	Create(ctx context.Context, commodityType *types.CommodityTypeRecord) error
+	CreateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error
+	Update(ctx context.Context, commodityType *types.CommodityTypeRecord) error
+	UpdateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error
+	Delete(ctx context.Context, id types.CommodityType) error
+	DeleteTx(ctx context.Context, tx *xorm.Session, id types.CommodityType) error
+}
+
+type commodityTypesRepo struct {
+	db *xorm.Engine
+}
+
+func NewCommodityTypesRepo(db *xorm.Engine) CommodityTypesRepo {
+	return &commodityTypesRepo{db: db}
+}
+
+// Get retrieves a visible commodity type by ID.
+func (r *commodityTypesRepo) Get(ctx context.Context, id types.CommodityType) (*types.CommodityTypeRecord, bool, error) {
+	commodityType := new(types.CommodityTypeRecord)
+	has, err := r.db.Context(ctx).Where("id = ? AND visible = ?", id, true).Get(commodityType)
+	return commodityType, has, err
+}
+
+// Find retrieves visible commodity types ordered by ID, and a total count.
+func (r *commodityTypesRepo) Find(ctx context.Context, opts *CommodityTypeFindOpts) ([]*types.CommodityTypeRecord, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	s.Where("visible = ?", true)
+	applyCommodityTypeFindOpts(s, opts)
+	var commodityTypes []*types.CommodityTypeRecord
+	count, err := s.OrderBy("id ASC").FindAndCount(&commodityTypes)
+	return commodityTypes, count, err
+}
+
+func (r *commodityTypesRepo) Create(ctx context.Context, commodityType *types.CommodityTypeRecord) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, commodityType)
+	})
+	return err
+}
+
+// CreateTx inserts a new commodity type. Names are stored trimmed and lowercased.
+func (r *commodityTypesRepo) CreateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error {
+	commodityType.Name = normalizeCommodityTypeName(commodityType.Name)
+	if err := types.Validate(commodityType); err != nil {
+		return err
+	}
+	exists, err := tx.Context(ctx).Where("name = ?", commodityType.Name).Exist(&types.CommodityTypeRecord{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCommodityTypeNameExists
+	}
+	commodityType.Visible = true
+	if _, err = tx.Context(ctx).Insert(commodityType); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCommodityType, types.AuditActionCreate, int64(commodityType.ID), nil, commodityType)
+}
+
+func (r *commodityTypesRepo) Update(ctx context.Context, commodityType *types.CommodityTypeRecord) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.UpdateTx(ctx, tx, commodityType)
+	})
+	return err
+}
+
+// UpdateTx renames a commodity type. Names are stored trimmed and lowercased.
+func (r *commodityTypesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error {
+	commodityType.Name = normalizeCommodityTypeName(commodityType.Name)
+	if err := types.Validate(commodityType); err != nil {
+		return err
+	}
+	exists, err := tx.Context(ctx).
+		Where("name = ? AND id != ?", commodityType.Name, commodityType.ID).
+		Exist(&types.CommodityTypeRecord{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCommodityTypeNameExists
+	}
+	return auditedTx[types.CommodityTypeRecord](ctx, tx, types.AuditEntityCommodityType, types.AuditActionUpdate, int64(commodityType.ID), func() error {
+		return versionChecked(tx.Context(ctx).ID(commodityType.ID).Cols("name").Update(commodityType))
+	})
+}
+
+func (r *commodityTypesRepo) Delete(ctx context.Context, id types.CommodityType) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.DeleteTx(ctx, tx, id)
+	})
+	return err
+}
+
+// DeleteTx performs a soft delete. Existing commodities and attributes keep referencing the type,
+// but it can no longer be assigned to new ones.
+func (r *commodityTypesRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id types.CommodityType) error {
+	return auditedTx[types.CommodityTypeRecord](ctx, tx, types.AuditEntityCommodityType, types.AuditActionDelete, int64(id), func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.CommodityTypeRecord{Visible: false})
+		return err
+	})
+}
+
+func normalizeCommodityTypeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func applyCommodityTypeFindOpts(s *xorm.Session, opts *CommodityTypeFindOpts) {
+	if opts == nil {
+		return
+	}
+	if len(opts.IDs) > 0 {
+		ids := make([]int, len(opts.IDs))
+		for i, id := range opts.IDs {
+			ids[i] = int(id)
+		}
+		s.In("id", ids)
+	}
+	if len(opts.Names) > 0 {
+		names := make([]string, len(opts.Names))
+		for i, name := range opts.Names {
+			names[i] = normalizeCommodityTypeName(name)
+		}
+		s.In("name", names)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
@@ -0,0 +1,106 @@
+package repos_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CommodityTypesRepo Integration", func() {
+	var commodityTypesRepo repos.CommodityTypesRepo
+
+	BeforeEach(func() {
+		commodityTypesRepo = gr.CommodityTypes()
+	})
+
+	Context("Seeded types", func() {
+		It("should return the seeded produce type", func() {
+			commodityType, found, err := commodityTypesRepo.Get(ctx, types.CommodityTypeProduce)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(commodityType.Name).To(Equal("produce"))
+		})
+
+		It("should not return the hidden unknown type", func() {
+			_, found, err := commodityTypesRepo.Get(ctx, types.CommodityTypeUnknown)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("Create and Get", func() {
+		It("should create a commodity type with a normalized name", func() {
+			commodityType := &types.CommodityTypeRecord{Name: "  Grain "}
+			Expect(commodityTypesRepo.Create(ctx, commodityType)).To(Succeed())
+			Expect(commodityType.ID).To(BeNumerically(">", types.CommodityTypeProduce))
+
+			fetched, found, err := commodityTypesRepo.Get(ctx, commodityType.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(fetched.Name).To(Equal("grain"))
+		})
+
+		It("should return ErrCommodityTypeNameExists for a duplicate name", func() {
+			err := commodityTypesRepo.Create(ctx, &types.CommodityTypeRecord{Name: "Produce"})
+			Expect(err).To(MatchError(repos.ErrCommodityTypeNameExists))
+		})
+	})
+
+	Context("Find", func() {
+		BeforeEach(func() {
+			Expect(commodityTypesRepo.Create(ctx, &types.CommodityTypeRecord{Name: "grain"})).To(Succeed())
+			Expect(commodityTypesRepo.Create(ctx, &types.CommodityTypeRecord{Name: "dairy"})).To(Succeed())
+		})
+
+		It("should find all visible commodity types ordered by ID", func() {
+			commodityTypes, count, err := commodityTypesRepo.Find(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(3)))
+			Expect(commodityTypes[0].Name).To(Equal("produce"))
+		})
+
+		It("should find commodity types by name case-insensitively", func() {
+			commodityTypes, count, err := commodityTypesRepo.Find(ctx, &repos.CommodityTypeFindOpts{Names: []string{"GRAIN"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(commodityTypes[0].Name).To(Equal("grain"))
+		})
+	})
+
+	Context("Update", func() {
+		It("should rename a commodity type", func() {
+			commodityType := &types.CommodityTypeRecord{Name: "grain"}
+			Expect(commodityTypesRepo.Create(ctx, commodityType)).To(Succeed())
+
+			commodityType.Name = "Grains"
+			Expect(commodityTypesRepo.Update(ctx, commodityType)).To(Succeed())
+
+			fetched, found, err := commodityTypesRepo.Get(ctx, commodityType.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(fetched.Name).To(Equal("grains"))
+		})
+
+		It("should reject renaming to an existing name", func() {
+			commodityType := &types.CommodityTypeRecord{Name: "grain"}
+			Expect(commodityTypesRepo.Create(ctx, commodityType)).To(Succeed())
+
+			commodityType.Name = "produce"
+			Expect(commodityTypesRepo.Update(ctx, commodityType)).To(MatchError(repos.ErrCommodityTypeNameExists))
+		})
+	})
+
+	Context("Delete", func() {
+		It("should soft delete a commodity type", func() {
+			commodityType := &types.CommodityTypeRecord{Name: "grain"}
+			Expect(commodityTypesRepo.Create(ctx, commodityType)).To(Succeed())
+
+			Expect(commodityTypesRepo.Delete(ctx, commodityType.ID)).To(Succeed())
+
+			_, found, err := commodityTypesRepo.Get(ctx, commodityType.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+	})
+})
@@ -48,8 +48,10 @@ func (r *companiesRepo) CreateTx(ctx context.Context, tx *xorm.Session, company
 		return err
 	}
 	company.Visible = true
-	_, err := tx.Context(ctx).Insert(company)
-	return err
+	if _, err := tx.Context(ctx).Insert(company); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCompany, types.AuditActionCreate, company.ID, nil, company)
 }
 
 type CompanyWithAddress struct {
@@ -95,8 +97,11 @@ func (r *companiesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, company
 	if err := types.Validate(company); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(company.ID).Update(company)
-	return err
+	// The naming template may be cleared back to the default and MFA may be turned off, so they are
+	// always written.
+	return auditedTx[types.Company](ctx, tx, types.AuditEntityCompany, types.AuditActionUpdate, company.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(company.ID).MustCols("product_name_template", "require_mfa").Update(company))
+	})
 }
 
 func (r *companiesRepo) Delete(ctx context.Context, id int64) error {
@@ -107,8 +112,10 @@ func (r *companiesRepo) Delete(ctx context.Context, id int64) error {
 }
 
 func (r *companiesRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
-	_, err := tx.Context(ctx).ID(id).Cols("visible").Update(&types.Company{Visible: false})
-	return err
+	return auditedTx[types.Company](ctx, tx, types.AuditEntityCompany, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.Company{Visible: false})
+		return err
+	})
 }
 
 func (r *companiesRepo) Find(ctx context.Context, opts *CompanyFindOpts) ([]*types.Company, int64, error) {
@@ -2,11 +2,18 @@ package repos
 
 import (
 	"context"
+	"errors"
 
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	"xorm.io/xorm"
 )
 
+var (
+	// ErrCompanyAttributeSettingExists is returned when a company already has a setting for the attribute
+	// or already uses the display order.
+	ErrCompanyAttributeSettingExists = errors.New("a setting for this attribute or display order already exists for the company")
+)
+
 // CompanyAttributeSettingFindOpts provides options for finding company attribute settings.
 type CompanyAttributeSettingFindOpts struct {
 	CompanyIDs []int64
@@ -22,6 +29,8 @@ type CompanyAttributeSettingsRepo interface {
 	UpdateTx(ctx context.Context, tx *xorm.Session, setting *types.CompanyAttributeSetting) error
 	Delete(ctx context.Context, ids ...int64) error
 	DeleteTx(ctx context.Context, tx *xorm.Session, ids ...int64) error
+	Reorder(ctx context.Context, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error)
+	ReorderTx(ctx context.Context, tx *xorm.Session, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error)
 }
 
 type companyAttributeSettingsRepo struct {
@@ -49,7 +58,7 @@ func (r *companyAttributeSettingsRepo) Find(ctx context.Context, opts *CompanyAt
 	}
 
 	var settings []*types.CompanyAttributeSetting
-	count, err := s.FindAndCount(&settings)
+	count, err := s.OrderBy("company_id ASC, display_order ASC").FindAndCount(&settings)
 	return settings, count, err
 }
 
@@ -64,8 +73,19 @@ func (r *companyAttributeSettingsRepo) CreateTx(ctx context.Context, tx *xorm.Se
 	if err := types.Validate(setting); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).Insert(setting)
-	return err
+	exists, err := tx.Context(ctx).
+		Where("company_id = ? AND (commodity_attribute_id = ? OR display_order = ?)", setting.CompanyID, setting.CommodityAttributeID, setting.DisplayOrder).
+		Exist(&types.CompanyAttributeSetting{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCompanyAttributeSettingExists
+	}
+	if _, err = tx.Context(ctx).Insert(setting); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCompanyAttributeSetting, types.AuditActionCreate, setting.ID, nil, setting)
 }
 
 func (r *companyAttributeSettingsRepo) Update(ctx context.Context, setting *types.CompanyAttributeSetting) error {
@@ -79,8 +99,18 @@ func (r *companyAttributeSettingsRepo) UpdateTx(ctx context.Context, tx *xorm.Se
 	if err := types.Validate(setting); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(setting.ID).Cols("display_order", "commodity_attribute_id").Update(setting)
-	return err
+	exists, err := tx.Context(ctx).
+		Where("company_id = ? AND id != ? AND (commodity_attribute_id = ? OR display_order = ?)", setting.CompanyID, setting.ID, setting.CommodityAttributeID, setting.DisplayOrder).
+		Exist(&types.CompanyAttributeSetting{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCompanyAttributeSettingExists
+	}
+	return auditedTx[types.CompanyAttributeSetting](ctx, tx, types.AuditEntityCompanyAttributeSetting, types.AuditActionUpdate, setting.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(setting.ID).Cols("display_order", "commodity_attribute_id").Update(setting))
+	})
 }
 
 func (r *companyAttributeSettingsRepo) Delete(ctx context.Context, ids ...int64) error {
@@ -94,6 +124,101 @@ func (r *companyAttributeSettingsRepo) DeleteTx(ctx context.Context, tx *xorm.Se
 	if len(ids) == 0 {
 		return nil
 	}
-	_, err := tx.Context(ctx).In("id", ids).Delete(&types.CompanyAttributeSetting{})
-	return err
-}
\ No newline at end of file
+	return auditedDeleteTx[types.CompanyAttributeSetting](ctx, tx, types.AuditEntityCompanyAttributeSetting, ids, func() error {
+		_, err := tx.Context(ctx).In("id", ids).Delete(&types.CompanyAttributeSetting{})
+		return err
+	})
+}
+
+// Reorder replaces a company's attribute display order with the given list of commodity attribute IDs.
+func (r *companyAttributeSettingsRepo) Reorder(ctx context.Context, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) ([]*types.CompanyAttributeSetting, error) {
+		return r.ReorderTx(ctx, tx, companyID, commodityAttributeIDs)
+	})
+}
+
+// ReorderTx rewrites every display_order for the company within a transaction. The first attribute
+// in the list gets display order 1, the second 2, and so on. Settings for attributes missing from
+// the list are removed and settings are created for attributes that did not have one.
+func (r *companyAttributeSettingsRepo) ReorderTx(ctx context.Context, tx *xorm.Session, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error) {
+	var previous []*types.CompanyAttributeSetting
+	if err := tx.Context(ctx).Where("company_id = ?", companyID).Find(&previous); err != nil {
+		return nil, err
+	}
+
+	// Postgres checks uq_cas_company_display_order row by row, so the new orders cannot be written
+	// directly over the old ones. Park every row on a unique negative value first.
+	if _, err := tx.Context(ctx).Exec("UPDATE company_attribute_settings SET display_order = -id WHERE company_id = ?", companyID); err != nil {
+		return nil, err
+	}
+
+	del := tx.Context(ctx).Where("company_id = ?", companyID)
+	if len(commodityAttributeIDs) > 0 {
+		del.NotIn("commodity_attribute_id", commodityAttributeIDs)
+	}
+	if _, err := del.Delete(&types.CompanyAttributeSetting{}); err != nil {
+		return nil, err
+	}
+
+	var existing []*types.CompanyAttributeSetting
+	if err := tx.Context(ctx).Where("company_id = ?", companyID).Find(&existing); err != nil {
+		return nil, err
+	}
+	byAttribute := make(map[int64]*types.CompanyAttributeSetting, len(existing))
+	for _, setting := range existing {
+		byAttribute[setting.CommodityAttributeID] = setting
+	}
+
+	settings := make([]*types.CompanyAttributeSetting, 0, len(commodityAttributeIDs))
+	for i, attributeID := range commodityAttributeIDs {
+		setting, ok := byAttribute[attributeID]
+		if !ok {
+			setting = &types.CompanyAttributeSetting{CompanyID: companyID, CommodityAttributeID: attributeID}
+		}
+		setting.DisplayOrder = i + 1
+
+		if ok {
+			if _, err := tx.Context(ctx).ID(setting.ID).Cols("display_order").Update(setting); err != nil {
+				return nil, err
+			}
+		} else if _, err := tx.Context(ctx).Insert(setting); err != nil {
+			return nil, err
+		}
+		settings = append(settings, setting)
+	}
+
+	if err := auditReorderTx(ctx, tx, previous, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// auditReorderTx records how a reorder changed a company's settings: each setting that was removed,
+// added or moved.
+func auditReorderTx(ctx context.Context, tx *xorm.Session, previous, settings []*types.CompanyAttributeSetting) error {
+	before := make(map[int64]*types.CompanyAttributeSetting, len(previous))
+	for _, setting := range previous {
+		before[setting.ID] = setting
+	}
+
+	for _, setting := range settings {
+		old, ok := before[setting.ID]
+		delete(before, setting.ID)
+		action := types.AuditActionUpdate
+		if !ok {
+			action = types.AuditActionCreate
+			old = nil
+		}
+		if err := recordAuditTx(ctx, tx, types.AuditEntityCompanyAttributeSetting, action, setting.ID, old, setting); err != nil {
+			return err
+		}
+	}
+	for _, setting := range previous {
+		if _, removed := before[setting.ID]; removed {
+			if err := recordAuditTx(ctx, tx, types.AuditEntityCompanyAttributeSetting, types.AuditActionDelete, setting.ID, setting, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
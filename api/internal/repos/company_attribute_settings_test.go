@@ -51,6 +51,22 @@ var _ = Describe("CompanyAttributeSettingsRepo", func() {
 			Expect(retrieved.DisplayOrder).To(Equal(1))
 			Expect(retrieved.CommodityAttributeID).To(Equal(commodityAttribute1.ID))
 		})
+
+		It("should return a conflict error for a duplicate attribute or display order", func() {
+			Expect(repo.Create(ctx, &types.CompanyAttributeSetting{
+				CompanyID: company.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 1,
+			})).To(Succeed())
+
+			err := repo.Create(ctx, &types.CompanyAttributeSetting{
+				CompanyID: company.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 2,
+			})
+			Expect(err).To(MatchError(repos.ErrCompanyAttributeSettingExists))
+
+			err = repo.Create(ctx, &types.CompanyAttributeSetting{
+				CompanyID: company.ID, CommodityAttributeID: commodityAttribute2.ID, DisplayOrder: 1,
+			})
+			Expect(err).To(MatchError(repos.ErrCompanyAttributeSettingExists))
+		})
 	})
 
 	Describe("Update", func() {
@@ -113,4 +129,74 @@ var _ = Describe("CompanyAttributeSettingsRepo", func() {
 			Expect(found).To(BeEmpty())
 		})
 	})
+
+	Describe("Reorder", func() {
+		var commodityAttribute3 *types.CommodityAttribute
+
+		BeforeEach(func() {
+			commodityAttribute3 = &types.CommodityAttribute{Name: "Grade", CommodityType: types.CommodityTypeProduce}
+			Expect(gr.CommodityAttributes().Create(ctx, commodityAttribute3)).To(Succeed())
+		})
+
+		It("should swap display orders without violating the unique constraint", func() {
+			setting1 := &types.CompanyAttributeSetting{CompanyID: company.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 1}
+			setting2 := &types.CompanyAttributeSetting{CompanyID: company.ID, CommodityAttributeID: commodityAttribute2.ID, DisplayOrder: 2}
+			Expect(repo.Create(ctx, setting1)).To(Succeed())
+			Expect(repo.Create(ctx, setting2)).To(Succeed())
+
+			settings, err := repo.Reorder(ctx, company.ID, []int64{commodityAttribute2.ID, commodityAttribute1.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(settings).To(HaveLen(2))
+			Expect(settings[0].ID).To(Equal(setting2.ID))
+			Expect(settings[0].DisplayOrder).To(Equal(1))
+			Expect(settings[1].ID).To(Equal(setting1.ID))
+			Expect(settings[1].DisplayOrder).To(Equal(2))
+		})
+
+		It("should create missing settings and remove unlisted ones", func() {
+			setting1 := &types.CompanyAttributeSetting{CompanyID: company.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 1}
+			setting2 := &types.CompanyAttributeSetting{CompanyID: company.ID, CommodityAttributeID: commodityAttribute2.ID, DisplayOrder: 2}
+			Expect(repo.Create(ctx, setting1)).To(Succeed())
+			Expect(repo.Create(ctx, setting2)).To(Succeed())
+
+			settings, err := repo.Reorder(ctx, company.ID, []int64{commodityAttribute3.ID, commodityAttribute2.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(settings).To(HaveLen(2))
+			Expect(settings[0].CommodityAttributeID).To(Equal(commodityAttribute3.ID))
+			Expect(settings[0].DisplayOrder).To(Equal(1))
+			Expect(settings[1].ID).To(Equal(setting2.ID))
+			Expect(settings[1].DisplayOrder).To(Equal(2))
+
+			_, found, err := repo.Get(ctx, setting1.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("should not touch other companies' settings", func() {
+			otherCompany := &types.Company{Name: "Other Co", AddressID: company.AddressID}
+			Expect(gr.Companies().Create(ctx, otherCompany)).To(Succeed())
+			other := &types.CompanyAttributeSetting{CompanyID: otherCompany.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 1}
+			Expect(repo.Create(ctx, other)).To(Succeed())
+
+			_, err := repo.Reorder(ctx, company.ID, []int64{commodityAttribute2.ID})
+			Expect(err).NotTo(HaveOccurred())
+
+			retrieved, found, err := repo.Get(ctx, other.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.DisplayOrder).To(Equal(1))
+		})
+
+		It("should remove every setting when given an empty list", func() {
+			Expect(repo.Create(ctx, &types.CompanyAttributeSetting{CompanyID: company.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 1})).To(Succeed())
+
+			settings, err := repo.Reorder(ctx, company.ID, []int64{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(settings).To(BeEmpty())
+
+			_, count, err := repo.Find(ctx, &repos.CompanyAttributeSettingFindOpts{CompanyIDs: []int64{company.ID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+	})
 })
@@ -0,0 +1,251 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+var (
+	// ErrCompanyRoleNameExists is returned when the company already has a role with the same name.
+	ErrCompanyRoleNameExists = errors.New("a role with this name already exists")
+	// ErrCompanyRoleNotFound is returned when a role to assign does not exist in the user's company.
+	ErrCompanyRoleNotFound = errors.New("role not found")
+)
+
+// CompanyRoleFindOpts provides options for finding company roles.
+type CompanyRoleFindOpts struct {
+	IDs       []int64
+	CompanyID int64
+	Limit     int
+	Offset    int
+}
+
+// CompanyRolesRepo defines the interface for company roles and their assignment to users.
+//
+//go:generate mockgen -source=./company_roles.go -destination=./mocks/company_roles.go -package=mock_repos CompanyRolesRepo
+type CompanyRolesRepo interface {
+	Get(ctx context.Context, companyID, id int64) (*types.CompanyRole, bool, error)
+	Find(ctx context.Context, opts *CompanyRoleFindOpts) ([]*types.CompanyRole, int64, error)
+	Create(ctx context.Context, role *types.CompanyRole) error
+	CreateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error
+	Update(ctx context.Context, role *types.CompanyRole) error
+	UpdateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error
+	Delete(ctx context.Context, companyID, id int64) error
+	DeleteTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error
+	FindForUser(ctx context.Context, userID int64) ([]*types.CompanyRole, error)
+	SetUserRoles(ctx context.Context, companyID, userID int64, roleIDs []int64) error
+	SetUserRolesTx(ctx context.Context, tx *xorm.Session, companyID, userID int64, roleIDs []int64) error
+	GetUserPermissions(ctx context.Context, userID int64) (types.Permissions, error)
+}
+
+type companyRolesRepo struct {
+	db *xorm.Engine
+}
+
+// NewCompanyRolesRepo creates a new CompanyRolesRepo.
+func NewCompanyRolesRepo(db *xorm.Engine) CompanyRolesRepo {
+	return &companyRolesRepo{db: db}
+}
+
+// Get retrieves a company's role by ID.
+func (r *companyRolesRepo) Get(ctx context.Context, companyID, id int64) (*types.CompanyRole, bool, error) {
+	role := new(types.CompanyRole)
+	has, err := r.db.Context(ctx).Where("id = ? AND company_id = ?", id, companyID).Get(role)
+	return role, has, err
+}
+
+// Find retrieves a list of company roles ordered by name, and a total count.
+func (r *companyRolesRepo) Find(ctx context.Context, opts *CompanyRoleFindOpts) ([]*types.CompanyRole, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	applyCompanyRoleFindOpts(s, opts)
+	var roles []*types.CompanyRole
+	count, err := s.OrderBy("name ASC").FindAndCount(&roles)
+	return roles, count, err
+}
+
+// Create inserts a new company role.
+func (r *companyRolesRepo) Create(ctx context.Context, role *types.CompanyRole) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, role)
+	})
+	return err
+}
+
+// CreateTx inserts a new company role. Role names are unique within a company.
+func (r *companyRolesRepo) CreateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error {
+	if err := types.Validate(role); err != nil {
+		return err
+	}
+	exists, err := tx.Context(ctx).
+		Where("company_id = ? AND name = ?", role.CompanyID, role.Name).
+		Exist(&types.CompanyRole{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCompanyRoleNameExists
+	}
+	if _, err = tx.Context(ctx).Insert(role); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityCompanyRole, types.AuditActionCreate, role.ID, nil, role)
+}
+
+// Update changes a company role's name, description and permissions.
+func (r *companyRolesRepo) Update(ctx context.Context, role *types.CompanyRole) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.UpdateTx(ctx, tx, role)
+	})
+	return err
+}
+
+// UpdateTx changes a company role's name, description and permissions. The change applies to
+// every user with the role from their next request.
+func (r *companyRolesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error {
+	if err := types.Validate(role); err != nil {
+		return err
+	}
+	exists, err := tx.Context(ctx).
+		Where("company_id = ? AND name = ? AND id <> ?", role.CompanyID, role.Name, role.ID).
+		Exist(&types.CompanyRole{})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrCompanyRoleNameExists
+	}
+	return auditedTx[types.CompanyRole](ctx, tx, types.AuditEntityCompanyRole, types.AuditActionUpdate, role.ID, func() error {
+		return versionChecked(tx.Context(ctx).
+			Where("id = ? AND company_id = ?", role.ID, role.CompanyID).
+			Cols("name", "description", "permissions").
+			Update(role))
+	})
+}
+
+// Delete removes a company role.
+func (r *companyRolesRepo) Delete(ctx context.Context, companyID, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.DeleteTx(ctx, tx, companyID, id)
+	})
+	return err
+}
+
+// DeleteTx removes a company role. Its assignments to users are removed with it.
+func (r *companyRolesRepo) DeleteTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	return auditedTx[types.CompanyRole](ctx, tx, types.AuditEntityCompanyRole, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).Where("id = ? AND company_id = ?", id, companyID).Delete(&types.CompanyRole{})
+		return err
+	})
+}
+
+// FindForUser retrieves the roles assigned to a user, ordered by name. Roles of a company the user
+// no longer belongs to are left out.
+func (r *companyRolesRepo) FindForUser(ctx context.Context, userID int64) ([]*types.CompanyRole, error) {
+	var roles []*types.CompanyRole
+	err := r.db.Context(ctx).
+		Select("company_roles.*").
+		Join("INNER", "user_company_roles", "user_company_roles.company_role_id = company_roles.id").
+		Join("INNER", "users", "users.id = user_company_roles.user_id AND users.company_id = company_roles.company_id").
+		Where("user_company_roles.user_id = ?", userID).
+		OrderBy("company_roles.name ASC").
+		Find(&roles)
+	return roles, err
+}
+
+// SetUserRoles replaces the roles assigned to a user.
+func (r *companyRolesRepo) SetUserRoles(ctx context.Context, companyID, userID int64, roleIDs []int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.SetUserRolesTx(ctx, tx, companyID, userID, roleIDs)
+	})
+	return err
+}
+
+// SetUserRolesTx replaces the roles assigned to a user. Every role must belong to companyID,
+// otherwise ErrCompanyRoleNotFound is returned and nothing changes.
+func (r *companyRolesRepo) SetUserRolesTx(ctx context.Context, tx *xorm.Session, companyID, userID int64, roleIDs []int64) error {
+	ids := uniqueInt64s(roleIDs)
+	if len(ids) > 0 {
+		count, err := tx.Context(ctx).Where("company_id = ?", companyID).In("id", ids).Count(&types.CompanyRole{})
+		if err != nil {
+			return fmt.Errorf("failed to check roles: %w", err)
+		}
+		if count != int64(len(ids)) {
+			return ErrCompanyRoleNotFound
+		}
+	}
+
+	var previous []*types.UserCompanyRole
+	if err := tx.Context(ctx).Where("user_id = ?", userID).OrderBy("company_role_id ASC").Find(&previous); err != nil {
+		return fmt.Errorf("failed to get roles of user %d: %w", userID, err)
+	}
+	if _, err := tx.Context(ctx).Where("user_id = ?", userID).Delete(&types.UserCompanyRole{}); err != nil {
+		return fmt.Errorf("failed to remove roles of user %d: %w", userID, err)
+	}
+	for _, id := range ids {
+		if _, err := tx.Context(ctx).Insert(&types.UserCompanyRole{UserID: userID, CompanyRoleID: id}); err != nil {
+			return fmt.Errorf("failed to assign role %d to user %d: %w", id, userID, err)
+		}
+	}
+
+	before := &userRolesAudit{CompanyID: companyID, RoleIDs: types.IDs{}}
+	for _, assignment := range previous {
+		before.RoleIDs = append(before.RoleIDs, assignment.CompanyRoleID)
+	}
+	after := &userRolesAudit{CompanyID: companyID, RoleIDs: append(types.IDs{}, ids...)}
+	sort.Slice(after.RoleIDs, func(i, j int) bool { return after.RoleIDs[i] < after.RoleIDs[j] })
+	return recordAuditTx(ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, userID, before, after)
+}
+
+// userRolesAudit is how the audit log shows the roles assigned to a user: as a change of the user.
+type userRolesAudit struct {
+	CompanyID int64     `xorm:"-"`
+	RoleIDs   types.IDs `xorm:"'role_ids'"`
+}
+
+// GetUserPermissions returns the permissions granted to a user by their company roles.
+func (r *companyRolesRepo) GetUserPermissions(ctx context.Context, userID int64) (types.Permissions, error) {
+	roles, err := r.FindForUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	perms := types.Permissions{}
+	for _, role := range roles {
+		perms = perms.Merge(role.Permissions)
+	}
+	return perms, nil
+}
+
+// applyCompanyRoleFindOpts is a helper function to build the query based on find options.
+func applyCompanyRoleFindOpts(s *xorm.Session, opts *CompanyRoleFindOpts) {
+	if opts == nil {
+		return
+	}
+	if len(opts.IDs) > 0 {
+		s.In("id", opts.IDs)
+	}
+	if opts.CompanyID > 0 {
+		s.And("company_id = ?", opts.CompanyID)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
+
+// uniqueInt64s returns ids without duplicates, keeping their order.
+func uniqueInt64s(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,108 @@
+package repos_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CompanyRolesRepo", func() {
+	var (
+		repo    repos.CompanyRolesRepo
+		company *types.Company
+		other   *types.Company
+		user    *types.User
+		role    *types.CompanyRole
+	)
+
+	BeforeEach(func() {
+		repo = gr.CompanyRoles()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+		other = &types.Company{Name: "Other Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, other)).To(Succeed())
+
+		user = &types.User{
+			CompanyID: company.ID,
+			AddressID: address.ID,
+			Email:     "role.user@example.com",
+			Password:  "password123",
+			FirstName: "Role",
+			LastName:  "User",
+			Roles:     types.Roles{types.RoleUser},
+		}
+		Expect(gr.Users().Create(ctx, user)).To(Succeed())
+
+		role = &types.CompanyRole{
+			CompanyID:   company.ID,
+			Name:        "Warehouse",
+			Permissions: types.Permissions{types.PermissionLocationsWrite, types.PermissionLocationsDelete},
+		}
+		Expect(repo.Create(ctx, role)).To(Succeed())
+		Expect(role.ID).NotTo(BeZero())
+	})
+
+	It("should reject a duplicate name within a company but allow it in another", func() {
+		Expect(repo.Create(ctx, &types.CompanyRole{
+			CompanyID: company.ID, Name: "Warehouse", Permissions: types.Permissions{types.PermissionOrdersRead},
+		})).To(MatchError(repos.ErrCompanyRoleNameExists))
+
+		Expect(repo.Create(ctx, &types.CompanyRole{
+			CompanyID: other.ID, Name: "Warehouse", Permissions: types.Permissions{types.PermissionOrdersRead},
+		})).To(Succeed())
+	})
+
+	It("should update a role's permissions", func() {
+		role.Permissions = types.Permissions{types.PermissionUsersManage}
+		Expect(repo.Update(ctx, role)).To(Succeed())
+
+		found, has, err := repo.Get(ctx, company.ID, role.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+		Expect(found.Permissions).To(Equal(types.Permissions{types.PermissionUsersManage}))
+	})
+
+	It("should grant the permissions of assigned roles", func() {
+		sales := &types.CompanyRole{CompanyID: company.ID, Name: "Sales", Permissions: types.Permissions{types.PermissionOrdersRead, types.PermissionLocationsWrite}}
+		Expect(repo.Create(ctx, sales)).To(Succeed())
+		Expect(repo.SetUserRoles(ctx, company.ID, user.ID, []int64{role.ID, sales.ID})).To(Succeed())
+
+		perms, err := repo.GetUserPermissions(ctx, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(perms).To(ConsistOf(types.PermissionLocationsWrite, types.PermissionLocationsDelete, types.PermissionOrdersRead))
+
+		Expect(repo.SetUserRoles(ctx, company.ID, user.ID, []int64{sales.ID})).To(Succeed())
+		roles, err := repo.FindForUser(ctx, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(HaveLen(1))
+		Expect(roles[0].ID).To(Equal(sales.ID))
+	})
+
+	It("should not assign roles of another company", func() {
+		foreign := &types.CompanyRole{CompanyID: other.ID, Name: "Foreign", Permissions: types.Permissions{types.PermissionUsersManage}}
+		Expect(repo.Create(ctx, foreign)).To(Succeed())
+
+		err := repo.SetUserRoles(ctx, company.ID, user.ID, []int64{role.ID, foreign.ID})
+		Expect(err).To(MatchError(repos.ErrCompanyRoleNotFound))
+
+		roles, err := repo.FindForUser(ctx, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(roles).To(BeEmpty())
+	})
+
+	It("should remove assignments when a role is deleted", func() {
+		Expect(repo.SetUserRoles(ctx, company.ID, user.ID, []int64{role.ID})).To(Succeed())
+		Expect(repo.Delete(ctx, company.ID, role.ID)).To(Succeed())
+
+		perms, err := repo.GetUserPermissions(ctx, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(perms).To(BeEmpty())
+	})
+})
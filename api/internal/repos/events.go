@@ -0,0 +1,119 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// EventsChannel is the Postgres notification channel new events are announced on, with the ID of
+// their company as the payload. Notifications are sent when the change's transaction commits, so
+// every API instance hears about every committed event.
+const EventsChannel = "outbox_events"
+
+// EventPosition is a place in a company's event stream. Event IDs are handed out when an event is
+// written, not when its transaction commits, so an event with a lower ID can commit after one with
+// a higher ID. The stream is therefore ordered by the transaction that wrote each event and then by
+// ID, and only holds events whose transaction is older than every transaction still running: no
+// event can commit behind a position once it was reached.
+type EventPosition struct {
+	TxID int64
+	ID   int64
+}
+
+// PositionOf returns the position right after an event.
+func PositionOf(event *types.OutboxEvent) EventPosition {
+	return EventPosition{TxID: event.TxID, ID: event.ID}
+}
+
+// EventsRepo defines the interface for reading the events of a company's changes, for the event
+// stream. Events are written by the repos that make the changes.
+//
+//go:generate mockgen -source=./events.go -destination=./mocks/events.go -package=mock_repos EventsRepo
+type EventsRepo interface {
+	FindAfter(ctx context.Context, companyID int64, after EventPosition, limit int) ([]*types.OutboxEvent, error)
+	Position(ctx context.Context, companyID, eventID int64) (EventPosition, error)
+	Head(ctx context.Context) (EventPosition, error)
+}
+
+type eventsRepo struct {
+	db *xorm.Engine
+}
+
+// NewEventsRepo creates a new EventsRepo.
+func NewEventsRepo(db *xorm.Engine) EventsRepo {
+	return &eventsRepo{db: db}
+}
+
+// FindAfter retrieves up to limit of a company's events after a position, in stream order. Events
+// of transactions that may still be followed by an earlier one are held back until they cannot.
+func (r *eventsRepo) FindAfter(ctx context.Context, companyID int64, after EventPosition, limit int) ([]*types.OutboxEvent, error) {
+	var events []*types.OutboxEvent
+	err := r.db.Context(ctx).
+		Where("company_id = ? AND (txid, id) > (?, ?)", companyID, after.TxID, after.ID).
+		And("txid < pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		OrderBy("txid ASC, id ASC").
+		Limit(limit).
+		Find(&events)
+	return events, err
+}
+
+// Position returns the position right after a company's event with the given ID, so that a client
+// that has it resumes with the events it has not seen. IDs that are not the company's resume after
+// the company's latest event before them, and 0 starts at the beginning.
+func (r *eventsRepo) Position(ctx context.Context, companyID, eventID int64) (EventPosition, error) {
+	var event types.OutboxEvent
+	has, err := r.db.Context(ctx).
+		Where("company_id = ? AND id <= ?", companyID, eventID).
+		OrderBy("id DESC").
+		Cols("id", "txid").
+		Get(&event)
+	if err != nil || !has {
+		return EventPosition{}, err
+	}
+	return PositionOf(&event), nil
+}
+
+// Head returns the position of a client that only wants new events: before every transaction that
+// is still running. Events of transactions that committed just before may be sent too.
+func (r *eventsRepo) Head(ctx context.Context) (EventPosition, error) {
+	var xmin int64
+	if _, err := r.db.Context(ctx).SQL("SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").Get(&xmin); err != nil {
+		return EventPosition{}, err
+	}
+	return EventPosition{TxID: xmin}, nil
+}
+
+// enqueueEventTx writes the event of a change to the outbox, for the changes that are sent to
+// webhook endpoints and the event stream, and announces it on EventsChannel. before is nil for
+// created records and after is nil for deleted ones.
+func enqueueEventTx(ctx context.Context, tx *xorm.Session, companyID int64, entityType types.AuditEntityType, action types.AuditAction, entityID int64, before, after interface{}) error {
+	eventType, ok := types.WebhookEventTypeFor(entityType, action)
+	if !ok || companyID == 0 {
+		return nil
+	}
+
+	record := after
+	if isNilRecord(record) {
+		record = before
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Context(ctx).Insert(&types.OutboxEvent{
+		CompanyID:  companyID,
+		EventType:  eventType,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Data:       types.EventData(data),
+	}); err != nil {
+		return err
+	}
+	_, err = tx.Context(ctx).Exec("SELECT pg_notify(?, ?)", EventsChannel, strconv.FormatInt(companyID, 10))
+	return err
+}
@@ -0,0 +1,87 @@
+package repos_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventsRepo", func() {
+	var (
+		repo    repos.EventsRepo
+		company *types.Company
+		other   *types.Company
+		address *types.Address
+	)
+
+	BeforeEach(func() {
+		repo = gr.Events()
+
+		var err error
+		address, err = gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+		other = &types.Company{Name: "Other Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, other)).To(Succeed())
+	})
+
+	It("should find a company's events after a position, in stream order", func() {
+		for _, name := range []string{"Dock", "Yard", "Shed"} {
+			Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: name})).To(Succeed())
+		}
+		Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: other.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+
+		events, err := repo.FindAfter(ctx, company.ID, repos.EventPosition{}, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(4)) // The company was created too.
+		Expect(events[0].EventType).To(Equal(types.WebhookEventType("company.created")))
+		for _, event := range events {
+			Expect(event.CompanyID).To(Equal(company.ID))
+			Expect(event.TxID).NotTo(BeZero())
+		}
+
+		after, err := repo.FindAfter(ctx, company.ID, repos.PositionOf(events[1]), 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after).To(HaveLen(1))
+		Expect(after[0].ID).To(Equal(events[2].ID))
+
+		position, err := repo.Position(ctx, company.ID, events[1].ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(position).To(Equal(repos.PositionOf(events[1])))
+	})
+
+	It("should hold back events until every older transaction has ended", func() {
+		head, err := repo.Head(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The first transaction writes its event, with the lower ID, but commits last.
+		tx := db.NewSession()
+		defer tx.Close()
+		Expect(tx.Begin()).To(Succeed())
+		Expect(gr.Locations().CreateTx(ctx, tx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+
+		Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Yard"})).To(Succeed())
+
+		events, err := repo.FindAfter(ctx, company.ID, head, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(BeEmpty())
+
+		Expect(tx.Commit()).To(Succeed())
+
+		events, err = repo.FindAfter(ctx, company.ID, head, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].ID).To(BeNumerically("<", events[1].ID))
+	})
+
+	It("should start a company without events at the beginning", func() {
+		position, err := repo.Position(ctx, company.ID+100, 50)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(position).To(BeZero())
+	})
+})
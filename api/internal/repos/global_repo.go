@@ -14,9 +14,23 @@ type GlobalRepo interface {
 	Locations() LocationsRepo
 	CommodityAttributes() CommodityAttributesRepo
 	Commodities() CommoditiesRepo
+	CommodityTypes() CommodityTypesRepo
 	Products() ProductsRepo
 	ProductAttributeValues() ProductAttributeValuesRepo
 	CompanyAttributeSettings() CompanyAttributeSettingsRepo
+	ProductPacks() ProductPacksRepo
+	Sessions() SessionsRepo
+	PasswordResetTokens() PasswordResetTokensRepo
+	APIKeys() APIKeysRepo
+	CompanyRoles() CompanyRolesRepo
+	LoginThrottles() LoginThrottlesRepo
+	MFA() MFARepo
+	Invitations() InvitationsRepo
+	SSO() SSORepo
+	AuditLog() AuditLogRepo
+	Webhooks() WebhooksRepo
+	Events() EventsRepo
+	IdempotencyKeys() IdempotencyKeysRepo
 }
 
 func NewGlobalRepo(db *xorm.Engine, gclient GoogleAPIClient) GlobalRepo {
@@ -88,6 +102,10 @@ func (gr *globalRepo) Commodities() CommoditiesRepo {
 	return gr.factory("Commodities", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewCommoditiesRepo(db) }).(CommoditiesRepo)
 }
 
+func (gr *globalRepo) CommodityTypes() CommodityTypesRepo {
+	return gr.factory("CommodityTypes", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewCommodityTypesRepo(db) }).(CommodityTypesRepo)
+}
+
 func (gr *globalRepo) Products() ProductsRepo {
 	return gr.factory("Products", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewProductsRepo(db) }).(ProductsRepo)
 }
@@ -99,3 +117,55 @@ func (gr *globalRepo) ProductAttributeValues() ProductAttributeValuesRepo {
 func (gr *globalRepo) CompanyAttributeSettings() CompanyAttributeSettingsRepo {
 	return gr.factory("CompanyAttributeSettings", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewCompanyAttributeSettingsRepo(db) }).(CompanyAttributeSettingsRepo)
 }
+
+func (gr *globalRepo) ProductPacks() ProductPacksRepo {
+	return gr.factory("ProductPacks", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewProductPacksRepo(db) }).(ProductPacksRepo)
+}
+
+func (gr *globalRepo) Sessions() SessionsRepo {
+	return gr.factory("Sessions", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewSessionsRepo(db) }).(SessionsRepo)
+}
+
+func (gr *globalRepo) PasswordResetTokens() PasswordResetTokensRepo {
+	return gr.factory("PasswordResetTokens", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewPasswordResetTokensRepo(db) }).(PasswordResetTokensRepo)
+}
+
+func (gr *globalRepo) APIKeys() APIKeysRepo {
+	return gr.factory("APIKeys", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewAPIKeysRepo(db) }).(APIKeysRepo)
+}
+
+func (gr *globalRepo) CompanyRoles() CompanyRolesRepo {
+	return gr.factory("CompanyRoles", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewCompanyRolesRepo(db) }).(CompanyRolesRepo)
+}
+
+func (gr *globalRepo) LoginThrottles() LoginThrottlesRepo {
+	return gr.factory("LoginThrottles", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewLoginThrottlesRepo(db) }).(LoginThrottlesRepo)
+}
+
+func (gr *globalRepo) MFA() MFARepo {
+	return gr.factory("MFA", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewMFARepo(db) }).(MFARepo)
+}
+
+func (gr *globalRepo) Invitations() InvitationsRepo {
+	return gr.factory("Invitations", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewInvitationsRepo(db) }).(InvitationsRepo)
+}
+
+func (gr *globalRepo) SSO() SSORepo {
+	return gr.factory("SSO", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewSSORepo(db) }).(SSORepo)
+}
+
+func (gr *globalRepo) AuditLog() AuditLogRepo {
+	return gr.factory("AuditLog", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewAuditLogRepo(db) }).(AuditLogRepo)
+}
+
+func (gr *globalRepo) Webhooks() WebhooksRepo {
+	return gr.factory("Webhooks", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewWebhooksRepo(db) }).(WebhooksRepo)
+}
+
+func (gr *globalRepo) Events() EventsRepo {
+	return gr.factory("Events", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewEventsRepo(db) }).(EventsRepo)
+}
+
+func (gr *globalRepo) IdempotencyKeys() IdempotencyKeysRepo {
+	return gr.factory("IdempotencyKeys", func(db *xorm.Engine, _ GoogleAPIClient) interface{} { return NewIdempotencyKeysRepo(db) }).(IdempotencyKeysRepo)
+}
@@ -0,0 +1,81 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// IdempotencyKeysRepo defines the interface for remembering requests sent with an Idempotency-Key
+// header and their responses.
+//
+//go:generate mockgen -source=./idempotency_keys.go -destination=./mocks/idempotency_keys.go -package=mock_repos IdempotencyKeysRepo
+type IdempotencyKeysRepo interface {
+	Reserve(ctx context.Context, owner, key, requestHash string) (*types.IdempotencyKey, bool, error)
+	Complete(ctx context.Context, owner, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, owner, key string) error
+}
+
+type idempotencyKeysRepo struct {
+	db *xorm.Engine
+}
+
+// NewIdempotencyKeysRepo creates a new IdempotencyKeysRepo.
+func NewIdempotencyKeysRepo(db *xorm.Engine) IdempotencyKeysRepo {
+	return &idempotencyKeysRepo{db: db}
+}
+
+// Reserve claims a key for a request, to expire after types.IdempotencyKeyTTL. It returns true when
+// the key was free, or expired, and is now the request's. Otherwise it returns the request the key
+// was used for, whose response may still be missing. Every owner's expired keys are removed on the
+// way, so that the keys and stored responses of owners who never come back, such as revoked API
+// keys, do not stay forever.
+func (r *idempotencyKeysRepo) Reserve(ctx context.Context, owner, key, requestHash string) (*types.IdempotencyKey, bool, error) {
+	now := time.Now()
+	if _, err := r.db.Context(ctx).Where("expires_at <= ?", now).Delete(&types.IdempotencyKey{}); err != nil {
+		return nil, false, fmt.Errorf("failed to remove expired idempotency keys: %w", err)
+	}
+
+	result, err := r.db.Context(ctx).Exec(
+		"INSERT INTO idempotency_keys (owner, key, request_hash, status_code, content_type, created_at, expires_at) VALUES (?, ?, ?, 0, '', ?, ?) ON CONFLICT (owner, key) DO NOTHING",
+		owner, key, requestHash, now, now.Add(types.IdempotencyKeyTTL),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return nil, false, err
+	} else if inserted == 1 {
+		return &types.IdempotencyKey{Owner: owner, Key: key, RequestHash: requestHash, CreatedAt: now, ExpiresAt: now.Add(types.IdempotencyKeyTTL)}, true, nil
+	}
+
+	existing := new(types.IdempotencyKey)
+	has, err := r.db.Context(ctx).Where("owner = ? AND key = ?", owner, key).Get(existing)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	if !has {
+		// The key was released between the insert and the read; the client can retry.
+		return nil, false, fmt.Errorf("idempotency key %q was released meanwhile", key)
+	}
+	return existing, false, nil
+}
+
+// Complete stores the response to the request a key was reserved for.
+func (r *idempotencyKeysRepo) Complete(ctx context.Context, owner, key string, statusCode int, contentType string, body []byte) error {
+	_, err := r.db.Context(ctx).
+		Where("owner = ? AND key = ?", owner, key).
+		Cols("status_code", "content_type", "response_body").
+		Update(&types.IdempotencyKey{StatusCode: statusCode, ContentType: contentType, ResponseBody: body})
+	return err
+}
+
+// Release frees a key whose request failed without a response worth replaying, so that it can be
+// retried.
+func (r *idempotencyKeysRepo) Release(ctx context.Context, owner, key string) error {
+	_, err := r.db.Context(ctx).Where("owner = ? AND key = ?", owner, key).Delete(&types.IdempotencyKey{})
+	return err
+}
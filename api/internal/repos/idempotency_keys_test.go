@@ -0,0 +1,86 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IdempotencyKeysRepo", func() {
+	var repo repos.IdempotencyKeysRepo
+
+	BeforeEach(func() {
+		repo = gr.IdempotencyKeys()
+	})
+
+	It("should reserve a free key and return the stored response afterwards", func() {
+		_, reserved, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+
+		stored, reserved, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeFalse())
+		Expect(stored.Completed()).To(BeFalse())
+
+		Expect(repo.Complete(ctx, "user:1", "abc", 201, "application/json", []byte(`{"id":9}`))).To(Succeed())
+
+		stored, reserved, err = repo.Reserve(ctx, "user:1", "abc", "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeFalse())
+		Expect(stored.RequestHash).To(Equal("hash"))
+		Expect(stored.StatusCode).To(Equal(201))
+		Expect(stored.ContentType).To(Equal("application/json"))
+		Expect(stored.ResponseBody).To(Equal([]byte(`{"id":9}`)))
+	})
+
+	It("should keep the keys of different owners apart", func() {
+		_, reserved, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+
+		_, reserved, err = repo.Reserve(ctx, "api_key:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+	})
+
+	It("should free released and expired keys", func() {
+		_, _, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repo.Release(ctx, "user:1", "abc")).To(Succeed())
+
+		_, reserved, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+
+		_, err = db.Exec("UPDATE idempotency_keys SET expires_at = ?", time.Now().Add(-time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, reserved, err = repo.Reserve(ctx, "user:1", "abc", "other")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+
+		count, err := db.Count(&types.IdempotencyKey{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(1)))
+	})
+	It("should remove the expired keys of other owners", func() {
+		_, _, err := repo.Reserve(ctx, "api_key:7", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repo.Complete(ctx, "api_key:7", "abc", 201, "application/json", []byte(`{"id":9}`))).To(Succeed())
+
+		_, err = db.Exec("UPDATE idempotency_keys SET expires_at = ?", time.Now().Add(-time.Minute))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, reserved, err := repo.Reserve(ctx, "user:1", "abc", "hash")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reserved).To(BeTrue())
+
+		has, err := db.Where("owner = ?", "api_key:7").Exist(&types.IdempotencyKey{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeFalse())
+	})
+})
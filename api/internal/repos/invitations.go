@@ -0,0 +1,269 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+var (
+	// ErrInvitationPending is returned when inviting an email that already has a pending invitation.
+	ErrInvitationPending = errors.New("an invitation for this email is already pending")
+	// ErrInvitationNotPending is returned when resending or revoking an invitation that was already
+	// accepted or revoked.
+	ErrInvitationNotPending = errors.New("invitation was already accepted or revoked")
+	// ErrInvitationInvalid is returned when an invite token is unknown, expired, revoked or already used.
+	ErrInvitationInvalid = errors.New("invitation is invalid or expired")
+	// ErrInvitationEmailTaken is returned when accepting an invitation for an email that belongs to
+	// a user by now.
+	ErrInvitationEmailTaken = errors.New("a user with this email already exists")
+)
+
+// InvitationFindOpts provides options for finding invitations.
+type InvitationFindOpts struct {
+	CompanyID int64
+	Status    types.InvitationStatus
+	Limit     int
+	Offset    int
+}
+
+// InvitationsRepo defines the interface for user invitation operations.
+//
+//go:generate mockgen -source=./invitations.go -destination=./mocks/invitations.go -package=mock_repos InvitationsRepo
+type InvitationsRepo interface {
+	Get(ctx context.Context, companyID, id int64) (*types.Invitation, bool, error)
+	Find(ctx context.Context, opts *InvitationFindOpts) ([]*types.Invitation, int64, error)
+	Create(ctx context.Context, invitation *types.Invitation) error
+	CreateTx(ctx context.Context, tx *xorm.Session, invitation *types.Invitation) error
+	Renew(ctx context.Context, companyID, id int64, tokenHash string, expiresAt time.Time) error
+	RenewTx(ctx context.Context, tx *xorm.Session, companyID, id int64, tokenHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, companyID, id int64) error
+	RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error
+	Accept(ctx context.Context, tokenHash string, user *types.User) (*types.Invitation, error)
+	AcceptTx(ctx context.Context, tx *xorm.Session, tokenHash string, user *types.User) (*types.Invitation, error)
+}
+
+type invitationsRepo struct {
+	db *xorm.Engine
+}
+
+// NewInvitationsRepo creates a new InvitationsRepo.
+func NewInvitationsRepo(db *xorm.Engine) InvitationsRepo {
+	return &invitationsRepo{db: db}
+}
+
+// Get retrieves an invitation of a company by ID. A companyID of 0 matches any company.
+func (r *invitationsRepo) Get(ctx context.Context, companyID, id int64) (*types.Invitation, bool, error) {
+	invitation := new(types.Invitation)
+	s := r.db.Context(ctx).Where("id = ?", id)
+	if companyID > 0 {
+		s.And("company_id = ?", companyID)
+	}
+	has, err := s.Get(invitation)
+	if err != nil || !has {
+		return nil, has, err
+	}
+	invitation.Status = invitation.StatusAt(time.Now())
+	return invitation, true, nil
+}
+
+// Find retrieves a list of invitations, newest first, and a total count.
+func (r *invitationsRepo) Find(ctx context.Context, opts *InvitationFindOpts) ([]*types.Invitation, int64, error) {
+	now := time.Now()
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	applyInvitationFindOpts(s, opts, now)
+	var invitations []*types.Invitation
+	count, err := s.OrderBy("id DESC").FindAndCount(&invitations)
+	for _, invitation := range invitations {
+		invitation.Status = invitation.StatusAt(now)
+	}
+	return invitations, count, err
+}
+
+// Create inserts a new invitation.
+func (r *invitationsRepo) Create(ctx context.Context, invitation *types.Invitation) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, invitation)
+	})
+	return err
+}
+
+// CreateTx inserts a new invitation. TokenHash and ExpiresAt must already be set. It returns
+// ErrInvitationPending when the email has a pending invitation already, which should be resent
+// instead.
+func (r *invitationsRepo) CreateTx(ctx context.Context, tx *xorm.Session, invitation *types.Invitation) error {
+	now := time.Now()
+	pending, err := tx.Context(ctx).
+		Where("email = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", invitation.Email, now).
+		Exist(new(types.Invitation))
+	if err != nil {
+		return fmt.Errorf("failed to check pending invitations: %w", err)
+	}
+	if pending {
+		return ErrInvitationPending
+	}
+
+	invitation.AcceptedAt = nil
+	invitation.RevokedAt = nil
+	invitation.UserID = nil
+	if invitation.RoleIDs == nil {
+		invitation.RoleIDs = types.IDs{}
+	}
+	if _, err := tx.Context(ctx).Insert(invitation); err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	invitation.Status = invitation.StatusAt(now)
+	return recordAuditTx(ctx, tx, types.AuditEntityInvitation, types.AuditActionCreate, invitation.ID, nil, invitation)
+}
+
+// Renew gives an invitation a new token and expiry. See RenewTx.
+func (r *invitationsRepo) Renew(ctx context.Context, companyID, id int64, tokenHash string, expiresAt time.Time) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RenewTx(ctx, tx, companyID, id, tokenHash, expiresAt)
+	})
+	return err
+}
+
+// RenewTx replaces the token of an invitation that was neither accepted nor revoked and extends it
+// until expiresAt. The link sent earlier stops working. Expired invitations become pending again.
+func (r *invitationsRepo) RenewTx(ctx context.Context, tx *xorm.Session, companyID, id int64, tokenHash string, expiresAt time.Time) error {
+	return auditedTx[types.Invitation](ctx, tx, types.AuditEntityInvitation, types.AuditActionUpdate, id, func() error {
+		affected, err := tx.Context(ctx).
+			Where("id = ? AND company_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id, companyID).
+			Cols("token_hash", "expires_at").
+			Update(&types.Invitation{TokenHash: tokenHash, ExpiresAt: expiresAt})
+		if err != nil {
+			return fmt.Errorf("failed to renew invitation %d: %w", id, err)
+		}
+		if affected == 0 {
+			return ErrInvitationNotPending
+		}
+		return nil
+	})
+}
+
+// Revoke withdraws an invitation. See RevokeTx.
+func (r *invitationsRepo) Revoke(ctx context.Context, companyID, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RevokeTx(ctx, tx, companyID, id)
+	})
+	return err
+}
+
+// RevokeTx withdraws an invitation that was neither accepted nor revoked. Its link stops working
+// immediately.
+func (r *invitationsRepo) RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	now := time.Now()
+	return auditedTx[types.Invitation](ctx, tx, types.AuditEntityInvitation, types.AuditActionDelete, id, func() error {
+		affected, err := tx.Context(ctx).
+			Where("id = ? AND company_id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id, companyID).
+			Cols("revoked_at").
+			Update(&types.Invitation{RevokedAt: &now})
+		if err != nil {
+			return fmt.Errorf("failed to revoke invitation %d: %w", id, err)
+		}
+		if affected == 0 {
+			return ErrInvitationNotPending
+		}
+		return nil
+	})
+}
+
+// Accept creates the invited user. See AcceptTx.
+func (r *invitationsRepo) Accept(ctx context.Context, tokenHash string, user *types.User) (*types.Invitation, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) (*types.Invitation, error) {
+		return r.AcceptTx(ctx, tx, tokenHash, user)
+	})
+}
+
+// AcceptTx uses up a pending invitation and creates its user from the name and password the invitee
+// chose. The user joins the invitation's company with its email and address, and gets the roles of
+// the invitation that still exist. It returns a *types.PasswordPolicyError when the password breaks
+// the password policy, in which case the invitation stays usable.
+func (r *invitationsRepo) AcceptTx(ctx context.Context, tx *xorm.Session, tokenHash string, user *types.User) (*types.Invitation, error) {
+	now := time.Now()
+
+	// The row lock makes a concurrent accept with the same token wait, so only one user is created.
+	invitation := new(types.Invitation)
+	has, err := tx.Context(ctx).Where("token_hash = ?", tokenHash).ForUpdate().Get(invitation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if !has || invitation.StatusAt(now) != types.InvitationStatusPending {
+		return nil, ErrInvitationInvalid
+	}
+
+	taken, err := tx.Context(ctx).Where("email = ?", invitation.Email).Exist(new(types.User))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing user: %w", err)
+	}
+	if taken {
+		return nil, ErrInvitationEmailTaken
+	}
+	if err := types.ActivePasswordPolicy.Check(user.Password, invitation.Email); err != nil {
+		return nil, err
+	}
+
+	user.CompanyID = invitation.CompanyID
+	user.Email = invitation.Email
+	user.AddressID = invitation.AddressID
+	user.Roles = types.Roles{types.RoleUser}
+	if err := NewUsersRepo(r.db).CreateTx(ctx, tx, user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	// Roles deleted since the invitation was sent are skipped rather than failing the invitation.
+	var roles []*types.CompanyRole
+	if len(invitation.RoleIDs) > 0 {
+		if err := tx.Context(ctx).Where("company_id = ?", invitation.CompanyID).In("id", []int64(invitation.RoleIDs)).Find(&roles); err != nil {
+			return nil, fmt.Errorf("failed to get invited roles: %w", err)
+		}
+	}
+	roleIDs := make([]int64, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, role.ID)
+	}
+	if err := NewCompanyRolesRepo(r.db).SetUserRolesTx(ctx, tx, invitation.CompanyID, user.ID, roleIDs); err != nil {
+		return nil, fmt.Errorf("failed to assign invited roles: %w", err)
+	}
+
+	if err := auditedTx[types.Invitation](ctx, tx, types.AuditEntityInvitation, types.AuditActionUpdate, invitation.ID, func() error {
+		invitation.AcceptedAt = &now
+		invitation.UserID = &user.ID
+		_, err := tx.Context(ctx).ID(invitation.ID).Cols("accepted_at", "user_id").Update(invitation)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to mark invitation as accepted: %w", err)
+	}
+	invitation.Status = types.InvitationStatusAccepted
+
+	return invitation, nil
+}
+
+// applyInvitationFindOpts is a helper function to build the query based on find options.
+func applyInvitationFindOpts(s *xorm.Session, opts *InvitationFindOpts, now time.Time) {
+	if opts == nil {
+		return
+	}
+	if opts.CompanyID > 0 {
+		s.And("company_id = ?", opts.CompanyID)
+	}
+	switch opts.Status {
+	case types.InvitationStatusPending:
+		s.And("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now)
+	case types.InvitationStatusAccepted:
+		s.And("accepted_at IS NOT NULL")
+	case types.InvitationStatusRevoked:
+		s.And("accepted_at IS NULL AND revoked_at IS NOT NULL")
+	case types.InvitationStatusExpired:
+		s.And("accepted_at IS NULL AND revoked_at IS NULL AND expires_at <= ?", now)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
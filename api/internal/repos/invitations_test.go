@@ -0,0 +1,187 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InvitationsRepo", func() {
+	var (
+		repo       repos.InvitationsRepo
+		company    *types.Company
+		role       *types.CompanyRole
+		invitation *types.Invitation
+	)
+
+	BeforeEach(func() {
+		repo = gr.Invitations()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		role = &types.CompanyRole{CompanyID: company.ID, Name: "Buyer", Permissions: types.Permissions{types.PermissionProductsManage}}
+		Expect(gr.CompanyRoles().Create(ctx, role)).To(Succeed())
+
+		invitation = &types.Invitation{
+			CompanyID: company.ID,
+			Email:     "invitee@example.com",
+			AddressID: address.ID,
+			RoleIDs:   types.IDs{role.ID},
+			TokenHash: "hash-1",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		Expect(repo.Create(ctx, invitation)).To(Succeed())
+		Expect(invitation.ID).NotTo(BeZero())
+		Expect(invitation.Status).To(Equal(types.InvitationStatusPending))
+	})
+
+	newInvitee := func() *types.User {
+		return &types.User{FirstName: "New", LastName: "User", Password: "password123"}
+	}
+
+	Describe("Create", func() {
+		It("should refuse a second pending invitation for the same email", func() {
+			err := repo.Create(ctx, &types.Invitation{
+				CompanyID: company.ID, Email: invitation.Email, AddressID: invitation.AddressID,
+				TokenHash: "hash-2", ExpiresAt: time.Now().Add(time.Hour),
+			})
+			Expect(err).To(MatchError(repos.ErrInvitationPending))
+		})
+
+		It("should allow inviting the email again once the invitation was revoked", func() {
+			Expect(repo.Revoke(ctx, company.ID, invitation.ID)).To(Succeed())
+			Expect(repo.Create(ctx, &types.Invitation{
+				CompanyID: company.ID, Email: invitation.Email, AddressID: invitation.AddressID,
+				TokenHash: "hash-2", ExpiresAt: time.Now().Add(time.Hour),
+			})).To(Succeed())
+		})
+	})
+
+	Describe("Get and Find", func() {
+		It("should return the invitation with its roles and status", func() {
+			retrieved, found, err := repo.Get(ctx, company.ID, invitation.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.RoleIDs).To(Equal(types.IDs{role.ID}))
+			Expect(retrieved.Status).To(Equal(types.InvitationStatusPending))
+
+			_, found, err = repo.Get(ctx, company.ID+100, invitation.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("should filter by status", func() {
+			invitations, count, err := repo.Find(ctx, &repos.InvitationFindOpts{CompanyID: company.ID, Status: types.InvitationStatusPending})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(invitations[0].ID).To(Equal(invitation.ID))
+
+			Expect(repo.Revoke(ctx, company.ID, invitation.ID)).To(Succeed())
+
+			_, count, err = repo.Find(ctx, &repos.InvitationFindOpts{CompanyID: company.ID, Status: types.InvitationStatusPending})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			invitations, count, err = repo.Find(ctx, &repos.InvitationFindOpts{CompanyID: company.ID, Status: types.InvitationStatusRevoked})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(invitations[0].Status).To(Equal(types.InvitationStatusRevoked))
+		})
+	})
+
+	Describe("Renew", func() {
+		It("should replace the token so that only the new link works", func() {
+			Expect(repo.Renew(ctx, company.ID, invitation.ID, "hash-2", time.Now().Add(time.Hour))).To(Succeed())
+
+			_, err := repo.Accept(ctx, "hash-1", newInvitee())
+			Expect(err).To(MatchError(repos.ErrInvitationInvalid))
+
+			_, err = repo.Accept(ctx, "hash-2", newInvitee())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should not renew a revoked invitation", func() {
+			Expect(repo.Revoke(ctx, company.ID, invitation.ID)).To(Succeed())
+			err := repo.Renew(ctx, company.ID, invitation.ID, "hash-2", time.Now().Add(time.Hour))
+			Expect(err).To(MatchError(repos.ErrInvitationNotPending))
+		})
+	})
+
+	Describe("Revoke", func() {
+		It("should make the link stop working", func() {
+			Expect(repo.Revoke(ctx, company.ID, invitation.ID)).To(Succeed())
+
+			_, err := repo.Accept(ctx, "hash-1", newInvitee())
+			Expect(err).To(MatchError(repos.ErrInvitationInvalid))
+
+			Expect(repo.Revoke(ctx, company.ID, invitation.ID)).To(MatchError(repos.ErrInvitationNotPending))
+		})
+	})
+
+	Describe("Accept", func() {
+		It("should create the user with the invited email, company and roles", func() {
+			user := newInvitee()
+			accepted, err := repo.Accept(ctx, "hash-1", user)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(accepted.Status).To(Equal(types.InvitationStatusAccepted))
+			Expect(*accepted.UserID).To(Equal(user.ID))
+
+			created, found, err := gr.Users().GetByEmail(ctx, invitation.Email)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(created.CompanyID).To(Equal(company.ID))
+			Expect(created.FirstName).To(Equal("New"))
+
+			roles, err := gr.CompanyRoles().FindForUser(ctx, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(HaveLen(1))
+			Expect(roles[0].ID).To(Equal(role.ID))
+
+			_, err = repo.Accept(ctx, "hash-1", newInvitee())
+			Expect(err).To(MatchError(repos.ErrInvitationInvalid))
+		})
+
+		It("should skip roles that were deleted since the invitation was sent", func() {
+			Expect(gr.CompanyRoles().Delete(ctx, company.ID, role.ID)).To(Succeed())
+
+			user := newInvitee()
+			_, err := repo.Accept(ctx, "hash-1", user)
+			Expect(err).NotTo(HaveOccurred())
+
+			roles, err := gr.CompanyRoles().FindForUser(ctx, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roles).To(BeEmpty())
+		})
+
+		It("should keep the invitation usable when the password is rejected", func() {
+			user := newInvitee()
+			user.Password = "short"
+			_, err := repo.Accept(ctx, "hash-1", user)
+			var policyErr *types.PasswordPolicyError
+			Expect(err).To(BeAssignableToTypeOf(policyErr))
+
+			_, err = repo.Accept(ctx, "hash-1", newInvitee())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an expired invitation", func() {
+			expired := &types.Invitation{
+				CompanyID: company.ID, Email: "late@example.com", AddressID: invitation.AddressID,
+				TokenHash: "hash-late", ExpiresAt: time.Now().Add(-time.Minute),
+			}
+			Expect(repo.Create(ctx, expired)).To(Succeed())
+
+			_, err := repo.Accept(ctx, "hash-late", newInvitee())
+			Expect(err).To(MatchError(repos.ErrInvitationInvalid))
+		})
+	})
+})
@@ -62,8 +62,10 @@ func (r *locationsRepo) CreateTx(ctx context.Context, tx *xorm.Session, location
 		return ErrLocationNameExists
 	}
 	location.Visible = true
-	_, err = tx.Context(ctx).Insert(location)
-	return err
+	if _, err = tx.Context(ctx).Insert(location); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityLocation, types.AuditActionCreate, location.ID, nil, location)
 }
 
 type LocationWithAddress struct {
@@ -120,8 +122,9 @@ func (r *locationsRepo) UpdateTx(ctx context.Context, tx *xorm.Session, location
 	if exists {
 		return ErrLocationNameExists
 	}
-	_, err = tx.Context(ctx).ID(location.ID).AllCols().Update(location)
-	return err
+	return auditedTx[types.Location](ctx, tx, types.AuditEntityLocation, types.AuditActionUpdate, location.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(location.ID).AllCols().Update(location))
+	})
 }
 
 func (r *locationsRepo) Delete(ctx context.Context, id int64) error {
@@ -132,8 +135,10 @@ func (r *locationsRepo) Delete(ctx context.Context, id int64) error {
 }
 
 func (r *locationsRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
-	_, err := tx.Context(ctx).ID(id).Cols("visible").Update(&types.Location{Visible: false})
-	return err
+	return auditedTx[types.Location](ctx, tx, types.AuditEntityLocation, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.Location{Visible: false})
+		return err
+	})
 }
 
 func (r *locationsRepo) Find(ctx context.Context, opts *LocationFindOpts) ([]*types.Location, int64, error) {
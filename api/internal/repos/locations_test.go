@@ -159,6 +159,34 @@ var _ = Describe("LocationsRepo", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(repos.ErrLocationNameExists))
 		})
+
+		It("should move the location to the next version", func() {
+			Expect(existingLocation.Version).To(Equal(int64(1)))
+			existingLocation.Name = "Updated Location Name"
+			Expect(repo.Update(ctx, existingLocation)).To(Succeed())
+			Expect(existingLocation.Version).To(Equal(int64(2)))
+
+			retrieved, found, err := repo.Get(ctx, company.ID, existingLocation.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Version).To(Equal(int64(2)))
+		})
+
+		It("should not overwrite a change made since the location was read", func() {
+			stale, found, err := repo.Get(ctx, company.ID, existingLocation.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			existingLocation.Name = "First Writer"
+			Expect(repo.Update(ctx, existingLocation)).To(Succeed())
+
+			stale.Name = "Second Writer"
+			Expect(repo.Update(ctx, stale)).To(MatchError(repos.ErrVersionConflict))
+
+			retrieved, _, err := repo.Get(ctx, company.ID, existingLocation.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.Name).To(Equal("First Writer"))
+		})
 	})
 
 		Describe("Delete", func() {
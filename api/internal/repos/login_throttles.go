@@ -0,0 +1,245 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// LoginThrottlesRepo defines the interface for counting failed logins and locking out emails and
+// IP addresses that have too many of them. Password reset requests are counted and locked the same
+// way, apart from logins.
+//
+//go:generate mockgen -source=./login_throttles.go -destination=./mocks/login_throttles.go -package=mock_repos LoginThrottlesRepo
+type LoginThrottlesRepo interface {
+	LockedUntil(ctx context.Context, email, ipAddress string) (*time.Time, error)
+	RecordFailure(ctx context.Context, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error)
+	RecordFailureTx(ctx context.Context, tx *xorm.Session, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error)
+	PasswordResetLockedUntil(ctx context.Context, email, ipAddress string) (*time.Time, error)
+	RecordPasswordReset(ctx context.Context, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error)
+	Reset(ctx context.Context, email string) error
+	ResetTx(ctx context.Context, tx *xorm.Session, email string) error
+	Unlock(ctx context.Context, email string, userID, actorID int64) error
+	UnlockTx(ctx context.Context, tx *xorm.Session, email string, userID, actorID int64) error
+	FindEvents(ctx context.Context, userID int64, limit, offset int) ([]*types.LoginLockoutEvent, int64, error)
+}
+
+type loginThrottlesRepo struct {
+	db *xorm.Engine
+}
+
+// NewLoginThrottlesRepo creates a new LoginThrottlesRepo.
+func NewLoginThrottlesRepo(db *xorm.Engine) LoginThrottlesRepo {
+	return &loginThrottlesRepo{db: db}
+}
+
+// loginScopes are the scopes failed logins are counted in.
+var loginScopes = throttleScopes{account: types.LoginThrottleScopeAccount, ip: types.LoginThrottleScopeIP}
+
+// passwordResetScopes are the scopes password reset requests are counted in.
+var passwordResetScopes = throttleScopes{account: types.LoginThrottleScopePasswordReset, ip: types.LoginThrottleScopePasswordResetIP}
+
+// throttleScopes are the scopes of one kind of attempt: per email and per IP address.
+type throttleScopes struct {
+	account types.LoginThrottleScope
+	ip      types.LoginThrottleScope
+}
+
+// LockedUntil returns when the lockout of the email or the IP address ends, whichever is later, or
+// nil when neither is locked.
+func (r *loginThrottlesRepo) LockedUntil(ctx context.Context, email, ipAddress string) (*time.Time, error) {
+	return r.lockedUntil(ctx, loginScopes, email, ipAddress)
+}
+
+// PasswordResetLockedUntil returns when the password reset lockout of the email or the IP address
+// ends, whichever is later, or nil when neither is locked.
+func (r *loginThrottlesRepo) PasswordResetLockedUntil(ctx context.Context, email, ipAddress string) (*time.Time, error) {
+	return r.lockedUntil(ctx, passwordResetScopes, email, ipAddress)
+}
+
+func (r *loginThrottlesRepo) lockedUntil(ctx context.Context, scopes throttleScopes, email, ipAddress string) (*time.Time, error) {
+	var throttles []*types.LoginThrottle
+	err := r.db.Context(ctx).
+		Where("(scope = ? AND key = ?) OR (scope = ? AND key = ?)",
+			scopes.account, types.LoginThrottleKey(email),
+			scopes.ip, ipAddress).
+		And("locked_until > ?", time.Now()).
+		Find(&throttles)
+	if err != nil {
+		return nil, err
+	}
+
+	var lockedUntil *time.Time
+	for _, t := range throttles {
+		if lockedUntil == nil || t.LockedUntil.After(*lockedUntil) {
+			lockedUntil = t.LockedUntil
+		}
+	}
+	return lockedUntil, nil
+}
+
+// RecordFailure counts a failed login. See RecordFailureTx.
+func (r *loginThrottlesRepo) RecordFailure(ctx context.Context, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) ([]*types.LoginLockoutEvent, error) {
+		return r.RecordFailureTx(ctx, tx, email, ipAddress, userID)
+	})
+}
+
+// RecordFailureTx counts a failed login against the email and the IP address and locks whichever
+// reached the limit of types.ActiveLoginThrottlePolicy. userID is the account the email belongs to,
+// or 0. It returns the lockout events it recorded.
+func (r *loginThrottlesRepo) RecordFailureTx(ctx context.Context, tx *xorm.Session, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error) {
+	return r.recordTx(ctx, tx, loginScopes, email, ipAddress, userID)
+}
+
+// RecordPasswordReset counts a password reset request against the email and the IP address and
+// locks whichever reached the limit of types.ActiveLoginThrottlePolicy, like RecordFailureTx does
+// for logins. It returns the lockout events it recorded.
+func (r *loginThrottlesRepo) RecordPasswordReset(ctx context.Context, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) ([]*types.LoginLockoutEvent, error) {
+		return r.recordTx(ctx, tx, passwordResetScopes, email, ipAddress, userID)
+	})
+}
+
+func (r *loginThrottlesRepo) recordTx(ctx context.Context, tx *xorm.Session, scopes throttleScopes, email, ipAddress string, userID int64) ([]*types.LoginLockoutEvent, error) {
+	policy := types.ActiveLoginThrottlePolicy
+	now := time.Now()
+
+	var events []*types.LoginLockoutEvent
+	for _, key := range []struct {
+		scope types.LoginThrottleScope
+		key   string
+	}{
+		{scopes.account, types.LoginThrottleKey(email)},
+		{scopes.ip, ipAddress},
+	} {
+		if key.key == "" {
+			continue
+		}
+
+		throttle, err := r.getForUpdate(ctx, tx, key.scope, key.key, now)
+		if err != nil {
+			return nil, err
+		}
+
+		if now.Sub(throttle.LastFailureAt) > policy.FailureWindow {
+			throttle.Failures = 0
+		}
+		if throttle.LastLockoutAt != nil && now.Sub(*throttle.LastLockoutAt) > policy.LockoutMemory {
+			throttle.Lockouts = 0
+		}
+		throttle.Failures++
+		throttle.LastFailureAt = now
+
+		if throttle.Failures >= policy.MaxFailures(key.scope) {
+			lockedUntil := now.Add(policy.LockoutDuration(throttle.Lockouts + 1))
+			throttle.Failures = 0
+			throttle.Lockouts++
+			throttle.LockedUntil = &lockedUntil
+			throttle.LastLockoutAt = &now
+
+			event := &types.LoginLockoutEvent{
+				Event:       types.LoginLockoutEventLocked,
+				Scope:       key.scope,
+				Key:         key.key,
+				IPAddress:   ipAddress,
+				LockedUntil: &lockedUntil,
+			}
+			if key.scope == scopes.account && userID > 0 {
+				event.UserID = &userID
+			}
+			if _, err := tx.Context(ctx).Insert(event); err != nil {
+				return nil, fmt.Errorf("failed to record lockout of %s %q: %w", key.scope, key.key, err)
+			}
+			events = append(events, event)
+		}
+
+		if _, err := tx.Context(ctx).
+			Where("scope = ? AND key = ?", key.scope, key.key).
+			Cols("failures", "lockouts", "last_failure_at", "locked_until", "last_lockout_at").
+			Update(throttle); err != nil {
+			return nil, fmt.Errorf("failed to update failed logins of %s %q: %w", key.scope, key.key, err)
+		}
+	}
+
+	return events, nil
+}
+
+// Reset forgets the failed logins of an email. See ResetTx.
+func (r *loginThrottlesRepo) Reset(ctx context.Context, email string) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.ResetTx(ctx, tx, email)
+	})
+	return err
+}
+
+// ResetTx forgets the failed logins of an email after a successful login. Earlier lockouts are
+// still remembered for the backoff.
+func (r *loginThrottlesRepo) ResetTx(ctx context.Context, tx *xorm.Session, email string) error {
+	_, err := tx.Context(ctx).
+		Where("scope = ? AND key = ?", types.LoginThrottleScopeAccount, types.LoginThrottleKey(email)).
+		Cols("failures").
+		Update(&types.LoginThrottle{Failures: 0})
+	return err
+}
+
+// Unlock ends the lockout of an account. See UnlockTx.
+func (r *loginThrottlesRepo) Unlock(ctx context.Context, email string, userID, actorID int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.UnlockTx(ctx, tx, email, userID, actorID)
+	})
+	return err
+}
+
+// UnlockTx ends the lockout of an account, forgets its failed logins and earlier lockouts and
+// records that actorID unlocked it. Lockouts of IP addresses are left alone.
+func (r *loginThrottlesRepo) UnlockTx(ctx context.Context, tx *xorm.Session, email string, userID, actorID int64) error {
+	key := types.LoginThrottleKey(email)
+	if _, err := tx.Context(ctx).
+		Where("scope = ? AND key = ?", types.LoginThrottleScopeAccount, key).
+		Delete(&types.LoginThrottle{}); err != nil {
+		return fmt.Errorf("failed to unlock %q: %w", key, err)
+	}
+
+	_, err := tx.Context(ctx).Insert(&types.LoginLockoutEvent{
+		Event:   types.LoginLockoutEventUnlocked,
+		Scope:   types.LoginThrottleScopeAccount,
+		Key:     key,
+		UserID:  &userID,
+		ActorID: &actorID,
+	})
+	return err
+}
+
+// FindEvents retrieves the lockout events of a user, newest first, and a total count.
+func (r *loginThrottlesRepo) FindEvents(ctx context.Context, userID int64, limit, offset int) ([]*types.LoginLockoutEvent, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	s.Where("user_id = ?", userID)
+	if limit > 0 {
+		s.Limit(limit, offset)
+	}
+	var events []*types.LoginLockoutEvent
+	count, err := s.OrderBy("created_at DESC, id DESC").FindAndCount(&events)
+	return events, count, err
+}
+
+// getForUpdate returns the failed login count of an email or IP address, creating it when there is
+// none. The row lock makes concurrent failures count one after another.
+func (r *loginThrottlesRepo) getForUpdate(ctx context.Context, tx *xorm.Session, scope types.LoginThrottleScope, key string, now time.Time) (*types.LoginThrottle, error) {
+	if _, err := tx.Context(ctx).Exec(
+		"INSERT INTO login_throttles (scope, key, failures, lockouts, last_failure_at) VALUES (?, ?, 0, 0, ?) ON CONFLICT (scope, key) DO NOTHING",
+		scope, key, now,
+	); err != nil {
+		return nil, fmt.Errorf("failed to create failed logins of %s %q: %w", scope, key, err)
+	}
+
+	throttle := new(types.LoginThrottle)
+	if _, err := tx.Context(ctx).Where("scope = ? AND key = ?", scope, key).ForUpdate().Get(throttle); err != nil {
+		return nil, fmt.Errorf("failed to get failed logins of %s %q: %w", scope, key, err)
+	}
+	return throttle, nil
+}
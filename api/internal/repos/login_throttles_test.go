@@ -0,0 +1,149 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoginThrottlesRepo", func() {
+	var (
+		repo           repos.LoginThrottlesRepo
+		user           *types.User
+		originalPolicy types.LoginThrottlePolicy
+	)
+
+	BeforeEach(func() {
+		repo = gr.LoginThrottles()
+
+		originalPolicy = types.ActiveLoginThrottlePolicy
+		types.ActiveLoginThrottlePolicy = types.LoginThrottlePolicy{
+			MaxAccountFailures: 3,
+			MaxIPFailures:      5,
+			FailureWindow:      time.Hour,
+			BaseLockout:        time.Minute,
+			MaxLockout:         time.Hour,
+			LockoutMemory:      24 * time.Hour,
+		}
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company := &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		user = &types.User{
+			CompanyID: company.ID,
+			AddressID: address.ID,
+			Email:     "locked.user@example.com",
+			Password:  "password123",
+			FirstName: "Locked",
+			LastName:  "User",
+			Roles:     types.Roles{types.RoleUser},
+		}
+		Expect(gr.Users().Create(ctx, user)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		types.ActiveLoginThrottlePolicy = originalPolicy
+	})
+
+	fail := func(times int, email, ip string) []*types.LoginLockoutEvent {
+		var events []*types.LoginLockoutEvent
+		for i := 0; i < times; i++ {
+			e, err := repo.RecordFailure(ctx, email, ip, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			events = append(events, e...)
+		}
+		return events
+	}
+
+	It("should lock an email after too many failures and record the lockout", func() {
+		Expect(fail(2, user.Email, "10.0.0.1")).To(BeEmpty())
+		lockedUntil, err := repo.LockedUntil(ctx, user.Email, "10.0.0.2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).To(BeNil())
+
+		events := fail(1, "LOCKED.user@example.com", "10.0.0.1")
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Scope).To(Equal(types.LoginThrottleScopeAccount))
+		Expect(*events[0].UserID).To(Equal(user.ID))
+
+		lockedUntil, err = repo.LockedUntil(ctx, user.Email, "10.0.0.2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).NotTo(BeNil())
+		Expect(*lockedUntil).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+
+		found, count, err := repo.FindEvents(ctx, user.ID, 10, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(1)))
+		Expect(found[0].Event).To(Equal(types.LoginLockoutEventLocked))
+	})
+
+	It("should double the lockout every time", func() {
+		fail(3, user.Email, "")
+		events := fail(3, user.Email, "")
+		Expect(events).To(HaveLen(1))
+		Expect(*events[0].LockedUntil).To(BeTemporally("~", time.Now().Add(2*time.Minute), 5*time.Second))
+	})
+
+	It("should lock an IP address across emails", func() {
+		fail(2, "a@example.com", "10.0.0.9")
+		fail(2, "b@example.com", "10.0.0.9")
+		events := fail(1, "c@example.com", "10.0.0.9")
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Scope).To(Equal(types.LoginThrottleScopeIP))
+		Expect(events[0].UserID).To(BeNil())
+
+		lockedUntil, err := repo.LockedUntil(ctx, "d@example.com", "10.0.0.9")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).NotTo(BeNil())
+	})
+
+	It("should lock password resets apart from logins", func() {
+		for i := 0; i < 2; i++ {
+			events, err := repo.RecordPasswordReset(ctx, user.Email, "10.0.0.3", user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		}
+		events, err := repo.RecordPasswordReset(ctx, user.Email, "10.0.0.3", user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Scope).To(Equal(types.LoginThrottleScopePasswordReset))
+		Expect(*events[0].UserID).To(Equal(user.ID))
+
+		lockedUntil, err := repo.PasswordResetLockedUntil(ctx, user.Email, "10.0.0.4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).NotTo(BeNil())
+
+		lockedUntil, err = repo.LockedUntil(ctx, user.Email, "10.0.0.3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).To(BeNil())
+	})
+
+	It("should forget failures after a successful login", func() {
+		fail(2, user.Email, "")
+		Expect(repo.Reset(ctx, user.Email)).To(Succeed())
+		Expect(fail(2, user.Email, "")).To(BeEmpty())
+	})
+
+	It("should let an admin unlock the account", func() {
+		fail(3, user.Email, "")
+		Expect(repo.Unlock(ctx, user.Email, user.ID, user.ID)).To(Succeed())
+
+		lockedUntil, err := repo.LockedUntil(ctx, user.Email, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lockedUntil).To(BeNil())
+
+		found, count, err := repo.FindEvents(ctx, user.ID, 10, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(2)))
+		Expect(found[0].Event).To(Equal(types.LoginLockoutEventUnlocked))
+		Expect(*found[0].ActorID).To(Equal(user.ID))
+	})
+})
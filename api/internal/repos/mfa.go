@@ -0,0 +1,183 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// ErrMFAAlreadyEnabled is returned when enrolling a user whose MFA is already on.
+var ErrMFAAlreadyEnabled = errors.New("multi-factor authentication is already enabled")
+
+// ErrMFANotEnrolled is returned when confirming MFA for a user who has not enrolled.
+var ErrMFANotEnrolled = errors.New("multi-factor authentication has not been enrolled")
+
+// MFARepo defines the interface for the TOTP secrets and recovery codes of users.
+//
+//go:generate mockgen -source=./mfa.go -destination=./mocks/mfa.go -package=mock_repos MFARepo
+type MFARepo interface {
+	SetSecret(ctx context.Context, userID int64, secret string) error
+	SetSecretTx(ctx context.Context, tx *xorm.Session, userID int64, secret string) error
+	Enable(ctx context.Context, userID, step int64, recoveryCodeHashes []string) error
+	EnableTx(ctx context.Context, tx *xorm.Session, userID, step int64, recoveryCodeHashes []string) error
+	Disable(ctx context.Context, userID int64) error
+	DisableTx(ctx context.Context, tx *xorm.Session, userID int64) error
+	UseStep(ctx context.Context, userID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error
+	ReplaceRecoveryCodesTx(ctx context.Context, tx *xorm.Session, userID int64, codeHashes []string) error
+}
+
+type mfaRepo struct {
+	db *xorm.Engine
+}
+
+// NewMFARepo creates a new MFARepo.
+func NewMFARepo(db *xorm.Engine) MFARepo {
+	return &mfaRepo{db: db}
+}
+
+// SetSecret starts an enrollment. See SetSecretTx.
+func (r *mfaRepo) SetSecret(ctx context.Context, userID int64, secret string) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.SetSecretTx(ctx, tx, userID, secret)
+	})
+	return err
+}
+
+// SetSecretTx stores the TOTP secret of a user who is enrolling. MFA stays off until the secret is
+// confirmed with Enable; enrolling again replaces an unconfirmed secret. It returns
+// ErrMFAAlreadyEnabled when the user's MFA is already on.
+func (r *mfaRepo) SetSecretTx(ctx context.Context, tx *xorm.Session, userID int64, secret string) error {
+	affected, err := tx.Context(ctx).
+		Where("id = ? AND mfa_enabled = ?", userID, false).
+		Cols("mfa_secret").
+		NoVersionCheck().
+		Update(&types.User{MFASecret: secret})
+	if err != nil {
+		return fmt.Errorf("failed to store MFA secret: %w", err)
+	}
+	if affected == 0 {
+		return ErrMFAAlreadyEnabled
+	}
+	return nil
+}
+
+// Enable confirms an enrollment. See EnableTx.
+func (r *mfaRepo) Enable(ctx context.Context, userID, step int64, recoveryCodeHashes []string) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.EnableTx(ctx, tx, userID, step, recoveryCodeHashes)
+	})
+	return err
+}
+
+// EnableTx turns MFA on for a user whose first code, of the given time step, confirmed their
+// secret, and gives them a fresh set of recovery codes. It returns ErrMFANotEnrolled when the user
+// has no unconfirmed secret.
+func (r *mfaRepo) EnableTx(ctx context.Context, tx *xorm.Session, userID, step int64, recoveryCodeHashes []string) error {
+	if err := auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, userID, func() error {
+		affected, err := tx.Context(ctx).
+			Where("id = ? AND mfa_enabled = ? AND mfa_secret IS NOT NULL", userID, false).
+			Cols("mfa_enabled", "mfa_last_step").
+			NoVersionCheck().
+			Update(&types.User{MFAEnabled: true, MFALastStep: step})
+		if err != nil {
+			return fmt.Errorf("failed to enable MFA: %w", err)
+		}
+		if affected == 0 {
+			return ErrMFANotEnrolled
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return r.ReplaceRecoveryCodesTx(ctx, tx, userID, recoveryCodeHashes)
+}
+
+// Disable turns MFA off. See DisableTx.
+func (r *mfaRepo) Disable(ctx context.Context, userID int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.DisableTx(ctx, tx, userID)
+	})
+	return err
+}
+
+// DisableTx turns MFA off for a user and forgets their secret and recovery codes, so that they can
+// enroll a new device.
+func (r *mfaRepo) DisableTx(ctx context.Context, tx *xorm.Session, userID int64) error {
+	if err := auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, userID, func() error {
+		// A map update skips the version check, so the version is bumped by hand for clients that
+		// read the user before.
+		_, err := tx.Context(ctx).Table("users").ID(userID).Incr("version").Update(map[string]interface{}{
+			"mfa_secret":    nil,
+			"mfa_enabled":   false,
+			"mfa_last_step": 0,
+		})
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to disable MFA: %w", err)
+	}
+	if _, err := tx.Context(ctx).Where("user_id = ?", userID).Delete(&types.MFARecoveryCode{}); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	return nil
+}
+
+// UseStep records that a user logged in with the code of a time step. It reports false when a code
+// of that step or a later one was already used, so that a code works only once.
+func (r *mfaRepo) UseStep(ctx context.Context, userID, step int64) (bool, error) {
+	affected, err := r.db.Context(ctx).
+		Where("id = ? AND mfa_last_step < ?", userID, step).
+		Cols("mfa_last_step").
+		NoVersionCheck().
+		Update(&types.User{MFALastStep: step})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// UseRecoveryCode uses up one of a user's recovery codes. It reports false when the user has no
+// unused code with that hash.
+func (r *mfaRepo) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	now := time.Now()
+	affected, err := r.db.Context(ctx).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Cols("used_at").
+		Update(&types.MFARecoveryCode{UsedAt: &now})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ReplaceRecoveryCodes gives a user a new set of recovery codes. See ReplaceRecoveryCodesTx.
+func (r *mfaRepo) ReplaceRecoveryCodes(ctx context.Context, userID int64, codeHashes []string) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.ReplaceRecoveryCodesTx(ctx, tx, userID, codeHashes)
+	})
+	return err
+}
+
+// ReplaceRecoveryCodesTx gives a user a new set of recovery codes; the earlier ones stop working.
+func (r *mfaRepo) ReplaceRecoveryCodesTx(ctx context.Context, tx *xorm.Session, userID int64, codeHashes []string) error {
+	if _, err := tx.Context(ctx).Where("user_id = ?", userID).Delete(&types.MFARecoveryCode{}); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	codes := make([]*types.MFARecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, &types.MFARecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	if _, err := tx.Context(ctx).Insert(&codes); err != nil {
+		return fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return nil
+}
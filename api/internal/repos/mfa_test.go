@@ -0,0 +1,107 @@
+package repos_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MFARepo", func() {
+	var (
+		repo repos.MFARepo
+		user *types.User
+	)
+
+	BeforeEach(func() {
+		repo = gr.MFA()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company := &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		user = &types.User{
+			CompanyID: company.ID,
+			AddressID: address.ID,
+			Email:     "mfa.user@example.com",
+			Password:  "password123",
+			FirstName: "MFA",
+			LastName:  "User",
+			Roles:     types.Roles{types.RoleUser},
+		}
+		Expect(gr.Users().Create(ctx, user)).To(Succeed())
+	})
+
+	getUser := func() *types.User {
+		found, has, err := gr.Users().Get(ctx, user.CompanyID, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(has).To(BeTrue())
+		return found
+	}
+
+	It("should enable MFA once the secret is confirmed", func() {
+		Expect(repo.Enable(ctx, user.ID, 10, []string{"a"})).To(MatchError(repos.ErrMFANotEnrolled))
+
+		Expect(repo.SetSecret(ctx, user.ID, "SECRET")).To(Succeed())
+		Expect(getUser().MFAEnabled).To(BeFalse())
+
+		Expect(repo.Enable(ctx, user.ID, 10, []string{"a", "b"})).To(Succeed())
+		found := getUser()
+		Expect(found.MFAEnabled).To(BeTrue())
+		Expect(found.MFASecret).To(Equal("SECRET"))
+		Expect(found.MFALastStep).To(Equal(int64(10)))
+
+		Expect(repo.SetSecret(ctx, user.ID, "OTHER")).To(MatchError(repos.ErrMFAAlreadyEnabled))
+	})
+
+	It("should accept each time step once", func() {
+		Expect(repo.SetSecret(ctx, user.ID, "SECRET")).To(Succeed())
+		Expect(repo.Enable(ctx, user.ID, 10, nil)).To(Succeed())
+
+		used, err := repo.UseStep(ctx, user.ID, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeFalse())
+
+		used, err = repo.UseStep(ctx, user.ID, 11)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeTrue())
+	})
+
+	It("should use each recovery code once", func() {
+		Expect(repo.SetSecret(ctx, user.ID, "SECRET")).To(Succeed())
+		Expect(repo.Enable(ctx, user.ID, 10, []string{"a", "b"})).To(Succeed())
+
+		used, err := repo.UseRecoveryCode(ctx, user.ID, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeTrue())
+
+		used, err = repo.UseRecoveryCode(ctx, user.ID, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeFalse())
+
+		Expect(repo.ReplaceRecoveryCodes(ctx, user.ID, []string{"c"})).To(Succeed())
+		used, err = repo.UseRecoveryCode(ctx, user.ID, "b")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeFalse())
+	})
+
+	It("should forget the secret and recovery codes when disabled", func() {
+		Expect(repo.SetSecret(ctx, user.ID, "SECRET")).To(Succeed())
+		Expect(repo.Enable(ctx, user.ID, 10, []string{"a"})).To(Succeed())
+
+		version := getUser().Version
+		Expect(repo.Disable(ctx, user.ID)).To(Succeed())
+		found := getUser()
+		Expect(found.Version).To(Equal(version + 1))
+		Expect(found.MFAEnabled).To(BeFalse())
+		Expect(found.MFASecret).To(BeEmpty())
+
+		used, err := repo.UseRecoveryCode(ctx, user.ID, "a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(used).To(BeFalse())
+	})
+})
@@ -160,6 +160,21 @@ func (mr *MockAddressesRepoMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAddressesRepo)(nil).Get), ctx, id)
 }
 
+// IsUsedByCompany mocks base method.
+func (m *MockAddressesRepo) IsUsedByCompany(ctx context.Context, id, companyID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsedByCompany", ctx, id, companyID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUsedByCompany indicates an expected call of IsUsedByCompany.
+func (mr *MockAddressesRepoMockRecorder) IsUsedByCompany(ctx, id, companyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsedByCompany", reflect.TypeOf((*MockAddressesRepo)(nil).IsUsedByCompany), ctx, id, companyID)
+}
+
 // Update mocks base method.
 func (m *MockAddressesRepo) Update(ctx context.Context, address *types.Address) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./api_keys.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./api_keys.go -destination=./mocks/api_keys.go -package=mock_repos APIKeysRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockAPIKeysRepo is a mock of APIKeysRepo interface.
+type MockAPIKeysRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeysRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeysRepoMockRecorder is the mock recorder for MockAPIKeysRepo.
+type MockAPIKeysRepoMockRecorder struct {
+	mock *MockAPIKeysRepo
+}
+
+// NewMockAPIKeysRepo creates a new mock instance.
+func NewMockAPIKeysRepo(ctrl *gomock.Controller) *MockAPIKeysRepo {
+	mock := &MockAPIKeysRepo{ctrl: ctrl}
+	mock.recorder = &MockAPIKeysRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeysRepo) EXPECT() *MockAPIKeysRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKeysRepo) Create(ctx context.Context, key *types.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeysRepoMockRecorder) Create(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKeysRepo)(nil).Create), ctx, key)
+}
+
+// CreateTx mocks base method.
+func (m *MockAPIKeysRepo) CreateTx(ctx context.Context, tx *xorm.Session, key *types.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockAPIKeysRepoMockRecorder) CreateTx(ctx, tx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockAPIKeysRepo)(nil).CreateTx), ctx, tx, key)
+}
+
+// Find mocks base method.
+func (m *MockAPIKeysRepo) Find(ctx context.Context, opts *repos.APIKeyFindOpts) ([]*types.APIKey, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.APIKey)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAPIKeysRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAPIKeysRepo)(nil).Find), ctx, opts)
+}
+
+// Get mocks base method.
+func (m *MockAPIKeysRepo) Get(ctx context.Context, companyID, id int64) (*types.APIKey, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, companyID, id)
+	ret0, _ := ret[0].(*types.APIKey)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeysRepoMockRecorder) Get(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeysRepo)(nil).Get), ctx, companyID, id)
+}
+
+// GetByHash mocks base method.
+func (m *MockAPIKeysRepo) GetByHash(ctx context.Context, keyHash string) (*types.APIKey, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*types.APIKey)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockAPIKeysRepoMockRecorder) GetByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockAPIKeysRepo)(nil).GetByHash), ctx, keyHash)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKeysRepo) Revoke(ctx context.Context, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeysRepoMockRecorder) Revoke(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKeysRepo)(nil).Revoke), ctx, companyID, id)
+}
+
+// RevokeTx mocks base method.
+func (m *MockAPIKeysRepo) RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTx", ctx, tx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTx indicates an expected call of RevokeTx.
+func (mr *MockAPIKeysRepoMockRecorder) RevokeTx(ctx, tx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTx", reflect.TypeOf((*MockAPIKeysRepo)(nil).RevokeTx), ctx, tx, companyID, id)
+}
+
+// TouchLastUsed mocks base method.
+func (m *MockAPIKeysRepo) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchLastUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchLastUsed indicates an expected call of TouchLastUsed.
+func (mr *MockAPIKeysRepoMockRecorder) TouchLastUsed(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchLastUsed", reflect.TypeOf((*MockAPIKeysRepo)(nil).TouchLastUsed), ctx, id, usedAt)
+}
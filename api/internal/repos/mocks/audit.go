@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./audit.go -destination=./mocks/audit.go -package=mock_repos AuditLogRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditLogRepo is a mock of AuditLogRepo interface.
+type MockAuditLogRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditLogRepoMockRecorder is the mock recorder for MockAuditLogRepo.
+type MockAuditLogRepoMockRecorder struct {
+	mock *MockAuditLogRepo
+}
+
+// NewMockAuditLogRepo creates a new mock instance.
+func NewMockAuditLogRepo(ctrl *gomock.Controller) *MockAuditLogRepo {
+	mock := &MockAuditLogRepo{ctrl: ctrl}
+	mock.recorder = &MockAuditLogRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLogRepo) EXPECT() *MockAuditLogRepoMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockAuditLogRepo) Find(ctx context.Context, opts *repos.AuditLogFindOpts) ([]*types.AuditLogEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.AuditLogEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAuditLogRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAuditLogRepo)(nil).Find), ctx, opts)
+}
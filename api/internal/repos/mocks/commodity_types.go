@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./commodity_types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./commodity_types.go -destination=./mocks/commodity_types.go -package=mock_repos CommodityTypesRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockCommodityTypesRepo is a mock of CommodityTypesRepo interface.
+type MockCommodityTypesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommodityTypesRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCommodityTypesRepoMockRecorder is the mock recorder for MockCommodityTypesRepo.
+type MockCommodityTypesRepoMockRecorder struct {
+	mock *MockCommodityTypesRepo
+}
+
+// NewMockCommodityTypesRepo creates a new mock instance.
+func NewMockCommodityTypesRepo(ctrl *gomock.Controller) *MockCommodityTypesRepo {
+	mock := &MockCommodityTypesRepo{ctrl: ctrl}
+	mock.recorder = &MockCommodityTypesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommodityTypesRepo) EXPECT() *MockCommodityTypesRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCommodityTypesRepo) Create(ctx context.Context, commodityType *types.CommodityTypeRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, commodityType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommodityTypesRepoMockRecorder) Create(ctx, commodityType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommodityTypesRepo)(nil).Create), ctx, commodityType)
+}
+
+// CreateTx mocks base method.
+func (m *MockCommodityTypesRepo) CreateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, commodityType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockCommodityTypesRepoMockRecorder) CreateTx(ctx, tx, commodityType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockCommodityTypesRepo)(nil).CreateTx), ctx, tx, commodityType)
+}
+
+// Delete mocks base method.
+func (m *MockCommodityTypesRepo) Delete(ctx context.Context, id types.CommodityType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommodityTypesRepoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommodityTypesRepo)(nil).Delete), ctx, id)
+}
+
+// DeleteTx mocks base method.
+func (m *MockCommodityTypesRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id types.CommodityType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTx indicates an expected call of DeleteTx.
+func (mr *MockCommodityTypesRepoMockRecorder) DeleteTx(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTx", reflect.TypeOf((*MockCommodityTypesRepo)(nil).DeleteTx), ctx, tx, id)
+}
+
+// Find mocks base method.
+func (m *MockCommodityTypesRepo) Find(ctx context.Context, opts *repos.CommodityTypeFindOpts) ([]*types.CommodityTypeRecord, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.CommodityTypeRecord)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCommodityTypesRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCommodityTypesRepo)(nil).Find), ctx, opts)
+}
+
+// Get mocks base method.
+func (m *MockCommodityTypesRepo) Get(ctx context.Context, id types.CommodityType) (*types.CommodityTypeRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*types.CommodityTypeRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCommodityTypesRepoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCommodityTypesRepo)(nil).Get), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockCommodityTypesRepo) Update(ctx context.Context, commodityType *types.CommodityTypeRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, commodityType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommodityTypesRepoMockRecorder) Update(ctx, commodityType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommodityTypesRepo)(nil).Update), ctx, commodityType)
+}
+
+// UpdateTx mocks base method.
+func (m *MockCommodityTypesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, commodityType *types.CommodityTypeRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTx", ctx, tx, commodityType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTx indicates an expected call of UpdateTx.
+func (mr *MockCommodityTypesRepoMockRecorder) UpdateTx(ctx, tx, commodityType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTx", reflect.TypeOf((*MockCommodityTypesRepo)(nil).UpdateTx), ctx, tx, commodityType)
+}
@@ -141,6 +141,36 @@ func (mr *MockCompanyAttributeSettingsRepoMockRecorder) Get(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCompanyAttributeSettingsRepo)(nil).Get), ctx, id)
 }
 
+// Reorder mocks base method.
+func (m *MockCompanyAttributeSettingsRepo) Reorder(ctx context.Context, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, companyID, commodityAttributeIDs)
+	ret0, _ := ret[0].([]*types.CompanyAttributeSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockCompanyAttributeSettingsRepoMockRecorder) Reorder(ctx, companyID, commodityAttributeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockCompanyAttributeSettingsRepo)(nil).Reorder), ctx, companyID, commodityAttributeIDs)
+}
+
+// ReorderTx mocks base method.
+func (m *MockCompanyAttributeSettingsRepo) ReorderTx(ctx context.Context, tx *xorm.Session, companyID int64, commodityAttributeIDs []int64) ([]*types.CompanyAttributeSetting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTx", ctx, tx, companyID, commodityAttributeIDs)
+	ret0, _ := ret[0].([]*types.CompanyAttributeSetting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderTx indicates an expected call of ReorderTx.
+func (mr *MockCompanyAttributeSettingsRepoMockRecorder) ReorderTx(ctx, tx, companyID, commodityAttributeIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTx", reflect.TypeOf((*MockCompanyAttributeSettingsRepo)(nil).ReorderTx), ctx, tx, companyID, commodityAttributeIDs)
+}
+
 // Update mocks base method.
 func (m *MockCompanyAttributeSettingsRepo) Update(ctx context.Context, setting *types.CompanyAttributeSetting) error {
 	m.ctrl.T.Helper()
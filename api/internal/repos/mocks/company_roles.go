@@ -0,0 +1,218 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./company_roles.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./company_roles.go -destination=./mocks/company_roles.go -package=mock_repos CompanyRolesRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockCompanyRolesRepo is a mock of CompanyRolesRepo interface.
+type MockCompanyRolesRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompanyRolesRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockCompanyRolesRepoMockRecorder is the mock recorder for MockCompanyRolesRepo.
+type MockCompanyRolesRepoMockRecorder struct {
+	mock *MockCompanyRolesRepo
+}
+
+// NewMockCompanyRolesRepo creates a new mock instance.
+func NewMockCompanyRolesRepo(ctrl *gomock.Controller) *MockCompanyRolesRepo {
+	mock := &MockCompanyRolesRepo{ctrl: ctrl}
+	mock.recorder = &MockCompanyRolesRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompanyRolesRepo) EXPECT() *MockCompanyRolesRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCompanyRolesRepo) Create(ctx context.Context, role *types.CompanyRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCompanyRolesRepoMockRecorder) Create(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCompanyRolesRepo)(nil).Create), ctx, role)
+}
+
+// CreateTx mocks base method.
+func (m *MockCompanyRolesRepo) CreateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockCompanyRolesRepoMockRecorder) CreateTx(ctx, tx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockCompanyRolesRepo)(nil).CreateTx), ctx, tx, role)
+}
+
+// Delete mocks base method.
+func (m *MockCompanyRolesRepo) Delete(ctx context.Context, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCompanyRolesRepoMockRecorder) Delete(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCompanyRolesRepo)(nil).Delete), ctx, companyID, id)
+}
+
+// DeleteTx mocks base method.
+func (m *MockCompanyRolesRepo) DeleteTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTx", ctx, tx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTx indicates an expected call of DeleteTx.
+func (mr *MockCompanyRolesRepoMockRecorder) DeleteTx(ctx, tx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTx", reflect.TypeOf((*MockCompanyRolesRepo)(nil).DeleteTx), ctx, tx, companyID, id)
+}
+
+// Find mocks base method.
+func (m *MockCompanyRolesRepo) Find(ctx context.Context, opts *repos.CompanyRoleFindOpts) ([]*types.CompanyRole, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.CompanyRole)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockCompanyRolesRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockCompanyRolesRepo)(nil).Find), ctx, opts)
+}
+
+// FindForUser mocks base method.
+func (m *MockCompanyRolesRepo) FindForUser(ctx context.Context, userID int64) ([]*types.CompanyRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForUser", ctx, userID)
+	ret0, _ := ret[0].([]*types.CompanyRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForUser indicates an expected call of FindForUser.
+func (mr *MockCompanyRolesRepoMockRecorder) FindForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForUser", reflect.TypeOf((*MockCompanyRolesRepo)(nil).FindForUser), ctx, userID)
+}
+
+// Get mocks base method.
+func (m *MockCompanyRolesRepo) Get(ctx context.Context, companyID, id int64) (*types.CompanyRole, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, companyID, id)
+	ret0, _ := ret[0].(*types.CompanyRole)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCompanyRolesRepoMockRecorder) Get(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCompanyRolesRepo)(nil).Get), ctx, companyID, id)
+}
+
+// GetUserPermissions mocks base method.
+func (m *MockCompanyRolesRepo) GetUserPermissions(ctx context.Context, userID int64) (types.Permissions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissions", ctx, userID)
+	ret0, _ := ret[0].(types.Permissions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissions indicates an expected call of GetUserPermissions.
+func (mr *MockCompanyRolesRepoMockRecorder) GetUserPermissions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissions", reflect.TypeOf((*MockCompanyRolesRepo)(nil).GetUserPermissions), ctx, userID)
+}
+
+// SetUserRoles mocks base method.
+func (m *MockCompanyRolesRepo) SetUserRoles(ctx context.Context, companyID, userID int64, roleIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRoles", ctx, companyID, userID, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRoles indicates an expected call of SetUserRoles.
+func (mr *MockCompanyRolesRepoMockRecorder) SetUserRoles(ctx, companyID, userID, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRoles", reflect.TypeOf((*MockCompanyRolesRepo)(nil).SetUserRoles), ctx, companyID, userID, roleIDs)
+}
+
+// SetUserRolesTx mocks base method.
+func (m *MockCompanyRolesRepo) SetUserRolesTx(ctx context.Context, tx *xorm.Session, companyID, userID int64, roleIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRolesTx", ctx, tx, companyID, userID, roleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRolesTx indicates an expected call of SetUserRolesTx.
+func (mr *MockCompanyRolesRepoMockRecorder) SetUserRolesTx(ctx, tx, companyID, userID, roleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRolesTx", reflect.TypeOf((*MockCompanyRolesRepo)(nil).SetUserRolesTx), ctx, tx, companyID, userID, roleIDs)
+}
+
+// Update mocks base method.
+func (m *MockCompanyRolesRepo) Update(ctx context.Context, role *types.CompanyRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCompanyRolesRepoMockRecorder) Update(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCompanyRolesRepo)(nil).Update), ctx, role)
+}
+
+// UpdateTx mocks base method.
+func (m *MockCompanyRolesRepo) UpdateTx(ctx context.Context, tx *xorm.Session, role *types.CompanyRole) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTx", ctx, tx, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTx indicates an expected call of UpdateTx.
+func (mr *MockCompanyRolesRepoMockRecorder) UpdateTx(ctx, tx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTx", reflect.TypeOf((*MockCompanyRolesRepo)(nil).UpdateTx), ctx, tx, role)
+}
@@ -40,6 +40,20 @@ func (m *MockGlobalRepo) EXPECT() *MockGlobalRepoMockRecorder {
 	return m.recorder
 }
 
+// APIKeys mocks base method.
+func (m *MockGlobalRepo) APIKeys() repos.APIKeysRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "APIKeys")
+	ret0, _ := ret[0].(repos.APIKeysRepo)
+	return ret0
+}
+
+// APIKeys indicates an expected call of APIKeys.
+func (mr *MockGlobalRepoMockRecorder) APIKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "APIKeys", reflect.TypeOf((*MockGlobalRepo)(nil).APIKeys))
+}
+
 // Addresses mocks base method.
 func (m *MockGlobalRepo) Addresses() repos.AddressesRepo {
 	m.ctrl.T.Helper()
@@ -54,6 +68,20 @@ func (mr *MockGlobalRepoMockRecorder) Addresses() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Addresses", reflect.TypeOf((*MockGlobalRepo)(nil).Addresses))
 }
 
+// AuditLog mocks base method.
+func (m *MockGlobalRepo) AuditLog() repos.AuditLogRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuditLog")
+	ret0, _ := ret[0].(repos.AuditLogRepo)
+	return ret0
+}
+
+// AuditLog indicates an expected call of AuditLog.
+func (mr *MockGlobalRepoMockRecorder) AuditLog() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuditLog", reflect.TypeOf((*MockGlobalRepo)(nil).AuditLog))
+}
+
 // Commodities mocks base method.
 func (m *MockGlobalRepo) Commodities() repos.CommoditiesRepo {
 	m.ctrl.T.Helper()
@@ -82,6 +110,20 @@ func (mr *MockGlobalRepoMockRecorder) CommodityAttributes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommodityAttributes", reflect.TypeOf((*MockGlobalRepo)(nil).CommodityAttributes))
 }
 
+// CommodityTypes mocks base method.
+func (m *MockGlobalRepo) CommodityTypes() repos.CommodityTypesRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommodityTypes")
+	ret0, _ := ret[0].(repos.CommodityTypesRepo)
+	return ret0
+}
+
+// CommodityTypes indicates an expected call of CommodityTypes.
+func (mr *MockGlobalRepoMockRecorder) CommodityTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommodityTypes", reflect.TypeOf((*MockGlobalRepo)(nil).CommodityTypes))
+}
+
 // Companies mocks base method.
 func (m *MockGlobalRepo) Companies() repos.CompaniesRepo {
 	m.ctrl.T.Helper()
@@ -110,6 +152,62 @@ func (mr *MockGlobalRepoMockRecorder) CompanyAttributeSettings() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyAttributeSettings", reflect.TypeOf((*MockGlobalRepo)(nil).CompanyAttributeSettings))
 }
 
+// CompanyRoles mocks base method.
+func (m *MockGlobalRepo) CompanyRoles() repos.CompanyRolesRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompanyRoles")
+	ret0, _ := ret[0].(repos.CompanyRolesRepo)
+	return ret0
+}
+
+// CompanyRoles indicates an expected call of CompanyRoles.
+func (mr *MockGlobalRepoMockRecorder) CompanyRoles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompanyRoles", reflect.TypeOf((*MockGlobalRepo)(nil).CompanyRoles))
+}
+
+// Events mocks base method.
+func (m *MockGlobalRepo) Events() repos.EventsRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Events")
+	ret0, _ := ret[0].(repos.EventsRepo)
+	return ret0
+}
+
+// Events indicates an expected call of Events.
+func (mr *MockGlobalRepoMockRecorder) Events() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Events", reflect.TypeOf((*MockGlobalRepo)(nil).Events))
+}
+
+// IdempotencyKeys mocks base method.
+func (m *MockGlobalRepo) IdempotencyKeys() repos.IdempotencyKeysRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IdempotencyKeys")
+	ret0, _ := ret[0].(repos.IdempotencyKeysRepo)
+	return ret0
+}
+
+// IdempotencyKeys indicates an expected call of IdempotencyKeys.
+func (mr *MockGlobalRepoMockRecorder) IdempotencyKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IdempotencyKeys", reflect.TypeOf((*MockGlobalRepo)(nil).IdempotencyKeys))
+}
+
+// Invitations mocks base method.
+func (m *MockGlobalRepo) Invitations() repos.InvitationsRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invitations")
+	ret0, _ := ret[0].(repos.InvitationsRepo)
+	return ret0
+}
+
+// Invitations indicates an expected call of Invitations.
+func (mr *MockGlobalRepoMockRecorder) Invitations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invitations", reflect.TypeOf((*MockGlobalRepo)(nil).Invitations))
+}
+
 // Locations mocks base method.
 func (m *MockGlobalRepo) Locations() repos.LocationsRepo {
 	m.ctrl.T.Helper()
@@ -124,6 +222,48 @@ func (mr *MockGlobalRepoMockRecorder) Locations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Locations", reflect.TypeOf((*MockGlobalRepo)(nil).Locations))
 }
 
+// LoginThrottles mocks base method.
+func (m *MockGlobalRepo) LoginThrottles() repos.LoginThrottlesRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginThrottles")
+	ret0, _ := ret[0].(repos.LoginThrottlesRepo)
+	return ret0
+}
+
+// LoginThrottles indicates an expected call of LoginThrottles.
+func (mr *MockGlobalRepoMockRecorder) LoginThrottles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginThrottles", reflect.TypeOf((*MockGlobalRepo)(nil).LoginThrottles))
+}
+
+// MFA mocks base method.
+func (m *MockGlobalRepo) MFA() repos.MFARepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MFA")
+	ret0, _ := ret[0].(repos.MFARepo)
+	return ret0
+}
+
+// MFA indicates an expected call of MFA.
+func (mr *MockGlobalRepoMockRecorder) MFA() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MFA", reflect.TypeOf((*MockGlobalRepo)(nil).MFA))
+}
+
+// PasswordResetTokens mocks base method.
+func (m *MockGlobalRepo) PasswordResetTokens() repos.PasswordResetTokensRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PasswordResetTokens")
+	ret0, _ := ret[0].(repos.PasswordResetTokensRepo)
+	return ret0
+}
+
+// PasswordResetTokens indicates an expected call of PasswordResetTokens.
+func (mr *MockGlobalRepoMockRecorder) PasswordResetTokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PasswordResetTokens", reflect.TypeOf((*MockGlobalRepo)(nil).PasswordResetTokens))
+}
+
 // ProductAttributeValues mocks base method.
 func (m *MockGlobalRepo) ProductAttributeValues() repos.ProductAttributeValuesRepo {
 	m.ctrl.T.Helper()
@@ -138,6 +278,20 @@ func (mr *MockGlobalRepoMockRecorder) ProductAttributeValues() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductAttributeValues", reflect.TypeOf((*MockGlobalRepo)(nil).ProductAttributeValues))
 }
 
+// ProductPacks mocks base method.
+func (m *MockGlobalRepo) ProductPacks() repos.ProductPacksRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProductPacks")
+	ret0, _ := ret[0].(repos.ProductPacksRepo)
+	return ret0
+}
+
+// ProductPacks indicates an expected call of ProductPacks.
+func (mr *MockGlobalRepoMockRecorder) ProductPacks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProductPacks", reflect.TypeOf((*MockGlobalRepo)(nil).ProductPacks))
+}
+
 // Products mocks base method.
 func (m *MockGlobalRepo) Products() repos.ProductsRepo {
 	m.ctrl.T.Helper()
@@ -152,6 +306,34 @@ func (mr *MockGlobalRepoMockRecorder) Products() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Products", reflect.TypeOf((*MockGlobalRepo)(nil).Products))
 }
 
+// SSO mocks base method.
+func (m *MockGlobalRepo) SSO() repos.SSORepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SSO")
+	ret0, _ := ret[0].(repos.SSORepo)
+	return ret0
+}
+
+// SSO indicates an expected call of SSO.
+func (mr *MockGlobalRepoMockRecorder) SSO() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SSO", reflect.TypeOf((*MockGlobalRepo)(nil).SSO))
+}
+
+// Sessions mocks base method.
+func (m *MockGlobalRepo) Sessions() repos.SessionsRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sessions")
+	ret0, _ := ret[0].(repos.SessionsRepo)
+	return ret0
+}
+
+// Sessions indicates an expected call of Sessions.
+func (mr *MockGlobalRepoMockRecorder) Sessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sessions", reflect.TypeOf((*MockGlobalRepo)(nil).Sessions))
+}
+
 // Users mocks base method.
 func (m *MockGlobalRepo) Users() repos.UsersRepo {
 	m.ctrl.T.Helper()
@@ -165,3 +347,17 @@ func (mr *MockGlobalRepoMockRecorder) Users() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Users", reflect.TypeOf((*MockGlobalRepo)(nil).Users))
 }
+
+// Webhooks mocks base method.
+func (m *MockGlobalRepo) Webhooks() repos.WebhooksRepo {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks")
+	ret0, _ := ret[0].(repos.WebhooksRepo)
+	return ret0
+}
+
+// Webhooks indicates an expected call of Webhooks.
+func (mr *MockGlobalRepoMockRecorder) Webhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockGlobalRepo)(nil).Webhooks))
+}
@@ -0,0 +1,191 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./invitations.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./invitations.go -destination=./mocks/invitations.go -package=mock_repos InvitationsRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockInvitationsRepo is a mock of InvitationsRepo interface.
+type MockInvitationsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationsRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockInvitationsRepoMockRecorder is the mock recorder for MockInvitationsRepo.
+type MockInvitationsRepoMockRecorder struct {
+	mock *MockInvitationsRepo
+}
+
+// NewMockInvitationsRepo creates a new mock instance.
+func NewMockInvitationsRepo(ctrl *gomock.Controller) *MockInvitationsRepo {
+	mock := &MockInvitationsRepo{ctrl: ctrl}
+	mock.recorder = &MockInvitationsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitationsRepo) EXPECT() *MockInvitationsRepoMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvitationsRepo) Accept(ctx context.Context, tokenHash string, user *types.User) (*types.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, tokenHash, user)
+	ret0, _ := ret[0].(*types.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInvitationsRepoMockRecorder) Accept(ctx, tokenHash, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvitationsRepo)(nil).Accept), ctx, tokenHash, user)
+}
+
+// AcceptTx mocks base method.
+func (m *MockInvitationsRepo) AcceptTx(ctx context.Context, tx *xorm.Session, tokenHash string, user *types.User) (*types.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptTx", ctx, tx, tokenHash, user)
+	ret0, _ := ret[0].(*types.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptTx indicates an expected call of AcceptTx.
+func (mr *MockInvitationsRepoMockRecorder) AcceptTx(ctx, tx, tokenHash, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptTx", reflect.TypeOf((*MockInvitationsRepo)(nil).AcceptTx), ctx, tx, tokenHash, user)
+}
+
+// Create mocks base method.
+func (m *MockInvitationsRepo) Create(ctx context.Context, invitation *types.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInvitationsRepoMockRecorder) Create(ctx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInvitationsRepo)(nil).Create), ctx, invitation)
+}
+
+// CreateTx mocks base method.
+func (m *MockInvitationsRepo) CreateTx(ctx context.Context, tx *xorm.Session, invitation *types.Invitation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, invitation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockInvitationsRepoMockRecorder) CreateTx(ctx, tx, invitation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockInvitationsRepo)(nil).CreateTx), ctx, tx, invitation)
+}
+
+// Find mocks base method.
+func (m *MockInvitationsRepo) Find(ctx context.Context, opts *repos.InvitationFindOpts) ([]*types.Invitation, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.Invitation)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockInvitationsRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockInvitationsRepo)(nil).Find), ctx, opts)
+}
+
+// Get mocks base method.
+func (m *MockInvitationsRepo) Get(ctx context.Context, companyID, id int64) (*types.Invitation, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, companyID, id)
+	ret0, _ := ret[0].(*types.Invitation)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInvitationsRepoMockRecorder) Get(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInvitationsRepo)(nil).Get), ctx, companyID, id)
+}
+
+// Renew mocks base method.
+func (m *MockInvitationsRepo) Renew(ctx context.Context, companyID, id int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Renew", ctx, companyID, id, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Renew indicates an expected call of Renew.
+func (mr *MockInvitationsRepoMockRecorder) Renew(ctx, companyID, id, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Renew", reflect.TypeOf((*MockInvitationsRepo)(nil).Renew), ctx, companyID, id, tokenHash, expiresAt)
+}
+
+// RenewTx mocks base method.
+func (m *MockInvitationsRepo) RenewTx(ctx context.Context, tx *xorm.Session, companyID, id int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenewTx", ctx, tx, companyID, id, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenewTx indicates an expected call of RenewTx.
+func (mr *MockInvitationsRepoMockRecorder) RenewTx(ctx, tx, companyID, id, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewTx", reflect.TypeOf((*MockInvitationsRepo)(nil).RenewTx), ctx, tx, companyID, id, tokenHash, expiresAt)
+}
+
+// Revoke mocks base method.
+func (m *MockInvitationsRepo) Revoke(ctx context.Context, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockInvitationsRepoMockRecorder) Revoke(ctx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockInvitationsRepo)(nil).Revoke), ctx, companyID, id)
+}
+
+// RevokeTx mocks base method.
+func (m *MockInvitationsRepo) RevokeTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTx", ctx, tx, companyID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTx indicates an expected call of RevokeTx.
+func (mr *MockInvitationsRepoMockRecorder) RevokeTx(ctx, tx, companyID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTx", reflect.TypeOf((*MockInvitationsRepo)(nil).RevokeTx), ctx, tx, companyID, id)
+}
@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./password_reset_tokens.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./password_reset_tokens.go -destination=./mocks/password_reset_tokens.go -package=mock_repos PasswordResetTokensRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockPasswordResetTokensRepo is a mock of PasswordResetTokensRepo interface.
+type MockPasswordResetTokensRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordResetTokensRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordResetTokensRepoMockRecorder is the mock recorder for MockPasswordResetTokensRepo.
+type MockPasswordResetTokensRepoMockRecorder struct {
+	mock *MockPasswordResetTokensRepo
+}
+
+// NewMockPasswordResetTokensRepo creates a new mock instance.
+func NewMockPasswordResetTokensRepo(ctrl *gomock.Controller) *MockPasswordResetTokensRepo {
+	mock := &MockPasswordResetTokensRepo{ctrl: ctrl}
+	mock.recorder = &MockPasswordResetTokensRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordResetTokensRepo) EXPECT() *MockPasswordResetTokensRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordResetTokensRepo) Create(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordResetTokensRepoMockRecorder) Create(ctx, userID, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordResetTokensRepo)(nil).Create), ctx, userID, tokenHash, expiresAt)
+}
+
+// CreateTx mocks base method.
+func (m *MockPasswordResetTokensRepo) CreateTx(ctx context.Context, tx *xorm.Session, userID int64, tokenHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, userID, tokenHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockPasswordResetTokensRepoMockRecorder) CreateTx(ctx, tx, userID, tokenHash, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockPasswordResetTokensRepo)(nil).CreateTx), ctx, tx, userID, tokenHash, expiresAt)
+}
+
+// ResetPassword mocks base method.
+func (m *MockPasswordResetTokensRepo) ResetPassword(ctx context.Context, tokenHash, newPassword string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, tokenHash, newPassword)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockPasswordResetTokensRepoMockRecorder) ResetPassword(ctx, tokenHash, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockPasswordResetTokensRepo)(nil).ResetPassword), ctx, tokenHash, newPassword)
+}
+
+// ResetPasswordTx mocks base method.
+func (m *MockPasswordResetTokensRepo) ResetPasswordTx(ctx context.Context, tx *xorm.Session, tokenHash, newPassword string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPasswordTx", ctx, tx, tokenHash, newPassword)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPasswordTx indicates an expected call of ResetPasswordTx.
+func (mr *MockPasswordResetTokensRepoMockRecorder) ResetPasswordTx(ctx, tx, tokenHash, newPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPasswordTx", reflect.TypeOf((*MockPasswordResetTokensRepo)(nil).ResetPasswordTx), ctx, tx, tokenHash, newPassword)
+}
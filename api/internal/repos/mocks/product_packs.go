@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./product_packs.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./product_packs.go -destination=./mocks/product_packs.go -package=mock_repos ProductPacksRepo
+//
+
+// Package mock_repos is a generated GoMock package.
+package mock_repos
+
+import (
+	context "context"
+	reflect "reflect"
+
+	repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	types "github.com/happilymarrieddad/order-management-v3/api/types"
+	gomock "go.uber.org/mock/gomock"
+	xorm "xorm.io/xorm"
+)
+
+// MockProductPacksRepo is a mock of ProductPacksRepo interface.
+type MockProductPacksRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductPacksRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockProductPacksRepoMockRecorder is the mock recorder for MockProductPacksRepo.
+type MockProductPacksRepoMockRecorder struct {
+	mock *MockProductPacksRepo
+}
+
+// NewMockProductPacksRepo creates a new mock instance.
+func NewMockProductPacksRepo(ctrl *gomock.Controller) *MockProductPacksRepo {
+	mock := &MockProductPacksRepo{ctrl: ctrl}
+	mock.recorder = &MockProductPacksRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductPacksRepo) EXPECT() *MockProductPacksRepoMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockProductPacksRepo) Create(ctx context.Context, pack *types.ProductPack) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, pack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockProductPacksRepoMockRecorder) Create(ctx, pack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProductPacksRepo)(nil).Create), ctx, pack)
+}
+
+// CreateTx mocks base method.
+func (m *MockProductPacksRepo) CreateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", ctx, tx, pack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockProductPacksRepoMockRecorder) CreateTx(ctx, tx, pack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockProductPacksRepo)(nil).CreateTx), ctx, tx, pack)
+}
+
+// Delete mocks base method.
+func (m *MockProductPacksRepo) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockProductPacksRepoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProductPacksRepo)(nil).Delete), ctx, id)
+}
+
+// DeleteTx mocks base method.
+func (m *MockProductPacksRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTx", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTx indicates an expected call of DeleteTx.
+func (mr *MockProductPacksRepoMockRecorder) DeleteTx(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTx", reflect.TypeOf((*MockProductPacksRepo)(nil).DeleteTx), ctx, tx, id)
+}
+
+// Find mocks base method.
+func (m *MockProductPacksRepo) Find(ctx context.Context, opts *repos.ProductPackFindOpts) ([]*types.ProductPack, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, opts)
+	ret0, _ := ret[0].([]*types.ProductPack)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockProductPacksRepoMockRecorder) Find(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockProductPacksRepo)(nil).Find), ctx, opts)
+}
+
+// Get mocks base method.
+func (m *MockProductPacksRepo) Get(ctx context.Context, id int64) (*types.ProductPack, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*types.ProductPack)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProductPacksRepoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProductPacksRepo)(nil).Get), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockProductPacksRepo) Update(ctx context.Context, pack *types.ProductPack) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, pack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProductPacksRepoMockRecorder) Update(ctx, pack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProductPacksRepo)(nil).Update), ctx, pack)
+}
+
+// UpdateTx mocks base method.
+func (m *MockProductPacksRepo) UpdateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTx", ctx, tx, pack)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTx indicates an expected call of UpdateTx.
+func (mr *MockProductPacksRepoMockRecorder) UpdateTx(ctx, tx, pack any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTx", reflect.TypeOf((*MockProductPacksRepo)(nil).UpdateTx), ctx, tx, pack)
+}
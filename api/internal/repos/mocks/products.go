@@ -131,6 +131,36 @@ func (mr *MockProductsRepoMockRecorder) Get(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProductsRepo)(nil).Get), ctx, id)
 }
 
+// Import mocks base method.
+func (m *MockProductsRepo) Import(ctx context.Context, rows []*repos.ProductImportRow, dryRun bool) ([]*repos.ProductImportRowError, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, rows, dryRun)
+	ret0, _ := ret[0].([]*repos.ProductImportRowError)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockProductsRepoMockRecorder) Import(ctx, rows, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockProductsRepo)(nil).Import), ctx, rows, dryRun)
+}
+
+// RederiveNames mocks base method.
+func (m *MockProductsRepo) RederiveNames(ctx context.Context, companyID int64, batchSize int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RederiveNames", ctx, companyID, batchSize)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RederiveNames indicates an expected call of RederiveNames.
+func (mr *MockProductsRepoMockRecorder) RederiveNames(ctx, companyID, batchSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RederiveNames", reflect.TypeOf((*MockProductsRepo)(nil).RederiveNames), ctx, companyID, batchSize)
+}
+
 // Update mocks base method.
 func (m *MockProductsRepo) Update(ctx context.Context, product *types.Product, attrs []*types.ProductAttributeValue) error {
 	m.ctrl.T.Helper()
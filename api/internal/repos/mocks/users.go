@@ -43,6 +43,34 @@ func (m *MockUsersRepo) EXPECT() *MockUsersRepoMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockUsersRepo) ChangePassword(ctx context.Context, userID int64, newPassword string, keepSessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, newPassword, keepSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockUsersRepoMockRecorder) ChangePassword(ctx, userID, newPassword, keepSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockUsersRepo)(nil).ChangePassword), ctx, userID, newPassword, keepSessionID)
+}
+
+// ChangePasswordTx mocks base method.
+func (m *MockUsersRepo) ChangePasswordTx(ctx context.Context, tx *xorm.Session, userID int64, newPassword string, keepSessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePasswordTx", ctx, tx, userID, newPassword, keepSessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePasswordTx indicates an expected call of ChangePasswordTx.
+func (mr *MockUsersRepoMockRecorder) ChangePasswordTx(ctx, tx, userID, newPassword, keepSessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePasswordTx", reflect.TypeOf((*MockUsersRepo)(nil).ChangePasswordTx), ctx, tx, userID, newPassword, keepSessionID)
+}
+
 // Create mocks base method.
 func (m *MockUsersRepo) Create(ctx context.Context, user *types.User) error {
 	m.ctrl.T.Helper()
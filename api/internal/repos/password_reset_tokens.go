@@ -0,0 +1,106 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// ErrPasswordResetTokenInvalid is returned when a reset token is unknown, expired or already used.
+var ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid or expired")
+
+// PasswordResetTokensRepo defines the interface for password reset token operations.
+//
+//go:generate mockgen -source=./password_reset_tokens.go -destination=./mocks/password_reset_tokens.go -package=mock_repos PasswordResetTokensRepo
+type PasswordResetTokensRepo interface {
+	Create(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error
+	CreateTx(ctx context.Context, tx *xorm.Session, userID int64, tokenHash string, expiresAt time.Time) error
+	ResetPassword(ctx context.Context, tokenHash, newPassword string) (int64, error)
+	ResetPasswordTx(ctx context.Context, tx *xorm.Session, tokenHash, newPassword string) (int64, error)
+}
+
+type passwordResetTokensRepo struct {
+	db *xorm.Engine
+}
+
+// NewPasswordResetTokensRepo creates a new PasswordResetTokensRepo.
+func NewPasswordResetTokensRepo(db *xorm.Engine) PasswordResetTokensRepo {
+	return &passwordResetTokensRepo{db: db}
+}
+
+// Create stores a new reset token for a user.
+func (r *passwordResetTokensRepo) Create(ctx context.Context, userID int64, tokenHash string, expiresAt time.Time) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, userID, tokenHash, expiresAt)
+	})
+	return err
+}
+
+// CreateTx stores a new reset token for a user. Tokens sent to the user earlier stop working, so
+// only the most recent email can be used.
+func (r *passwordResetTokensRepo) CreateTx(ctx context.Context, tx *xorm.Session, userID int64, tokenHash string, expiresAt time.Time) error {
+	now := time.Now()
+	if _, err := tx.Context(ctx).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Cols("used_at").
+		Update(&types.PasswordResetToken{UsedAt: &now}); err != nil {
+		return fmt.Errorf("failed to invalidate earlier reset tokens: %w", err)
+	}
+
+	_, err := tx.Context(ctx).Insert(&types.PasswordResetToken{
+		UserID:    userID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+	})
+	return err
+}
+
+// ResetPassword sets a new password using a reset token. See ResetPasswordTx.
+func (r *passwordResetTokensRepo) ResetPassword(ctx context.Context, tokenHash, newPassword string) (int64, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) (int64, error) {
+		return r.ResetPasswordTx(ctx, tx, tokenHash, newPassword)
+	})
+}
+
+// ResetPasswordTx uses up the reset token, sets the user's new password and ends all of the user's
+// sessions. It returns the ID of the user whose password was reset, or a *types.PasswordPolicyError
+// when the new password breaks the password policy.
+func (r *passwordResetTokensRepo) ResetPasswordTx(ctx context.Context, tx *xorm.Session, tokenHash, newPassword string) (int64, error) {
+	now := time.Now()
+
+	// The row lock makes a concurrent reset with the same token wait, so the token is used only once.
+	token := new(types.PasswordResetToken)
+	has, err := tx.Context(ctx).Where("token_hash = ?", tokenHash).ForUpdate().Get(token)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+	if !has || token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+		return 0, ErrPasswordResetTokenInvalid
+	}
+
+	user := new(types.User)
+	has, err = tx.Context(ctx).Where("id = ? AND visible = ?", token.UserID, true).Get(user)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user %d: %w", token.UserID, err)
+	}
+	if !has {
+		return 0, ErrPasswordResetTokenInvalid
+	}
+	// The token stays usable when the password is rejected, so the user can try another.
+	if err := types.ActivePasswordPolicy.Check(newPassword, user.Email); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Context(ctx).ID(token.ID).Cols("used_at").Update(&types.PasswordResetToken{UsedAt: &now}); err != nil {
+		return 0, fmt.Errorf("failed to mark password reset token as used: %w", err)
+	}
+	if err := NewUsersRepo(r.db).ChangePasswordTx(ctx, tx, token.UserID, newPassword, 0); err != nil {
+		return 0, fmt.Errorf("failed to update password: %w", err)
+	}
+
+	return token.UserID, nil
+}
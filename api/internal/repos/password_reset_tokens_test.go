@@ -0,0 +1,91 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ = Describe("PasswordResetTokensRepo", func() {
+	var (
+		repo repos.PasswordResetTokensRepo
+		user *types.User
+	)
+
+	BeforeEach(func() {
+		repo = gr.PasswordResetTokens()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company := &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		user = &types.User{
+			CompanyID: company.ID,
+			AddressID: address.ID,
+			Email:     "reset.user@example.com",
+			Password:  "password123",
+			FirstName: "Reset",
+			LastName:  "User",
+			Roles:     types.Roles{types.RoleUser},
+		}
+		Expect(gr.Users().Create(ctx, user)).To(Succeed())
+	})
+
+	passwordMatches := func(password string) bool {
+		retrieved, found, err := gr.Users().Get(ctx, user.CompanyID, user.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		return bcrypt.CompareHashAndPassword([]byte(retrieved.Password), []byte(password)) == nil
+	}
+
+	It("should set the new password, use up the token and end the user's sessions", func() {
+		session := &types.Session{UserID: user.ID, CompanyID: user.CompanyID, ExpiresAt: time.Now().Add(time.Hour)}
+		Expect(gr.Sessions().Create(ctx, session, "refresh-hash")).To(Succeed())
+		Expect(repo.Create(ctx, user.ID, "reset-hash", time.Now().Add(time.Hour))).To(Succeed())
+
+		userID, err := repo.ResetPassword(ctx, "reset-hash", "newpassword123")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(userID).To(Equal(user.ID))
+		Expect(passwordMatches("newpassword123")).To(BeTrue())
+
+		active, err := gr.Sessions().IsTokenActive(ctx, session.ID, "jti")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(BeFalse())
+
+		_, err = repo.ResetPassword(ctx, "reset-hash", "anotherpassword")
+		Expect(err).To(MatchError(repos.ErrPasswordResetTokenInvalid))
+		Expect(passwordMatches("newpassword123")).To(BeTrue())
+	})
+
+	It("should reject an expired token", func() {
+		Expect(repo.Create(ctx, user.ID, "reset-hash", time.Now().Add(-time.Minute))).To(Succeed())
+
+		_, err := repo.ResetPassword(ctx, "reset-hash", "newpassword123")
+		Expect(err).To(MatchError(repos.ErrPasswordResetTokenInvalid))
+		Expect(passwordMatches("password123")).To(BeTrue())
+	})
+
+	It("should invalidate earlier tokens when a new one is created", func() {
+		Expect(repo.Create(ctx, user.ID, "first-hash", time.Now().Add(time.Hour))).To(Succeed())
+		Expect(repo.Create(ctx, user.ID, "second-hash", time.Now().Add(time.Hour))).To(Succeed())
+
+		_, err := repo.ResetPassword(ctx, "first-hash", "newpassword123")
+		Expect(err).To(MatchError(repos.ErrPasswordResetTokenInvalid))
+
+		_, err = repo.ResetPassword(ctx, "second-hash", "newpassword123")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should reject an unknown token", func() {
+		_, err := repo.ResetPassword(ctx, "unknown", "newpassword123")
+		Expect(err).To(MatchError(repos.ErrPasswordResetTokenInvalid))
+	})
+})
@@ -52,8 +52,10 @@ func (r *productAttributeValuesRepo) CreateTx(ctx context.Context, tx *xorm.Sess
 	if err := types.Validate(attr); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).Insert(attr)
-	return err
+	if _, err := tx.Context(ctx).Insert(attr); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityProductAttributeValue, types.AuditActionCreate, attr.ID, nil, attr)
 }
 
 func (r *productAttributeValuesRepo) Update(ctx context.Context, attr *types.ProductAttributeValue) error {
@@ -67,8 +69,10 @@ func (r *productAttributeValuesRepo) UpdateTx(ctx context.Context, tx *xorm.Sess
 	if err := types.Validate(attr); err != nil {
 		return err
 	}
-	_, err := tx.Context(ctx).ID(attr.ID).Cols("value").Update(attr)
-	return err
+	return auditedTx[types.ProductAttributeValue](ctx, tx, types.AuditEntityProductAttributeValue, types.AuditActionUpdate, attr.ID, func() error {
+		_, err := tx.Context(ctx).ID(attr.ID).Cols("value").Update(attr)
+		return err
+	})
 }
 
 func (r *productAttributeValuesRepo) Delete(ctx context.Context, ids ...int64) error {
@@ -82,8 +86,10 @@ func (r *productAttributeValuesRepo) DeleteTx(ctx context.Context, tx *xorm.Sess
 	if len(ids) == 0 {
 		return nil
 	}
-	_, err := tx.Context(ctx).In("id", ids).Delete(&types.ProductAttributeValue{})
-	return err
+	return auditedDeleteTx[types.ProductAttributeValue](ctx, tx, types.AuditEntityProductAttributeValue, ids, func() error {
+		_, err := tx.Context(ctx).In("id", ids).Delete(&types.ProductAttributeValue{})
+		return err
+	})
 }
 
 func (r *productAttributeValuesRepo) Find(ctx context.Context, opts *ProductAttributeValueFindOpts) ([]*types.ProductAttributeValue, int64, error) {
@@ -0,0 +1,154 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// ProductPackFindOpts provides options for finding product packs.
+type ProductPackFindOpts struct {
+	IDs        []int64
+	CompanyIDs []int64
+	ProductIDs []int64
+	Limit      int
+	Offset     int
+}
+
+// ProductPacksRepo defines the interface for product pack data operations.
+//
+//go:generate mockgen -source=./product_packs.go -destination=./mocks/product_packs.go -package=mock_repos ProductPacksRepo
+type ProductPacksRepo interface {
+	Get(ctx context.Context, id int64) (*types.ProductPack, bool, error)
+	Find(ctx context.Context, opts *ProductPackFindOpts) ([]*types.ProductPack, int64, error)
+	Create(ctx context.Context, pack *types.ProductPack) error
+	CreateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error
+	Update(ctx context.Context, pack *types.ProductPack) error
+	UpdateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error
+	Delete(ctx context.Context, id int64) error
+	DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error
+}
+
+type productPacksRepo struct {
+	db *xorm.Engine
+}
+
+// NewProductPacksRepo creates a new ProductPacksRepo.
+func NewProductPacksRepo(db *xorm.Engine) ProductPacksRepo {
+	return &productPacksRepo{db: db}
+}
+
+// Get retrieves a visible product pack by ID.
+func (r *productPacksRepo) Get(ctx context.Context, id int64) (*types.ProductPack, bool, error) {
+	pack := new(types.ProductPack)
+	has, err := r.db.Context(ctx).Where("id = ? AND visible = ?", id, true).Get(pack)
+	return pack, has, err
+}
+
+// Find retrieves a list of visible product packs with pagination and filtering, and a total count.
+func (r *productPacksRepo) Find(ctx context.Context, opts *ProductPackFindOpts) ([]*types.ProductPack, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	s.Where("visible = ?", true)
+	applyProductPackFindOpts(s, opts)
+	var packs []*types.ProductPack
+	count, err := s.OrderBy("product_id ASC, id ASC").FindAndCount(&packs)
+	return packs, count, err
+}
+
+// Create inserts a new product pack into the database.
+func (r *productPacksRepo) Create(ctx context.Context, pack *types.ProductPack) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, pack)
+	})
+	return err
+}
+
+// CreateTx inserts a new product pack for the pack's product. The company is taken from the product.
+func (r *productPacksRepo) CreateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error {
+	if err := types.Validate(pack); err != nil {
+		return err
+	}
+
+	product := new(types.Product)
+	has, err := tx.Context(ctx).Where("id = ? AND visible = ?", pack.ProductID, true).Get(product)
+	if err != nil {
+		return fmt.Errorf("failed to get product %d: %w", pack.ProductID, err)
+	}
+	if !has {
+		return types.NewNotFoundError(fmt.Sprintf("product %d", pack.ProductID))
+	}
+
+	pack.CompanyID = product.CompanyID
+	pack.Visible = true
+	if pack.CasesPerPallet == 0 {
+		pack.CasesPerPallet = pack.Ti * pack.Hi
+	}
+
+	if _, err = tx.Context(ctx).Insert(pack); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityProductPack, types.AuditActionCreate, pack.ID, nil, pack)
+}
+
+// Update updates an existing product pack. The product and company cannot be changed.
+func (r *productPacksRepo) Update(ctx context.Context, pack *types.ProductPack) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.UpdateTx(ctx, tx, pack)
+	})
+	return err
+}
+
+func (r *productPacksRepo) UpdateTx(ctx context.Context, tx *xorm.Session, pack *types.ProductPack) error {
+	if err := types.Validate(pack); err != nil {
+		return err
+	}
+
+	if pack.CasesPerPallet == 0 {
+		pack.CasesPerPallet = pack.Ti * pack.Hi
+	}
+
+	return auditedTx[types.ProductPack](ctx, tx, types.AuditEntityProductPack, types.AuditActionUpdate, pack.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(pack.ID).Cols(
+			"pack_style", "count_per_case", "unit_weight", "net_weight", "gross_weight", "weight_unit",
+			"case_length", "case_width", "case_height", "dimension_unit", "ti", "hi", "cases_per_pallet",
+		).Update(pack))
+	})
+}
+
+// Delete performs a soft delete on a product pack.
+func (r *productPacksRepo) Delete(ctx context.Context, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.DeleteTx(ctx, tx, id)
+	})
+	return err
+}
+
+// DeleteTx performs a soft delete on a product pack by setting its visible flag to false.
+func (r *productPacksRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
+	return auditedTx[types.ProductPack](ctx, tx, types.AuditEntityProductPack, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.ProductPack{Visible: false})
+		return err
+	})
+}
+
+// applyProductPackFindOpts is a helper function to build the query based on find options.
+func applyProductPackFindOpts(s *xorm.Session, opts *ProductPackFindOpts) {
+	if opts == nil {
+		return
+	}
+	if len(opts.IDs) > 0 {
+		s.In("id", opts.IDs)
+	}
+	if len(opts.CompanyIDs) > 0 {
+		s.In("company_id", opts.CompanyIDs)
+	}
+	if len(opts.ProductIDs) > 0 {
+		s.In("product_id", opts.ProductIDs)
+	}
+	if opts.Limit > 0 {
+		s.Limit(opts.Limit, opts.Offset)
+	}
+}
@@ -0,0 +1,137 @@
+package repos_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProductPacksRepo", func() {
+	var (
+		repo    repos.ProductPacksRepo
+		company *types.Company
+		product *types.Product
+	)
+
+	newPack := func() *types.ProductPack {
+		return &types.ProductPack{
+			ProductID:    product.ID,
+			PackStyle:    "carton",
+			CountPerCase: 88,
+			NetWeight:    40,
+			GrossWeight:  42.5,
+			WeightUnit:   types.WeightUnitPound,
+			Ti:           10,
+			Hi:           6,
+		}
+	}
+
+	BeforeEach(func() {
+		repo = gr.ProductPacks()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		commodity := &types.Commodity{Name: "Apple", CommodityType: types.CommodityTypeProduce}
+		Expect(gr.Commodities().Create(ctx, commodity)).To(Succeed())
+
+		product = &types.Product{CompanyID: company.ID, CommodityID: commodity.ID}
+		Expect(gr.Products().Create(ctx, product, nil)).To(Succeed())
+	})
+
+	Describe("Create and Get", func() {
+		It("should create a pack with the product's company and default cases per pallet", func() {
+			pack := newPack()
+			Expect(repo.Create(ctx, pack)).To(Succeed())
+			Expect(pack.ID).NotTo(BeZero())
+
+			retrieved, found, err := repo.Get(ctx, pack.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.CompanyID).To(Equal(company.ID))
+			Expect(retrieved.CountPerCase).To(Equal(88))
+			Expect(retrieved.NetWeight).To(Equal(40.0))
+			Expect(retrieved.GrossWeight).To(Equal(42.5))
+			Expect(retrieved.CasesPerPallet).To(Equal(60))
+		})
+
+		It("should keep an explicit cases per pallet", func() {
+			pack := newPack()
+			pack.CasesPerPallet = 56
+			Expect(repo.Create(ctx, pack)).To(Succeed())
+
+			retrieved, found, err := repo.Get(ctx, pack.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.CasesPerPallet).To(Equal(56))
+		})
+
+		It("should return a not found error for an unknown product", func() {
+			pack := newPack()
+			pack.ProductID = 999
+			err := repo.Create(ctx, pack)
+			Expect(types.IsNotFoundError(err)).To(BeTrue())
+		})
+
+		It("should reject an invalid pack", func() {
+			pack := newPack()
+			pack.WeightUnit = "stone"
+			Expect(repo.Create(ctx, pack)).NotTo(Succeed())
+		})
+	})
+
+	Describe("Update", func() {
+		It("should update the pack configuration", func() {
+			pack := newPack()
+			Expect(repo.Create(ctx, pack)).To(Succeed())
+
+			pack.PackStyle = "bag"
+			pack.CountPerCase = 10
+			pack.UnitWeight = 5
+			pack.NetWeight = 50
+			pack.GrossWeight = 51
+			pack.Ti, pack.Hi, pack.CasesPerPallet = 8, 5, 0
+			Expect(repo.Update(ctx, pack)).To(Succeed())
+
+			retrieved, found, err := repo.Get(ctx, pack.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.PackStyle).To(Equal("bag"))
+			Expect(retrieved.UnitWeight).To(Equal(5.0))
+			Expect(retrieved.CasesPerPallet).To(Equal(40))
+			Expect(retrieved.CompanyID).To(Equal(company.ID))
+		})
+	})
+
+	Describe("Delete and Find", func() {
+		It("should only find visible packs of the requested products", func() {
+			pack1 := newPack()
+			pack2 := newPack()
+			pack2.PackStyle = "bag"
+			Expect(repo.Create(ctx, pack1)).To(Succeed())
+			Expect(repo.Create(ctx, pack2)).To(Succeed())
+
+			packs, count, err := repo.Find(ctx, &repos.ProductPackFindOpts{ProductIDs: []int64{product.ID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(2)))
+			Expect(packs).To(HaveLen(2))
+
+			Expect(repo.Delete(ctx, pack1.ID)).To(Succeed())
+
+			_, found, err := repo.Get(ctx, pack1.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			packs, count, err = repo.Find(ctx, &repos.ProductPackFindOpts{CompanyIDs: []int64{company.ID}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(packs[0].ID).To(Equal(pack2.ID))
+		})
+	})
+})
@@ -2,10 +2,13 @@ package repos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/jackc/pgx/v5/pgconn"
 	"xorm.io/xorm"
 )
 
@@ -21,8 +24,37 @@ type ProductsRepo interface {
 	Delete(ctx context.Context, id int64) error
 	DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error
 	Find(ctx context.Context, opts *ProductFindOpts) ([]*types.Product, int64, error) // Added
+	RederiveNames(ctx context.Context, companyID int64, batchSize int) (int64, error)
+	Import(ctx context.Context, rows []*ProductImportRow, dryRun bool) ([]*ProductImportRowError, error)
 }
 
+// ProductExistsError is returned by Create and Update when the company already has a visible product
+// with the same commodity and attribute values.
+type ProductExistsError struct {
+	ExistingID int64
+}
+
+func (e *ProductExistsError) Error() string {
+	return fmt.Sprintf("a product with the same commodity and attributes already exists (id %d)", e.ExistingID)
+}
+
+// ProductImportRow is a product to be created by Import. Row is the line of the source file the
+// product came from and is only used to report errors.
+type ProductImportRow struct {
+	Row        int
+	Product    *types.Product
+	Attributes []*types.ProductAttributeValue
+}
+
+// ProductImportRowError is the reason a single row of an import could not be created.
+type ProductImportRowError struct {
+	Row int
+	Err error
+}
+
+// defaultRederiveBatchSize is the number of products renamed per transaction by RederiveNames.
+const defaultRederiveBatchSize = 100
+
 type productsRepo struct {
 	db *xorm.Engine
 }
@@ -37,10 +69,33 @@ type ProductFindOpts struct {
 	CompanyID int64
 	IDs       []int64
 	Name      string
-	Limit     int
-	Offset    int
+	// Query is a full-text search over the product name, commodity name and attribute values, in
+	// web search syntax ("granny apple organic", "apple -red", "\"granny smith\""). When set, results
+	// are ordered by relevance.
+	Query  string
+	Limit  int
+	Offset int
 }
 
+// productSearchConfig is the Postgres text search configuration used for products.search_vector.
+const productSearchConfig = "english"
+
+// productSearchVectorSQL rebuilds products.search_vector from the derived name (weight A), the
+// commodity name (B) and the attribute values (C). Callers append the condition selecting the
+// products to refresh. The 20250909090000_add_search_vector_to_products migration uses the same
+// expression.
+const productSearchVectorSQL = `UPDATE products AS p
+SET search_vector =
+	setweight(to_tsvector('` + productSearchConfig + `', COALESCE(p.name, '')), 'A') ||
+	setweight(to_tsvector('` + productSearchConfig + `', COALESCE(c.name, '')), 'B') ||
+	setweight(to_tsvector('` + productSearchConfig + `', COALESCE((
+		SELECT string_agg(pav.value, ' ')
+		FROM product_attribute_values pav
+		WHERE pav.product_id = p.id
+	), '')), 'C')
+FROM commodities AS c
+WHERE c.id = p.commodity_id AND `
+
 func (r *productsRepo) Get(ctx context.Context, id int64) (*types.Product, bool, error) {
 	product := new(types.Product)
 	has, err := r.db.Context(ctx).ID(id).Get(product)
@@ -61,10 +116,14 @@ func (r *productsRepo) CreateTx(ctx context.Context, tx *xorm.Session, product *
 	}
 
 	product.Visible = true
+	product.Fingerprint = types.ProductFingerprint(product.CommodityID, attrs)
+	if err := r.checkFingerprintTx(ctx, tx, product); err != nil {
+		return err
+	}
 
 	// Insert the base product record first to get an ID
 	if _, err := tx.Context(ctx).Insert(product); err != nil {
-		return err
+		return r.fingerprintConflict(ctx, product, err)
 	}
 
 	// Insert the attribute values
@@ -77,7 +136,10 @@ func (r *productsRepo) CreateTx(ctx context.Context, tx *xorm.Session, product *
 	}
 
 	// Derive and save the product name based on its attributes
-	return r.deriveAndSaveNameTx(ctx, tx, product)
+	if err := r.deriveAndSaveNameTx(ctx, tx, product); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityProduct, types.AuditActionCreate, product.ID, nil, product)
 }
 
 // Update updates an existing product and its attributes.
@@ -93,31 +155,46 @@ func (r *productsRepo) UpdateTx(ctx context.Context, tx *xorm.Session, product *
 		return err
 	}
 
-	// 1. Update the base product record (excluding the name)
-	if _, err := tx.Context(ctx).ID(product.ID).Cols("commodity_id", "company_id", "visible").Update(product); err != nil {
+	// The fingerprint is derived from the new attributes, or the stored ones if none are provided.
+	fingerprintAttrs := attrs
+	if len(fingerprintAttrs) == 0 {
+		if err := tx.Context(ctx).Where("product_id = ?", product.ID).Find(&fingerprintAttrs); err != nil {
+			return fmt.Errorf("failed to get product attribute values for product %d: %w", product.ID, err)
+		}
+	}
+	product.Fingerprint = types.ProductFingerprint(product.CommodityID, fingerprintAttrs)
+	if err := r.checkFingerprintTx(ctx, tx, product); err != nil {
 		return err
 	}
 
-	// If new attributes are provided, delete existing ones and insert the new ones.
-	// Otherwise, keep the existing attributes.
-	if len(attrs) > 0 {
-		// 2. Delete existing attribute values
-		if _, err := tx.Context(ctx).Where("product_id = ?", product.ID).Delete(&types.ProductAttributeValue{}); err != nil {
-			return err
+	// Attribute changes show in the audit log through the product's derived name.
+	return auditedTx[types.Product](ctx, tx, types.AuditEntityProduct, types.AuditActionUpdate, product.ID, func() error {
+		// 1. Update the base product record (excluding the name)
+		if err := versionChecked(tx.Context(ctx).ID(product.ID).Cols("commodity_id", "company_id", "visible", "fingerprint").Update(product)); err != nil {
+			return r.fingerprintConflict(ctx, product, err)
 		}
 
-		// 3. Insert the new attribute values
-		for _, attr := range attrs {
-			attr.ProductID = product.ID
-			attr.CompanyID = product.CompanyID
-			if _, err := tx.Context(ctx).Insert(attr); err != nil {
+		// If new attributes are provided, delete existing ones and insert the new ones.
+		// Otherwise, keep the existing attributes.
+		if len(attrs) > 0 {
+			// 2. Delete existing attribute values
+			if _, err := tx.Context(ctx).Where("product_id = ?", product.ID).Delete(&types.ProductAttributeValue{}); err != nil {
 				return err
 			}
+
+			// 3. Insert the new attribute values
+			for _, attr := range attrs {
+				attr.ProductID = product.ID
+				attr.CompanyID = product.CompanyID
+				if _, err := tx.Context(ctx).Insert(attr); err != nil {
+					return err
+				}
+			}
 		}
-	}
 
-	// 4. Derive and save the product name based on its new attributes
-	return r.deriveAndSaveNameTx(ctx, tx, product)
+		// 4. Derive and save the product name based on its new attributes
+		return r.deriveAndSaveNameTx(ctx, tx, product)
+	})
 }
 
 // Delete performs a soft delete on a product.
@@ -130,8 +207,10 @@ func (r *productsRepo) Delete(ctx context.Context, id int64) error {
 
 // DeleteTx performs a soft delete on a product by setting their visible flag to false.
 func (r *productsRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
-	_, err := tx.Context(ctx).ID(id).Cols("visible").Update(&types.Product{Visible: false})
-	return err
+	return auditedTx[types.Product](ctx, tx, types.AuditEntityProduct, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.Product{Visible: false})
+		return err
+	})
 }
 
 // Find retrieves a list of visible products with pagination and filtering, and a total count.
@@ -140,11 +219,53 @@ func (r *productsRepo) Find(ctx context.Context, opts *ProductFindOpts) ([]*type
 	defer s.Close()
 	s.Where("visible = ?", true)
 	applyProductFindOpts(s, opts)
+	if opts == nil || opts.Query == "" {
+		s.OrderBy("id ASC")
+	}
 	var products []*types.Product
 	count, err := s.FindAndCount(&products)
 	return products, count, err
 }
 
+// Import creates all rows with CreateTx in a single transaction. Each row runs under its own savepoint
+// so a failing row is rolled back on its own and the remaining rows are still checked, including
+// against duplicates earlier in the same import. The transaction is only committed when every row
+// succeeded and dryRun is false; otherwise nothing is written and the failing rows are returned.
+func (r *productsRepo) Import(ctx context.Context, rows []*ProductImportRow, dryRun bool) ([]*ProductImportRowError, error) {
+	sess := r.db.NewSession()
+	defer sess.Close()
+
+	if err := sess.Begin(); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	var rowErrs []*ProductImportRowError
+	for i, row := range rows {
+		savepoint := fmt.Sprintf("product_import_%d", i)
+		if _, err := sess.Context(ctx).Exec("SAVEPOINT " + savepoint); err != nil {
+			return nil, handleRollback(sess, err)
+		}
+
+		if err := r.CreateTx(ctx, sess, row.Product, row.Attributes); err != nil {
+			if _, rbErr := sess.Context(ctx).Exec("ROLLBACK TO SAVEPOINT " + savepoint); rbErr != nil {
+				return nil, handleRollback(sess, rbErr)
+			}
+			rowErrs = append(rowErrs, &ProductImportRowError{Row: row.Row, Err: err})
+			continue
+		}
+
+		if _, err := sess.Context(ctx).Exec("RELEASE SAVEPOINT " + savepoint); err != nil {
+			return nil, handleRollback(sess, err)
+		}
+	}
+
+	if dryRun || len(rowErrs) > 0 {
+		return rowErrs, sess.Rollback()
+	}
+
+	return nil, sess.Commit()
+}
+
 // applyProductFindOpts is a helper function to build the query based on find options.
 func applyProductFindOpts(s *xorm.Session, opts *ProductFindOpts) {
 	if opts == nil {
@@ -160,72 +281,221 @@ func applyProductFindOpts(s *xorm.Session, opts *ProductFindOpts) {
 	if opts.Name != "" {
 		s.And("LOWER(name) LIKE LOWER(?)", "%"+opts.Name+"%")
 	}
+	if opts.Query != "" {
+		tsQuery := "websearch_to_tsquery('" + productSearchConfig + "', ?)"
+		s.And("search_vector @@ "+tsQuery, opts.Query)
+		s.OrderBy("ts_rank(search_vector, "+tsQuery+") DESC, id ASC", opts.Query)
+	}
 
 	if opts.Limit > 0 {
 		s.Limit(opts.Limit, opts.Offset)
 	}
 }
 
-// deriveAndSaveNameTx constructs the product's name from its attributes and commodity, then updates the record.
+// checkFingerprintTx returns a *ProductExistsError if another visible product of the same company
+// already has the product's fingerprint.
+func (r *productsRepo) checkFingerprintTx(ctx context.Context, tx *xorm.Session, product *types.Product) error {
+	existing := new(types.Product)
+	has, err := tx.Context(ctx).
+		Where("company_id = ? AND fingerprint = ? AND visible = ? AND id != ?", product.CompanyID, product.Fingerprint, true, product.ID).
+		Cols("id").
+		Get(existing)
+	if err != nil {
+		return fmt.Errorf("failed to check for duplicate products: %w", err)
+	}
+	if has {
+		return &ProductExistsError{ExistingID: existing.ID}
+	}
+	return nil
+}
+
+// uniqueViolationCode is the Postgres error code of a unique index violation.
+const uniqueViolationCode = "23505"
+
+// productFingerprintIndex is the unique index that keeps a company's visible products apart by
+// fingerprint.
+const productFingerprintIndex = "uq_products_company_fingerprint"
+
+// fingerprintConflict turns a violation of productFingerprintIndex into a *ProductExistsError. The
+// index is violated when a concurrent request saved the same product after checkFingerprintTx ran.
+// Other errors are returned as they are.
+func (r *productsRepo) fingerprintConflict(ctx context.Context, product *types.Product, err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolationCode || pgErr.ConstraintName != productFingerprintIndex {
+		return err
+	}
+
+	// The violation aborted the transaction, so the other product is looked up outside of it. It
+	// has been committed by the time the index reports it.
+	s := r.db.NewSession()
+	defer s.Close()
+	var existsErr *ProductExistsError
+	if errors.As(r.checkFingerprintTx(ctx, s, product), &existsErr) {
+		return existsErr
+	}
+	return &ProductExistsError{}
+}
+
+// RederiveNames re-derives the names of all of a company's visible products, processing them in batches
+// of batchSize with one transaction per batch. Products whose name does not change are left untouched.
+// It returns the number of products renamed.
+func (r *productsRepo) RederiveNames(ctx context.Context, companyID int64, batchSize int) (int64, error) {
+	if batchSize <= 0 {
+		batchSize = defaultRederiveBatchSize
+	}
+
+	var (
+		lastID  int64
+		renamed int64
+	)
+	for {
+		var batch []*types.Product
+		if err := r.db.Context(ctx).
+			Where("company_id = ? AND id > ? AND visible = true", companyID, lastID).
+			OrderBy("id ASC").
+			Limit(batchSize).
+			Find(&batch); err != nil {
+			return renamed, fmt.Errorf("failed to get products for company %d: %w", companyID, err)
+		}
+		if len(batch) == 0 {
+			return renamed, nil
+		}
+
+		var batchRenamed int64
+		if _, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+			for _, product := range batch {
+				name, err := r.deriveNameTx(ctx, tx, product)
+				if err != nil {
+					return nil, err
+				}
+				if name == product.Name {
+					continue
+				}
+
+				// The rename is a change of its own here, so it is audited and the product takes a
+				// new version.
+				if err = auditedTx[types.Product](ctx, tx, types.AuditEntityProduct, types.AuditActionUpdate, product.ID, func() error {
+					if _, err := tx.Context(ctx).Table(new(types.Product)).ID(product.ID).Incr("version").Update(map[string]interface{}{"name": name}); err != nil {
+						return fmt.Errorf("failed to update product name for product %d: %w", product.ID, err)
+					}
+					if _, err := tx.Context(ctx).Exec(productSearchVectorSQL+"p.id = ?", product.ID); err != nil {
+						return fmt.Errorf("failed to update search vector for product %d: %w", product.ID, err)
+					}
+					return nil
+				}); err != nil {
+					return nil, err
+				}
+				batchRenamed++
+			}
+			return nil, nil
+		}); err != nil {
+			return renamed, err
+		}
+
+		renamed += batchRenamed
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// deriveAndSaveNameTx constructs the product's name from its company's naming template, attributes
+// and commodity, then updates the record.
 func (r *productsRepo) deriveAndSaveNameTx(ctx context.Context, tx *xorm.Session, product *types.Product) error {
+	name, err := r.deriveNameTx(ctx, tx, product)
+	if err != nil {
+		return err
+	}
+	product.Name = name
+
+	// Update the product with the new name. The name is derived from the change that is being made,
+	// so it does not take a version of its own.
+	if _, err = tx.Context(ctx).ID(product.ID).NoVersionCheck().Cols("name").Update(product); err != nil {
+		return fmt.Errorf("failed to update product name for product %d: %w", product.ID, err)
+	}
+
+	// Refresh the search vector from the new name and attribute values
+	if _, err = tx.Context(ctx).Exec(productSearchVectorSQL+"p.id = ?", product.ID); err != nil {
+		return fmt.Errorf("failed to update search vector for product %d: %w", product.ID, err)
+	}
+
+	return nil
+}
+
+// deriveNameTx constructs the product's name from its company's naming template, attributes and
+// commodity without saving it.
+func (r *productsRepo) deriveNameTx(ctx context.Context, tx *xorm.Session, product *types.Product) (string, error) {
 	// 1. Get the base commodity
 	commodity := new(types.Commodity)
 	has, err := tx.Context(ctx).ID(product.CommodityID).Get(commodity)
 	if err != nil {
-		return fmt.Errorf("failed to get commodity %d: %w", product.CommodityID, err)
+		return "", fmt.Errorf("failed to get commodity %d: %w", product.CommodityID, err)
 	}
 	if !has {
-		return fmt.Errorf("commodity %d not found", product.CommodityID)
+		return "", fmt.Errorf("commodity %d not found", product.CommodityID)
 	}
 
-	// 2. Get the company-specific attribute order settings
+	// 2. Get the company's naming template
+	company := new(types.Company)
+	has, err = tx.Context(ctx).ID(product.CompanyID).Get(company)
+	if err != nil {
+		return "", fmt.Errorf("failed to get company %d: %w", product.CompanyID, err)
+	}
+	if !has {
+		return "", fmt.Errorf("company %d not found", product.CompanyID)
+	}
+
+	tmpl, err := types.ParseProductNameTemplate(company.ProductNameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid product name template for company %d: %w", product.CompanyID, err)
+	}
+
+	// 3. Get the company-specific attribute order settings
 	var settings []types.CompanyAttributeSetting
 	if err = tx.Context(ctx).Where("company_id = ?", product.CompanyID).OrderBy("display_order ASC").Find(&settings); err != nil {
-		return fmt.Errorf("failed to get company attribute settings for company %d: %w", product.CompanyID, err)
+		return "", fmt.Errorf("failed to get company attribute settings for company %d: %w", product.CompanyID, err)
 	}
 
-	// 3. Get all attribute values for the product
+	// 4. Get all attribute values for the product, along with their attribute names
 	var productAttrs []types.ProductAttributeValue
 	if err = tx.Context(ctx).Where("product_id = ?", product.ID).Find(&productAttrs); err != nil {
-		return fmt.Errorf("failed to get product attribute values for product %d: %w", product.ID, err)
+		return "", fmt.Errorf("failed to get product attribute values for product %d: %w", product.ID, err)
 	}
 
 	valuesMap := make(map[int64]string)
+	attrIDs := make([]int64, 0, len(productAttrs))
 	for _, pav := range productAttrs {
 		valuesMap[pav.CommodityAttributeID] = pav.Value
+		attrIDs = append(attrIDs, pav.CommodityAttributeID)
+	}
+
+	values := types.ProductNameValues{
+		Commodity:  commodity.Name,
+		Attributes: make(map[string]string),
+	}
+
+	if len(attrIDs) > 0 {
+		var attrs []types.CommodityAttribute
+		if err = tx.Context(ctx).In("id", attrIDs).Find(&attrs); err != nil {
+			return "", fmt.Errorf("failed to get commodity attributes for product %d: %w", product.ID, err)
+		}
+		for _, attr := range attrs {
+			values.Attributes[strings.ToLower(attr.Name)] = valuesMap[attr.ID]
+		}
 	}
 
-	var nameParts []string
 	// If a custom order is defined, use it.
 	if len(settings) > 0 {
 		for _, setting := range settings {
 			if value, ok := valuesMap[setting.CommodityAttributeID]; ok {
-				nameParts = append(nameParts, value)
+				values.Ordered = append(values.Ordered, value)
 			}
 		}
 	} else {
 		// Fallback: if no order is defined, sort by commodity attribute ID for consistent naming
-		var fallbackAttrs []types.ProductAttributeValue
-		if err = tx.Context(ctx).Table("product_attribute_values").
-			Join("INNER", "commodity_attributes", "commodity_attributes.id = product_attribute_values.commodity_attribute_id").
-			Where("product_attribute_values.product_id = ?", product.ID).
-			OrderBy("commodity_attributes.id").
-			Find(&fallbackAttrs); err != nil {
-			return fmt.Errorf("failed to get fallback product attribute values: %w", err)
-		}
-		for _, attr := range fallbackAttrs {
-			nameParts = append(nameParts, attr.Value)
+		sort.Slice(attrIDs, func(i, j int) bool { return attrIDs[i] < attrIDs[j] })
+		for _, id := range attrIDs {
+			values.Ordered = append(values.Ordered, valuesMap[id])
 		}
 	}
 
-	nameParts = append(nameParts, commodity.Name)
-
-	product.Name = strings.Join(nameParts, " ")
-
-	// 4. Update the product with the new name
-	if _, err = tx.Context(ctx).ID(product.ID).Cols("name").Update(product); err != nil {
-		return fmt.Errorf("failed to update product name for product %d: %w", product.ID, err)
-	}
-
-	return nil
-}
\ No newline at end of file
+	return tmpl.Render(values), nil
+}
@@ -1,7 +1,9 @@
 package repos_test
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
@@ -125,32 +127,34 @@ var _ = Describe("ProductsRepo", func() {
 			},
 		}
 
-		// Adding more products to reach the ~20 goal
+		// Adding more products to reach the ~20 goal. Each gets its own size since a company
+		// cannot have two products with identical attributes.
 		for i := 0; i < 15; i++ {
+			size := fmt.Sprintf("Size%d", i)
 			company := company1
 			attrs := []*types.ProductAttributeValue{
 				{CommodityAttributeID: commodityAttribute1.ID, Value: "Honeycrisp"},
 				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
-				{CommodityAttributeID: commodityAttribute3.ID, Value: "Large"},
+				{CommodityAttributeID: commodityAttribute3.ID, Value: size},
 			}
-			expectedName := "Large Red Honeycrisp Apple" // Company 1 order: Size, Color, Variety
+			expectedName := size + " Red Honeycrisp Apple" // Company 1 order: Size, Color, Variety
 
 			if i%2 == 0 {
 				company = company2
 				attrs = []*types.ProductAttributeValue{
 					{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
 					{CommodityAttributeID: commodityAttribute2.ID, Value: "Pink"},
-					{CommodityAttributeID: commodityAttribute3.ID, Value: "Medium"},
+					{CommodityAttributeID: commodityAttribute3.ID, Value: size},
 				}
-				expectedName = "Pink Fuji Medium Apple" // Company 2 order: Color, Variety, Size
+				expectedName = "Pink Fuji " + size + " Apple" // Company 2 order: Color, Variety, Size
 			} else if i%3 == 0 {
 				company = company3
 				attrs = []*types.ProductAttributeValue{
 					{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"},
 					{CommodityAttributeID: commodityAttribute2.ID, Value: "Red-Yellow"},
-					{CommodityAttributeID: commodityAttribute3.ID, Value: "Medium"},
+					{CommodityAttributeID: commodityAttribute3.ID, Value: size},
 				}
-				expectedName = "Gala Red-Yellow Medium Apple" // Fallback order: Variety, Color, Size
+				expectedName = "Gala Red-Yellow " + size + " Apple" // Fallback order: Variety, Color, Size
 			}
 
 			testCases = append(testCases, struct {
@@ -207,6 +211,7 @@ var _ = Describe("ProductsRepo", func() {
 				ID:          product.ID,
 				CompanyID:   product.CompanyID,
 				CommodityID: product.CommodityID,
+				Version:     product.Version,
 			}
 			Expect(repo.Update(ctx, updatedProduct, nil)).To(Succeed())
 
@@ -233,6 +238,7 @@ var _ = Describe("ProductsRepo", func() {
 				ID:          product.ID,
 				CompanyID:   product.CompanyID,
 				CommodityID: product.CommodityID,
+				Version:     product.Version,
 			}
 			Expect(repo.Update(ctx, updatedProduct, newAttrs)).To(Succeed())
 
@@ -245,4 +251,362 @@ var _ = Describe("ProductsRepo", func() {
 			Expect(retrieved.Name).NotTo(ContainSubstring("InitialVariety"))
 		})
 	})
-})
\ No newline at end of file
+
+	Context("duplicate products", func() {
+		var existing *types.Product
+
+		BeforeEach(func() {
+			existing = &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, existing, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+			})).To(Succeed())
+		})
+
+		It("should reject a product with the same commodity and attributes in any order", func() {
+			product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			err := repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+			})
+
+			var existsErr *repos.ProductExistsError
+			Expect(errors.As(err, &existsErr)).To(BeTrue())
+			Expect(existsErr.ExistingID).To(Equal(existing.ID))
+
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company1.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+
+		It("should reject a product that a concurrent request created after the duplicate check", func() {
+			attrs := func() []*types.ProductAttributeValue {
+				return []*types.ProductAttributeValue{{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"}}
+			}
+
+			tx := db.NewSession()
+			defer tx.Close()
+			Expect(tx.Begin()).To(Succeed())
+			first := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.CreateTx(ctx, tx, first, attrs())).To(Succeed())
+
+			// The second product passes the duplicate check and then waits on the unique index
+			// until the first transaction ends.
+			done := make(chan error, 1)
+			go func() {
+				done <- repo.Create(ctx, &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}, attrs())
+			}()
+			Consistently(done, 200*time.Millisecond).ShouldNot(Receive())
+			Expect(tx.Commit()).To(Succeed())
+
+			var err error
+			Eventually(done).Should(Receive(&err))
+			var existsErr *repos.ProductExistsError
+			Expect(errors.As(err, &existsErr)).To(BeTrue())
+			Expect(existsErr.ExistingID).To(Equal(first.ID))
+		})
+
+		It("should allow the same product for another company", func() {
+			product := &types.Product{CompanyID: company2.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+			})).To(Succeed())
+		})
+
+		It("should allow recreating a deleted product", func() {
+			Expect(repo.Delete(ctx, existing.ID)).To(Succeed())
+
+			product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+			})).To(Succeed())
+		})
+
+		It("should reject an update that would duplicate another product", func() {
+			product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"},
+			})).To(Succeed())
+
+			err := repo.Update(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+			})
+
+			var existsErr *repos.ProductExistsError
+			Expect(errors.As(err, &existsErr)).To(BeTrue())
+			Expect(existsErr.ExistingID).To(Equal(existing.ID))
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Name).To(Equal("Gala Apple"))
+		})
+
+		It("should allow updating a product without changing its attributes", func() {
+			Expect(repo.Update(ctx, existing, nil)).To(Succeed())
+		})
+	})
+
+	Context("with a company naming template", func() {
+		It("should derive names from the template placeholders", func() {
+			company3.ProductNameTemplate = "{commodity} - {Variety}[ ({Size})]"
+			Expect(gr.Companies().Update(ctx, company3)).To(Succeed())
+
+			product := &types.Product{CompanyID: company3.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+				{CommodityAttributeID: commodityAttribute3.ID, Value: "Large"},
+			})).To(Succeed())
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Name).To(Equal("Apple - Fuji (Large)"))
+		})
+
+		It("should skip optional parts whose attribute value is missing", func() {
+			company3.ProductNameTemplate = "{commodity} - {Variety}[ ({Size})]"
+			Expect(gr.Companies().Update(ctx, company3)).To(Succeed())
+
+			product := &types.Product{CompanyID: company3.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+			})).To(Succeed())
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Name).To(Equal("Apple - Fuji"))
+		})
+	})
+
+	Context("RederiveNames method", func() {
+		It("should rename all of a company's products after its template changes", func() {
+			var products []*types.Product
+			for i := 0; i < 5; i++ {
+				product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+				Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+					{CommodityAttributeID: commodityAttribute1.ID, Value: fmt.Sprintf("Variety%d", i)},
+					{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+				})).To(Succeed())
+				products = append(products, product)
+			}
+
+			company1.ProductNameTemplate = "{Variety} {commodity}"
+			Expect(gr.Companies().Update(ctx, company1)).To(Succeed())
+
+			renamed, err := repo.RederiveNames(ctx, company1.ID, 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renamed).To(Equal(int64(5)))
+
+			for i, product := range products {
+				retrieved, found, err := repo.Get(ctx, product.ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(retrieved.Name).To(Equal(fmt.Sprintf("Variety%d Apple", i)))
+				Expect(retrieved.Version).To(Equal(product.Version + 1))
+
+				entries, _, err := gr.AuditLog().Find(ctx, &repos.AuditLogFindOpts{
+					EntityType: types.AuditEntityProduct,
+					EntityID:   product.ID,
+					Action:     types.AuditActionUpdate,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(entries[0].Changes).To(HaveKey("name"))
+			}
+		})
+
+		It("should leave products whose name does not change untouched", func() {
+			product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"},
+			})).To(Succeed())
+
+			renamed, err := repo.RederiveNames(ctx, company1.ID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renamed).To(BeZero())
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Version).To(Equal(product.Version))
+
+			_, count, err := gr.AuditLog().Find(ctx, &repos.AuditLogFindOpts{
+				EntityType: types.AuditEntityProduct,
+				EntityID:   product.ID,
+				Action:     types.AuditActionUpdate,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("should rename products after the company's attribute order changes", func() {
+			product := &types.Product{CompanyID: company3.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"},
+				{CommodityAttributeID: commodityAttribute2.ID, Value: "Red"},
+			})).To(Succeed())
+
+			Expect(casRepo.Create(ctx, &types.CompanyAttributeSetting{CompanyID: company3.ID, CommodityAttributeID: commodityAttribute2.ID, DisplayOrder: 1})).To(Succeed())
+			Expect(casRepo.Create(ctx, &types.CompanyAttributeSetting{CompanyID: company3.ID, CommodityAttributeID: commodityAttribute1.ID, DisplayOrder: 2})).To(Succeed())
+
+			renamed, err := repo.RederiveNames(ctx, company3.ID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renamed).To(Equal(int64(1)))
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Name).To(Equal("Red Gala Apple"))
+		})
+
+		It("should not rename deleted products", func() {
+			product := &types.Product{CompanyID: company1.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Gala"},
+			})).To(Succeed())
+			Expect(repo.Delete(ctx, product.ID)).To(Succeed())
+
+			company1.ProductNameTemplate = "{Variety} {commodity}"
+			Expect(gr.Companies().Update(ctx, company1)).To(Succeed())
+
+			renamed, err := repo.RederiveNames(ctx, company1.ID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renamed).To(BeZero())
+
+			retrieved, found, err := repo.Get(ctx, product.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.Name).To(Equal(product.Name))
+		})
+
+		It("should not rename products of other companies", func() {
+			product := &types.Product{CompanyID: company2.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+			})).To(Succeed())
+
+			renamed, err := repo.RederiveNames(ctx, company1.ID, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(renamed).To(BeZero())
+		})
+	})
+
+	Context("Import method", func() {
+		importRow := func(row int, companyID int64, variety string) *repos.ProductImportRow {
+			return &repos.ProductImportRow{
+				Row:     row,
+				Product: &types.Product{CompanyID: companyID, CommodityID: commodity.ID},
+				Attributes: []*types.ProductAttributeValue{
+					{CommodityAttributeID: commodityAttribute1.ID, Value: variety},
+				},
+			}
+		}
+
+		It("should create every row when all of them succeed", func() {
+			rowErrs, err := repo.Import(ctx, []*repos.ProductImportRow{
+				importRow(2, company3.ID, "Fuji"),
+				importRow(3, company3.ID, "Gala"),
+			}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rowErrs).To(BeEmpty())
+
+			products, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(2)))
+			Expect(products[0].Name).To(Equal("Fuji Apple"))
+		})
+
+		It("should not write anything for a dry run", func() {
+			rowErrs, err := repo.Import(ctx, []*repos.ProductImportRow{importRow(2, company3.ID, "Fuji")}, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rowErrs).To(BeEmpty())
+
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+		})
+
+		It("should report every failing row and roll back the others", func() {
+			existing := &types.Product{CompanyID: company3.ID, CommodityID: commodity.ID}
+			Expect(repo.Create(ctx, existing, []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Fuji"},
+			})).To(Succeed())
+
+			rowErrs, err := repo.Import(ctx, []*repos.ProductImportRow{
+				importRow(2, company3.ID, "Gala"),
+				importRow(3, company3.ID, "Fuji"),
+				importRow(4, company3.ID, "Gala"),
+			}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rowErrs).To(HaveLen(2))
+			Expect(rowErrs[0].Row).To(Equal(3))
+			var existsErr *repos.ProductExistsError
+			Expect(errors.As(rowErrs[0].Err, &existsErr)).To(BeTrue())
+			Expect(existsErr.ExistingID).To(Equal(existing.ID))
+			Expect(rowErrs[1].Row).To(Equal(4))
+
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+	})
+
+	Context("full-text search", func() {
+		BeforeEach(func() {
+			for _, values := range [][]string{
+				{"Granny Smith", "Green", "Organic"},
+				{"Granny Smith", "Green", "Large"},
+				{"Fuji", "Red", "Organic"},
+			} {
+				product := &types.Product{CompanyID: company3.ID, CommodityID: commodity.ID}
+				Expect(repo.Create(ctx, product, []*types.ProductAttributeValue{
+					{CommodityAttributeID: commodityAttribute1.ID, Value: values[0]},
+					{CommodityAttributeID: commodityAttribute2.ID, Value: values[1]},
+					{CommodityAttributeID: commodityAttribute3.ID, Value: values[2]},
+				})).To(Succeed())
+			}
+		})
+
+		It("should match every word across name, commodity and attribute values", func() {
+			products, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "granny apple organic"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(products[0].Name).To(Equal("Granny Smith Green Organic Apple"))
+		})
+
+		It("should match stemmed words", func() {
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "apples"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(3)))
+		})
+
+		It("should support excluding words", func() {
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "organic -fuji"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+
+		It("should reflect attribute changes after an update", func() {
+			products, _, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "fuji"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(products).To(HaveLen(1))
+
+			Expect(repo.Update(ctx, products[0], []*types.ProductAttributeValue{
+				{CommodityAttributeID: commodityAttribute1.ID, Value: "Honeycrisp"},
+			})).To(Succeed())
+
+			_, count, err := repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "fuji"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			_, count, err = repo.Find(ctx, &repos.ProductFindOpts{CompanyID: company3.ID, Query: "honeycrisp"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+		})
+	})
+})
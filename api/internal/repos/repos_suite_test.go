@@ -95,9 +95,32 @@ var _ = BeforeEach(func() {
 		"products",
 		"product_attribute_values",
 		"company_attribute_settings",
+		"product_packs",
+		"user_sessions",
+		"refresh_tokens",
+		"revoked_access_tokens",
+		"password_reset_tokens",
+		"api_keys",
+		"user_company_roles",
+		"company_roles",
+		"login_throttles",
+		"login_lockout_events",
+		"mfa_recovery_codes",
+		"user_invitations",
+		"sso_login_states",
+		"company_sso_configs",
+		"audit_log",
+		"webhook_deliveries",
+		"outbox_events",
+		"webhook_endpoints",
+		"idempotency_keys",
 	}
 
 	truncateStatement := fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", strings.Join(tablesToTruncate, ", "))
 	_, err := db.Exec(truncateStatement)
 	Expect(err).NotTo(HaveOccurred())
+
+	// commodity_types holds seeded rows, so only the rows created by tests are removed.
+	_, err = db.Exec("DELETE FROM commodity_types WHERE id > 1")
+	Expect(err).NotTo(HaveOccurred())
 })
@@ -0,0 +1,256 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+var (
+	// ErrRefreshTokenInvalid is returned when a refresh token is unknown, expired or belongs to a
+	// session that is no longer active.
+	ErrRefreshTokenInvalid = errors.New("refresh token is invalid or expired")
+	// ErrRefreshTokenReused is returned when a refresh token that was already exchanged is presented
+	// again. The token has most likely been stolen, so its session is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+)
+
+// SessionsRepo defines the interface for login sessions, their refresh tokens and revoked access tokens.
+//
+//go:generate mockgen -source=./sessions.go -destination=./mocks/sessions.go -package=mock_repos SessionsRepo
+type SessionsRepo interface {
+	Get(ctx context.Context, id int64) (*types.Session, bool, error)
+	FindActiveForUser(ctx context.Context, userID int64, limit, offset int) ([]*types.Session, int64, error)
+	Create(ctx context.Context, session *types.Session, refreshTokenHash string) error
+	CreateTx(ctx context.Context, tx *xorm.Session, session *types.Session, refreshTokenHash string) error
+	Rotate(ctx context.Context, refreshTokenHash, newRefreshTokenHash string, expiresAt time.Time) (*types.Session, error)
+	RotateTx(ctx context.Context, tx *xorm.Session, refreshTokenHash, newRefreshTokenHash string, expiresAt time.Time) (*types.Session, error)
+	Revoke(ctx context.Context, id int64) error
+	RevokeTx(ctx context.Context, tx *xorm.Session, id int64) error
+	RevokeForUser(ctx context.Context, userID, exceptSessionID int64) error
+	RevokeForUserTx(ctx context.Context, tx *xorm.Session, userID, exceptSessionID int64) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	RevokeAccessTokenTx(ctx context.Context, tx *xorm.Session, jti string, expiresAt time.Time) error
+	IsTokenActive(ctx context.Context, sessionID int64, jti string) (bool, error)
+	TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+// sessionLastUsedResolution is how stale last_used_at may get before a request updates it, so that
+// not every request made with an access token writes to the database.
+const sessionLastUsedResolution = time.Minute
+
+type sessionsRepo struct {
+	db *xorm.Engine
+}
+
+// NewSessionsRepo creates a new SessionsRepo.
+func NewSessionsRepo(db *xorm.Engine) SessionsRepo {
+	return &sessionsRepo{db: db}
+}
+
+// Get retrieves a session by ID, whether or not it is still active.
+func (r *sessionsRepo) Get(ctx context.Context, id int64) (*types.Session, bool, error) {
+	session := new(types.Session)
+	has, err := r.db.Context(ctx).ID(id).Get(session)
+	return session, has, err
+}
+
+// FindActiveForUser lists the sessions of a user that are neither revoked nor expired, most recently
+// used first.
+func (r *sessionsRepo) FindActiveForUser(ctx context.Context, userID int64, limit, offset int) ([]*types.Session, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	s.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+	if limit > 0 {
+		s.Limit(limit, offset)
+	}
+	var sessions []*types.Session
+	count, err := s.OrderBy("last_used_at DESC, id DESC").FindAndCount(&sessions)
+	return sessions, count, err
+}
+
+// Create inserts a new session together with its first refresh token.
+func (r *sessionsRepo) Create(ctx context.Context, session *types.Session, refreshTokenHash string) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateTx(ctx, tx, session, refreshTokenHash)
+	})
+	return err
+}
+
+// CreateTx inserts a new session together with its first refresh token, which expires with the session.
+func (r *sessionsRepo) CreateTx(ctx context.Context, tx *xorm.Session, session *types.Session, refreshTokenHash string) error {
+	session.RevokedAt = nil
+	session.LastUsedAt = time.Now()
+	if _, err := tx.Context(ctx).Insert(session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	_, err := tx.Context(ctx).Insert(&types.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: refreshTokenHash,
+		ExpiresAt: session.ExpiresAt,
+	})
+	return err
+}
+
+// Rotate exchanges a refresh token for a new one. See RotateTx.
+func (r *sessionsRepo) Rotate(ctx context.Context, refreshTokenHash, newRefreshTokenHash string, expiresAt time.Time) (*types.Session, error) {
+	var reusedErr error
+	session, err := wrapInSession(r.db, func(tx *xorm.Session) (*types.Session, error) {
+		session, err := r.RotateTx(ctx, tx, refreshTokenHash, newRefreshTokenHash, expiresAt)
+		if errors.Is(err, ErrRefreshTokenReused) {
+			// The session was revoked in this transaction, which must still be committed.
+			reusedErr = err
+			return nil, nil
+		}
+		return session, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, reusedErr
+}
+
+// RotateTx marks the refresh token as used, stores its replacement and extends the session until
+// expiresAt. Presenting a token that was already used revokes the whole session and returns
+// ErrRefreshTokenReused; the caller must commit the transaction for the revocation to take effect.
+func (r *sessionsRepo) RotateTx(ctx context.Context, tx *xorm.Session, refreshTokenHash, newRefreshTokenHash string, expiresAt time.Time) (*types.Session, error) {
+	now := time.Now()
+
+	// The row lock makes concurrent refreshes with the same token wait, so only one of them wins.
+	token := new(types.RefreshToken)
+	has, err := tx.Context(ctx).Where("token_hash = ?", refreshTokenHash).ForUpdate().Get(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if !has {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	if token.UsedAt != nil {
+		if err := r.RevokeTx(ctx, tx, token.SessionID); err != nil {
+			return nil, err
+		}
+		return nil, ErrRefreshTokenReused
+	}
+	if !now.Before(token.ExpiresAt) {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	session := new(types.Session)
+	has, err = tx.Context(ctx).ID(token.SessionID).Get(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session %d: %w", token.SessionID, err)
+	}
+	if !has || !session.IsActive(now) {
+		return nil, ErrRefreshTokenInvalid
+	}
+
+	if _, err := tx.Context(ctx).ID(token.ID).Cols("used_at").Update(&types.RefreshToken{UsedAt: &now}); err != nil {
+		return nil, fmt.Errorf("failed to mark refresh token as used: %w", err)
+	}
+	if _, err := tx.Context(ctx).Insert(&types.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: newRefreshTokenHash,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = now
+	if _, err := tx.Context(ctx).ID(session.ID).Cols("expires_at", "last_used_at").Update(session); err != nil {
+		return nil, fmt.Errorf("failed to update session %d: %w", session.ID, err)
+	}
+
+	return session, nil
+}
+
+// Revoke ends a session. Its refresh tokens and access tokens stop working immediately.
+func (r *sessionsRepo) Revoke(ctx context.Context, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RevokeTx(ctx, tx, id)
+	})
+	return err
+}
+
+// RevokeTx ends a session. Revoking a session that is already revoked keeps the original time.
+func (r *sessionsRepo) RevokeTx(ctx context.Context, tx *xorm.Session, id int64) error {
+	now := time.Now()
+	_, err := tx.Context(ctx).ID(id).Where("revoked_at IS NULL").Cols("revoked_at").Update(&types.Session{RevokedAt: &now})
+	return err
+}
+
+// RevokeForUser ends all sessions of a user except exceptSessionID, which may be 0.
+func (r *sessionsRepo) RevokeForUser(ctx context.Context, userID, exceptSessionID int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RevokeForUserTx(ctx, tx, userID, exceptSessionID)
+	})
+	return err
+}
+
+// RevokeForUserTx ends all sessions of a user except exceptSessionID, which may be 0.
+func (r *sessionsRepo) RevokeForUserTx(ctx context.Context, tx *xorm.Session, userID, exceptSessionID int64) error {
+	now := time.Now()
+	_, err := tx.Context(ctx).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptSessionID).
+		Cols("revoked_at").
+		Update(&types.Session{RevokedAt: &now})
+	return err
+}
+
+// RevokeAccessToken revokes a single access token by its jti until it expires.
+func (r *sessionsRepo) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.RevokeAccessTokenTx(ctx, tx, jti, expiresAt)
+	})
+	return err
+}
+
+// RevokeAccessTokenTx revokes a single access token by its jti. Revoking it again is a no-op.
+// Tokens that have expired no longer need to be remembered, so they are removed on the way.
+func (r *sessionsRepo) RevokeAccessTokenTx(ctx context.Context, tx *xorm.Session, jti string, expiresAt time.Time) error {
+	now := time.Now()
+	if _, err := tx.Context(ctx).Where("expires_at <= ?", now).Delete(&types.RevokedAccessToken{}); err != nil {
+		return fmt.Errorf("failed to remove expired revoked access tokens: %w", err)
+	}
+
+	_, err := tx.Context(ctx).Exec(
+		"INSERT INTO revoked_access_tokens (jti, expires_at, created_at) VALUES (?, ?, ?) ON CONFLICT (jti) DO NOTHING",
+		jti, expiresAt, now,
+	)
+	return err
+}
+
+// IsTokenActive reports whether an access token may still be used: its session must be active and
+// the token itself must not have been revoked.
+func (r *sessionsRepo) IsTokenActive(ctx context.Context, sessionID int64, jti string) (bool, error) {
+	session, has, err := r.Get(ctx, sessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get session %d: %w", sessionID, err)
+	}
+	if !has || !session.IsActive(time.Now()) {
+		return false, nil
+	}
+
+	revoked, err := r.db.Context(ctx).Where("jti = ?", jti).Exist(new(types.RevokedAccessToken))
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked access tokens: %w", err)
+	}
+	return !revoked, nil
+}
+
+// TouchLastUsed records that the session was used. The time is only written when the stored one is
+// older than a minute.
+func (r *sessionsRepo) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := r.db.Context(ctx).
+		Where("id = ? AND last_used_at < ?", id, usedAt.Add(-sessionLastUsedResolution)).
+		Cols("last_used_at").
+		NoAutoTime().
+		Update(&types.Session{LastUsedAt: usedAt})
+	return err
+}
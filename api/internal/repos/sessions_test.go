@@ -0,0 +1,166 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SessionsRepo", func() {
+	var (
+		repo    repos.SessionsRepo
+		user    *types.User
+		session *types.Session
+	)
+
+	BeforeEach(func() {
+		repo = gr.Sessions()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company := &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		user = &types.User{
+			CompanyID: company.ID,
+			AddressID: address.ID,
+			Email:     "session.user@example.com",
+			Password:  "password123",
+			FirstName: "Session",
+			LastName:  "User",
+			Roles:     types.Roles{types.RoleUser},
+		}
+		Expect(gr.Users().Create(ctx, user)).To(Succeed())
+
+		session = &types.Session{
+			UserID:    user.ID,
+			CompanyID: company.ID,
+			UserAgent: "test-agent",
+			IPAddress: "127.0.0.1",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+		Expect(repo.Create(ctx, session, "hash-1")).To(Succeed())
+		Expect(session.ID).NotTo(BeZero())
+	})
+
+	Describe("Rotate", func() {
+		It("should exchange a refresh token for a new one and extend the session", func() {
+			expiresAt := time.Now().Add(2 * time.Hour)
+			rotated, err := repo.Rotate(ctx, "hash-1", "hash-2", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rotated.ID).To(Equal(session.ID))
+			Expect(rotated.ExpiresAt).To(BeTemporally("~", expiresAt, time.Second))
+
+			_, err = repo.Rotate(ctx, "hash-2", "hash-3", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an unknown refresh token", func() {
+			_, err := repo.Rotate(ctx, "unknown", "hash-2", time.Now().Add(time.Hour))
+			Expect(err).To(MatchError(repos.ErrRefreshTokenInvalid))
+		})
+
+		It("should revoke the session when a used refresh token is presented again", func() {
+			_, err := repo.Rotate(ctx, "hash-1", "hash-2", time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = repo.Rotate(ctx, "hash-1", "hash-3", time.Now().Add(time.Hour))
+			Expect(err).To(MatchError(repos.ErrRefreshTokenReused))
+
+			retrieved, found, err := repo.Get(ctx, session.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(retrieved.RevokedAt).NotTo(BeNil())
+
+			// The latest token belongs to the revoked session and no longer works either.
+			_, err = repo.Rotate(ctx, "hash-2", "hash-4", time.Now().Add(time.Hour))
+			Expect(err).To(MatchError(repos.ErrRefreshTokenInvalid))
+		})
+	})
+
+	Describe("FindActiveForUser", func() {
+		It("should list only sessions that have not ended", func() {
+			other := &types.Session{UserID: user.ID, CompanyID: user.CompanyID, ExpiresAt: time.Now().Add(time.Hour)}
+			Expect(repo.Create(ctx, other, "hash-other")).To(Succeed())
+			expired := &types.Session{UserID: user.ID, CompanyID: user.CompanyID, ExpiresAt: time.Now().Add(-time.Minute)}
+			Expect(repo.Create(ctx, expired, "hash-expired")).To(Succeed())
+			Expect(repo.Revoke(ctx, other.ID)).To(Succeed())
+
+			sessions, count, err := repo.FindActiveForUser(ctx, user.ID, 10, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(Equal(int64(1)))
+			Expect(sessions).To(HaveLen(1))
+			Expect(sessions[0].ID).To(Equal(session.ID))
+			Expect(sessions[0].UserAgent).To(Equal("test-agent"))
+		})
+	})
+
+	Describe("TouchLastUsed", func() {
+		It("should only record uses older than a minute", func() {
+			Expect(repo.TouchLastUsed(ctx, session.ID, time.Now().Add(30*time.Second))).To(Succeed())
+			retrieved, _, err := repo.Get(ctx, session.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.LastUsedAt).To(BeTemporally("~", session.LastUsedAt, time.Second))
+
+			usedAt := time.Now().Add(2 * time.Minute)
+			Expect(repo.TouchLastUsed(ctx, session.ID, usedAt)).To(Succeed())
+			retrieved, _, err = repo.Get(ctx, session.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(retrieved.LastUsedAt).To(BeTemporally("~", usedAt, time.Second))
+		})
+	})
+
+	Describe("IsTokenActive", func() {
+		It("should be active for a live session and token", func() {
+			active, err := repo.IsTokenActive(ctx, session.ID, "jti-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeTrue())
+		})
+
+		It("should be inactive once the session is revoked", func() {
+			Expect(repo.Revoke(ctx, session.ID)).To(Succeed())
+
+			active, err := repo.IsTokenActive(ctx, session.ID, "jti-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+
+			_, err = repo.Rotate(ctx, "hash-1", "hash-2", time.Now().Add(time.Hour))
+			Expect(err).To(MatchError(repos.ErrRefreshTokenInvalid))
+		})
+
+		It("should be inactive once the access token is revoked", func() {
+			Expect(repo.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Minute))).To(Succeed())
+			Expect(repo.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Minute))).To(Succeed())
+
+			active, err := repo.IsTokenActive(ctx, session.ID, "jti-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+
+			active, err = repo.IsTokenActive(ctx, session.ID, "jti-2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeTrue())
+		})
+
+		It("should forget revoked access tokens once they have expired", func() {
+			Expect(repo.RevokeAccessToken(ctx, "jti-old", time.Now().Add(-time.Minute))).To(Succeed())
+			Expect(repo.RevokeAccessToken(ctx, "jti-1", time.Now().Add(time.Minute))).To(Succeed())
+
+			var revoked []*types.RevokedAccessToken
+			Expect(db.Find(&revoked)).To(Succeed())
+			Expect(revoked).To(HaveLen(1))
+			Expect(revoked[0].JTI).To(Equal("jti-1"))
+		})
+
+		It("should be inactive for an unknown session", func() {
+			active, err := repo.IsTokenActive(ctx, session.ID+100, "jti-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,141 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// ErrSSOLoginStateInvalid is returned when an SSO state is unknown, expired or already used.
+var ErrSSOLoginStateInvalid = errors.New("single sign-on state is invalid or expired")
+
+// SSORepo defines the interface for the OpenID Connect configurations of companies and the
+// sign-ins in progress with them.
+//
+//go:generate mockgen -source=./sso.go -destination=./mocks/sso.go -package=mock_repos SSORepo
+type SSORepo interface {
+	GetConfig(ctx context.Context, companyID int64) (*types.SSOConfig, bool, error)
+	SaveConfig(ctx context.Context, config *types.SSOConfig) error
+	SaveConfigTx(ctx context.Context, tx *xorm.Session, config *types.SSOConfig) error
+	DeleteConfig(ctx context.Context, companyID int64) (bool, error)
+	DeleteConfigTx(ctx context.Context, tx *xorm.Session, companyID int64) (bool, error)
+	CreateLoginState(ctx context.Context, state *types.SSOLoginState) error
+	UseLoginState(ctx context.Context, stateHash string) (*types.SSOLoginState, error)
+	UseLoginStateTx(ctx context.Context, tx *xorm.Session, stateHash string) (*types.SSOLoginState, error)
+}
+
+type ssoRepo struct {
+	db *xorm.Engine
+}
+
+// NewSSORepo creates a new SSORepo.
+func NewSSORepo(db *xorm.Engine) SSORepo {
+	return &ssoRepo{db: db}
+}
+
+// GetConfig retrieves a company's SSO configuration.
+func (r *ssoRepo) GetConfig(ctx context.Context, companyID int64) (*types.SSOConfig, bool, error) {
+	config := new(types.SSOConfig)
+	has, err := r.db.Context(ctx).Where("company_id = ?", companyID).Get(config)
+	if err != nil {
+		return nil, false, err
+	}
+	if !has {
+		return nil, false, nil
+	}
+	return config, true, nil
+}
+
+// SaveConfig creates or replaces a company's SSO configuration. See SaveConfigTx.
+func (r *ssoRepo) SaveConfig(ctx context.Context, config *types.SSOConfig) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.SaveConfigTx(ctx, tx, config)
+	})
+	return err
+}
+
+// SaveConfigTx creates or replaces a company's SSO configuration within a transaction. A company
+// has at most one identity provider.
+func (r *ssoRepo) SaveConfigTx(ctx context.Context, tx *xorm.Session, config *types.SSOConfig) error {
+	exists, err := tx.Context(ctx).Where("company_id = ?", config.CompanyID).Exist(new(types.SSOConfig))
+	if err != nil {
+		return fmt.Errorf("failed to check for an SSO configuration: %w", err)
+	}
+	if !exists {
+		if _, err = tx.Context(ctx).Insert(config); err != nil {
+			return err
+		}
+		return recordAuditTx(ctx, tx, types.AuditEntitySSOConfig, types.AuditActionCreate, config.CompanyID, nil, config)
+	}
+	return auditedTx[types.SSOConfig](ctx, tx, types.AuditEntitySSOConfig, types.AuditActionUpdate, config.CompanyID, func() error {
+		_, err := tx.Context(ctx).
+			Where("company_id = ?", config.CompanyID).
+			Cols("issuer", "client_id", "client_secret", "allowed_domains", "jit_provisioning").
+			Update(config)
+		return err
+	})
+}
+
+// DeleteConfig removes a company's SSO configuration. See DeleteConfigTx.
+func (r *ssoRepo) DeleteConfig(ctx context.Context, companyID int64) (bool, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) (bool, error) {
+		return r.DeleteConfigTx(ctx, tx, companyID)
+	})
+}
+
+// DeleteConfigTx removes a company's SSO configuration and the sign-ins in progress with it. It
+// reports whether the company had one.
+func (r *ssoRepo) DeleteConfigTx(ctx context.Context, tx *xorm.Session, companyID int64) (bool, error) {
+	if _, err := tx.Context(ctx).Where("company_id = ?", companyID).Delete(new(types.SSOLoginState)); err != nil {
+		return false, fmt.Errorf("failed to delete SSO login states: %w", err)
+	}
+	var affected int64
+	err := auditedTx[types.SSOConfig](ctx, tx, types.AuditEntitySSOConfig, types.AuditActionDelete, companyID, func() error {
+		var err error
+		affected, err = tx.Context(ctx).Where("company_id = ?", companyID).Delete(new(types.SSOConfig))
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// CreateLoginState stores a sign-in that is sent to the identity provider.
+func (r *ssoRepo) CreateLoginState(ctx context.Context, state *types.SSOLoginState) error {
+	_, err := r.db.Context(ctx).Insert(state)
+	return err
+}
+
+// UseLoginState uses up the state of a sign-in. See UseLoginStateTx.
+func (r *ssoRepo) UseLoginState(ctx context.Context, stateHash string) (*types.SSOLoginState, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) (*types.SSOLoginState, error) {
+		return r.UseLoginStateTx(ctx, tx, stateHash)
+	})
+}
+
+// UseLoginStateTx uses up the state of a sign-in and returns it, so that a code can be redeemed
+// only once. It returns ErrSSOLoginStateInvalid for unknown, expired and used states.
+func (r *ssoRepo) UseLoginStateTx(ctx context.Context, tx *xorm.Session, stateHash string) (*types.SSOLoginState, error) {
+	now := time.Now()
+
+	// The row lock makes a concurrent callback with the same state wait, so the state is used once.
+	state := new(types.SSOLoginState)
+	has, err := tx.Context(ctx).Where("state_hash = ?", stateHash).ForUpdate().Get(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSO login state: %w", err)
+	}
+	if !has || state.UsedAt != nil || !now.Before(state.ExpiresAt) {
+		return nil, ErrSSOLoginStateInvalid
+	}
+
+	state.UsedAt = &now
+	if _, err := tx.Context(ctx).ID(state.ID).Cols("used_at").Update(state); err != nil {
+		return nil, fmt.Errorf("failed to mark SSO login state as used: %w", err)
+	}
+	return state, nil
+}
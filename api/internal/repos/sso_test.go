@@ -0,0 +1,110 @@
+package repos_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSORepo", func() {
+	var (
+		repo    repos.SSORepo
+		company *types.Company
+		config  *types.SSOConfig
+	)
+
+	BeforeEach(func() {
+		repo = gr.SSO()
+
+		address, err := gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		config = &types.SSOConfig{
+			CompanyID:      company.ID,
+			Issuer:         "https://idp.example.com",
+			ClientID:       "client",
+			ClientSecret:   "secret",
+			AllowedDomains: types.EmailDomains{"example.com"},
+		}
+		Expect(repo.SaveConfig(ctx, config)).To(Succeed())
+	})
+
+	Describe("SaveConfig", func() {
+		It("should replace the company's configuration", func() {
+			Expect(repo.SaveConfig(ctx, &types.SSOConfig{
+				CompanyID:       company.ID,
+				Issuer:          "https://other.example.com",
+				ClientID:        "other-client",
+				ClientSecret:    "other-secret",
+				AllowedDomains:  types.EmailDomains{"example.com", "example.org"},
+				JITProvisioning: true,
+			})).To(Succeed())
+
+			saved, found, err := repo.GetConfig(ctx, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(saved.Issuer).To(Equal("https://other.example.com"))
+			Expect(saved.ClientSecret).To(Equal("other-secret"))
+			Expect(saved.AllowedDomains).To(Equal(types.EmailDomains{"example.com", "example.org"}))
+			Expect(saved.JITProvisioning).To(BeTrue())
+		})
+	})
+
+	Describe("DeleteConfig", func() {
+		It("should report whether the company had a configuration", func() {
+			deleted, err := repo.DeleteConfig(ctx, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+
+			_, found, err := repo.GetConfig(ctx, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			deleted, err = repo.DeleteConfig(ctx, company.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+		})
+	})
+
+	Describe("UseLoginState", func() {
+		newState := func(hash string, expiresAt time.Time) {
+			Expect(repo.CreateLoginState(ctx, &types.SSOLoginState{
+				CompanyID:    company.ID,
+				StateHash:    hash,
+				Nonce:        "nonce",
+				CodeVerifier: "verifier",
+				ExpiresAt:    expiresAt,
+			})).To(Succeed())
+		}
+
+		It("should return the state only once", func() {
+			newState("hash-1", time.Now().Add(time.Minute))
+
+			state, err := repo.UseLoginState(ctx, "hash-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.CompanyID).To(Equal(company.ID))
+			Expect(state.CodeVerifier).To(Equal("verifier"))
+
+			_, err = repo.UseLoginState(ctx, "hash-1")
+			Expect(err).To(MatchError(repos.ErrSSOLoginStateInvalid))
+		})
+
+		It("should reject expired and unknown states", func() {
+			newState("hash-1", time.Now().Add(-time.Minute))
+
+			_, err := repo.UseLoginState(ctx, "hash-1")
+			Expect(err).To(MatchError(repos.ErrSSOLoginStateInvalid))
+
+			_, err = repo.UseLoginState(ctx, "unknown")
+			Expect(err).To(MatchError(repos.ErrSSOLoginStateInvalid))
+		})
+	})
+})
@@ -24,6 +24,8 @@ type UsersRepo interface {
 	Delete(ctx context.Context, id int64) error
 	UpdatePassword(ctx context.Context, userID int64, newPassword string) error
 	UpdatePasswordTx(ctx context.Context, tx *xorm.Session, userID int64, newPassword string) error
+	ChangePassword(ctx context.Context, userID int64, newPassword string, keepSessionID int64) error
+	ChangePasswordTx(ctx context.Context, tx *xorm.Session, userID int64, newPassword string, keepSessionID int64) error
 	GetIncludeInvisible(ctx context.Context, id int64) (*types.User, bool, error)
 	UpdateUserCompanyTx(ctx context.Context, tx *xorm.Session, userID, companyID int64) error
 	UpdateUserCompany(ctx context.Context, userID, companyID int64) error
@@ -70,8 +72,10 @@ func (r *usersRepo) CreateTx(ctx context.Context, tx *xorm.Session, user *types.
 	user.Password = string(hashedPassword)
 	user.Visible = true
 
-	_, err = tx.Context(ctx).Insert(user)
-	return err
+	if _, err = tx.Context(ctx).Insert(user); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityUser, types.AuditActionCreate, user.ID, nil, user)
 }
 
 // Delete performs a soft delete on a user.
@@ -84,8 +88,10 @@ func (r *usersRepo) Delete(ctx context.Context, id int64) error {
 
 // DeleteTx performs a soft delete on a user by setting their visible flag to false.
 func (r *usersRepo) DeleteTx(ctx context.Context, tx *xorm.Session, id int64) error {
-	_, err := tx.Context(ctx).ID(id).Cols("visible").Update(&types.User{Visible: false})
-	return err
+	return auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).ID(id).NoVersionCheck().Cols("visible").Update(&types.User{Visible: false})
+		return err
+	})
 }
 
 // Find retrieves a list of visible users with pagination and filtering, and a total count.
@@ -163,8 +169,9 @@ func (r *usersRepo) UpdateTx(ctx context.Context, tx *xorm.Session, user *types.
 	}
 
 	// Explicitly update only non-sensitive fields. Password must be updated via UpdatePassword.
-	_, err := tx.Context(ctx).ID(user.ID).Cols("first_name", "last_name", "address_id", "roles").Update(user)
-	return err
+	return auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, user.ID, func() error {
+		return versionChecked(tx.Context(ctx).ID(user.ID).Cols("first_name", "last_name", "address_id", "roles").Update(user))
+	})
 }
 
 // UpdatePassword updates a user's password.
@@ -183,10 +190,29 @@ func (r *usersRepo) UpdatePasswordTx(ctx context.Context, tx *xorm.Session, user
 	}
 
 	// Update only the password column for the given user ID.
-	_, err = tx.Context(ctx).ID(userID).Cols("password").Update(&types.User{Password: string(hashedPassword)})
+	return auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, userID, func() error {
+		_, err := tx.Context(ctx).ID(userID).NoVersionCheck().Cols("password").Update(&types.User{Password: string(hashedPassword)})
+		return err
+	})
+}
+
+// ChangePassword updates a user's password and ends the user's other sessions.
+func (r *usersRepo) ChangePassword(ctx context.Context, userID int64, newPassword string, keepSessionID int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.ChangePasswordTx(ctx, tx, userID, newPassword, keepSessionID)
+	})
 	return err
 }
 
+// ChangePasswordTx updates a user's password and ends every session of the user except
+// keepSessionID, which may be 0 to end them all.
+func (r *usersRepo) ChangePasswordTx(ctx context.Context, tx *xorm.Session, userID int64, newPassword string, keepSessionID int64) error {
+	if err := r.UpdatePasswordTx(ctx, tx, userID, newPassword); err != nil {
+		return err
+	}
+	return NewSessionsRepo(r.db).RevokeForUserTx(ctx, tx, userID, keepSessionID)
+}
+
 // UpdateUserCompany updates a user's company.
 func (r *usersRepo) UpdateUserCompany(ctx context.Context, userID, companyID int64) error {
 	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
@@ -198,8 +224,10 @@ func (r *usersRepo) UpdateUserCompany(ctx context.Context, userID, companyID int
 // UpdateUserCompanyTx updates a user's company within a transaction.
 func (r *usersRepo) UpdateUserCompanyTx(ctx context.Context, tx *xorm.Session, userID, companyID int64) error {
 	// Update only the company_id column for the given user ID.
-	_, err := tx.Context(ctx).ID(userID).Cols("company_id").Update(&types.User{CompanyID: companyID})
-	return err
+	return auditedTx[types.User](ctx, tx, types.AuditEntityUser, types.AuditActionUpdate, userID, func() error {
+		_, err := tx.Context(ctx).ID(userID).NoVersionCheck().Cols("company_id").Update(&types.User{CompanyID: companyID})
+		return err
+	})
 }
 
 // applyUserFindOpts is a helper function to build the query based on find options.
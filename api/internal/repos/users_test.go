@@ -1,10 +1,13 @@
 package repos_test
 
 import (
+	"time"
+
 	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
 	"github.com/happilymarrieddad/order-management-v3/api/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/bcrypt"
 )
 
 var _ = Describe("UsersRepo", func() {
@@ -345,6 +348,40 @@ var _ = Describe("UsersRepo", func() {
 		})
 	})
 
+	Describe("ChangePassword", func() {
+		It("should set the new password and end every session but the one kept", func() {
+			user := &types.User{
+				CompanyID: company.ID,
+				Email:     "change.password@example.com",
+				Password:  "password123",
+				AddressID: address.ID,
+				FirstName: "Change",
+				LastName:  "Password",
+				Roles:     types.Roles{types.RoleUser},
+			}
+			Expect(repo.Create(ctx, user)).To(Succeed())
+
+			current := &types.Session{UserID: user.ID, CompanyID: company.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			Expect(gr.Sessions().Create(ctx, current, "current-hash")).To(Succeed())
+			other := &types.Session{UserID: user.ID, CompanyID: company.ID, ExpiresAt: time.Now().Add(time.Hour)}
+			Expect(gr.Sessions().Create(ctx, other, "other-hash")).To(Succeed())
+
+			Expect(repo.ChangePassword(ctx, user.ID, "newpassword123", current.ID)).To(Succeed())
+
+			retrieved, found, err := repo.Get(ctx, company.ID, user.ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(bcrypt.CompareHashAndPassword([]byte(retrieved.Password), []byte("newpassword123"))).To(Succeed())
+
+			active, err := gr.Sessions().IsTokenActive(ctx, current.ID, "jti")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeTrue())
+			active, err = gr.Sessions().IsTokenActive(ctx, other.ID, "jti")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+	})
+
 	Describe("Delete", func() {
 		var (
 			userToDelete *types.User
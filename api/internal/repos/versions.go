@@ -0,0 +1,20 @@
+package repos
+
+import "errors"
+
+// ErrVersionConflict is returned when a record has been changed since the version an update is
+// based on was read. Records with a version field (tagged `xorm:"version"`) are only updated at the
+// version they carry, which the update increments.
+var ErrVersionConflict = errors.New("record has been changed since it was read")
+
+// versionChecked returns the outcome of updating a versioned record: ErrVersionConflict when no row
+// had the record's version anymore.
+func versionChecked(affected int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
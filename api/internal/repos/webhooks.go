@@ -0,0 +1,293 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"xorm.io/xorm"
+)
+
+// WebhookEndpointFindOpts provides options for finding webhook endpoints.
+type WebhookEndpointFindOpts struct {
+	CompanyID int64
+	Limit     int
+	Offset    int
+}
+
+// WebhookDeliveryFindOpts provides options for finding webhook deliveries.
+type WebhookDeliveryFindOpts struct {
+	CompanyID  int64
+	EndpointID int64
+	Status     types.WebhookDeliveryStatus
+	Limit      int
+	Offset     int
+}
+
+// WebhookDeliveryJob is a delivery the dispatcher has claimed, with the endpoint and event it sends.
+type WebhookDeliveryJob struct {
+	Delivery *types.WebhookDelivery
+	Endpoint *types.WebhookEndpoint
+	Event    *types.OutboxEvent
+}
+
+// WebhooksRepo defines the interface for webhook endpoints, the outbox and the delivery log.
+//
+//go:generate mockgen -source=./webhooks.go -destination=./mocks/webhooks.go -package=mock_repos WebhooksRepo
+type WebhooksRepo interface {
+	GetEndpoint(ctx context.Context, companyID, id int64) (*types.WebhookEndpoint, bool, error)
+	FindEndpoints(ctx context.Context, opts *WebhookEndpointFindOpts) ([]*types.WebhookEndpoint, int64, error)
+	CreateEndpoint(ctx context.Context, endpoint *types.WebhookEndpoint) error
+	CreateEndpointTx(ctx context.Context, tx *xorm.Session, endpoint *types.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *types.WebhookEndpoint) error
+	UpdateEndpointTx(ctx context.Context, tx *xorm.Session, endpoint *types.WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, companyID, id int64) error
+	DeleteEndpointTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error
+	GetDelivery(ctx context.Context, companyID, id int64) (*types.WebhookDelivery, bool, error)
+	FindDeliveries(ctx context.Context, opts *WebhookDeliveryFindOpts) ([]*types.WebhookDelivery, int64, error)
+	Redeliver(ctx context.Context, delivery *types.WebhookDelivery) (*types.WebhookDelivery, error)
+	DispatchEvents(ctx context.Context, limit int) (int, error)
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDeliveryJob, error)
+	RecordAttempt(ctx context.Context, delivery *types.WebhookDelivery) error
+}
+
+type webhooksRepo struct {
+	db *xorm.Engine
+}
+
+// NewWebhooksRepo creates a new WebhooksRepo.
+func NewWebhooksRepo(db *xorm.Engine) WebhooksRepo {
+	return &webhooksRepo{db: db}
+}
+
+// GetEndpoint retrieves a company's webhook endpoint by ID.
+func (r *webhooksRepo) GetEndpoint(ctx context.Context, companyID, id int64) (*types.WebhookEndpoint, bool, error) {
+	endpoint := new(types.WebhookEndpoint)
+	has, err := r.db.Context(ctx).Where("id = ? AND company_id = ?", id, companyID).Get(endpoint)
+	return endpoint, has, err
+}
+
+// FindEndpoints retrieves a list of webhook endpoints, newest first, and a total count.
+func (r *webhooksRepo) FindEndpoints(ctx context.Context, opts *WebhookEndpointFindOpts) ([]*types.WebhookEndpoint, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	if opts != nil {
+		if opts.CompanyID > 0 {
+			s.And("company_id = ?", opts.CompanyID)
+		}
+		if opts.Limit > 0 {
+			s.Limit(opts.Limit, opts.Offset)
+		}
+	}
+	var endpoints []*types.WebhookEndpoint
+	count, err := s.OrderBy("id DESC").FindAndCount(&endpoints)
+	return endpoints, count, err
+}
+
+// CreateEndpoint inserts a new webhook endpoint.
+func (r *webhooksRepo) CreateEndpoint(ctx context.Context, endpoint *types.WebhookEndpoint) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.CreateEndpointTx(ctx, tx, endpoint)
+	})
+	return err
+}
+
+// CreateEndpointTx inserts a new webhook endpoint. Secret must already be set.
+func (r *webhooksRepo) CreateEndpointTx(ctx context.Context, tx *xorm.Session, endpoint *types.WebhookEndpoint) error {
+	if err := types.Validate(endpoint); err != nil {
+		return err
+	}
+	if _, err := tx.Context(ctx).Insert(endpoint); err != nil {
+		return err
+	}
+	return recordAuditTx(ctx, tx, types.AuditEntityWebhookEndpoint, types.AuditActionCreate, endpoint.ID, nil, endpoint)
+}
+
+// UpdateEndpoint changes a webhook endpoint's URL, description, event types and whether it is active.
+func (r *webhooksRepo) UpdateEndpoint(ctx context.Context, endpoint *types.WebhookEndpoint) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.UpdateEndpointTx(ctx, tx, endpoint)
+	})
+	return err
+}
+
+// UpdateEndpointTx changes a webhook endpoint's URL, description, event types and whether it is
+// active. Deliveries already created are sent to the new URL.
+func (r *webhooksRepo) UpdateEndpointTx(ctx context.Context, tx *xorm.Session, endpoint *types.WebhookEndpoint) error {
+	if err := types.Validate(endpoint); err != nil {
+		return err
+	}
+	return auditedTx[types.WebhookEndpoint](ctx, tx, types.AuditEntityWebhookEndpoint, types.AuditActionUpdate, endpoint.ID, func() error {
+		return versionChecked(tx.Context(ctx).
+			Where("id = ? AND company_id = ?", endpoint.ID, endpoint.CompanyID).
+			Cols("url", "description", "event_types", "active").
+			Update(endpoint))
+	})
+}
+
+// DeleteEndpoint removes a webhook endpoint.
+func (r *webhooksRepo) DeleteEndpoint(ctx context.Context, companyID, id int64) error {
+	_, err := wrapInSession(r.db, func(tx *xorm.Session) (*struct{}, error) {
+		return nil, r.DeleteEndpointTx(ctx, tx, companyID, id)
+	})
+	return err
+}
+
+// DeleteEndpointTx removes a webhook endpoint. Its deliveries are removed with it.
+func (r *webhooksRepo) DeleteEndpointTx(ctx context.Context, tx *xorm.Session, companyID, id int64) error {
+	return auditedTx[types.WebhookEndpoint](ctx, tx, types.AuditEntityWebhookEndpoint, types.AuditActionDelete, id, func() error {
+		_, err := tx.Context(ctx).Where("id = ? AND company_id = ?", id, companyID).Delete(&types.WebhookEndpoint{})
+		return err
+	})
+}
+
+// GetDelivery retrieves a company's webhook delivery by ID.
+func (r *webhooksRepo) GetDelivery(ctx context.Context, companyID, id int64) (*types.WebhookDelivery, bool, error) {
+	delivery := new(types.WebhookDelivery)
+	has, err := r.db.Context(ctx).Where("id = ? AND company_id = ?", id, companyID).Get(delivery)
+	return delivery, has, err
+}
+
+// FindDeliveries retrieves a list of webhook deliveries, newest first, and a total count.
+func (r *webhooksRepo) FindDeliveries(ctx context.Context, opts *WebhookDeliveryFindOpts) ([]*types.WebhookDelivery, int64, error) {
+	s := r.db.NewSession().Context(ctx)
+	defer s.Close()
+	if opts != nil {
+		if opts.CompanyID > 0 {
+			s.And("company_id = ?", opts.CompanyID)
+		}
+		if opts.EndpointID > 0 {
+			s.And("endpoint_id = ?", opts.EndpointID)
+		}
+		if opts.Status != "" {
+			s.And("status = ?", opts.Status)
+		}
+		if opts.Limit > 0 {
+			s.Limit(opts.Limit, opts.Offset)
+		}
+	}
+	var deliveries []*types.WebhookDelivery
+	count, err := s.OrderBy("id DESC").FindAndCount(&deliveries)
+	return deliveries, count, err
+}
+
+// Redeliver sends the event of a delivery to its endpoint again, as a new delivery that is due
+// immediately. The original delivery stays in the log as it is.
+func (r *webhooksRepo) Redeliver(ctx context.Context, delivery *types.WebhookDelivery) (*types.WebhookDelivery, error) {
+	now := time.Now()
+	redelivery := &types.WebhookDelivery{
+		CompanyID:     delivery.CompanyID,
+		EndpointID:    delivery.EndpointID,
+		EventID:       delivery.EventID,
+		EventType:     delivery.EventType,
+		Status:        types.WebhookDeliveryStatusPending,
+		NextAttemptAt: &now,
+	}
+	if _, err := r.db.Context(ctx).Insert(redelivery); err != nil {
+		return nil, err
+	}
+	return redelivery, nil
+}
+
+// DispatchEvents creates a delivery for every subscribed endpoint of up to limit events in the
+// outbox, oldest first, and returns how many events it dispatched. Events stay in the outbox
+// afterwards, for the event stream, until they are older than types.OutboxEventRetention and none
+// of their deliveries is pending. Concurrent dispatchers skip the events another one is working on.
+func (r *webhooksRepo) DispatchEvents(ctx context.Context, limit int) (int, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) (int, error) {
+		now := time.Now()
+		if _, err := tx.Context(ctx).Exec(
+			"DELETE FROM outbox_events WHERE dispatched_at <= ? AND NOT EXISTS (SELECT 1 FROM webhook_deliveries d WHERE d.event_id = outbox_events.id AND d.status = ?)",
+			now.Add(-types.OutboxEventRetention), types.WebhookDeliveryStatusPending,
+		); err != nil {
+			return 0, fmt.Errorf("failed to remove old outbox events: %w", err)
+		}
+
+		var events []*types.OutboxEvent
+		if err := tx.Context(ctx).
+			SQL("SELECT * FROM outbox_events WHERE dispatched_at IS NULL ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED", limit).
+			Find(&events); err != nil {
+			return 0, err
+		}
+
+		endpoints := make(map[int64][]*types.WebhookEndpoint)
+		for _, event := range events {
+			companyEndpoints, ok := endpoints[event.CompanyID]
+			if !ok {
+				if err := tx.Context(ctx).Where("company_id = ? AND active = ?", event.CompanyID, true).Find(&companyEndpoints); err != nil {
+					return 0, err
+				}
+				endpoints[event.CompanyID] = companyEndpoints
+			}
+
+			var deliveries []*types.WebhookDelivery
+			for _, endpoint := range companyEndpoints {
+				if endpoint.Subscribes(event.EventType) {
+					deliveries = append(deliveries, &types.WebhookDelivery{
+						CompanyID:     event.CompanyID,
+						EndpointID:    endpoint.ID,
+						EventID:       event.ID,
+						EventType:     event.EventType,
+						Status:        types.WebhookDeliveryStatusPending,
+						NextAttemptAt: &now,
+					})
+				}
+			}
+
+			if len(deliveries) > 0 {
+				if _, err := tx.Context(ctx).Insert(&deliveries); err != nil {
+					return 0, err
+				}
+			}
+			if _, err := tx.Context(ctx).ID(event.ID).Cols("dispatched_at").Update(&types.OutboxEvent{DispatchedAt: &now}); err != nil {
+				return 0, err
+			}
+		}
+		return len(events), nil
+	})
+}
+
+// ClaimDeliveries returns up to limit pending deliveries that are due, and moves their next attempt
+// lease into the future so that no other dispatcher claims them meanwhile. A delivery whose attempt
+// is never recorded, because the dispatcher stopped, is tried again once the lease runs out.
+func (r *webhooksRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDeliveryJob, error) {
+	return wrapInSession(r.db, func(tx *xorm.Session) ([]*WebhookDeliveryJob, error) {
+		now := time.Now()
+		var deliveries []*types.WebhookDelivery
+		if err := tx.Context(ctx).
+			SQL("SELECT * FROM webhook_deliveries WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ? FOR UPDATE SKIP LOCKED",
+				types.WebhookDeliveryStatusPending, now, limit).
+			Find(&deliveries); err != nil {
+			return nil, err
+		}
+
+		leasedUntil := now.Add(lease)
+		jobs := make([]*WebhookDeliveryJob, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			delivery.NextAttemptAt = &leasedUntil
+			if _, err := tx.Context(ctx).ID(delivery.ID).Cols("next_attempt_at").Update(delivery); err != nil {
+				return nil, err
+			}
+
+			job := &WebhookDeliveryJob{Delivery: delivery, Endpoint: new(types.WebhookEndpoint), Event: new(types.OutboxEvent)}
+			if _, err := tx.Context(ctx).ID(delivery.EndpointID).Get(job.Endpoint); err != nil {
+				return nil, err
+			}
+			if _, err := tx.Context(ctx).ID(delivery.EventID).Get(job.Event); err != nil {
+				return nil, err
+			}
+			jobs = append(jobs, job)
+		}
+		return jobs, nil
+	})
+}
+
+// RecordAttempt saves the outcome of an attempt to send a delivery.
+func (r *webhooksRepo) RecordAttempt(ctx context.Context, delivery *types.WebhookDelivery) error {
+	_, err := r.db.Context(ctx).
+		ID(delivery.ID).
+		Cols("status", "attempts", "next_attempt_at", "last_attempt_at", "response_status", "last_error").
+		Update(delivery)
+	return err
+}
@@ -0,0 +1,181 @@
+package repos_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhooksRepo", func() {
+	var (
+		repo     repos.WebhooksRepo
+		company  *types.Company
+		address  *types.Address
+		endpoint *types.WebhookEndpoint
+	)
+
+	BeforeEach(func() {
+		repo = gr.Webhooks()
+
+		var err error
+		address, err = gr.Addresses().Create(ctx, &types.Address{
+			Line1: "123 Main St", City: "Anytown", State: "CA", Country: "USA", PostalCode: "12345",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		company = &types.Company{Name: "Test Co", AddressID: address.ID}
+		Expect(gr.Companies().Create(ctx, company)).To(Succeed())
+
+		endpoint = &types.WebhookEndpoint{
+			CompanyID:  company.ID,
+			URL:        "https://example.com/hooks",
+			Secret:     "whsec_test",
+			EventTypes: types.WebhookEventTypes{"location.created", "location.updated"},
+			Active:     true,
+		}
+		Expect(repo.CreateEndpoint(ctx, endpoint)).To(Succeed())
+	})
+
+	findEvents := func() []*types.OutboxEvent {
+		var events []*types.OutboxEvent
+		Expect(db.OrderBy("id").Find(&events)).To(Succeed())
+		return events
+	}
+
+	It("should write the events of changes to the outbox with the change", func() {
+		location := &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"}
+		Expect(gr.Locations().Create(ctx, location)).To(Succeed())
+
+		events := findEvents()
+		Expect(events).To(HaveLen(2)) // The company was created too.
+		Expect(events[1].EventType).To(Equal(types.WebhookEventType("location.created")))
+		Expect(events[1].EntityID).To(Equal(location.ID))
+		data, err := json.Marshal(location)
+		Expect(err).NotTo(HaveOccurred())
+		Expect([]byte(events[1].Data)).To(MatchJSON(data))
+	})
+
+	It("should not write events of changes that are rolled back", func() {
+		tx := db.NewSession()
+		defer tx.Close()
+		Expect(tx.Begin()).To(Succeed())
+		Expect(gr.Locations().CreateTx(ctx, tx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+		Expect(tx.Rollback()).To(Succeed())
+
+		Expect(findEvents()).To(HaveLen(1))
+	})
+
+	It("should create deliveries for subscribed endpoints only", func() {
+		location := &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"}
+		Expect(gr.Locations().Create(ctx, location)).To(Succeed())
+
+		dispatched, err := repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dispatched).To(Equal(2))
+
+		events := findEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].DispatchedAt).NotTo(BeNil())
+		Expect(events[1].EventType).To(Equal(types.WebhookEventType("location.created")))
+		Expect(events[1].DispatchedAt).NotTo(BeNil())
+
+		deliveries, count, err := repo.FindDeliveries(ctx, &repos.WebhookDeliveryFindOpts{EndpointID: endpoint.ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(Equal(int64(1)))
+		Expect(deliveries[0].Status).To(Equal(types.WebhookDeliveryStatusPending))
+		Expect(deliveries[0].EventID).To(Equal(events[1].ID))
+
+		dispatched, err = repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dispatched).To(BeZero())
+	})
+
+	It("should remove old events once none of their deliveries is pending", func() {
+		retention := types.OutboxEventRetention
+		types.OutboxEventRetention = 0
+		DeferCleanup(func() { types.OutboxEventRetention = retention })
+
+		Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+		_, err := repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		// The company's event has no deliveries; the location's event still has a pending one.
+		_, err = repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+		events := findEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].EventType).To(Equal(types.WebhookEventType("location.created")))
+
+		jobs, err := repo.ClaimDeliveries(ctx, 10, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobs).To(HaveLen(1))
+		jobs[0].Delivery.Status = types.WebhookDeliveryStatusSucceeded
+		jobs[0].Delivery.NextAttemptAt = nil
+		Expect(repo.RecordAttempt(ctx, jobs[0].Delivery)).To(Succeed())
+
+		_, err = repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(findEvents()).To(BeEmpty())
+		_, count, err := repo.FindDeliveries(ctx, &repos.WebhookDeliveryFindOpts{EndpointID: endpoint.ID})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(count).To(BeZero())
+	})
+
+	It("should keep events within their retention", func() {
+		Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+		_, err := repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(findEvents()).To(HaveLen(2))
+	})
+
+	It("should lease claimed deliveries and record their attempts", func() {
+		Expect(gr.Locations().Create(ctx, &types.Location{CompanyID: company.ID, AddressID: address.ID, Name: "Dock"})).To(Succeed())
+		_, err := repo.DispatchEvents(ctx, 10)
+		Expect(err).NotTo(HaveOccurred())
+
+		jobs, err := repo.ClaimDeliveries(ctx, 10, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(jobs).To(HaveLen(1))
+		Expect(jobs[0].Endpoint.Secret).To(Equal("whsec_test"))
+		Expect(jobs[0].Event.EventType).To(Equal(types.WebhookEventType("location.created")))
+
+		again, err := repo.ClaimDeliveries(ctx, 10, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again).To(BeEmpty())
+
+		delivery := jobs[0].Delivery
+		now := time.Now()
+		delivery.Status = types.WebhookDeliveryStatusSucceeded
+		delivery.Attempts = 1
+		delivery.NextAttemptAt = nil
+		delivery.LastAttemptAt = &now
+		delivery.ResponseStatus = 200
+		Expect(repo.RecordAttempt(ctx, delivery)).To(Succeed())
+
+		saved, found, err := repo.GetDelivery(ctx, company.ID, delivery.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(saved.Status).To(Equal(types.WebhookDeliveryStatusSucceeded))
+		Expect(saved.NextAttemptAt).To(BeNil())
+
+		redelivery, err := repo.Redeliver(ctx, saved)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redelivery.ID).NotTo(Equal(saved.ID))
+		Expect(redelivery.Status).To(Equal(types.WebhookDeliveryStatusPending))
+	})
+
+	It("should remove an endpoint with its deliveries", func() {
+		Expect(repo.DeleteEndpoint(ctx, company.ID, endpoint.ID)).To(Succeed())
+
+		_, found, err := repo.GetEndpoint(ctx, company.ID, endpoint.ID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+})
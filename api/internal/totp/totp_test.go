@@ -0,0 +1,71 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/totp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TOTP", func() {
+	// The SHA-1 secret of the RFC 6238 test vectors.
+	rfcSecret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	It("should match the RFC 6238 test vectors", func() {
+		for unix, code := range map[int64]string{
+			59:          "287082",
+			1111111109:  "081804",
+			1111111111:  "050471",
+			1234567890:  "005924",
+			2000000000:  "279037",
+			20000000000: "353130",
+		} {
+			got, err := totp.Code(rfcSecret, totp.Step(time.Unix(unix, 0)))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(got).To(Equal(code), "at %d", unix)
+		}
+	})
+
+	It("should accept codes of the neighbouring steps once", func() {
+		now := time.Unix(1111111111, 0)
+		previous, err := totp.Code(rfcSecret, totp.Step(now)-1)
+		Expect(err).NotTo(HaveOccurred())
+
+		step, ok := totp.Validate(rfcSecret, previous, now, 0)
+		Expect(ok).To(BeTrue())
+		Expect(step).To(Equal(totp.Step(now) - 1))
+
+		_, ok = totp.Validate(rfcSecret, previous, now, step)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should reject wrong and old codes", func() {
+		now := time.Unix(1111111111, 0)
+		_, ok := totp.Validate(rfcSecret, "000000", now, 0)
+		Expect(ok).To(BeFalse())
+
+		old, err := totp.Code(rfcSecret, totp.Step(now)-5)
+		Expect(err).NotTo(HaveOccurred())
+		_, ok = totp.Validate(rfcSecret, old, now, 0)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should build an otpauth URI", func() {
+		secret, err := totp.GenerateSecret()
+		Expect(err).NotTo(HaveOccurred())
+
+		uri := totp.URI("Order Management", "jane@example.com", secret)
+		Expect(uri).To(HavePrefix("otpauth://totp/Order%20Management:jane@example.com?"))
+		Expect(uri).To(ContainSubstring("secret=" + secret))
+	})
+
+	It("should hash recovery codes however they are typed", func() {
+		codes, hashes, err := totp.GenerateRecoveryCodes()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(codes).To(HaveLen(totp.RecoveryCodeCount))
+		Expect(hashes[0]).To(Equal(totp.HashRecoveryCode(" " + strings.ToUpper(strings.ReplaceAll(codes[0], "-", "")))))
+	})
+})
@@ -0,0 +1,247 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	"github.com/happilymarrieddad/order-management-v3/api/utils"
+)
+
+// maxDrainLength limits how much of a response is read before the connection is reused.
+const maxDrainLength = 4096
+
+// ErrAddressNotAllowed is returned for endpoints that resolve to a loopback, private, link-local or
+// otherwise internal address, so that webhooks cannot reach the network the API runs in.
+var ErrAddressNotAllowed = errors.New("endpoint address is not allowed")
+
+// ErrHTTPSRequired is returned for endpoints that do not use https.
+var ErrHTTPSRequired = errors.New("endpoint must use https")
+
+// Config holds the settings of the dispatcher.
+type Config struct {
+	// PollInterval is how often the outbox and the due deliveries are checked.
+	PollInterval time.Duration
+	// BatchSize is how many events and deliveries are handled per poll.
+	BatchSize int
+	// Timeout is how long an endpoint has to respond.
+	Timeout time.Duration
+	// Retry decides when failed deliveries are tried again.
+	Retry types.WebhookRetryPolicy
+	// AllowInsecure lets endpoints use http and resolve to internal addresses. It is only meant for
+	// development, where the receiving end usually runs on localhost.
+	AllowInsecure bool
+}
+
+// ConfigFromEnv reads the dispatcher settings from WEBHOOK_POLL_INTERVAL and WEBHOOK_TIMEOUT, and the
+// retry policy from WEBHOOK_MAX_ATTEMPTS, WEBHOOK_BACKOFF_BASE and WEBHOOK_BACKOFF_MAX, falling back
+// to types.DefaultWebhookRetryPolicy.
+func ConfigFromEnv() Config {
+	retry := types.DefaultWebhookRetryPolicy
+	return Config{
+		PollInterval: utils.GetEnvDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second),
+		BatchSize:    100,
+		Timeout:      utils.GetEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		Retry: types.WebhookRetryPolicy{
+			MaxAttempts: utils.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", retry.MaxAttempts),
+			BaseBackoff: utils.GetEnvDuration("WEBHOOK_BACKOFF_BASE", retry.BaseBackoff),
+			MaxBackoff:  utils.GetEnvDuration("WEBHOOK_BACKOFF_MAX", retry.MaxBackoff),
+		},
+	}
+}
+
+// Dispatcher sends the events in the outbox to the webhook endpoints of their company. Several
+// dispatchers can run against the same database; each event and delivery is handled by one of them.
+type Dispatcher struct {
+	repo   repos.WebhooksRepo
+	client *http.Client
+	config Config
+	logger *log.Logger
+}
+
+// NewDispatcher creates a Dispatcher. Redirects are not followed; they count as failed attempts.
+// Unless the config allows insecure endpoints, connections are only made to public addresses, which
+// are checked after DNS resolution, and proxies are not used.
+func NewDispatcher(repo repos.WebhooksRepo, config Config, logger *log.Logger) *Dispatcher {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowInsecure {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		}
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &Dispatcher{
+		repo: repo,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		config: config,
+		logger: logger,
+	}
+}
+
+// Run dispatches events until the context is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Printf("webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce turns the events in the outbox into deliveries and makes one attempt at every
+// delivery that is due.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) error {
+	if _, err := d.repo.DispatchEvents(ctx, d.config.BatchSize); err != nil {
+		return fmt.Errorf("unable to dispatch events: %w", err)
+	}
+
+	// The lease outlasts the attempt, so that a delivery is only claimed again when the attempt
+	// was never recorded.
+	jobs, err := d.repo.ClaimDeliveries(ctx, d.config.BatchSize, 2*d.config.Timeout)
+	if err != nil {
+		return fmt.Errorf("unable to claim deliveries: %w", err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, job := range jobs {
+		wg.Add(1)
+		go func(job *repos.WebhookDeliveryJob) {
+			defer wg.Done()
+			if err := d.deliver(ctx, job); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("unable to record delivery %d: %w", job.Delivery.ID, err))
+				mu.Unlock()
+			}
+		}(job)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// deliver makes one attempt at a delivery and records its outcome. Failed deliveries are retried
+// with exponential backoff until they run out of attempts.
+func (d *Dispatcher) deliver(ctx context.Context, job *repos.WebhookDeliveryJob) error {
+	delivery := job.Delivery
+	now := time.Now()
+
+	var (
+		status int
+		err    error
+	)
+	if job.Endpoint.Active {
+		status, err = d.send(ctx, job, now)
+	} else {
+		err = errors.New("endpoint is inactive")
+	}
+
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = status
+	switch {
+	case err == nil:
+		delivery.Status = types.WebhookDeliveryStatusSucceeded
+		delivery.NextAttemptAt = nil
+		delivery.LastError = ""
+	case delivery.Attempts >= d.config.Retry.MaxAttempts || !job.Endpoint.Active:
+		delivery.Status = types.WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = err.Error()
+	default:
+		next := now.Add(d.config.Retry.Backoff(delivery.Attempts))
+		delivery.Status = types.WebhookDeliveryStatusPending
+		delivery.NextAttemptAt = &next
+		delivery.LastError = err.Error()
+	}
+
+	// The attempt is recorded even when the dispatcher is stopping, so that it is not repeated.
+	return d.repo.RecordAttempt(context.WithoutCancel(ctx), delivery)
+}
+
+// send posts the signed event to the endpoint and returns the response status. Any status other
+// than 2xx is an error. The response body is never kept: the delivery log is readable by the
+// company, so it must not relay what an endpoint answered.
+func (d *Dispatcher) send(ctx context.Context, job *repos.WebhookDeliveryJob, now time.Time) (int, error) {
+	if !d.config.AllowInsecure {
+		if u, err := url.Parse(job.Endpoint.URL); err != nil || u.Scheme != "https" {
+			return 0, ErrHTTPSRequired
+		}
+	}
+
+	body, err := json.Marshal(job.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.Endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "order-management-webhooks/1.0")
+	req.Header.Set(EventHeader, string(job.Event.EventType))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(job.Delivery.ID, 10))
+	req.Header.Set(SignatureHeader, Sign(job.Endpoint.Secret, now, body))
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(res.Body, maxDrainLength))
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("endpoint responded with %d", res.StatusCode)
+	}
+	return res.StatusCode, nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which net.IP does not count as
+// private.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicIP reports whether ip is an address on the public internet, which endpoints must resolve
+// to unless insecure endpoints are allowed.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() || sharedAddressSpace.Contains(ip))
+}
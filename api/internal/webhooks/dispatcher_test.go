@@ -0,0 +1,185 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/repos"
+	mock_repos "github.com/happilymarrieddad/order-management-v3/api/internal/repos/mocks"
+	"github.com/happilymarrieddad/order-management-v3/api/internal/webhooks"
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockRepo   *mock_repos.MockWebhooksRepo
+		dispatcher *webhooks.Dispatcher
+		server     *httptest.Server
+		status     int
+		mu         sync.Mutex
+		received   []*http.Request
+		bodies     [][]byte
+		job        *repos.WebhookDeliveryJob
+		recorded   *types.WebhookDelivery
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockRepo = mock_repos.NewMockWebhooksRepo(mockCtrl)
+		status = http.StatusOK
+		received, bodies, recorded = nil, nil, nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			received = append(received, r)
+			bodies = append(bodies, body)
+			mu.Unlock()
+			w.WriteHeader(status)
+			w.Write([]byte("nope"))
+		}))
+
+		dispatcher = webhooks.NewDispatcher(mockRepo, webhooks.Config{
+			PollInterval:  time.Second,
+			BatchSize:     10,
+			Timeout:       time.Second,
+			Retry:         types.WebhookRetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+			AllowInsecure: true,
+		}, log.New(io.Discard, "", 0))
+
+		job = &repos.WebhookDeliveryJob{
+			Delivery: &types.WebhookDelivery{ID: 9, EndpointID: 2, EventID: 4, Status: types.WebhookDeliveryStatusPending},
+			Endpoint: &types.WebhookEndpoint{ID: 2, URL: server.URL, Secret: "whsec_test", Active: true},
+			Event:    &types.OutboxEvent{ID: 4, CompanyID: 1, EventType: "product.created", EntityType: types.AuditEntityProduct, EntityID: 3, Data: types.EventData(`{"id":3}`)},
+		}
+
+		mockRepo.EXPECT().DispatchEvents(gomock.Any(), 10).Return(1, nil)
+	})
+
+	AfterEach(func() {
+		server.Close()
+		mockCtrl.Finish()
+	})
+
+	expectAttempt := func() {
+		mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), 10, 2*time.Second).Return([]*repos.WebhookDeliveryJob{job}, nil)
+		mockRepo.EXPECT().RecordAttempt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, delivery *types.WebhookDelivery) error {
+			recorded = delivery
+			return nil
+		})
+	}
+
+	It("should post the signed event and record the success", func() {
+		expectAttempt()
+
+		Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+		Expect(received).To(HaveLen(1))
+		req := received[0]
+		Expect(req.Header.Get(webhooks.EventHeader)).To(Equal("product.created"))
+		Expect(req.Header.Get(webhooks.DeliveryHeader)).To(Equal("9"))
+		Expect(bodies[0]).To(MatchJSON(`{"id":4,"companyId":1,"type":"product.created","entityType":"product","entityId":3,"data":{"id":3},"createdAt":"0001-01-01T00:00:00Z"}`))
+
+		signature := req.Header.Get(webhooks.SignatureHeader)
+		Expect(signature).To(HavePrefix("t="))
+		var unix int64
+		_, err := fmt.Sscanf(signature, "t=%d,", &unix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(signature).To(Equal(webhooks.Sign("whsec_test", time.Unix(unix, 0), bodies[0])))
+
+		Expect(recorded.Status).To(Equal(types.WebhookDeliveryStatusSucceeded))
+		Expect(recorded.Attempts).To(Equal(1))
+		Expect(recorded.ResponseStatus).To(Equal(http.StatusOK))
+		Expect(recorded.NextAttemptAt).To(BeNil())
+	})
+
+	It("should retry failed attempts with backoff", func() {
+		status = http.StatusInternalServerError
+		expectAttempt()
+
+		Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+		Expect(recorded.Status).To(Equal(types.WebhookDeliveryStatusPending))
+		Expect(recorded.Attempts).To(Equal(1))
+		Expect(recorded.ResponseStatus).To(Equal(http.StatusInternalServerError))
+		Expect(recorded.LastError).To(Equal("endpoint responded with 500"))
+		Expect(*recorded.NextAttemptAt).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+	})
+
+	It("should fail deliveries that ran out of attempts", func() {
+		status = http.StatusGone
+		job.Delivery.Attempts = 2
+		expectAttempt()
+
+		Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+		Expect(recorded.Status).To(Equal(types.WebhookDeliveryStatusFailed))
+		Expect(recorded.Attempts).To(Equal(3))
+		Expect(recorded.NextAttemptAt).To(BeNil())
+	})
+
+	It("should fail deliveries to inactive endpoints without sending them", func() {
+		job.Endpoint.Active = false
+		expectAttempt()
+
+		Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+		Expect(received).To(BeEmpty())
+		Expect(recorded.Status).To(Equal(types.WebhookDeliveryStatusFailed))
+		Expect(recorded.LastError).To(Equal("endpoint is inactive"))
+	})
+
+	Context("without insecure endpoints", func() {
+		BeforeEach(func() {
+			dispatcher = webhooks.NewDispatcher(mockRepo, webhooks.Config{
+				PollInterval: time.Second,
+				BatchSize:    10,
+				Timeout:      time.Second,
+				Retry:        types.WebhookRetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+			}, log.New(io.Discard, "", 0))
+		})
+
+		It("should not send events to http endpoints", func() {
+			expectAttempt()
+
+			Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+			Expect(received).To(BeEmpty())
+			Expect(recorded.Status).To(Equal(types.WebhookDeliveryStatusPending))
+			Expect(recorded.LastError).To(Equal(webhooks.ErrHTTPSRequired.Error()))
+		})
+
+		DescribeTable("should not connect to internal addresses",
+			func(url string) {
+				job.Endpoint.URL = url
+				expectAttempt()
+
+				Expect(dispatcher.DispatchOnce(context.Background())).To(Succeed())
+
+				Expect(recorded.ResponseStatus).To(BeZero())
+				Expect(recorded.LastError).To(ContainSubstring(webhooks.ErrAddressNotAllowed.Error()))
+			},
+			Entry("loopback", "https://127.0.0.1:8443/hook"),
+			Entry("localhost", "https://localhost/hook"),
+			Entry("link-local metadata service", "https://169.254.169.254/latest/meta-data"),
+			Entry("private network", "https://10.0.0.1/hook"),
+			Entry("IPv6 loopback", "https://[::1]/hook"),
+		)
+	})
+
+	It("should return the error when events cannot be claimed", func() {
+		mockRepo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+		Expect(dispatcher.DispatchOnce(context.Background())).To(MatchError(ContainSubstring("db error")))
+	})
+})
@@ -0,0 +1,31 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the signature of a delivery, as "t=<unix time>,v1=<hex HMAC>".
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader carries the event type of a delivery, such as "product.updated".
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader carries the ID of a delivery. Redeliveries have a new ID but the same event ID
+	// in the body, which receivers can use to drop duplicates.
+	DeliveryHeader = "X-Webhook-Delivery"
+)
+
+// Sign returns the signature header of a delivery body sent at the given time. The signature is the
+// HMAC-SHA256, keyed with the endpoint's secret, of the Unix time, a dot and the body. Signing the
+// time lets receivers reject old deliveries that are replayed.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
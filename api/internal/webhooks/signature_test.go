@@ -0,0 +1,21 @@
+package webhooks_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/internal/webhooks"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sign", func() {
+	It("should sign the time and body with HMAC-SHA256", func() {
+		Expect(webhooks.Sign("whsec_test", time.Unix(1700000000, 0), []byte(`{"id":1}`))).
+			To(Equal("t=1700000000,v1=2f441ba4b3b2d50d28a9ab9d9fd8880376ecd1eb5d0435401553f5d8d0a5dcf8"))
+	})
+
+	It("should change with the secret", func() {
+		Expect(webhooks.Sign("other", time.Unix(1700000000, 0), []byte(`{"id":1}`))).
+			NotTo(Equal(webhooks.Sign("whsec_test", time.Unix(1700000000, 0), []byte(`{"id":1}`))))
+	})
+})
@@ -14,6 +14,7 @@ type Address struct {
 	GlobalCode string    `validate:"-" json:"globalCode" xorm:"'global_code'"`
 	CreatedAt  time.Time `validate:"-" json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt  time.Time `validate:"-" json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version    int64     `validate:"-" json:"version" xorm:"version 'version'" audit:"-"`
 }
 
 // TableName specifies the table name for the Address model.
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// ScopeActionRead allows GET requests to a resource.
+	ScopeActionRead = "read"
+	// ScopeActionWrite allows every request to a resource, including reads.
+	ScopeActionWrite = "write"
+	// ScopeAllResources matches every resource, e.g. "*:read".
+	ScopeAllResources = "*"
+)
+
+// APIKeyScopeResources lists the resources an API key can be scoped to. They match the first path
+// segment of the v1 routes.
+var APIKeyScopeResources = []string{
+	"addresses",
+	"commodities",
+	"commodity-attributes",
+	"commodity-types",
+	"companies",
+	"company-attribute-settings",
+	"events",
+	"locations",
+	"product-packs",
+	"products",
+	"users",
+}
+
+// APIKey lets another system call the API on behalf of a company without a user login. Only a
+// hash of the key is stored; the key itself is shown once, when it is created.
+type APIKey struct {
+	ID        int64  `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID int64  `json:"companyId" xorm:"notnull index 'company_id'"`
+	Name      string `json:"name" xorm:"notnull 'name'" validate:"required,max=100"`
+	// KeyPrefix is the start of the key, for telling keys apart.
+	KeyPrefix string `json:"keyPrefix" xorm:"notnull 'key_prefix'"`
+	KeyHash   string `json:"-" xorm:"notnull unique 'key_hash'" audit:"redact"`
+	// Scopes are "<resource>:read" or "<resource>:write", where the resource may be "*".
+	Scopes     Scopes     `json:"scopes" xorm:"'scopes'" validate:"required,min=1"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty" xorm:"'expires_at'"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty" xorm:"'last_used_at'"`
+	RevokedAt  *time.Time `json:"-" xorm:"'revoked_at'"`
+	// CreatedBy is the user who created the key.
+	CreatedBy int64     `json:"createdBy" xorm:"'created_by'"`
+	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+}
+
+// TableName specifies the table name for the APIKey model.
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsActive reports whether the key has neither been revoked nor expired at the given time.
+func (k APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// Scopes is a slice of API key scopes stored as a PostgreSQL text[].
+type Scopes []string
+
+// FromDB is called by xorm to convert a database value to a Scopes slice.
+// It parses a PostgreSQL array string like "{products:read,users:write}". Scopes never contain
+// commas or quotes, so no unquoting is needed.
+func (s *Scopes) FromDB(data []byte) error {
+	trimmed := strings.Trim(string(data), "{}")
+	if trimmed == "" {
+		*s = Scopes{}
+		return nil
+	}
+	*s = strings.Split(trimmed, ",")
+	return nil
+}
+
+// ToDB is called by xorm to convert a Scopes slice to a database value.
+func (s Scopes) ToDB() ([]byte, error) {
+	return []byte("{" + strings.Join(s, ",") + "}"), nil
+}
+
+// Allows reports whether the scopes permit the action on the resource. A write scope also
+// permits reads.
+func (s Scopes) Allows(resource, action string) bool {
+	for _, scope := range s {
+		scopeResource, scopeAction, _ := strings.Cut(scope, ":")
+		if scopeResource != resource && scopeResource != ScopeAllResources {
+			continue
+		}
+		if scopeAction == action || scopeAction == ScopeActionWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidScope reports whether s is a known "<resource>:<action>" scope.
+func IsValidScope(s string) bool {
+	resource, action, ok := strings.Cut(s, ":")
+	if !ok || (action != ScopeActionRead && action != ScopeActionWrite) {
+		return false
+	}
+	if resource == ScopeAllResources {
+		return true
+	}
+	for _, r := range APIKeyScopeResources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package types_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APIKey", func() {
+	Describe("Scopes", func() {
+		It("should round trip through the database format", func() {
+			scopes := types.Scopes{"products:read", "users:write"}
+			data, err := scopes.ToDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{products:read,users:write}"))
+
+			var parsed types.Scopes
+			Expect(parsed.FromDB(data)).To(Succeed())
+			Expect(parsed).To(Equal(scopes))
+
+			Expect(parsed.FromDB([]byte("{}"))).To(Succeed())
+			Expect(parsed).To(BeEmpty())
+		})
+
+		It("should allow reads with a read or write scope and writes only with a write scope", func() {
+			scopes := types.Scopes{"products:read", "locations:write"}
+			Expect(scopes.Allows("products", types.ScopeActionRead)).To(BeTrue())
+			Expect(scopes.Allows("products", types.ScopeActionWrite)).To(BeFalse())
+			Expect(scopes.Allows("locations", types.ScopeActionRead)).To(BeTrue())
+			Expect(scopes.Allows("locations", types.ScopeActionWrite)).To(BeTrue())
+			Expect(scopes.Allows("users", types.ScopeActionRead)).To(BeFalse())
+		})
+
+		It("should match every resource with a wildcard scope", func() {
+			scopes := types.Scopes{"*:read"}
+			Expect(scopes.Allows("users", types.ScopeActionRead)).To(BeTrue())
+			Expect(scopes.Allows("users", types.ScopeActionWrite)).To(BeFalse())
+		})
+
+		DescribeTable("IsValidScope",
+			func(scope string, valid bool) {
+				Expect(types.IsValidScope(scope)).To(Equal(valid))
+			},
+			Entry("resource read", "products:read", true),
+			Entry("wildcard write", "*:write", true),
+			Entry("unknown resource", "orders:read", false),
+			Entry("unknown action", "products:delete", false),
+			Entry("no action", "products", false),
+		)
+	})
+
+	Describe("IsActive", func() {
+		It("should be inactive once revoked or expired", func() {
+			now := time.Now()
+			past := now.Add(-time.Minute)
+			future := now.Add(time.Minute)
+
+			Expect(types.APIKey{}.IsActive(now)).To(BeTrue())
+			Expect(types.APIKey{ExpiresAt: &future}.IsActive(now)).To(BeTrue())
+			Expect(types.APIKey{ExpiresAt: &past}.IsActive(now)).To(BeFalse())
+			Expect(types.APIKey{RevokedAt: &past}.IsActive(now)).To(BeFalse())
+		})
+	})
+})
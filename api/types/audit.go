@@ -0,0 +1,208 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// AuditAction is the kind of change an audit log entry records.
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionDelete covers soft deletes and revocations as well, whose entries show the
+	// visible flag or revocation time changing.
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditEntityType names the kind of record an audit log entry is about.
+type AuditEntityType string
+
+const (
+	AuditEntityAddress                 AuditEntityType = "address"
+	AuditEntityAPIKey                  AuditEntityType = "api_key"
+	AuditEntityCommodity               AuditEntityType = "commodity"
+	AuditEntityCommodityAttribute      AuditEntityType = "commodity_attribute"
+	AuditEntityCommodityType           AuditEntityType = "commodity_type"
+	AuditEntityCompany                 AuditEntityType = "company"
+	AuditEntityCompanyAttributeSetting AuditEntityType = "company_attribute_setting"
+	AuditEntityCompanyRole             AuditEntityType = "company_role"
+	AuditEntityInvitation              AuditEntityType = "invitation"
+	AuditEntityLocation                AuditEntityType = "location"
+	AuditEntityProduct                 AuditEntityType = "product"
+	AuditEntityProductAttributeValue   AuditEntityType = "product_attribute_value"
+	AuditEntityProductPack             AuditEntityType = "product_pack"
+	AuditEntitySSOConfig               AuditEntityType = "sso_config"
+	AuditEntityUser                    AuditEntityType = "user"
+	AuditEntityWebhookEndpoint         AuditEntityType = "webhook_endpoint"
+)
+
+// AuditRedacted replaces the values of redacted columns in audit log entries.
+const AuditRedacted = "[redacted]"
+
+// AuditLogEntry records one change to a record: who made it, in which company, and how each column
+// changed. It is written in the same transaction as the change.
+type AuditLogEntry struct {
+	ID int64 `json:"id" xorm:"pk autoincr 'id'"`
+	// CompanyID is the company the record belongs to. It is empty for shared records such as
+	// commodities and addresses.
+	CompanyID *int64 `json:"companyId,omitempty" xorm:"index 'company_id'"`
+	// ActorID is the user who made the change, or the platform admin impersonating them. It is empty
+	// for changes made with an API key or by the system, like accepting an invitation.
+	ActorID *int64 `json:"actorId,omitempty" xorm:"index 'actor_id'"`
+	// ImpersonatedUserID is the user the actor was impersonating.
+	ImpersonatedUserID *int64 `json:"impersonatedUserId,omitempty" xorm:"'impersonated_user_id'"`
+	// APIKeyID is the API key the change was made with.
+	APIKeyID   *int64          `json:"apiKeyId,omitempty" xorm:"'api_key_id'"`
+	EntityType AuditEntityType `json:"entityType" xorm:"notnull 'entity_type'"`
+	EntityID   int64           `json:"entityId" xorm:"notnull 'entity_id'"`
+	Action     AuditAction     `json:"action" xorm:"notnull 'action'"`
+	Changes    AuditChanges    `json:"changes" xorm:"notnull 'changes'"`
+	CreatedAt  time.Time       `json:"createdAt" xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the AuditLogEntry model.
+func (AuditLogEntry) TableName() string {
+	return "audit_log"
+}
+
+// AuditChange is the value of a column before and after a change. Before is null for created
+// records and After is null for deleted ones.
+type AuditChange struct {
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+// AuditChanges maps the columns a change touched to their values before and after.
+type AuditChanges map[string]AuditChange
+
+// FromDB is called by xorm to convert a JSONB value to AuditChanges.
+func (c *AuditChanges) FromDB(data []byte) error {
+	return json.Unmarshal(data, c)
+}
+
+// ToDB is called by xorm to convert AuditChanges to a JSONB value.
+func (c AuditChanges) ToDB() ([]byte, error) {
+	if c == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(c)
+}
+
+// DiffAudit compares two versions of a record and returns the columns that differ. Either may be nil
+// for created or deleted records. Columns are named as in the database. Timestamps xorm maintains
+// and fields tagged `audit:"-"` are left out, and the values of fields tagged `audit:"redact"` are
+// replaced with AuditRedacted, so that secrets are never logged but their changes still are.
+func DiffAudit(before, after interface{}) (AuditChanges, error) {
+	beforeCols, err := auditColumns(before)
+	if err != nil {
+		return nil, err
+	}
+	afterCols, err := auditColumns(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(AuditChanges)
+	for name, col := range afterCols {
+		if old, ok := beforeCols[name]; !ok || string(old.value) != string(col.value) {
+			changes[name] = AuditChange{Before: beforeCols[name].shown, After: col.shown}
+		}
+	}
+	for name, col := range beforeCols {
+		if _, ok := afterCols[name]; !ok {
+			changes[name] = AuditChange{Before: col.shown}
+		}
+	}
+	return changes, nil
+}
+
+// auditColumn is the JSON-encoded value of a column, and the value shown in the audit log, which
+// differs for redacted columns.
+type auditColumn struct {
+	value json.RawMessage
+	shown json.RawMessage
+}
+
+// auditColumns returns the value of every audited column of a record.
+func auditColumns(record interface{}) (map[string]auditColumn, error) {
+	cols := make(map[string]auditColumn)
+	v := reflect.ValueOf(record)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return cols, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return cols, nil
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		audit := field.Tag.Get("audit")
+		name, ok := auditColumnName(field)
+		if !ok || audit == "-" {
+			continue
+		}
+
+		value, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		col := auditColumn{value: value, shown: value}
+		if audit == "redact" {
+			col.shown = json.RawMessage("null")
+			if !v.Field(i).IsZero() {
+				col.shown = json.RawMessage(`"` + AuditRedacted + `"`)
+			}
+		}
+		cols[name] = col
+	}
+	return cols, nil
+}
+
+// auditColumnName returns the column of a struct field from its xorm tag. Fields that are not
+// stored and the created and updated timestamps are not audited.
+func auditColumnName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("xorm")
+	if tag == "-" {
+		return "", false
+	}
+
+	name := ""
+	for _, part := range strings.Fields(tag) {
+		switch {
+		case strings.HasPrefix(part, "'") && strings.HasSuffix(part, "'"):
+			name = strings.Trim(part, "'")
+		case part == "-" || part == "extends" || part == "created" || part == "updated":
+			return "", false
+		}
+	}
+	if name == "" {
+		name = snakeCase(field.Name)
+	}
+	return name, true
+}
+
+// snakeCase maps a field name to a column name the way xorm's default mapper does.
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit", func() {
+	Describe("DiffAudit", func() {
+		It("should list every column of a created record", func() {
+			changes, err := types.DiffAudit(nil, &types.Location{ID: 1, CompanyID: 2, AddressID: 3, Name: "Dock", Visible: true})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(HaveLen(5))
+			Expect(changes).To(HaveKeyWithValue("name", types.AuditChange{After: json.RawMessage(`"Dock"`)}))
+			Expect(changes).To(HaveKeyWithValue("visible", types.AuditChange{After: json.RawMessage(`true`)}))
+		})
+
+		It("should list only the changed columns of an updated record", func() {
+			before := &types.Company{ID: 1, Name: "Acme", OrderPrefix: "A-", CreatedAt: time.Now()}
+			after := &types.Company{ID: 1, Name: "Acme", OrderPrefix: "B-", UpdatedAt: time.Now()}
+
+			changes, err := types.DiffAudit(before, after)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(Equal(types.AuditChanges{
+				"order_prefix": {Before: json.RawMessage(`"A-"`), After: json.RawMessage(`"B-"`)},
+			}))
+		})
+
+		It("should show a soft delete through the visible flag", func() {
+			changes, err := types.DiffAudit(&types.Product{ID: 1, Visible: true}, &types.Product{ID: 1})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(Equal(types.AuditChanges{
+				"visible": {Before: json.RawMessage(`true`), After: json.RawMessage(`false`)},
+			}))
+		})
+
+		It("should list every column of a deleted record as removed", func() {
+			changes, err := types.DiffAudit(&types.CompanyRole{ID: 1, Name: "Packers"}, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(HaveKeyWithValue("name", types.AuditChange{Before: json.RawMessage(`"Packers"`)}))
+		})
+
+		It("should record that secrets changed without their values", func() {
+			changes, err := types.DiffAudit(
+				&types.User{ID: 1, Password: "old-hash", MFALastStep: 1},
+				&types.User{ID: 1, Password: "new-hash", MFALastStep: 2},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(changes).To(Equal(types.AuditChanges{
+				"password": {Before: json.RawMessage(`"[redacted]"`), After: json.RawMessage(`"[redacted]"`)},
+			}))
+		})
+
+		It("should not list columns that did not change", func() {
+			changes, err := types.DiffAudit(&types.Address{ID: 1, City: "Fresno"}, &types.Address{ID: 1, City: "Fresno"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changes).To(BeEmpty())
+		})
+	})
+
+	Describe("AuditChanges", func() {
+		It("should round trip through the database format", func() {
+			changes := types.AuditChanges{"name": {Before: json.RawMessage(`"a"`), After: json.RawMessage(`"b"`)}}
+			data, err := changes.ToDB()
+			Expect(err).NotTo(HaveOccurred())
+
+			var parsed types.AuditChanges
+			Expect(parsed.FromDB(data)).To(Succeed())
+			Expect(parsed).To(Equal(changes))
+		})
+	})
+})
@@ -12,6 +12,7 @@ type Commodity struct {
 	Visible       bool          `json:"visible" xorm:"'visible'"`
 	CreatedAt     time.Time     `json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt     time.Time     `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version       int64         `json:"version" xorm:"version 'version'" audit:"-"`
 }
 
 // TableName specifies the table name for the Commodity model.
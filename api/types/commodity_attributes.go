@@ -11,6 +11,7 @@ type CommodityAttribute struct {
 	CommodityType CommodityType `json:"commodityType" xorm:"index 'commodity_type_name'"`
 	CreatedAt     time.Time     `json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt     time.Time     `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version       int64         `json:"version" xorm:"version 'version'" audit:"-"`
 }
 
 // TableName specifies the table name for the CommodityAttribute model.
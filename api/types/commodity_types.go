@@ -1,40 +1,30 @@
 package types
 
-import "fmt"
+import "time"
 
-// CommodityType represents the type of a commodity.
+// CommodityType is the ID of a row in the commodity_types table.
 type CommodityType int
 
 const (
 	// CommodityTypeUnknown is for when the type is not known.
 	CommodityTypeUnknown CommodityType = iota
-	// CommodityTypeProduce represents produce commodities.
+	// CommodityTypeProduce represents produce commodities. It is seeded by the migration that created
+	// the commodity_types table; further types are managed through the API.
 	CommodityTypeProduce
 )
 
-// String returns the string representation of a CommodityType.
-func (ct CommodityType) String() string {
-	switch ct {
-	case CommodityTypeProduce:
-		return "produce"
-	default:
-		return "unknown"
-	}
+// CommodityTypeRecord is a commodity category, e.g. "produce", "meat", "dairy" or "floral".
+// Commodities and commodity attributes reference it by ID.
+type CommodityTypeRecord struct {
+	ID        CommodityType `json:"id" xorm:"pk autoincr 'id'"`
+	Name      string        `json:"name" xorm:"notnull unique 'name'" validate:"required,max=100"`
+	Visible   bool          `xorm:"'visible'" json:"-"`
+	CreatedAt time.Time     `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt time.Time     `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version   int64         `json:"version" xorm:"version 'version'" audit:"-"`
 }
 
-// ParseCommodityType converts a string to a CommodityType.
-func ParseCommodityType(s string) (CommodityType, error) {
-	switch s {
-	case "produce":
-		return CommodityTypeProduce, nil
-	default:
-		return CommodityTypeUnknown, fmt.Errorf("unknown commodity type: %s", s)
-	}
-}
-
-// AllCommodityTypes returns a slice of all defined CommodityType constants.
-func AllCommodityTypes() []CommodityType {
-	return []CommodityType{
-		CommodityTypeProduce,
-	}
+// TableName specifies the table name for the CommodityTypeRecord model.
+func (CommodityTypeRecord) TableName() string {
+	return "commodity_types"
 }
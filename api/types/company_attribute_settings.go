@@ -10,6 +10,7 @@ type CompanyAttributeSetting struct {
 	DisplayOrder         int       `json:"displayOrder" xorm:"'display_order'"`
 	CreatedAt            time.Time `json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt            time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version              int64     `json:"version" xorm:"version 'version'" audit:"-"`
 }
 
 func (CompanyAttributeSetting) TableName() string {
@@ -0,0 +1,34 @@
+package types
+
+import "time"
+
+// IdempotencyKeyTTL is how long the response to a request with an Idempotency-Key is replayed to
+// retries. After that the key may be used again for a new request. The server sets it from its
+// configuration at startup.
+var IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKey remembers a request sent with an Idempotency-Key header and the response to it.
+type IdempotencyKey struct {
+	// Owner is who sent the request, as "user:<id>", "user:<id>:as:<impersonator id>" or
+	// "api_key:<id>". Keys of different owners never collide.
+	Owner string `xorm:"pk 'owner'"`
+	Key   string `xorm:"pk 'key'"`
+	// RequestHash is the fingerprint of the request's method, URL and body.
+	RequestHash string `xorm:"notnull 'request_hash'"`
+	// StatusCode is the status of the response, or 0 while the request is being handled.
+	StatusCode   int       `xorm:"notnull 'status_code'"`
+	ContentType  string    `xorm:"notnull 'content_type'"`
+	ResponseBody []byte    `xorm:"'response_body'"`
+	CreatedAt    time.Time `xorm:"created 'created_at'"`
+	ExpiresAt    time.Time `xorm:"notnull 'expires_at'"`
+}
+
+// TableName specifies the table name for the IdempotencyKey model.
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
+
+// Completed reports whether the response to the request has been stored.
+func (k IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
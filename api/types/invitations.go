@@ -0,0 +1,98 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InvitationStatus is the state of a user invitation. It is derived from the invitation's times.
+type InvitationStatus string
+
+const (
+	// InvitationStatusPending invitations can still be accepted.
+	InvitationStatusPending InvitationStatus = "pending"
+	// InvitationStatusAccepted invitations created a user.
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	// InvitationStatusRevoked invitations were withdrawn by an admin.
+	InvitationStatusRevoked InvitationStatus = "revoked"
+	// InvitationStatusExpired invitations were not accepted in time. Resending one makes it pending
+	// again.
+	InvitationStatusExpired InvitationStatus = "expired"
+)
+
+// Invitation asks someone by email to join a company. The invitee sets their own name and password
+// when accepting it, and gets the company roles the invitation names. Only a hash of the invite
+// token is stored.
+type Invitation struct {
+	ID        int64  `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID int64  `json:"companyId" xorm:"notnull index 'company_id'"`
+	Email     string `json:"email" xorm:"notnull index 'email'"`
+	// AddressID is the address the new user starts with. It defaults to the company's address.
+	AddressID  int64      `json:"addressId" xorm:"notnull 'address_id'"`
+	RoleIDs    IDs        `json:"roleIds" xorm:"'role_ids'"`
+	TokenHash  string     `json:"-" xorm:"notnull unique 'token_hash'" audit:"redact"`
+	ExpiresAt  time.Time  `json:"expiresAt" xorm:"notnull 'expires_at'"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty" xorm:"'accepted_at'"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" xorm:"'revoked_at'"`
+	// UserID is the user who accepted the invitation.
+	UserID *int64 `json:"userId,omitempty" xorm:"'user_id'"`
+	// InvitedBy is the user who sent the invitation.
+	InvitedBy int64     `json:"invitedBy" xorm:"'invited_by'"`
+	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+
+	Status InvitationStatus `json:"status" xorm:"-"`
+}
+
+// TableName specifies the table name for the Invitation model.
+func (Invitation) TableName() string {
+	return "user_invitations"
+}
+
+// StatusAt returns the state of the invitation at the given time.
+func (i Invitation) StatusAt(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
+
+// IDs is a slice of IDs stored as a PostgreSQL bigint[].
+type IDs []int64
+
+// FromDB is called by xorm to convert a database value to an IDs slice.
+// It parses a PostgreSQL array string like "{1,2}".
+func (ids *IDs) FromDB(data []byte) error {
+	trimmed := strings.Trim(string(data), "{}")
+	if trimmed == "" {
+		*ids = IDs{}
+		return nil
+	}
+	parts := strings.Split(trimmed, ",")
+	result := make(IDs, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return err
+		}
+		result = append(result, id)
+	}
+	*ids = result
+	return nil
+}
+
+// ToDB is called by xorm to convert an IDs slice to a database value.
+func (ids IDs) ToDB() ([]byte, error) {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return []byte("{" + strings.Join(parts, ",") + "}"), nil
+}
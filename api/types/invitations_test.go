@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Invitation", func() {
+	Describe("StatusAt", func() {
+		now := time.Now()
+
+		It("should derive the status from the invitation's times", func() {
+			invitation := types.Invitation{ExpiresAt: now.Add(time.Hour)}
+			Expect(invitation.StatusAt(now)).To(Equal(types.InvitationStatusPending))
+			Expect(invitation.StatusAt(now.Add(2 * time.Hour))).To(Equal(types.InvitationStatusExpired))
+
+			invitation.RevokedAt = &now
+			Expect(invitation.StatusAt(now)).To(Equal(types.InvitationStatusRevoked))
+
+			invitation.RevokedAt = nil
+			invitation.AcceptedAt = &now
+			Expect(invitation.StatusAt(now.Add(2 * time.Hour))).To(Equal(types.InvitationStatusAccepted))
+		})
+	})
+
+	Describe("IDs", func() {
+		It("should round trip through the database format", func() {
+			ids := types.IDs{3, 14}
+			data, err := ids.ToDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{3,14}"))
+
+			var parsed types.IDs
+			Expect(parsed.FromDB(data)).To(Succeed())
+			Expect(parsed).To(Equal(ids))
+
+			Expect(parsed.FromDB([]byte("{}"))).To(Succeed())
+			Expect(parsed).To(BeEmpty())
+
+			Expect(parsed.FromDB([]byte("{a}"))).NotTo(Succeed())
+		})
+	})
+})
@@ -11,6 +11,7 @@ type Location struct {
 	Visible   bool      `xorm:"'visible'" json:"-"`
 	CreatedAt time.Time `json:"createdAt" xorm:"created"`
 	UpdatedAt time.Time `json:"updatedAt" xorm:"updated"`
+	Version   int64     `json:"version" xorm:"version 'version'" audit:"-"`
 
 	// Relations (for API responses)
 	Company *Company `json:"company,omitempty" xorm:"-"`
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginThrottleScope is what failed logins are counted against.
+type LoginThrottleScope string
+
+const (
+	// LoginThrottleScopeAccount counts failed logins per email address, whether or not an account
+	// with that email exists.
+	LoginThrottleScopeAccount LoginThrottleScope = "account"
+	// LoginThrottleScopeIP counts failed logins per client IP address across all emails.
+	LoginThrottleScopeIP LoginThrottleScope = "ip"
+	// LoginThrottleScopePasswordReset counts password reset requests per email address, whether or
+	// not an account with that email exists.
+	LoginThrottleScopePasswordReset LoginThrottleScope = "reset"
+	// LoginThrottleScopePasswordResetIP counts password reset requests per client IP address.
+	LoginThrottleScopePasswordResetIP LoginThrottleScope = "reset_ip"
+)
+
+// LoginLockoutEventType is what happened to a lockout.
+type LoginLockoutEventType string
+
+const (
+	// LoginLockoutEventLocked is recorded when too many failed logins lock an email or IP address.
+	LoginLockoutEventLocked LoginLockoutEventType = "locked"
+	// LoginLockoutEventUnlocked is recorded when an admin unlocks an account.
+	LoginLockoutEventUnlocked LoginLockoutEventType = "unlocked"
+)
+
+// LoginThrottlePolicy decides when failed logins lock an email or IP address and for how long.
+type LoginThrottlePolicy struct {
+	// MaxAccountFailures is how many failed logins for one email lock it.
+	MaxAccountFailures int
+	// MaxIPFailures is how many failed logins from one IP address lock it.
+	MaxIPFailures int
+	// FailureWindow is how long a failed login counts. A failure after a quiet period this long
+	// starts counting from one again.
+	FailureWindow time.Duration
+	// BaseLockout is how long the first lockout lasts. Every further lockout within LockoutMemory
+	// doubles it, up to MaxLockout.
+	BaseLockout time.Duration
+	// MaxLockout is the longest a lockout lasts.
+	MaxLockout time.Duration
+	// LockoutMemory is how long earlier lockouts are remembered for the backoff.
+	LockoutMemory time.Duration
+}
+
+// ActiveLoginThrottlePolicy is the policy logins are throttled with. By default five failed logins
+// for an email, or 50 from an IP address, within 15 minutes lock it for one minute, doubling with
+// every further lockout within a day up to one hour. The server sets it from its configuration at
+// startup.
+var ActiveLoginThrottlePolicy = LoginThrottlePolicy{
+	MaxAccountFailures: 5,
+	MaxIPFailures:      50,
+	FailureWindow:      15 * time.Minute,
+	BaseLockout:        time.Minute,
+	MaxLockout:         time.Hour,
+	LockoutMemory:      24 * time.Hour,
+}
+
+// MaxFailures returns how many failed logins, or password reset requests, lock the scope.
+func (p LoginThrottlePolicy) MaxFailures(scope LoginThrottleScope) int {
+	if scope == LoginThrottleScopeIP || scope == LoginThrottleScopePasswordResetIP {
+		return p.MaxIPFailures
+	}
+	return p.MaxAccountFailures
+}
+
+// LockoutDuration returns how long the given lockout lasts; the first lockout is number 1.
+func (p LoginThrottlePolicy) LockoutDuration(lockout int) time.Duration {
+	d := p.BaseLockout
+	for i := 1; i < lockout && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		return p.MaxLockout
+	}
+	return d
+}
+
+// LoginThrottle counts the recent failed logins of an email or IP address.
+type LoginThrottle struct {
+	Scope         LoginThrottleScope `xorm:"pk 'scope'"`
+	Key           string             `xorm:"pk 'key'"`
+	Failures      int                `xorm:"notnull 'failures'"`
+	Lockouts      int                `xorm:"notnull 'lockouts'"`
+	LastFailureAt time.Time          `xorm:"notnull 'last_failure_at'"`
+	LockedUntil   *time.Time         `xorm:"'locked_until'"`
+	LastLockoutAt *time.Time         `xorm:"'last_lockout_at'"`
+}
+
+// TableName specifies the table name for the LoginThrottle model.
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
+
+// LoginLockoutEvent records an email or IP address being locked or unlocked.
+type LoginLockoutEvent struct {
+	ID    int64                 `json:"id" xorm:"pk autoincr 'id'"`
+	Event LoginLockoutEventType `json:"event" xorm:"notnull 'event'"`
+	Scope LoginThrottleScope    `json:"scope" xorm:"notnull 'scope'"`
+	Key   string                `json:"key" xorm:"notnull index 'key'"`
+	// UserID is the account that was locked or unlocked, when the email belongs to one.
+	UserID *int64 `json:"userId,omitempty" xorm:"'user_id'"`
+	// IPAddress is the client whose failed login caused the lockout.
+	IPAddress   string     `json:"ipAddress,omitempty" xorm:"'ip_address'"`
+	LockedUntil *time.Time `json:"lockedUntil,omitempty" xorm:"'locked_until'"`
+	// ActorID is the admin who unlocked the account.
+	ActorID   *int64    `json:"actorId,omitempty" xorm:"'actor_id'"`
+	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the LoginLockoutEvent model.
+func (LoginLockoutEvent) TableName() string {
+	return "login_lockout_events"
+}
+
+// LoginThrottleKey normalizes an email so that failed logins are counted the same however it is
+// capitalized.
+func LoginThrottleKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
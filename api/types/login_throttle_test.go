@@ -0,0 +1,35 @@
+package types_test
+
+import (
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LoginThrottlePolicy", func() {
+	policy := types.LoginThrottlePolicy{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      50,
+		BaseLockout:        time.Minute,
+		MaxLockout:         10 * time.Minute,
+	}
+
+	It("should double the lockout up to the maximum", func() {
+		Expect(policy.LockoutDuration(1)).To(Equal(time.Minute))
+		Expect(policy.LockoutDuration(2)).To(Equal(2 * time.Minute))
+		Expect(policy.LockoutDuration(4)).To(Equal(8 * time.Minute))
+		Expect(policy.LockoutDuration(5)).To(Equal(10 * time.Minute))
+		Expect(policy.LockoutDuration(100)).To(Equal(10 * time.Minute))
+	})
+
+	It("should use the limit of the scope", func() {
+		Expect(policy.MaxFailures(types.LoginThrottleScopeAccount)).To(Equal(5))
+		Expect(policy.MaxFailures(types.LoginThrottleScopeIP)).To(Equal(50))
+	})
+
+	It("should count emails however they are capitalized", func() {
+		Expect(types.LoginThrottleKey(" Jane.Doe@Example.com ")).To(Equal("jane.doe@example.com"))
+	})
+})
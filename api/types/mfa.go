@@ -0,0 +1,18 @@
+package types
+
+import "time"
+
+// MFARecoveryCode lets a user log in without their authenticator app. Only its hash is stored and
+// it can be used once.
+type MFARecoveryCode struct {
+	ID        int64      `xorm:"pk autoincr 'id'"`
+	UserID    int64      `xorm:"notnull index 'user_id'"`
+	CodeHash  string     `xorm:"notnull 'code_hash'"`
+	UsedAt    *time.Time `xorm:"'used_at'"`
+	CreatedAt time.Time  `xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the MFARecoveryCode model.
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxPasswordBytes is the longest password bcrypt can hash.
+const maxPasswordBytes = 72
+
+// PasswordPolicy describes what a new password must look like.
+type PasswordPolicy struct {
+	MinLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+}
+
+// ActivePasswordPolicy is the policy new passwords are checked against. By default a password only
+// needs 8 characters and no character classes are required. The server sets it from its
+// configuration at startup.
+var ActivePasswordPolicy = PasswordPolicy{MinLength: 8}
+
+// PasswordPolicyError lists every rule a password breaks.
+type PasswordPolicyError struct {
+	Problems []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password " + strings.Join(e.Problems, ", ")
+}
+
+// Check returns a *PasswordPolicyError when the password breaks the policy. The password may not
+// be the user's email address.
+func (p PasswordPolicy) Check(password, email string) error {
+	var problems []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		problems = append(problems, "must be at least "+strconv.Itoa(p.MinLength)+" characters")
+	}
+	if len(password) > maxPasswordBytes {
+		problems = append(problems, "must be at most "+strconv.Itoa(maxPasswordBytes)+" bytes")
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		problems = append(problems, "must contain an uppercase letter")
+	}
+	if p.RequireLower && !lower {
+		problems = append(problems, "must contain a lowercase letter")
+	}
+	if p.RequireDigit && !digit {
+		problems = append(problems, "must contain a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		problems = append(problems, "must contain a symbol")
+	}
+
+	if email != "" && strings.EqualFold(password, email) {
+		problems = append(problems, "must not be the email address")
+	}
+
+	if len(problems) > 0 {
+		return &PasswordPolicyError{Problems: problems}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package types_test
+
+import (
+	"errors"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PasswordPolicy", func() {
+	var policy types.PasswordPolicy
+
+	BeforeEach(func() {
+		policy = types.PasswordPolicy{
+			MinLength:     10,
+			RequireUpper:  true,
+			RequireLower:  true,
+			RequireDigit:  true,
+			RequireSymbol: true,
+		}
+	})
+
+	problems := func(err error) []string {
+		var policyErr *types.PasswordPolicyError
+		Expect(errors.As(err, &policyErr)).To(BeTrue())
+		return policyErr.Problems
+	}
+
+	It("should accept a password that meets every rule", func() {
+		Expect(policy.Check("Tr0ub4dor&3", "user@example.com")).To(Succeed())
+	})
+
+	It("should report every rule a password breaks", func() {
+		Expect(problems(policy.Check("abc", "user@example.com"))).To(Equal([]string{
+			"must be at least 10 characters",
+			"must contain an uppercase letter",
+			"must contain a digit",
+			"must contain a symbol",
+		}))
+	})
+
+	It("should count characters rather than bytes for the minimum length", func() {
+		policy = types.PasswordPolicy{MinLength: 4}
+		Expect(policy.Check("éééé", "")).To(Succeed())
+	})
+
+	It("should reject a password longer than bcrypt accepts", func() {
+		policy = types.PasswordPolicy{MinLength: 8}
+		long := make([]byte, 73)
+		for i := range long {
+			long[i] = 'a'
+		}
+		Expect(problems(policy.Check(string(long), ""))).To(Equal([]string{"must be at most 72 bytes"}))
+	})
+
+	It("should reject the email address regardless of case", func() {
+		policy = types.PasswordPolicy{MinLength: 8}
+		err := policy.Check("User@Example.com", "user@example.com")
+		Expect(problems(err)).To(Equal([]string{"must not be the email address"}))
+		Expect(err.Error()).To(Equal("password must not be the email address"))
+	})
+})
@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// PasswordResetToken lets a user who forgot their password set a new one. Only its hash is stored
+// and it can be used once.
+type PasswordResetToken struct {
+	ID        int64      `xorm:"pk autoincr 'id'"`
+	UserID    int64      `xorm:"notnull index 'user_id'"`
+	TokenHash string     `xorm:"notnull unique 'token_hash'"`
+	ExpiresAt time.Time  `xorm:"notnull 'expires_at'"`
+	UsedAt    *time.Time `xorm:"'used_at'"`
+	CreatedAt time.Time  `xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the PasswordResetToken model.
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
@@ -0,0 +1,191 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// Permission is a named action a user may be allowed to perform. Routes declare the permission
+// they require; users get permissions from their built-in roles and their company roles.
+type Permission string
+
+const (
+	// PermissionOrdersRead allows viewing orders. Reserved for the orders API.
+	PermissionOrdersRead Permission = "orders:read"
+	// PermissionOrdersWrite allows creating and changing orders. Reserved for the orders API.
+	PermissionOrdersWrite Permission = "orders:write"
+	// PermissionProductsManage allows creating, changing, importing and deleting the company's
+	// products and product packs.
+	PermissionProductsManage Permission = "products:manage"
+	// PermissionLocationsWrite allows creating and changing the company's locations.
+	PermissionLocationsWrite Permission = "locations:write"
+	// PermissionLocationsDelete allows deleting the company's locations.
+	PermissionLocationsDelete Permission = "locations:delete"
+	// PermissionUsersManage allows changing and deleting other users of the company and setting
+	// their passwords.
+	PermissionUsersManage Permission = "users:manage"
+	// PermissionAPIKeysManage allows creating, viewing and revoking the company's API keys.
+	PermissionAPIKeysManage Permission = "api-keys:manage"
+	// PermissionRolesManage allows defining the company's roles and assigning them to users.
+	PermissionRolesManage Permission = "roles:manage"
+	// PermissionCompanySettingsManage allows changing the company itself, its attribute settings
+	// and rederiving its product names.
+	PermissionCompanySettingsManage Permission = "company-settings:manage"
+	// PermissionAuditLogRead allows viewing the audit log of changes made in the company.
+	PermissionAuditLogRead Permission = "audit-log:read"
+	// PermissionWebhooksManage allows registering the company's webhook endpoints, viewing their
+	// delivery log and redelivering events.
+	PermissionWebhooksManage Permission = "webhooks:manage"
+
+	// PermissionCompaniesManage allows creating, listing and deleting companies, moving users
+	// between them and acting on any company's data. It is a platform permission and cannot be
+	// granted by a company role.
+	PermissionCompaniesManage Permission = "companies:manage"
+	// PermissionCatalogManage allows managing the commodities, commodity attributes and commodity
+	// types shared by all companies. It is a platform permission and cannot be granted by a
+	// company role.
+	PermissionCatalogManage Permission = "catalog:manage"
+	// PermissionUsersImpersonate allows signing in as another user to see and do what they can.
+	// Writes made while impersonating are logged with the real actor. It is a platform permission
+	// and cannot be granted by a company role.
+	PermissionUsersImpersonate Permission = "users:impersonate"
+)
+
+// CompanyPermissions are the permissions a company role can grant. They only ever apply within
+// the user's own company.
+var CompanyPermissions = Permissions{
+	PermissionOrdersRead,
+	PermissionOrdersWrite,
+	PermissionProductsManage,
+	PermissionLocationsWrite,
+	PermissionLocationsDelete,
+	PermissionUsersManage,
+	PermissionAPIKeysManage,
+	PermissionRolesManage,
+	PermissionCompanySettingsManage,
+	PermissionAuditLogRead,
+	PermissionWebhooksManage,
+}
+
+// PlatformPermissions are the permissions that reach beyond a single company. Only the built-in
+// super admin role grants them.
+var PlatformPermissions = Permissions{
+	PermissionCompaniesManage,
+	PermissionCatalogManage,
+	PermissionUsersImpersonate,
+}
+
+// userRolePermissions are granted to every user with the built-in user role.
+var userRolePermissions = Permissions{
+	PermissionOrdersRead,
+	PermissionOrdersWrite,
+	PermissionProductsManage,
+	PermissionLocationsWrite,
+}
+
+// AllPermissions returns every defined permission.
+func AllPermissions() Permissions {
+	all := make(Permissions, 0, len(CompanyPermissions)+len(PlatformPermissions))
+	all = append(all, CompanyPermissions...)
+	return append(all, PlatformPermissions...)
+}
+
+// IsCompanyPermission reports whether p is a permission a company role can grant.
+func IsCompanyPermission(p Permission) bool {
+	return CompanyPermissions.Has(p)
+}
+
+// Permissions returns the permissions granted by a built-in role.
+func (r Role) Permissions() Permissions {
+	switch r {
+	case RoleSuperAdmin:
+		return AllPermissions()
+	case RoleAdmin:
+		return CompanyPermissions
+	case RoleUser:
+		return userRolePermissions
+	default:
+		return nil
+	}
+}
+
+// Permissions is a slice of Permission stored as a PostgreSQL text[].
+type Permissions []Permission
+
+// FromDB is called by xorm to convert a database value to a Permissions slice.
+// It parses a PostgreSQL array string like "{products:manage,users:manage}". Permissions never
+// contain commas or quotes, so no unquoting is needed.
+func (p *Permissions) FromDB(data []byte) error {
+	trimmed := strings.Trim(string(data), "{}")
+	if trimmed == "" {
+		*p = Permissions{}
+		return nil
+	}
+	parts := strings.Split(trimmed, ",")
+	perms := make(Permissions, len(parts))
+	for i, part := range parts {
+		perms[i] = Permission(part)
+	}
+	*p = perms
+	return nil
+}
+
+// ToDB is called by xorm to convert a Permissions slice to a database value.
+func (p Permissions) ToDB() ([]byte, error) {
+	parts := make([]string, len(p))
+	for i, perm := range p {
+		parts[i] = string(perm)
+	}
+	return []byte("{" + strings.Join(parts, ",") + "}"), nil
+}
+
+// Has checks if a specific permission exists in the slice.
+func (p Permissions) Has(perm Permission) bool {
+	for _, existing := range p {
+		if existing == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns the permissions in p or other, without duplicates.
+func (p Permissions) Merge(other Permissions) Permissions {
+	merged := make(Permissions, 0, len(p)+len(other))
+	for _, perm := range append(append(Permissions{}, p...), other...) {
+		if !merged.Has(perm) {
+			merged = append(merged, perm)
+		}
+	}
+	return merged
+}
+
+// CompanyRole is a role a company defines for its users. It bundles company permissions and is
+// granted to users in addition to their built-in roles.
+type CompanyRole struct {
+	ID          int64       `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID   int64       `json:"companyId" xorm:"notnull index 'company_id'"`
+	Name        string      `json:"name" xorm:"notnull 'name'" validate:"required,max=100"`
+	Description string      `json:"description" xorm:"'description'" validate:"max=255"`
+	Permissions Permissions `json:"permissions" xorm:"'permissions'" validate:"required,min=1"`
+	CreatedAt   time.Time   `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt   time.Time   `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version     int64       `json:"version" xorm:"version 'version'" audit:"-"`
+}
+
+// TableName specifies the table name for the CompanyRole model.
+func (CompanyRole) TableName() string {
+	return "company_roles"
+}
+
+// UserCompanyRole assigns a company role to a user.
+type UserCompanyRole struct {
+	UserID        int64     `json:"userId" xorm:"pk 'user_id'"`
+	CompanyRoleID int64     `json:"companyRoleId" xorm:"pk 'company_role_id'"`
+	CreatedAt     time.Time `json:"createdAt" xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the UserCompanyRole model.
+func (UserCompanyRole) TableName() string {
+	return "user_company_roles"
+}
@@ -0,0 +1,124 @@
+package types_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Permissions", func() {
+	Describe("ToDB and FromDB", func() {
+		It("should round-trip through a postgres array", func() {
+			perms := types.Permissions{types.PermissionProductsManage, types.PermissionUsersManage}
+			dbBytes, err := perms.ToDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(dbBytes)).To(Equal("{products:manage,users:manage}"))
+
+			var parsed types.Permissions
+			Expect(parsed.FromDB(dbBytes)).To(Succeed())
+			Expect(parsed).To(Equal(perms))
+		})
+
+		It("should handle an empty array", func() {
+			var parsed types.Permissions
+			Expect(parsed.FromDB([]byte("{}"))).To(Succeed())
+			Expect(parsed).To(BeEmpty())
+		})
+	})
+
+	Describe("Merge", func() {
+		It("should combine permissions without duplicates", func() {
+			merged := types.Permissions{types.PermissionOrdersRead, types.PermissionUsersManage}.
+				Merge(types.Permissions{types.PermissionUsersManage, types.PermissionRolesManage})
+			Expect(merged).To(Equal(types.Permissions{
+				types.PermissionOrdersRead, types.PermissionUsersManage, types.PermissionRolesManage,
+			}))
+		})
+	})
+
+	Describe("IsCompanyPermission", func() {
+		It("should not allow company roles to grant platform permissions", func() {
+			Expect(types.IsCompanyPermission(types.PermissionUsersManage)).To(BeTrue())
+			Expect(types.IsCompanyPermission(types.PermissionCompaniesManage)).To(BeFalse())
+			Expect(types.IsCompanyPermission(types.PermissionCatalogManage)).To(BeFalse())
+			Expect(types.IsCompanyPermission(types.PermissionUsersImpersonate)).To(BeFalse())
+			Expect(types.IsCompanyPermission("orders:delete")).To(BeFalse())
+		})
+	})
+
+	Describe("User.HasPermission", func() {
+		It("should grant every permission to super admins", func() {
+			superAdmin := types.User{Roles: types.Roles{types.RoleSuperAdmin}}
+			for _, perm := range types.AllPermissions() {
+				Expect(superAdmin.HasPermission(perm)).To(BeTrue(), string(perm))
+			}
+		})
+
+		It("should grant admins the company permissions only", func() {
+			admin := types.User{Roles: types.Roles{types.RoleAdmin}}
+			for _, perm := range types.CompanyPermissions {
+				Expect(admin.HasPermission(perm)).To(BeTrue(), string(perm))
+			}
+			for _, perm := range types.PlatformPermissions {
+				Expect(admin.HasPermission(perm)).To(BeFalse(), string(perm))
+			}
+		})
+
+		It("should grant users the default bundle only", func() {
+			user := types.User{Roles: types.Roles{types.RoleUser}}
+			Expect(user.HasPermission(types.PermissionProductsManage)).To(BeTrue())
+			Expect(user.HasPermission(types.PermissionUsersManage)).To(BeFalse())
+			Expect(user.HasPermission(types.PermissionCompaniesManage)).To(BeFalse())
+		})
+
+		It("should grant the permissions of the user's company roles", func() {
+			user := types.User{
+				Roles:       types.Roles{types.RoleUser},
+				Permissions: types.Permissions{types.PermissionUsersManage},
+			}
+			Expect(user.HasPermission(types.PermissionUsersManage)).To(BeTrue())
+			Expect(user.HasPermission(types.PermissionRolesManage)).To(BeFalse())
+		})
+	})
+	Describe("User.CanAccessCompany", func() {
+		It("should only allow admins into their own company", func() {
+			admin := types.User{CompanyID: 1, Roles: types.Roles{types.RoleAdmin}}
+			Expect(admin.CanAccessCompany(1)).To(BeTrue())
+			Expect(admin.CanAccessCompany(2)).To(BeFalse())
+		})
+
+		It("should allow super admins into any company", func() {
+			superAdmin := types.User{CompanyID: 1, Roles: types.Roles{types.RoleSuperAdmin}}
+			Expect(superAdmin.CanAccessCompany(1)).To(BeTrue())
+			Expect(superAdmin.CanAccessCompany(2)).To(BeTrue())
+		})
+	})
+
+	Describe("User.HasPermissionsOf", func() {
+		It("should not let admins act on super admins", func() {
+			admin := types.User{Roles: types.Roles{types.RoleAdmin}}
+			superAdmin := types.User{Roles: types.Roles{types.RoleSuperAdmin}}
+			Expect(admin.HasPermissionsOf(superAdmin)).To(BeFalse())
+			Expect(superAdmin.HasPermissionsOf(admin)).To(BeTrue())
+		})
+
+		It("should take the other user's company roles into account", func() {
+			manager := types.User{Roles: types.Roles{types.RoleUser}, Permissions: types.Permissions{types.PermissionUsersManage}}
+			user := types.User{Roles: types.Roles{types.RoleUser}}
+			Expect(manager.HasPermissionsOf(user)).To(BeTrue())
+
+			user.Permissions = types.Permissions{types.PermissionRolesManage}
+			Expect(manager.HasPermissionsOf(user)).To(BeFalse())
+		})
+	})
+
+	Describe("User.ActorID", func() {
+		It("should be the impersonator while the user is impersonated", func() {
+			user := types.User{ID: 5}
+			Expect(user.ActorID()).To(Equal(int64(5)))
+
+			user.Impersonator = &types.User{ID: 1}
+			Expect(user.ActorID()).To(Equal(int64(1)))
+		})
+	})
+})
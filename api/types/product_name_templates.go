@@ -0,0 +1,188 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ProductNameTemplateCommodity is the placeholder that renders the commodity name.
+	ProductNameTemplateCommodity = "commodity"
+	// ProductNameTemplateAttributes is the placeholder that renders every attribute value
+	// in the company's display order, joined by spaces.
+	ProductNameTemplateAttributes = "attributes"
+)
+
+// DefaultProductNameTemplate reproduces the original naming behavior: all attribute
+// values in display order followed by the commodity name.
+const DefaultProductNameTemplate = "{attributes} {commodity}"
+
+// ProductNameTemplate is a parsed company naming template used to derive product names.
+//
+// A template is made of literal text and placeholders:
+//   - {Attribute Name} renders the product's value for that commodity attribute (case-insensitive).
+//   - {commodity} renders the commodity name.
+//   - {attributes} renders all attribute values in display order, separated by spaces.
+//   - [ ... ] marks an optional part that is skipped when any placeholder inside it is empty.
+//   - A backslash escapes the next character, e.g. \{ or \[.
+//
+// For example, "{Size} {Color}[ ({Grade})] - {commodity}" renders "Large Red (No. 1) - Apple",
+// or "Large Red - Apple" when the product has no Grade.
+type ProductNameTemplate struct {
+	parts []productNameTemplatePart
+}
+
+// productNameTemplatePart is either a literal, a placeholder or an optional group of parts.
+type productNameTemplatePart struct {
+	literal     string
+	placeholder string
+	optional    []productNameTemplatePart
+}
+
+// ProductNameValues holds the data a ProductNameTemplate is rendered with.
+type ProductNameValues struct {
+	Commodity string
+	// Attributes maps lowercased attribute names to their values.
+	Attributes map[string]string
+	// Ordered holds the attribute values in display order, used by {attributes}.
+	Ordered []string
+}
+
+// ParseProductNameTemplate parses a naming template. An empty string parses to the default template.
+func ParseProductNameTemplate(s string) (*ProductNameTemplate, error) {
+	if strings.TrimSpace(s) == "" {
+		s = DefaultProductNameTemplate
+	}
+
+	var (
+		top      []productNameTemplatePart
+		group    []productNameTemplatePart
+		inGroup  bool
+		literal  strings.Builder
+		runes    = []rune(s)
+		hasPlace bool
+	)
+
+	flush := func() {
+		if literal.Len() == 0 {
+			return
+		}
+		part := productNameTemplatePart{literal: literal.String()}
+		literal.Reset()
+		if inGroup {
+			group = append(group, part)
+		} else {
+			top = append(top, part)
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		switch c := runes[i]; c {
+		case '\\':
+			if i+1 >= len(runes) {
+				return nil, fmt.Errorf("template ends with an escape character")
+			}
+			i++
+			literal.WriteRune(runes[i])
+		case '{':
+			end := i + 1
+			for end < len(runes) && runes[end] != '}' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unclosed placeholder at position %d", i)
+			}
+			name := strings.TrimSpace(string(runes[i+1 : end]))
+			if name == "" || strings.ContainsAny(name, "{[]\\") {
+				return nil, fmt.Errorf("invalid placeholder at position %d", i)
+			}
+			flush()
+			part := productNameTemplatePart{placeholder: strings.ToLower(name)}
+			if inGroup {
+				group = append(group, part)
+			} else {
+				top = append(top, part)
+			}
+			hasPlace = true
+			i = end
+		case '}':
+			return nil, fmt.Errorf("unexpected '}' at position %d", i)
+		case '[':
+			if inGroup {
+				return nil, fmt.Errorf("optional parts cannot be nested (position %d)", i)
+			}
+			flush()
+			inGroup = true
+			group = nil
+		case ']':
+			if !inGroup {
+				return nil, fmt.Errorf("unexpected ']' at position %d", i)
+			}
+			flush()
+			inGroup = false
+			top = append(top, productNameTemplatePart{optional: group})
+		default:
+			literal.WriteRune(c)
+		}
+	}
+
+	if inGroup {
+		return nil, fmt.Errorf("unclosed optional part")
+	}
+	flush()
+
+	if !hasPlace {
+		return nil, fmt.Errorf("template must contain at least one placeholder")
+	}
+
+	return &ProductNameTemplate{parts: top}, nil
+}
+
+// Render builds a product name from the template. Runs of whitespace are collapsed
+// and the result is trimmed, so empty placeholders do not leave gaps behind.
+func (t *ProductNameTemplate) Render(values ProductNameValues) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		if part.optional != nil {
+			rendered, ok := renderProductNameParts(part.optional, values)
+			if ok {
+				b.WriteString(rendered)
+			}
+			continue
+		}
+		rendered, _ := renderProductNameParts([]productNameTemplatePart{part}, values)
+		b.WriteString(rendered)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// renderProductNameParts renders the given parts and reports whether every placeholder had a value.
+func renderProductNameParts(parts []productNameTemplatePart, values ProductNameValues) (string, bool) {
+	var (
+		b        strings.Builder
+		complete = true
+	)
+	for _, part := range parts {
+		if part.placeholder == "" {
+			b.WriteString(part.literal)
+			continue
+		}
+		value := values.lookup(part.placeholder)
+		if strings.TrimSpace(value) == "" {
+			complete = false
+		}
+		b.WriteString(value)
+	}
+	return b.String(), complete
+}
+
+func (v ProductNameValues) lookup(placeholder string) string {
+	switch placeholder {
+	case ProductNameTemplateCommodity:
+		return v.Commodity
+	case ProductNameTemplateAttributes:
+		return strings.Join(v.Ordered, " ")
+	default:
+		return v.Attributes[placeholder]
+	}
+}
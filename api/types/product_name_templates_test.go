@@ -0,0 +1,98 @@
+package types_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProductNameTemplate", func() {
+	var values types.ProductNameValues
+
+	BeforeEach(func() {
+		values = types.ProductNameValues{
+			Commodity: "Apple",
+			Attributes: map[string]string{
+				"size":  "Large",
+				"color": "Red",
+				"grade": "No. 1",
+			},
+			Ordered: []string{"Large", "Red", "No. 1"},
+		}
+	})
+
+	render := func(tmpl string) string {
+		t, err := types.ParseProductNameTemplate(tmpl)
+		Expect(err).NotTo(HaveOccurred())
+		return t.Render(values)
+	}
+
+	Describe("ParseProductNameTemplate", func() {
+		It("should use the default template for an empty string", func() {
+			Expect(render("")).To(Equal("Large Red No. 1 Apple"))
+		})
+
+		It("should return an error for an unclosed placeholder", func() {
+			_, err := types.ParseProductNameTemplate("{Size {commodity}")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an empty placeholder", func() {
+			_, err := types.ParseProductNameTemplate("{} {commodity}")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an unexpected closing brace", func() {
+			_, err := types.ParseProductNameTemplate("Size} {commodity}")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for an unclosed optional part", func() {
+			_, err := types.ParseProductNameTemplate("{commodity} [({Grade})")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for nested optional parts", func() {
+			_, err := types.ParseProductNameTemplate("{commodity}[ [{Grade}]]")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for a template without placeholders", func() {
+			_, err := types.ParseProductNameTemplate("just text")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for a trailing escape character", func() {
+			_, err := types.ParseProductNameTemplate("{commodity} \\")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Render", func() {
+		It("should render attribute placeholders case-insensitively with separators", func() {
+			Expect(render("{commodity} - {SIZE}/{Color}")).To(Equal("Apple - Large/Red"))
+		})
+
+		It("should render optional parts when all their placeholders have values", func() {
+			Expect(render("{Size} {Color}[ ({Grade})] - {commodity}")).To(Equal("Large Red (No. 1) - Apple"))
+		})
+
+		It("should skip optional parts when a placeholder inside is empty", func() {
+			delete(values.Attributes, "grade")
+			Expect(render("{Size} {Color}[ ({Grade})] - {commodity}")).To(Equal("Large Red - Apple"))
+		})
+
+		It("should collapse whitespace left behind by empty placeholders", func() {
+			delete(values.Attributes, "color")
+			Expect(render("{Size} {Color} {commodity}")).To(Equal("Large Apple"))
+		})
+
+		It("should render escaped characters literally", func() {
+			Expect(render("\\[{Size}\\] {commodity}")).To(Equal("[Large] Apple"))
+		})
+
+		It("should render all ordered attributes for the attributes placeholder", func() {
+			Expect(render("{commodity}: {attributes}")).To(Equal("Apple: Large Red No. 1"))
+		})
+	})
+})
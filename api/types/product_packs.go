@@ -0,0 +1,86 @@
+package types
+
+import "time"
+
+const (
+	WeightUnitPound    = "lb"
+	WeightUnitKilogram = "kg"
+
+	DimensionUnitInch       = "in"
+	DimensionUnitCentimeter = "cm"
+)
+
+// ProductPack describes one way a Product is packed and sold, e.g. a 50 lb carton, a case of
+// 10x5 lb bags or an 88-count box. A product can have many packs.
+type ProductPack struct {
+	ID        int64 `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID int64 `json:"companyId" xorm:"notnull index 'company_id'"`
+	ProductID int64 `json:"productId" xorm:"notnull index 'product_id'" validate:"required"`
+	// PackStyle is the container the product ships in, e.g. "carton", "bag" or "RPC".
+	PackStyle string `json:"packStyle" xorm:"notnull 'pack_style'" validate:"required,max=100"`
+	// CountPerCase is the number of units in a case, e.g. 88 for an 88-count box or 10 for 10x5 lb bags.
+	CountPerCase int `json:"countPerCase" xorm:"notnull 'count_per_case'" validate:"required,min=1"`
+	// UnitWeight is the net weight of a single unit inside the case, e.g. 5 for 10x5 lb bags.
+	UnitWeight    float64 `json:"unitWeight" xorm:"'unit_weight'" validate:"gte=0"`
+	NetWeight     float64 `json:"netWeight" xorm:"notnull 'net_weight'" validate:"required,gt=0"`
+	GrossWeight   float64 `json:"grossWeight" xorm:"'gross_weight'" validate:"omitempty,gtefield=NetWeight"`
+	WeightUnit    string  `json:"weightUnit" xorm:"notnull 'weight_unit'" validate:"required,oneof=lb kg"`
+	CaseLength    float64 `json:"caseLength" xorm:"'case_length'" validate:"gte=0"`
+	CaseWidth     float64 `json:"caseWidth" xorm:"'case_width'" validate:"gte=0"`
+	CaseHeight    float64 `json:"caseHeight" xorm:"'case_height'" validate:"gte=0"`
+	DimensionUnit string  `json:"dimensionUnit" xorm:"'dimension_unit'" validate:"omitempty,oneof=in cm"`
+	// Ti is the number of cases per pallet layer and Hi the number of layers.
+	Ti int `json:"ti" xorm:"'ti'" validate:"gte=0,required_with=Hi"`
+	Hi int `json:"hi" xorm:"'hi'" validate:"gte=0,required_with=Ti"`
+	// CasesPerPallet defaults to Ti x Hi when not set explicitly.
+	CasesPerPallet int       `json:"casesPerPallet" xorm:"'cases_per_pallet'" validate:"gte=0"`
+	Visible        bool      `xorm:"'visible'" json:"-"`
+	CreatedAt      time.Time `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt      time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version        int64     `json:"version" xorm:"version 'version'" audit:"-"`
+}
+
+// TableName specifies the table name for the ProductPack model.
+func (ProductPack) TableName() string {
+	return "product_packs"
+}
+
+// CaseVolume returns the volume of a case in cubic DimensionUnits, or 0 when the dimensions are unknown.
+func (p *ProductPack) CaseVolume() float64 {
+	return p.CaseLength * p.CaseWidth * p.CaseHeight
+}
+
+// PalletNetWeight returns the net weight of a full pallet in WeightUnits.
+func (p *ProductPack) PalletNetWeight() float64 {
+	return p.NetWeight * float64(p.CasesPerPallet)
+}
+
+// PalletGrossWeight returns the gross weight of a full pallet's cases in WeightUnits, falling back
+// to the net weight when no gross weight is recorded.
+func (p *ProductPack) PalletGrossWeight() float64 {
+	if p.GrossWeight == 0 {
+		return p.PalletNetWeight()
+	}
+	return p.GrossWeight * float64(p.CasesPerPallet)
+}
+
+// CasesForNetWeight returns the number of whole cases needed to ship at least the given net weight.
+func (p *ProductPack) CasesForNetWeight(weight float64) int {
+	if p.NetWeight <= 0 || weight <= 0 {
+		return 0
+	}
+	cases := int(weight / p.NetWeight)
+	if float64(cases)*p.NetWeight < weight {
+		cases++
+	}
+	return cases
+}
+
+// PalletsForCases returns the number of pallets needed to ship the given number of cases, or 0
+// when the pallet configuration is unknown.
+func (p *ProductPack) PalletsForCases(cases int) int {
+	if p.CasesPerPallet <= 0 || cases <= 0 {
+		return 0
+	}
+	return (cases + p.CasesPerPallet - 1) / p.CasesPerPallet
+}
@@ -0,0 +1,81 @@
+package types_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProductPack", func() {
+	var pack *types.ProductPack
+
+	BeforeEach(func() {
+		pack = &types.ProductPack{
+			ProductID:      1,
+			PackStyle:      "carton",
+			CountPerCase:   88,
+			NetWeight:      40,
+			GrossWeight:    42.5,
+			WeightUnit:     types.WeightUnitPound,
+			CaseLength:     20,
+			CaseWidth:      12,
+			CaseHeight:     10,
+			DimensionUnit:  types.DimensionUnitInch,
+			Ti:             10,
+			Hi:             6,
+			CasesPerPallet: 60,
+		}
+	})
+
+	Context("Validation", func() {
+		It("should accept a valid pack", func() {
+			Expect(types.Validate(pack)).To(Succeed())
+		})
+
+		It("should require a positive net weight", func() {
+			pack.NetWeight = 0
+			Expect(types.Validate(pack)).NotTo(Succeed())
+		})
+
+		It("should reject a gross weight below the net weight", func() {
+			pack.GrossWeight = 39
+			Expect(types.Validate(pack)).NotTo(Succeed())
+		})
+
+		It("should reject an unknown weight unit", func() {
+			pack.WeightUnit = "oz"
+			Expect(types.Validate(pack)).NotTo(Succeed())
+		})
+
+		It("should require ti and hi together", func() {
+			pack.Hi = 0
+			Expect(types.Validate(pack)).NotTo(Succeed())
+		})
+	})
+
+	Context("Calculations", func() {
+		It("should calculate the case volume", func() {
+			Expect(pack.CaseVolume()).To(Equal(2400.0))
+		})
+
+		It("should calculate pallet weights", func() {
+			Expect(pack.PalletNetWeight()).To(Equal(2400.0))
+			Expect(pack.PalletGrossWeight()).To(Equal(2550.0))
+
+			pack.GrossWeight = 0
+			Expect(pack.PalletGrossWeight()).To(Equal(2400.0))
+		})
+
+		It("should round cases and pallets up", func() {
+			Expect(pack.CasesForNetWeight(400)).To(Equal(10))
+			Expect(pack.CasesForNetWeight(401)).To(Equal(11))
+			Expect(pack.PalletsForCases(60)).To(Equal(1))
+			Expect(pack.PalletsForCases(61)).To(Equal(2))
+		})
+
+		It("should return zero when the pallet configuration is unknown", func() {
+			pack.CasesPerPallet = 0
+			Expect(pack.PalletsForCases(10)).To(BeZero())
+		})
+	})
+})
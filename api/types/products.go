@@ -1,6 +1,14 @@
 package types
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 // Product represents a specific product for a company in the system.
 type Product struct {
@@ -8,9 +16,11 @@ type Product struct {
 	CommodityID int64     `json:"commodityId" xorm:"notnull index 'commodity_id'"`
 	CompanyID   int64     `json:"companyId" xorm:"notnull index 'company_id'"`
 	Name        string    `json:"name" xorm:"'name'"` // Derived name for the product
+	Fingerprint string    `json:"-" xorm:"'fingerprint'" audit:"-"`
 	Visible     bool      `xorm:"'visible'" json:"-"`
 	CreatedAt   time.Time `json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt   time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version     int64     `json:"version" xorm:"version 'version'" audit:"-"`
 
 	ProductAttributeValues []*ProductAttributeValue `xorm:"-" json:"attributes,omitempty"`
 }
@@ -19,3 +29,33 @@ type Product struct {
 func (Product) TableName() string {
 	return "products"
 }
+
+// ProductFingerprint identifies a product by its commodity and attribute values, regardless of the
+// order the values were supplied in. Two visible products of the same company may not share one.
+//
+// The fingerprint is the hex SHA-256 of "<commodity id>:" followed by "<attribute id>=<length>:<value>;"
+// for each value sorted by attribute ID. The length prefix keeps values containing separators from
+// colliding. The 20250906090000_add_fingerprint_to_products migration computes the same format in SQL.
+func ProductFingerprint(commodityID int64, attrs []*ProductAttributeValue) string {
+	sorted := make([]*ProductAttributeValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr != nil {
+			sorted = append(sorted, attr)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].CommodityAttributeID != sorted[j].CommodityAttributeID {
+			return sorted[i].CommodityAttributeID < sorted[j].CommodityAttributeID
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d:", commodityID)
+	for _, attr := range sorted {
+		fmt.Fprintf(&b, "%d=%d:%s;", attr.CommodityAttributeID, utf8.RuneCountInString(attr.Value), attr.Value)
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
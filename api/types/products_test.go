@@ -0,0 +1,43 @@
+package types_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProductFingerprint", func() {
+	attrs := func(pairs ...interface{}) []*types.ProductAttributeValue {
+		var out []*types.ProductAttributeValue
+		for i := 0; i < len(pairs); i += 2 {
+			out = append(out, &types.ProductAttributeValue{CommodityAttributeID: int64(pairs[i].(int)), Value: pairs[i+1].(string)})
+		}
+		return out
+	}
+
+	It("should not depend on the order of the attributes", func() {
+		Expect(types.ProductFingerprint(1, attrs(1, "Red", 2, "Large"))).
+			To(Equal(types.ProductFingerprint(1, attrs(2, "Large", 1, "Red"))))
+	})
+
+	It("should differ by commodity", func() {
+		Expect(types.ProductFingerprint(1, attrs(1, "Red"))).NotTo(Equal(types.ProductFingerprint(2, attrs(1, "Red"))))
+	})
+
+	It("should differ by attribute value", func() {
+		Expect(types.ProductFingerprint(1, attrs(1, "Red"))).NotTo(Equal(types.ProductFingerprint(1, attrs(1, "Green"))))
+	})
+
+	It("should differ by attribute", func() {
+		Expect(types.ProductFingerprint(1, attrs(1, "Red"))).NotTo(Equal(types.ProductFingerprint(1, attrs(2, "Red"))))
+	})
+
+	It("should not let separators inside values collide", func() {
+		Expect(types.ProductFingerprint(1, attrs(1, "a;2=1:b"))).NotTo(Equal(types.ProductFingerprint(1, attrs(1, "a", 2, "b"))))
+	})
+
+	It("should fingerprint products without attributes", func() {
+		Expect(types.ProductFingerprint(1, nil)).To(HaveLen(64))
+		Expect(types.ProductFingerprint(1, nil)).To(Equal(types.ProductFingerprint(1, []*types.ProductAttributeValue{})))
+	})
+})
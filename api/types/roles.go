@@ -11,10 +11,14 @@ type Role int
 const (
 	// RoleUnknown is an unknown or unassigned role.
 	RoleUnknown Role = iota
-	// RoleAdmin has all permissions.
+	// RoleAdmin administers a single company: it has every company permission, but only within
+	// the user's own company.
 	RoleAdmin
 	// RoleUser is a standard user with limited permissions.
 	RoleUser
+	// RoleSuperAdmin is a platform operator. It has every permission, including the platform
+	// permissions that reach across companies.
+	RoleSuperAdmin
 )
 
 // String returns the string representation of a Role.
@@ -24,6 +28,8 @@ func (r Role) String() string {
 		return "admin"
 	case RoleUser:
 		return "user"
+	case RoleSuperAdmin:
+		return "super_admin"
 	default:
 		return "unknown"
 	}
@@ -36,6 +42,8 @@ func ParseRole(s string) (Role, error) {
 		return RoleAdmin, nil
 	case "user":
 		return RoleUser, nil
+	case "super_admin":
+		return RoleSuperAdmin, nil
 	default:
 		return RoleUnknown, fmt.Errorf("unknown role: %s", s)
 	}
@@ -57,7 +65,7 @@ func (r *Roles) FromDB(data []byte) error {
 
 	// Trim the curly braces and split by comma.
 	// Note: This is a simple parser and will not handle roles with commas
-	// or quotes in their names. For the current roles ("admin", "user"), this is safe.
+	// or quotes in their names. For the current roles ("admin", "user", "super_admin"), this is safe.
 	trimmed := strings.Trim(s, "{}")
 	parts := strings.Split(trimmed, ",")
 	roles := make([]Role, 0, len(parts))
@@ -105,5 +113,6 @@ func AllRoles() []Role {
 	return []Role{
 		RoleAdmin,
 		RoleUser,
+		RoleSuperAdmin,
 	}
 }
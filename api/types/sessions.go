@@ -0,0 +1,59 @@
+package types
+
+import "time"
+
+// Session is a signed-in device of a user. It is created on login and lives as long as its refresh
+// tokens keep being used. Every access token names the session it was issued for, so revoking the
+// session ends all of them.
+type Session struct {
+	ID         int64      `json:"id" xorm:"pk autoincr 'id'"`
+	UserID     int64      `json:"userId" xorm:"notnull index 'user_id'"`
+	CompanyID  int64      `json:"companyId" xorm:"notnull 'company_id'"`
+	UserAgent  string     `json:"userAgent" xorm:"'user_agent'"`
+	IPAddress  string     `json:"ipAddress" xorm:"'ip_address'"`
+	ExpiresAt  time.Time  `json:"expiresAt" xorm:"notnull 'expires_at'"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty" xorm:"'revoked_at'"`
+	LastUsedAt time.Time  `json:"lastUsedAt" xorm:"'last_used_at'"`
+	CreatedAt  time.Time  `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt  time.Time  `json:"updatedAt" xorm:"updated 'updated_at'"`
+
+	// Current marks the session of the access token that listed it.
+	Current bool `json:"current" xorm:"-"`
+}
+
+// TableName specifies the table name for the Session model.
+func (Session) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive reports whether the session has neither been revoked nor expired at the given time.
+func (s Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken is a single-use token that exchanges for a new access token. Only its hash is stored.
+type RefreshToken struct {
+	ID        int64      `xorm:"pk autoincr 'id'"`
+	SessionID int64      `xorm:"notnull index 'session_id'"`
+	TokenHash string     `xorm:"notnull unique 'token_hash'"`
+	ExpiresAt time.Time  `xorm:"notnull 'expires_at'"`
+	UsedAt    *time.Time `xorm:"'used_at'"`
+	CreatedAt time.Time  `xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the RefreshToken model.
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// RevokedAccessToken records an access token, by its jti, that was revoked before it expired.
+type RevokedAccessToken struct {
+	JTI       string    `xorm:"pk 'jti'"`
+	ExpiresAt time.Time `xorm:"notnull 'expires_at'"`
+	CreatedAt time.Time `xorm:"created 'created_at'"`
+}
+
+// TableName specifies the table name for the RevokedAccessToken model.
+func (RevokedAccessToken) TableName() string {
+	return "revoked_access_tokens"
+}
@@ -0,0 +1,38 @@
+package types_test
+
+import (
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSOConfig", func() {
+	Describe("EmailDomains", func() {
+		It("should round trip through the database format", func() {
+			domains := types.EmailDomains{"example.com", "example.org"}
+			data, err := domains.ToDB()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("{example.com,example.org}"))
+
+			var parsed types.EmailDomains
+			Expect(parsed.FromDB(data)).To(Succeed())
+			Expect(parsed).To(Equal(domains))
+
+			Expect(parsed.FromDB([]byte("{}"))).To(Succeed())
+			Expect(parsed).To(BeEmpty())
+		})
+
+		DescribeTable("Allows",
+			func(email string, allowed bool) {
+				domains := types.EmailDomains{"example.com"}
+				Expect(domains.Allows(email)).To(Equal(allowed))
+			},
+			Entry("listed domain", "jane@example.com", true),
+			Entry("different case", "Jane@Example.COM", true),
+			Entry("other domain", "jane@example.org", false),
+			Entry("subdomain", "jane@mail.example.com", false),
+			Entry("suffix of another domain", "jane@notexample.com", false),
+			Entry("no domain", "jane", false),
+		)
+	})
+})
@@ -8,16 +8,31 @@ type User struct {
 	FirstName string    `validate:"required,min=2,max=50" json:"firstName" xorm:"'first_name'"`
 	LastName  string    `validate:"required,min=2,max=50" json:"lastName" xorm:"'last_name'"`
 	Email     string    `validate:"required,email" json:"email" xorm:"unique 'email'"`
-	Password  string    `validate:"required,min=8" json:"-" xorm:"'password'"`
+	Password  string    `validate:"required,min=8" json:"-" xorm:"'password'" audit:"redact"`
 	CompanyID int64     `validate:"required" json:"companyId" xorm:"notnull index 'company_id'"`
 	AddressID int64     `validate:"required" json:"addressId" xorm:"notnull index 'address_id'"`
 	Visible   bool      `xorm:"'visible'" json:"-"`
 	Roles     Roles     `json:"roles" xorm:"'roles'"`
 	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
 	UpdatedAt time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version   int64     `json:"version" xorm:"version 'version'" audit:"-"`
+
+	// MFAEnabled is set once the user confirmed their TOTP enrollment with a first code. The
+	// secret and the last used time step never leave the server.
+	MFAEnabled  bool   `json:"mfaEnabled" xorm:"'mfa_enabled'"`
+	MFASecret   string `json:"-" xorm:"'mfa_secret'" audit:"redact"`
+	MFALastStep int64  `json:"-" xorm:"'mfa_last_step'" audit:"-"`
 
 	// Relations
 	Address *Address `json:"address,omitempty" xorm:"-"`
+
+	// Permissions are granted by the user's company roles. They are loaded for the authenticated
+	// user only.
+	Permissions Permissions `json:"-" xorm:"-"`
+
+	// Impersonator is the platform admin acting as the user. It is only set for the authenticated
+	// user of a request made with an impersonation token.
+	Impersonator *User `json:"-" xorm:"-"`
 }
 
 // TableName specifies the table name for the User model.
@@ -35,3 +50,41 @@ func (u User) HasRole(role Role) bool {
 	}
 	return false
 }
+
+// HasPermission checks if the user is granted a permission by one of their built-in roles or
+// company roles.
+func (u User) HasPermission(perm Permission) bool {
+	for _, role := range u.Roles {
+		if role.Permissions().Has(perm) {
+			return true
+		}
+	}
+	return u.Permissions.Has(perm)
+}
+
+// HasPermissionsOf checks if the user is granted every permission the other user is granted, so
+// acting on the other user's account cannot reach more than the user already can. The other user's
+// Permissions must be loaded.
+func (u User) HasPermissionsOf(other User) bool {
+	for _, perm := range AllPermissions() {
+		if other.HasPermission(perm) && !u.HasPermission(perm) {
+			return false
+		}
+	}
+	return true
+}
+
+// ActorID returns the ID of the person actually making the request: the impersonator while the user
+// is impersonated, the user otherwise.
+func (u User) ActorID() int64 {
+	if u.Impersonator != nil {
+		return u.Impersonator.ID
+	}
+	return u.ID
+}
+
+// CanAccessCompany checks if the user may act on the data of a company: their own company, or any
+// company with the companies:manage permission.
+func (u User) CanAccessCompany(companyID int64) bool {
+	return u.CompanyID == companyID || u.HasPermission(PermissionCompaniesManage)
+}
@@ -0,0 +1,256 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// WebhookEventType names a change that is sent to webhook endpoints, as "<entity>.<created|updated|deleted>".
+type WebhookEventType string
+
+// webhookEventActions maps audit log actions to the verbs of event types.
+var webhookEventActions = map[AuditAction]string{
+	AuditActionCreate: "created",
+	AuditActionUpdate: "updated",
+	AuditActionDelete: "deleted",
+}
+
+// WebhookEntityTypes are the records whose changes are sent to webhook endpoints. Records shared by
+// all companies, like commodities, and secrets, like API keys, are not.
+var WebhookEntityTypes = []AuditEntityType{
+	AuditEntityCompany,
+	AuditEntityCompanyRole,
+	AuditEntityLocation,
+	AuditEntityProduct,
+	AuditEntityProductPack,
+	AuditEntityUser,
+}
+
+// WebhookEventTypeFor returns the event type of a change, and false for changes that are not sent
+// to webhook endpoints.
+func WebhookEventTypeFor(entityType AuditEntityType, action AuditAction) (WebhookEventType, bool) {
+	verb, ok := webhookEventActions[action]
+	if !ok {
+		return "", false
+	}
+	for _, t := range WebhookEntityTypes {
+		if t == entityType {
+			return WebhookEventType(string(entityType) + "." + verb), true
+		}
+	}
+	return "", false
+}
+
+// IsValidWebhookEventType reports whether t is an event type endpoints can subscribe to.
+func IsValidWebhookEventType(t WebhookEventType) bool {
+	entity, verb, ok := strings.Cut(string(t), ".")
+	if !ok {
+		return false
+	}
+	for action, v := range webhookEventActions {
+		if v == verb {
+			_, ok := WebhookEventTypeFor(AuditEntityType(entity), action)
+			return ok
+		}
+	}
+	return false
+}
+
+// WebhookEventTypes is a slice of event types stored as a PostgreSQL text[].
+type WebhookEventTypes []WebhookEventType
+
+// FromDB is called by xorm to convert a database value to a WebhookEventTypes slice.
+// Event types never contain commas or quotes, so no unquoting is needed.
+func (t *WebhookEventTypes) FromDB(data []byte) error {
+	trimmed := strings.Trim(string(data), "{}")
+	if trimmed == "" {
+		*t = WebhookEventTypes{}
+		return nil
+	}
+	parts := strings.Split(trimmed, ",")
+	types := make(WebhookEventTypes, len(parts))
+	for i, part := range parts {
+		types[i] = WebhookEventType(part)
+	}
+	*t = types
+	return nil
+}
+
+// ToDB is called by xorm to convert a WebhookEventTypes slice to a database value.
+func (t WebhookEventTypes) ToDB() ([]byte, error) {
+	parts := make([]string, len(t))
+	for i, eventType := range t {
+		parts[i] = string(eventType)
+	}
+	return []byte("{" + strings.Join(parts, ",") + "}"), nil
+}
+
+// Has reports whether the event type is in the slice.
+func (t WebhookEventTypes) Has(eventType WebhookEventType) bool {
+	for _, existing := range t {
+		if existing == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEndpoint is a URL a company wants its changes sent to. Deliveries are signed with the
+// endpoint's secret, which is shown once, when the endpoint is created.
+type WebhookEndpoint struct {
+	ID          int64  `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID   int64  `json:"companyId" xorm:"notnull index 'company_id'"`
+	URL         string `json:"url" xorm:"notnull 'url'" validate:"required,url,max=2048"`
+	Description string `json:"description" xorm:"'description'" validate:"max=255"`
+	Secret      string `json:"-" xorm:"notnull 'secret'" audit:"redact"`
+	// EventTypes are the events sent to the endpoint. Endpoints without any get every event.
+	EventTypes WebhookEventTypes `json:"eventTypes" xorm:"'event_types'"`
+	// Active endpoints get deliveries; inactive ones are skipped.
+	Active bool `json:"active" xorm:"notnull 'active'"`
+	// CreatedBy is the user who created the endpoint.
+	CreatedBy int64     `json:"createdBy" xorm:"'created_by'"`
+	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+	Version   int64     `json:"version" xorm:"version 'version'" audit:"-"`
+}
+
+// TableName specifies the table name for the WebhookEndpoint model.
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Subscribes reports whether the endpoint wants events of the given type.
+func (e WebhookEndpoint) Subscribes(eventType WebhookEventType) bool {
+	return e.Active && (len(e.EventTypes) == 0 || e.EventTypes.Has(eventType))
+}
+
+// OutboxEvent is a change sent to a company's webhook endpoints and event stream. It is written in
+// the same transaction as the change, and it is also the body of every delivery of the change and
+// the data of its message on the stream.
+type OutboxEvent struct {
+	ID         int64            `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID  int64            `json:"companyId" xorm:"notnull 'company_id'"`
+	EventType  WebhookEventType `json:"type" xorm:"notnull 'event_type'"`
+	EntityType AuditEntityType  `json:"entityType" xorm:"notnull 'entity_type'"`
+	EntityID   int64            `json:"entityId" xorm:"notnull 'entity_id'"`
+	// Data is the record after the change, or before it for records that were removed.
+	Data EventData `json:"data" xorm:"notnull 'data'" swaggertype:"object"`
+	// DispatchedAt is when the dispatcher created the event's deliveries.
+	DispatchedAt *time.Time `json:"-" xorm:"'dispatched_at'"`
+	CreatedAt    time.Time  `json:"createdAt" xorm:"created 'created_at'"`
+	// TxID is the ID of the transaction that wrote the event. The database sets it; the event
+	// stream is ordered by it.
+	TxID int64 `json:"-" xorm:"<- 'txid'"`
+}
+
+// TableName specifies the table name for the OutboxEvent model.
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// OutboxEventRetention is how long events are kept after they are dispatched, for the event stream
+// and the delivery log. Removing an event also removes its deliveries. The server sets it from its
+// configuration at startup.
+var OutboxEventRetention = 30 * 24 * time.Hour
+
+// EventData is a JSON document stored as JSONB.
+type EventData json.RawMessage
+
+// MarshalJSON returns the document itself.
+func (d EventData) MarshalJSON() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("null"), nil
+	}
+	return d, nil
+}
+
+// UnmarshalJSON stores a copy of the document.
+func (d *EventData) UnmarshalJSON(data []byte) error {
+	*d = append((*d)[:0], data...)
+	return nil
+}
+
+// FromDB is called by xorm to convert a JSONB value to EventData.
+func (d *EventData) FromDB(data []byte) error {
+	*d = append(EventData{}, data...)
+	return nil
+}
+
+// ToDB is called by xorm to convert EventData to a JSONB value.
+func (d EventData) ToDB() ([]byte, error) {
+	if len(d) == 0 {
+		return []byte("{}"), nil
+	}
+	return d, nil
+}
+
+// WebhookDeliveryStatus is where a delivery stands.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending deliveries are waiting for their first or next attempt.
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusSucceeded deliveries got a 2xx response.
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryStatusFailed deliveries ran out of attempts. They can be redelivered by hand.
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an entry of the delivery log: the sending of one event to one endpoint, with
+// the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID         int64                 `json:"id" xorm:"pk autoincr 'id'"`
+	CompanyID  int64                 `json:"companyId" xorm:"notnull 'company_id'"`
+	EndpointID int64                 `json:"endpointId" xorm:"notnull index 'endpoint_id'"`
+	EventID    int64                 `json:"eventId" xorm:"notnull 'event_id'"`
+	EventType  WebhookEventType      `json:"eventType" xorm:"notnull 'event_type'"`
+	Status     WebhookDeliveryStatus `json:"status" xorm:"notnull 'status'"`
+	Attempts   int                   `json:"attempts" xorm:"notnull 'attempts'"`
+	// NextAttemptAt is when a pending delivery is tried next.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" xorm:"'next_attempt_at'"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty" xorm:"'last_attempt_at'"`
+	// ResponseStatus is the HTTP status of the latest attempt, or 0 when it got no response.
+	ResponseStatus int `json:"responseStatus" xorm:"'response_status'"`
+	// LastError says why the latest attempt failed.
+	LastError string    `json:"lastError,omitempty" xorm:"'last_error'"`
+	CreatedAt time.Time `json:"createdAt" xorm:"created 'created_at'"`
+	UpdatedAt time.Time `json:"updatedAt" xorm:"updated 'updated_at'"`
+}
+
+// TableName specifies the table name for the WebhookDelivery model.
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookRetryPolicy decides how often and when failed deliveries are retried.
+type WebhookRetryPolicy struct {
+	// MaxAttempts is how many times a delivery is tried before it fails.
+	MaxAttempts int
+	// BaseBackoff is how long the dispatcher waits after the first failed attempt. Every further
+	// failure doubles it, up to MaxBackoff.
+	BaseBackoff time.Duration
+	// MaxBackoff is the longest wait between attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultWebhookRetryPolicy is the policy deliveries are retried with unless the server configures
+// another one: a delivery is tried eight times, 30 seconds after the first failure and doubling up
+// to six hours in between.
+var DefaultWebhookRetryPolicy = WebhookRetryPolicy{
+	MaxAttempts: 8,
+	BaseBackoff: 30 * time.Second,
+	MaxBackoff:  6 * time.Hour,
+}
+
+// Backoff returns how long to wait after the given failed attempt; the first attempt is number 1.
+func (p WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
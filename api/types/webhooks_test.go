@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/happilymarrieddad/order-management-v3/api/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks", func() {
+	It("should name the events of changes that are sent to endpoints", func() {
+		eventType, ok := types.WebhookEventTypeFor(types.AuditEntityProduct, types.AuditActionUpdate)
+		Expect(ok).To(BeTrue())
+		Expect(eventType).To(Equal(types.WebhookEventType("product.updated")))
+
+		_, ok = types.WebhookEventTypeFor(types.AuditEntityAPIKey, types.AuditActionCreate)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should only accept known event types", func() {
+		Expect(types.IsValidWebhookEventType("user.deleted")).To(BeTrue())
+		Expect(types.IsValidWebhookEventType("user.renamed")).To(BeFalse())
+		Expect(types.IsValidWebhookEventType("api_key.created")).To(BeFalse())
+		Expect(types.IsValidWebhookEventType("product")).To(BeFalse())
+	})
+
+	It("should send every event to active endpoints without event types", func() {
+		Expect(types.WebhookEndpoint{Active: true}.Subscribes("product.created")).To(BeTrue())
+		Expect(types.WebhookEndpoint{Active: false}.Subscribes("product.created")).To(BeFalse())
+
+		endpoint := types.WebhookEndpoint{Active: true, EventTypes: types.WebhookEventTypes{"user.deleted"}}
+		Expect(endpoint.Subscribes("user.deleted")).To(BeTrue())
+		Expect(endpoint.Subscribes("product.created")).To(BeFalse())
+	})
+
+	It("should double the backoff up to the maximum", func() {
+		policy := types.WebhookRetryPolicy{MaxAttempts: 8, BaseBackoff: 30 * time.Second, MaxBackoff: 5 * time.Minute}
+		Expect(policy.Backoff(1)).To(Equal(30 * time.Second))
+		Expect(policy.Backoff(2)).To(Equal(time.Minute))
+		Expect(policy.Backoff(4)).To(Equal(4 * time.Minute))
+		Expect(policy.Backoff(5)).To(Equal(5 * time.Minute))
+		Expect(policy.Backoff(100)).To(Equal(5 * time.Minute))
+	})
+
+	It("should embed event data in the event as is", func() {
+		event := types.OutboxEvent{ID: 1, EventType: "location.created", Data: types.EventData(`{"name":"Dock"}`)}
+		body, err := json.Marshal(event)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(body)).To(ContainSubstring(`"data":{"name":"Dock"}`))
+
+		var parsed types.OutboxEvent
+		Expect(json.Unmarshal(body, &parsed)).To(Succeed())
+		Expect([]byte(parsed.Data)).To(MatchJSON(`{"name":"Dock"}`))
+	})
+
+	It("should round trip event types through the database format", func() {
+		eventTypes := types.WebhookEventTypes{"product.created", "user.deleted"}
+		data, err := eventTypes.ToDB()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("{product.created,user.deleted}"))
+
+		var parsed types.WebhookEventTypes
+		Expect(parsed.FromDB(data)).To(Succeed())
+		Expect(parsed).To(Equal(eventTypes))
+	})
+})
@@ -1,6 +1,10 @@
 package utils
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 // GetEnv is a helper to read an environment variable or return a default value.
 func GetEnv(key, fallback string) string {
@@ -9,3 +13,33 @@ func GetEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// GetEnvInt reads a positive integer environment variable, falling back to the default when it is
+// unset or invalid.
+func GetEnvInt(key string, fallback int) int {
+	n, err := strconv.Atoi(GetEnv(key, ""))
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// GetEnvBool reads a boolean environment variable such as "true" or "1", falling back to the
+// default when it is unset or invalid.
+func GetEnvBool(key string, fallback bool) bool {
+	b, err := strconv.ParseBool(GetEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+// GetEnvDuration reads a positive duration such as "15m" from an environment variable, falling back
+// to the default when it is unset or invalid.
+func GetEnvDuration(key string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(GetEnv(key, ""))
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}